@@ -101,20 +101,29 @@ func main() {
 	identityRepository := repositories.NewIdentity()
 	claimsRepository := repositories.NewClaims()
 	reqsRepository := repositories.NewAuthRequests()
+	templateRepository := repositories.NewProofRequestTemplates()
+	nullifierRepository := repositories.NewSybilNullifiers()
 	mtRepository := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepository := repositories.NewIdentityState()
+	holderCredentialRepository := repositories.NewHolderCredentials()
 	revocationRepository := repositories.NewRevocation()
+	profileRepository := repositories.NewProfiles()
+	credentialRequestRepository := repositories.NewCredentialRequests()
+	issuancePolicyRepository := repositories.NewIssuancePolicies()
+	issuanceAuditRepository := repositories.NewIssuanceAudit()
 
 	// services initialization
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
-	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, storage, rhsp)
+	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, profileRepository, storage, rhsp)
 	schemaService := services.NewSchema(schemaLoader)
+	templateService := services.NewProofRequestTemplates(templateRepository, storage)
 	claimsService := services.NewClaim(
 		claimsRepository,
 		schemaService,
 		identityService,
 		mtService,
 		identityStateRepository,
+		holderCredentialRepository,
 		storage,
 		services.ClaimCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
@@ -128,16 +137,21 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepository,
+		templateRepository,
+		nullifierRepository,
 		storage,
 		services.AuthRequestCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
 			RHSUrl:     cfg.ReverseHashService.URL,
 			Host:       cfg.ServerUrl,
+			Verifier:   cfg.Verifier,
 		},
 	)
+	issuancePolicyService := services.NewIssuancePolicies(issuancePolicyRepository, issuanceAuditRepository, storage)
+	credentialRequestService := services.NewCredentialRequests(credentialRequestRepository, issuanceAuditRepository, issuancePolicyService, claimsService, identityService, storage, cfg.ServerUrl)
 	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService)
 	revocationService := services.NewRevocationService(ethConn, common.HexToAddress(cfg.Ethereum.ContractAddress))
-	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, proofService, keyStore, storage, stateContract, schemaLoader)
+	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, holderCredentialRepository, proofService, keyStore, storage, stateContract, schemaLoader)
 	transactionService, err := gateways.NewTransaction(ethereumClient, cfg.Ethereum.ConfirmationBlockCount)
 	if err != nil {
 		log.Error(ctx, "error creating transaction service", err)
@@ -176,7 +190,7 @@ func main() {
 	)
 	api_admin.HandlerFromMux(
 		api_admin.NewStrictHandlerWithOptions(
-			api_admin.NewServer(cfg, identityService, claimsService, reqsService, schemaService, publisher, packageManager, serverHealth),
+			api_admin.NewServer(cfg, identityService, claimsService, reqsService, schemaService, templateService, issuancePolicyService, credentialRequestService, publisher, packageManager, serverHealth),
 			middlewares(ctx, cfg.HTTPAdminAuth),
 			api_admin.StrictHTTPServerOptions{
 				RequestErrorHandlerFunc:  errors.RequestErrorHandlerFunc,
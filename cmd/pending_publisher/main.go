@@ -12,7 +12,6 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/lastingasset/wallet-service/internal/config"
-	"github.com/lastingasset/wallet-service/internal/core/ports"
 	"github.com/lastingasset/wallet-service/internal/core/services"
 	"github.com/lastingasset/wallet-service/internal/db"
 	"github.com/lastingasset/wallet-service/internal/gateways"
@@ -20,9 +19,11 @@ import (
 	"github.com/lastingasset/wallet-service/internal/loader"
 	"github.com/lastingasset/wallet-service/internal/log"
 	"github.com/lastingasset/wallet-service/internal/providers"
+	"github.com/lastingasset/wallet-service/internal/redis"
 	"github.com/lastingasset/wallet-service/internal/repositories"
 	"github.com/lastingasset/wallet-service/pkg/blockchain/eth"
 	"github.com/lastingasset/wallet-service/pkg/loaders"
+	"github.com/lastingasset/wallet-service/pkg/pubsub"
 	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
 )
 
@@ -84,13 +85,17 @@ func main() {
 	identityRepo := repositories.NewIdentity()
 	claimsRepo := repositories.NewClaims()
 	reqsRepo := repositories.NewAuthRequests()
+	templateRepo := repositories.NewProofRequestTemplates()
+	nullifierRepo := repositories.NewSybilNullifiers()
 	mtRepo := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
 	revocationRepository := repositories.NewRevocation()
+	profileRepository := repositories.NewProfiles()
 	mtService := services.NewIdentityMerkleTrees(mtRepo)
 
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
-	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, storage, rhsp)
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, profileRepository, storage, rhsp)
 	schemaService := services.NewSchema(loader.HTTPFactory)
 	claimsService := services.NewClaim(
 		claimsRepo,
@@ -98,6 +103,7 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepo,
+		holderCredentialRepo,
 		storage,
 		services.ClaimCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
@@ -112,11 +118,14 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepo,
+		templateRepo,
+		nullifierRepo,
 		storage,
 		services.AuthRequestCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
 			RHSUrl:     cfg.ReverseHashService.URL,
 			Host:       cfg.ServerUrl,
+			Verifier:   cfg.Verifier,
 		},
 	)
 
@@ -138,7 +147,7 @@ func main() {
 	})
 
 	circuitsLoaderService := loaders.NewCircuits(cfg.Circuit.Path)
-	proofService := initProofService(ctx, cfg, circuitsLoaderService)
+	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService)
 
 	transactionService, err := gateways.NewTransaction(cl, cfg.Ethereum.ConfirmationBlockCount)
 	if err != nil {
@@ -167,24 +176,35 @@ func main() {
 		}
 	}(ctx)
 
+	if cfg.ExpiredClaimsCheckFrequency > 0 {
+		// the expiration events are the only use of redis in this process
+		rdb, err := redis.Open(cfg.Cache.RedisUrl)
+		if err != nil {
+			log.Error(ctx, "cannot connect to redis", err, "host", cfg.Cache.RedisUrl)
+			panic(err)
+		}
+		claimExpirationService := services.NewClaimExpiration(claimsRepo, pubsub.NewRedisPublisher(rdb), storage)
+
+		go func(ctx context.Context) {
+			ticker := time.NewTicker(cfg.ExpiredClaimsCheckFrequency)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					// errors are logged by the service, the claims not notified are retried on the next tick
+					if expired, _ := claimExpirationService.Sweep(ctx); len(expired) > 0 {
+						log.Info(ctx, "expired claims marked", "count", len(expired))
+					}
+				case <-ctx.Done():
+					log.Info(ctx, "finishing expired claims job")
+					return
+				}
+			}
+		}(ctx)
+	}
+
 	<-quit
 	log.Info(ctx, "finishing app")
 	cancel()
 	log.Info(ctx, "Finished")
 }
-
-func initProofService(ctx context.Context, config *config.Configuration, circuitLoaderService *loaders.Circuits) ports.ZKGenerator {
-	log.Info(ctx, "native prover enabled", "enabled", config.NativeProofGenerationEnabled)
-	if config.NativeProofGenerationEnabled {
-		proverConfig := &services.NativeProverConfig{
-			CircuitsLoader: circuitLoaderService,
-		}
-		return services.NewNativeProverService(proverConfig)
-	}
-
-	proverConfig := &gateways.ProverConfig{
-		ServerURL:       config.Prover.ServerURL,
-		ResponseTimeout: config.Prover.ResponseTimeout,
-	}
-	return gateways.NewProverService(proverConfig)
-}
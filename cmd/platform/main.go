@@ -101,13 +101,21 @@ func main() {
 	identityRepository := repositories.NewIdentity()
 	claimsRepository := repositories.NewClaims()
 	reqsRepository := repositories.NewAuthRequests()
+	templateRepository := repositories.NewProofRequestTemplates()
+	nullifierRepository := repositories.NewSybilNullifiers()
 	mtRepository := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepository := repositories.NewIdentityState()
+	holderCredentialRepository := repositories.NewHolderCredentials()
 	revocationRepository := repositories.NewRevocation()
+	profileRepository := repositories.NewProfiles()
+	credentialRequestRepository := repositories.NewCredentialRequests()
+	issuancePolicyRepository := repositories.NewIssuancePolicies()
+	issuanceAuditRepository := repositories.NewIssuanceAudit()
+	proofJobRepository := repositories.NewProofJobs()
 
 	// services initialization
 	mtService := services.NewIdentityMerkleTrees(mtRepository)
-	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, storage, rhsp)
+	identityService := services.NewIdentity(keyStore, identityRepository, mtRepository, identityStateRepository, mtService, claimsRepository, revocationRepository, profileRepository, storage, rhsp)
 	schemaService := services.NewSchema(schemaLoader)
 	claimsService := services.NewClaim(
 		claimsRepository,
@@ -115,6 +123,7 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepository,
+		holderCredentialRepository,
 		storage,
 		services.ClaimCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
@@ -128,16 +137,19 @@ func main() {
 		identityService,
 		mtService,
 		identityStateRepository,
+		templateRepository,
+		nullifierRepository,
 		storage,
 		services.AuthRequestCfg{
 			RHSEnabled: cfg.ReverseHashService.Enabled,
 			RHSUrl:     cfg.ReverseHashService.URL,
 			Host:       cfg.ServerUrl,
+			Verifier:   cfg.Verifier,
 		},
 	)
 	proofService := gateways.NewProver(ctx, cfg, circuitsLoaderService)
 	revocationService := services.NewRevocationService(ethConn, common.HexToAddress(cfg.Ethereum.ContractAddress))
-	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, claimsRepository, proofService, keyStore, storage, stateContract, schemaLoader)
+	zkProofService := services.NewProofService(claimsService, revocationService, identityService, mtService, holderCredentialRepository, proofService, keyStore, storage, stateContract, schemaLoader)
 	transactionService, err := gateways.NewTransaction(ethereumClient, cfg.Ethereum.ConfirmationBlockCount)
 	if err != nil {
 		log.Error(ctx, "error creating transaction service", err)
@@ -158,6 +170,24 @@ func main() {
 		return
 	}
 
+	verifierProfile, err := cfg.Verifier.Profile("")
+	if err != nil {
+		log.Warn(ctx, "no default verifier profile, presentations and received credentials are verified for the identities of this service only", "err", err)
+	}
+	stateResolvers := services.NewStateResolvers(verifierProfile.Chains, identityStateRepository, storage)
+
+	offerService := services.NewOffers(holderCredentialRepository, identityService, packageManager, storage, stateResolvers)
+	holderCredentialService := services.NewHolderCredentials(holderCredentialRepository, identityService, storage, stateResolvers)
+	issuancePolicyService := services.NewIssuancePolicies(issuancePolicyRepository, issuanceAuditRepository, storage)
+	credentialRequestService := services.NewCredentialRequests(credentialRequestRepository, issuanceAuditRepository, issuancePolicyService, claimsService, identityService, storage, cfg.ServerUrl)
+	proofJobService := services.NewProofJobs(proofJobRepository, zkProofService, storage, services.ProofJobCfg{
+		Workers:      cfg.ProofJobs.Workers,
+		PollInterval: cfg.ProofJobs.PollInterval,
+		ResultTTL:    cfg.ProofJobs.ResultTTL,
+		LeaseTimeout: cfg.ProofJobs.LeaseTimeout,
+	})
+	presentationService := services.NewPresentations(holderCredentialService, claimsService, identityService, mtService, keyStore, storage, stateResolvers)
+
 	serverHealth := health.New(health.Monitors{
 		"postgres": storage.Ping,
 		"redis": func(rdb *redis2.Client) health.Pinger {
@@ -176,7 +206,7 @@ func main() {
 	)
 	api.HandlerFromMux(
 		api.NewStrictHandlerWithOptions(
-			api.NewServer(cfg, identityService, zkProofService, claimsService, reqsService, schemaService, publisher, packageManager, serverHealth),
+			api.NewServer(cfg, identityService, zkProofService, claimsService, reqsService, offerService, holderCredentialService, presentationService, credentialRequestService, proofJobService, schemaService, publisher, packageManager, serverHealth),
 			middlewares(ctx, cfg.HTTPBasicAuth),
 			api.StrictHTTPServerOptions{
 				RequestErrorHandlerFunc:  errors.RequestErrorHandlerFunc,
@@ -192,6 +222,13 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		proofJobService.Run(workersCtx)
+	}()
+
 	go func() {
 		log.Info(ctx, "server started", "port", cfg.ServerPort)
 		if err := server.ListenAndServe(); err != nil {
@@ -201,6 +238,8 @@ func main() {
 
 	<-quit
 	log.Info(ctx, "Shutting down")
+	stopWorkers()
+	<-workersDone
 }
 
 func middlewares(ctx context.Context, auth config.HTTPBasicAuth) []api.StrictMiddlewareFunc {
@@ -92,11 +92,11 @@ func (s SybilAtomicSigInputs) Validate() error {
 	}
 
 	if s.IssuerClaim.Claim == nil {
-		return errors.New(ErrorEmptyGISTProof)
+		return errors.New(ErrorEmptyIssuerClaim)
 	}
 
 	if s.StateCommitmentClaim.Claim == nil {
-		return errors.New(ErrorEmptyGISTProof)
+		return errors.New(ErrorEmptyStateCommitmentClaim)
 	}
 
 	return nil
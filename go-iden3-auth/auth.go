@@ -82,28 +82,6 @@ func (v *Verifier) VerifyAuthResponse(
 	}
 
 	for _, proofRequest := range request.Body.Scope {
-		proofResponse := findProofByRequestID(response.Body.Scope, proofRequest.ID)
-		if proofResponse == nil {
-			return errors.Errorf("proof for zk request id %v is presented not found", proofRequest.ID)
-		}
-		if proofRequest.CircuitID != proofResponse.CircuitID {
-			return errors.Errorf("proof response for request id %v has different circuit id than requested. requested %s - presented %s", proofRequest.ID, proofRequest.CircuitID, proofResponse.CircuitID)
-		}
-
-		verificationKey, err := v.verificationKeyLoader.Load(circuits.CircuitID(proofResponse.CircuitID))
-		if err != nil {
-			return err
-		}
-		err = proofs.VerifyProof(*proofResponse, verificationKey)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("proof with request id %v and circuit id %s is not valid", proofRequest.ID, proofRequest.CircuitID))
-		}
-
-		cv, err := getPublicSignalsVerifier(circuits.CircuitID(proofResponse.CircuitID), proofResponse.PubSignals)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("circuit with id %s is not supported by library", proofRequest.CircuitID))
-		}
-
 		// prepare query from request
 		queryBytes, err := json.Marshal(proofRequest.Query)
 		if err != nil {
@@ -115,32 +93,84 @@ func (v *Verifier) VerifyAuthResponse(
 			return err
 		}
 
-		// verify proof author
-
-		err = cv.VerifyIDOwnership(response.From, big.NewInt(int64(proofResponse.ID)))
-		if err != nil {
-			return err
-		}
-
-		rawMessage, err := proofResponse.VerifiablePresentation.MarshalJSON()
-		if err != nil {
-			return errors.Errorf("failed get VerifiablePresentation: %v", err)
+		// none of the query circuits outputs a value of the credential a proof was generated with, so the proofs
+		// of several predicates could be of different credentials of the user and are not verified together
+		if len(query.Predicates()) > 1 {
+			return errors.Errorf("zk request id %v has several predicates, only one predicate is verified for each request", proofRequest.ID)
 		}
-		if string(rawMessage) == "null" {
-			rawMessage = nil
+		proofResponse := findProofByRequestID(response.Body.Scope, proofRequest.ID)
+		if proofResponse == nil {
+			return errors.Errorf("proof for zk request id %v is presented not found", proofRequest.ID)
 		}
 
-		// TODO: re-add the VerifyQuery check
-		err = cv.VerifyQuery(ctx, query, v.claimSchemaLoader, rawMessage)
+		err = v.verifyProofResponse(ctx, response.From, proofRequest, *proofResponse, query, opts...)
 		if err != nil {
 			return err
 		}
+	}
 
-		err = cv.VerifyStates(ctx, v.stateResolver, opts...)
-		if err != nil {
-			return err
+	return nil
+}
+
+// verifyProofResponse verifies the proof of the query of the request
+func (v *Verifier) verifyProofResponse(
+	ctx context.Context,
+	from string,
+	proofRequest protocol.ZeroKnowledgeProofRequest,
+	proofResponse protocol.ZeroKnowledgeProofResponse,
+	query pubsignals.Query,
+	opts ...pubsignals.VerifyOpt,
+) error {
+	if proofRequest.CircuitID != proofResponse.CircuitID {
+		return errors.Errorf("proof response for request id %v has different circuit id than requested. requested %s - presented %s", proofRequest.ID, proofRequest.CircuitID, proofResponse.CircuitID)
+	}
+
+	verificationKey, err := v.verificationKeyLoader.Load(circuits.CircuitID(proofResponse.CircuitID))
+	if err != nil {
+		return err
+	}
+	err = proofs.VerifyProof(proofResponse, verificationKey)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("proof with request id %v and circuit id %s is not valid", proofRequest.ID, proofRequest.CircuitID))
+	}
+
+	cv, err := getPublicSignalsVerifier(circuits.CircuitID(proofResponse.CircuitID), proofResponse.PubSignals)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("circuit with id %s is not supported by library", proofRequest.CircuitID))
+	}
+
+	// verify proof author. The authV2 proofs are bound to the challenge of the query, if it has one,
+	// and to the request id otherwise
+
+	ownership := big.NewInt(int64(proofResponse.ID))
+	if query.Challenge != "" && circuits.CircuitID(proofResponse.CircuitID) == circuits.AuthV2CircuitID {
+		var ok bool
+		if ownership, ok = new(big.Int).SetString(query.Challenge, 10); !ok {
+			return errors.Errorf("zk request id %v has an invalid challenge", proofRequest.ID)
 		}
 	}
+	err = cv.VerifyIDOwnership(from, ownership)
+	if err != nil {
+		return err
+	}
+
+	rawMessage, err := proofResponse.VerifiablePresentation.MarshalJSON()
+	if err != nil {
+		return errors.Errorf("failed get VerifiablePresentation: %v", err)
+	}
+	if string(rawMessage) == "null" {
+		rawMessage = nil
+	}
+
+	err = cv.VerifyQuery(ctx, query, v.claimSchemaLoader, rawMessage, opts...)
+	if err != nil {
+		return err
+	}
+
+	err = cv.VerifyStates(ctx, v.stateResolver, opts...)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
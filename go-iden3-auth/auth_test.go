@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/iden3/go-rapidsnark/types"
@@ -25,6 +26,9 @@ import (
 var verificationKeyloader = &loaders.FSKeyLoader{Dir: "./testdata"}
 var schemaLoader = &mockMemorySchemaLoader{}
 
+// proofs in the tests were generated long ago, so the proof timestamp window is widened
+var proofGenerationDelay = pubsignals.WithAcceptedProofGenerationDelay(time.Hour * 24 * 365 * 100)
+
 /*
 mock for schema loader
 */
@@ -252,7 +256,7 @@ func TestVerifyMessageWithSigProof_NonMerkalized(t *testing.T) {
 	}
 
 	authInstance := NewVerifier(verificationKeyloader, schemaLoader, stateResolvers)
-	err := authInstance.VerifyAuthResponse(context.Background(), message, request)
+	err := authInstance.VerifyAuthResponse(context.Background(), message, request, proofGenerationDelay)
 	require.Nil(t, err)
 }
 
@@ -413,7 +417,7 @@ func TestVerifyMessageWithMTPProof_Merkalized(t *testing.T) {
 	}
 
 	authInstance := NewVerifier(verificationKeyloader, schemaLoader, stateResolvers)
-	err := authInstance.VerifyAuthResponse(context.Background(), message, request)
+	err := authInstance.VerifyAuthResponse(context.Background(), message, request, proofGenerationDelay)
 	require.Nil(t, err)
 }
 
@@ -461,7 +465,7 @@ func TestVerifier_FullVerify(t *testing.T) {
 	token := ` eyJhbGciOiJncm90aDE2IiwiY2lyY3VpdElkIjoiYXV0aFYyIiwiY3JpdCI6WyJjaXJjdWl0SWQiXSwidHlwIjoiSldaIn0.eyJpZCI6IjI3NGI1ODE5LWMxNDctNGExNy1iNGUxLTRmZDJhOWNmNTdhNSIsInR5cCI6ImFwcGxpY2F0aW9uL2lkZW4zY29tbS1wbGFpbi1qc29uIiwidHlwZSI6Imh0dHBzOi8vaWRlbjMtY29tbXVuaWNhdGlvbi5pby9hdXRob3JpemF0aW9uLzEuMC9yZXNwb25zZSIsInRoaWQiOiI4NWFjMjc3Yi0xYWZlLTQzY2EtYWNmZC1mOTM5ZTAwODBkZDYiLCJib2R5Ijp7Im1lc3NhZ2UiOiJtZXNzYWdlIHRvIHNpZ24iLCJzY29wZSI6W3siaWQiOjEwLCJjaXJjdWl0SWQiOiJjcmVkZW50aWFsQXRvbWljUXVlcnlNVFBWMiIsInByb29mIjp7InBpX2EiOlsiOTUxNzExMjQ5MjQyMjQ4NjQxODM0NDY3MTUyMzc1MjY5MTE2MzYzNzYxMjMwNTU5MDU3MTYyNDM2MzY2ODg4NTc5NjkxMTE1MDMzMyIsIjg4NTU5Mzg0NTAyNzYyNTEyMDIzODcwNzM2NDY5NDMxMzYzMDY3MjA0MjI2MDMxMjM4NTQ3NjkyMzUxNTE3NTg1NDE0MzQ4MDc5NjgiLCIxIl0sInBpX2IiOltbIjE4ODgwNTY4MzIwODg0NDY2OTIzOTMwNTY0OTI1NTY1NzI3OTM5MDY3NjI4NjU1MjI3OTk5MjUyMjk2MDg0OTIzNzgyNzU1ODYwNDc2IiwiODcyNDg5MzQxNTE5NzQ1ODU0MzY5NTE5MjQ1NTc5ODU5NzQwMjM5NTA0NDkzMDIxNDQ3MTQ5Nzc3ODg4ODc0ODMxOTEyOTkwNTQ3OSJdLFsiOTgwNzU1OTM4MTA0MTQ2NDA3NTM0NzUxOTQzMzEzNzM1MzE0MzE1MTg5MDMzMDkxNjM2Mzg2MTE5Mzg5MTAzNzg2NTk5MzMyMDkyMyIsIjY5OTUyMDI5ODA0NTMyNTYwNjk1MzI3NzE1MjIzOTE2NzkyMjMwODU4MDg0MjY4MDU4NTc2OTgyMDkzMzEyMzI2NzIzODMwNDYwMTkiXSxbIjEiLCIwIl1dLCJwaV9jIjpbIjE2NDUzNjYwMjQ0MDk1Mzc3MTc0NTI1MzMxOTM3NzY1NjI0OTg2MjU4MTc4NDcyNjA4NzIzMTE5NDI5MzA4OTc3NTkxNzA0NTA5Mjk4IiwiNzUyMzE4NzcyNTcwNTE1MjU4NjQyNjg5MTg2ODc0NzI2NTc0NjU0MjA3MjU0NDkzNTMxMDk5MTQwOTg5MzIwNzMzNTM4NTUxOTUxMiIsIjEiXSwicHJvdG9jb2wiOiJncm90aDE2In0sInB1Yl9zaWduYWxzIjpbIjEiLCIyNTA1NDQ2NTkzNTkxNjM0MzczMzQ3MDA2NTk3NzM5MzU1Njg5ODE2NTgzMjc4MzIxNDYyMTg4MjIzOTA1MDAzNTg0NjUxNzI1MCIsIjEwIiwiMjUwNTQ0NjU5MzU5MTYzNDM3MzM0NzAwNjU5NzczOTM1NTY4OTgxNjU4MzI3ODMyMTQ2MjE4ODIyMzkwNTAwMzU4NDY1MTcyNTAiLCI3MTIwNDg1NzcwMDA4NDkwNTc5OTA4MzQzMTY3MDY4OTk5ODA2NDY4MDU2NDAxODAyOTA0NzEzNjUwMDY4NTAwMDAwNjQxNzcyNTc0IiwiMSIsIjcxMjA0ODU3NzAwMDg0OTA1Nzk5MDgzNDMxNjcwNjg5OTk4MDY0NjgwNTY0MDE4MDI5MDQ3MTM2NTAwNjg1MDAwMDA2NDE3NzI1NzQiLCIxNjcxNTQzNTk3IiwiMzM2NjE1NDIzOTAwOTE5NDY0MTkzMDc1NTkyODUwNDgzNzA0NjAwIiwiMCIsIjE3MDAyNDM3MTE5NDM0NjE4NzgzNTQ1Njk0NjMzMDM4NTM3MzgwNzI2MzM5OTk0MjQ0Njg0MzQ4OTEzODQ0OTIzNDIyNDcwODA2ODQ0IiwiMCIsIjUiLCI4NDAiLCIxMjAiLCIzNDAiLCI1MDkiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiLCIwIiwiMCIsIjAiXX1dfSwiZnJvbSI6ImRpZDpwb2x5Z29uaWQ6cG9seWdvbjptdW1iYWk6MnFOQWJmeGFtczJONGVud2dCaGo3eXZQVWJEckx3QzJic0JaWVpDVFFSIiwidG8iOiIxMTI1R0pxZ3c2WUVzS0Z3ajYzR1k4N01NeFBMOWt3REt4UFVpd01Ub1IifQ.eyJwcm9vZiI6eyJwaV9hIjpbIjgwMzM1NzQwMzc0MjEyOTIxMDY4Mzk5MjkwMTg5ODA4MzcxMDM3NTY2NTA4MTkyMTgzNjgzODYyOTAxNTY1MzY0MTIyNTY5MjQwOTUiLCI1MjA2ODkzODk0MTg2ODE1Mjg1MzIyNjQ3MDUwOTkyNDk0ODQwNzc1MDUwMTM2MzgwMjYyMjM2MTkyNTIwNzQ2ODY1OTA3OTczNDIyIiwiMSJdLCJwaV9iIjpbWyIyMTQzNzU0OTcxNTU3NzA2MzkzNDM3NTM3ODcyMDQwMzIxMzIzNDExODM5MDQ3NjQyMzI3MDY2NTQxNzUwMDA3ODU2ODg2NDE1NzIzOCIsIjY5MTQ0MjkxMTM0ODEwMDQyODYwODcxOTc3MTI4NjgzNjIzMTcwMTQyMTk2MjA3NDg0NjQ4OTgyMjI1MDU2NjA5MzgyMjQ4NDk4MDciXSxbIjEyMzUwMDk4MjEzMjk2OTM4NTM3Mzk0NTEwODQ0MzAyODM3NTk4MTUyOTQ1NTA5NzExNzk2OTg4MzM0MjAzOTY2NzU2MzY2OTQ1NTA4IiwiMjcwOTE5NDc5NjcyNTEzMzA1ODM4Mzc5MTczMjM2NDIxMjA3MTkyNDg2MTQxMjIyOTU4NjUzNTk3Njc1NTc1MjM4NzQyNjUyNzg0MyJdLFsiMSIsIjAiXV0sInBpX2MiOlsiMTkwMTQ3MzM1MTgwNTE1Nzg1MDk3Nzc4MzQ0ODEwMjg3NzkzODc1NDI0NjAwMjE5MzI3OTk1MTUxNzY4Mzk1NzE2MDI5MDU0ODQyNTIiLCIyMTUwNDg1MzA5MDQ0MTc3MDMzMzA2NDI4NDk3MjY1MDE3NDI2OTc5MjA3OTg1MTY1Mzk3NzczMjc0MjcyMDY2ODExNDAwMjk1OTQ5MCIsIjEiXSwicHJvdG9jb2wiOiJncm90aDE2In0sInB1Yl9zaWduYWxzIjpbIjI1MDU0NDY1OTM1OTE2MzQzNzMzNDcwMDY1OTc3MzkzNTU2ODk4MTY1ODMyNzgzMjE0NjIxODgyMjM5MDUwMDM1ODQ2NTE3MjUwIiwiODE4ODQ4NTI3MDk2MTY2NzYwMTc3MjQ5OTE2ODMwNzU2MDEyNDYxNzM5MjE3NzcxODQyODUxODg3NjgyNjU4MjAzNzk0NjU4MzI2NCIsIjUzMDQ2ODU5NDU1MjQxNzcyMDgzNDk0NzM3NzcyMzM5NzA2OTY1NTU4MDQ3NDA3MzYxNTg2MDY4MjUxMTY4MDYwODAwNTQ2MDQzODUiXX0`
 
 	authInstance := NewVerifier(verificationKeyloader, schemaLoader, stateResolvers)
-	_, err := authInstance.FullVerify(context.Background(), token, request)
+	_, err := authInstance.FullVerify(context.Background(), token, request, proofGenerationDelay)
 	assert.Nil(t, err)
 
 }
@@ -620,6 +624,75 @@ func TestVerifyAuthResponseWithEmptyReq_ErrorCase(t *testing.T) {
 	assert.ErrorIs(t, err, pubsignals.ErrRequestOperator)
 }
 
+func TestVerifyAuthResponseWithSeveralPredicates_ErrorCase(t *testing.T) {
+
+	verifierID := "1125GJqgw6YEsKFwj63GY87MMxPL9kwDKxPUiwMLNZ"
+	userID := "did:polygonid:polygon:mumbai:2qNAbfxams2N4enwgBhj7yvPUbDrLwC2bsBZYZCTQR"
+
+	var zkReq protocol.ZeroKnowledgeProofRequest
+	zkReq.ID = 10
+	zkReq.CircuitID = string(circuits.AtomicQueryMTPV2CircuitID)
+	zkReq.Query = map[string]interface{}{
+		"allowedIssuers": []string{"*"},
+		"context":        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		"type":           "KYCAgeCredential",
+		"credentialSubject": map[string]interface{}{
+			"birthday":     map[string]interface{}{"$lt": 20000101},
+			"documentType": map[string]interface{}{"$eq": 2},
+		},
+	}
+
+	authReq := CreateAuthorizationRequestWithMessage("test", "", verifierID, "https://test.com/callback")
+	authReq.Body.Scope = append(authReq.Body.Scope, zkReq)
+
+	// the proofs of the two predicates are of two credentials of the user, issued in the same issuer state:
+	// the public signals only differ in the predicate, nothing in them tells the credentials apart
+	credentialProof := func(claimPathKey, operator, value string) protocol.ZeroKnowledgeProofResponse {
+		return protocol.ZeroKnowledgeProofResponse{
+			ID:        10,
+			CircuitID: string(circuits.AtomicQueryMTPV2CircuitID),
+			ZKProof: types.ZKProof{
+				Proof: &types.ProofData{Protocol: "groth16"},
+				PubSignals: []string{
+					"1",
+					"25054465935916343733470065977393556898165832783214621882239050035846517250",
+					"10",
+					"25054465935916343733470065977393556898165832783214621882239050035846517250",
+					"7120485770008490579908343167068999806468056401802904713650068500000641772574",
+					"1",
+					"7120485770008490579908343167068999806468056401802904713650068500000641772574",
+					"1671543597",
+					"336615423900919464193075592850483704600",
+					"0",
+					claimPathKey,
+					"0",
+					operator,
+					value,
+				},
+			},
+		}
+	}
+	resp := protocol.AuthorizationResponseMessage{
+		ID:       "1",
+		Typ:      packers.MediaTypePlainMessage,
+		Type:     protocol.AuthorizationResponseMessageType,
+		ThreadID: authReq.ThreadID,
+		Body: protocol.AuthorizationMessageResponseBody{
+			Scope: []protocol.ZeroKnowledgeProofResponse{
+				credentialProof("17002437119434618783545694633038537380726339994244684348913844923422470806844", "2", "20000101"),
+				credentialProof("12891444986491254085560597052395677934694594587847693550621945641098238258096", "1", "2"),
+			},
+		},
+		From: userID,
+		To:   authReq.From,
+	}
+
+	authInstance := NewVerifier(verificationKeyloader, schemaLoader, map[string]pubsignals.StateResolver{})
+	err := authInstance.VerifyAuthResponse(context.Background(), resp, authReq)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "several predicates")
+}
+
 func TestCreateAuthorizationRequest(t *testing.T) {
 
 	sender := "1125GJqgw6YEsKFwj63GY87MMxPL9kwDKxPUiwMLNZ"
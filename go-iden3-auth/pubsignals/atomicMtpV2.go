@@ -24,6 +24,7 @@ func (c *AtomicQueryMTPV2) VerifyQuery(
 	query Query,
 	schemaLoader loaders.SchemaLoader,
 	verifiablePresentation json.RawMessage,
+	opts ...VerifyOpt,
 ) error {
 	return query.Check(ctx, schemaLoader, &CircuitOutputs{
 		IssuerID:            c.IssuerID,
@@ -37,7 +38,7 @@ func (c *AtomicQueryMTPV2) VerifyQuery(
 		ClaimPathNotExists:  c.ClaimPathNotExists,
 		ValueArraySize:      c.ValueArraySize,
 		IsRevocationChecked: c.IsRevocationChecked,
-	}, verifiablePresentation)
+	}, verifiablePresentation, opts...)
 }
 
 // VerifyStates verifies user state and issuer claim issuance state in the smart contract.
@@ -46,9 +47,9 @@ func (c *AtomicQueryMTPV2) VerifyStates(ctx context.Context, stateResolvers map[
 	if err != nil {
 		return err
 	}
-	resolver, ok := stateResolvers[fmt.Sprintf("%s:%s", issuerDID.Blockchain, issuerDID.NetworkID)]
-	if !ok {
-		return errors.Errorf("%s resolver not found", resolver)
+	resolver, err := GetStateResolver(stateResolvers, issuerDID)
+	if err != nil {
+		return err
 	}
 
 	issuerStateResolved, err := resolver.Resolve(ctx, c.IssuerID.BigInt(), c.IssuerClaimIdenState.BigInt())
@@ -23,21 +23,16 @@ func (c *AtomicQueryMTPV2OnChain) VerifyQuery(
 	ctx context.Context,
 	query Query,
 	schemaLoader loaders.SchemaLoader,
-	verifiablePresentation json.RawMessage,
+	_ json.RawMessage,
+	opts ...VerifyOpt,
 ) error {
-	return query.Check(ctx, schemaLoader, &CircuitOutputs{
+	return query.CheckOnChain(ctx, schemaLoader, &OnChainCircuitOutputs{
 		IssuerID:            c.IssuerID,
-		ClaimSchema:         c.ClaimSchema,
-		SlotIndex:           c.SlotIndex,
-		Operator:            c.Operator,
-		Value:               c.Value,
+		QueryHash:           c.QueryHash,
 		Timestamp:           c.Timestamp,
 		Merklized:           c.Merklized,
-		ClaimPathKey:        c.ClaimPathKey,
-		ClaimPathNotExists:  c.ClaimPathNotExists,
-		ValueArraySize:      c.ValueArraySize,
 		IsRevocationChecked: c.IsRevocationChecked,
-	}, verifiablePresentation)
+	}, opts...)
 }
 
 // VerifyStates verifies user state and issuer claim issuance state in the smart contract.
@@ -46,9 +41,9 @@ func (c *AtomicQueryMTPV2OnChain) VerifyStates(ctx context.Context, stateResolve
 	if err != nil {
 		return err
 	}
-	resolver, ok := stateResolvers[fmt.Sprintf("%s:%s", issuerDID.Blockchain, issuerDID.NetworkID)]
-	if !ok {
-		return errors.Errorf("%s resolver not found", resolver)
+	resolver, err := GetStateResolver(stateResolvers, issuerDID)
+	if err != nil {
+		return err
 	}
 
 	issuerStateResolved, err := resolver.Resolve(ctx, c.IssuerID.BigInt(), c.IssuerClaimIdenState.BigInt())
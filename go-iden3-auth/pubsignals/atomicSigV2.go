@@ -3,7 +3,6 @@ package pubsignals
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"time"
 
@@ -24,6 +23,7 @@ func (c *AtomicQuerySigV2) VerifyQuery(
 	query Query,
 	schemaLoader loaders.SchemaLoader,
 	verifiablePresentation json.RawMessage,
+	opts ...VerifyOpt,
 ) error {
 	err := query.Check(ctx, schemaLoader, &CircuitOutputs{
 		IssuerID:            c.IssuerID,
@@ -37,7 +37,7 @@ func (c *AtomicQuerySigV2) VerifyQuery(
 		ClaimPathNotExists:  c.ClaimPathNotExists,
 		ValueArraySize:      c.ValueArraySize,
 		IsRevocationChecked: c.IsRevocationChecked,
-	}, verifiablePresentation)
+	}, verifiablePresentation, opts...)
 	if err != nil {
 		return err
 	}
@@ -50,9 +50,9 @@ func (c *AtomicQuerySigV2) VerifyStates(ctx context.Context, stateResolvers map[
 	if err != nil {
 		return err
 	}
-	resolver, ok := stateResolvers[fmt.Sprintf("%s:%s", issuerDID.Blockchain, issuerDID.NetworkID)]
-	if !ok {
-		return errors.Errorf("%s resolver not found", resolver)
+	resolver, err := GetStateResolver(stateResolvers, issuerDID)
+	if err != nil {
+		return err
 	}
 
 	issuerStateResolved, err := resolver.Resolve(ctx, c.IssuerID.BigInt(), c.IssuerAuthState.BigInt())
@@ -3,7 +3,6 @@ package pubsignals
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"math/big"
 	"time"
 
@@ -23,7 +22,8 @@ func (c *AuthV2) VerifyQuery(
 	_ context.Context,
 	_ Query,
 	_ loaders.SchemaLoader,
-	_ json.RawMessage) error {
+	_ json.RawMessage,
+	_ ...VerifyOpt) error {
 	return errors.New("authV2 circuit doesn't support queries")
 }
 
@@ -33,10 +33,9 @@ func (c *AuthV2) VerifyStates(ctx context.Context, stateResolvers map[string]Sta
 	if err != nil {
 		return err
 	}
-	chainInfo := fmt.Sprintf("%s:%s", userDID.Blockchain, userDID.NetworkID)
-	resolver, ok := stateResolvers[chainInfo]
-	if !ok {
-		return errors.Errorf("%s resolver not found", chainInfo)
+	resolver, err := GetStateResolver(stateResolvers, userDID)
+	if err != nil {
+		return err
 	}
 
 	resolvedState, err := resolver.ResolveGlobalRoot(ctx, c.GISTRoot.BigInt())
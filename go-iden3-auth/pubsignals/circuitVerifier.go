@@ -3,8 +3,12 @@ package pubsignals
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 
+	core "github.com/iden3/go-iden3-core"
+	"github.com/pkg/errors"
+
 	"github.com/lastingasset/wallet-service/go-circuits"
 	"github.com/lastingasset/wallet-service/go-iden3-auth/loaders"
 	"github.com/lastingasset/wallet-service/go-iden3-auth/state"
@@ -16,9 +20,28 @@ type StateResolver interface {
 	ResolveGlobalRoot(ctx context.Context, state *big.Int) (*state.ResolvedState, error)
 }
 
+// AnyChain is the key of the state resolver used for the chains without a resolver of their own.
+const AnyChain = "*"
+
+// GetStateResolver returns the resolver of the chain of the given DID.
+// Resolvers are looked up by "blockchain:network", then by "blockchain" and finally by AnyChain.
+func GetStateResolver(stateResolvers map[string]StateResolver, did *core.DID) (StateResolver, error) {
+	keys := []string{
+		fmt.Sprintf("%s:%s", did.Blockchain, did.NetworkID),
+		string(did.Blockchain),
+		AnyChain,
+	}
+	for _, key := range keys {
+		if resolver, ok := stateResolvers[key]; ok {
+			return resolver, nil
+		}
+	}
+	return nil, errors.Errorf("%s resolver not found", keys[0])
+}
+
 // Verifier is interface for verification of public signals of zkp
 type Verifier interface {
-	VerifyQuery(ctx context.Context, query Query, schemaLoader loaders.SchemaLoader, verifiablePresentation json.RawMessage) error
+	VerifyQuery(ctx context.Context, query Query, schemaLoader loaders.SchemaLoader, verifiablePresentation json.RawMessage, opts ...VerifyOpt) error
 	VerifyStates(ctx context.Context, resolvers map[string]StateResolver, opts ...VerifyOpt) error
 	VerifyIDOwnership(userIdentifier string, challenge *big.Int) error
 
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
+	"time"
 
 	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/poseidon"
 	jsonSuite "github.com/iden3/go-schema-processor/json"
 	"github.com/iden3/go-schema-processor/merklize"
 	"github.com/iden3/go-schema-processor/utils"
@@ -19,6 +22,9 @@ import (
 // PathToSubjectType path to description of subject type.
 const PathToSubjectType = "https://www.w3.org/2018/credentials#credentialSubject"
 
+// onChainValueArraySize is the size of the values array hashed by the on-chain circuits.
+const onChainValueArraySize = 64
+
 var (
 	// ErrUnavailableIssuer issuer from proof not allowed.
 	ErrUnavailableIssuer = errors.New("issuer not exists in query access list")
@@ -30,6 +36,14 @@ var (
 	ErrValuesSize = errors.New("query asked proof about more values")
 	// ErrInvalidValues proof was created for different values.
 	ErrInvalidValues = errors.New("proof was generated for anther values")
+	// ErrProofGenerationOutdated proof was created outside the accepted time window.
+	ErrProofGenerationOutdated = errors.New("proof was generated outside the accepted time window")
+	// ErrQueryHash on-chain proof was created for another query.
+	ErrQueryHash = errors.New("proof was generated for another query hash")
+	// ErrSybilCRS proof was created for another common reference string.
+	ErrSybilCRS = errors.New("proof was generated for another crs")
+	// ErrSybilIDEmpty proof has no sybil id.
+	ErrSybilIDEmpty = errors.New("proof has no sybil id")
 )
 
 // Query represents structure for query to atomic circuit.
@@ -40,6 +54,8 @@ type Query struct {
 	Type                     string                 `json:"type"`
 	ClaimID                  string                 `json:"claimId,omitempty"`
 	SkipClaimRevocationCheck bool                   `json:"skipClaimRevocationCheck,omitempty"`
+	CRS                      string                 `json:"crs,omitempty"`
+	Challenge                string                 `json:"challenge,omitempty"`
 }
 
 // CircuitOutputs pub signals from circuit.
@@ -57,19 +73,71 @@ type CircuitOutputs struct {
 	IsRevocationChecked int
 }
 
+// Predicates returns one query for each field predicate of the credential subject, sorted by field and operator.
+// A field without predicate, which asks to disclose its value, is a query of its own.
+// Each of them is proved with a proof of its own. The proofs do not tell which credential they were generated with,
+// so the verifier only accepts requests with a single predicate.
+func (q Query) Predicates() []Query {
+	conditions := SplitPredicates(q.CredentialSubject)
+	predicates := make([]Query, len(conditions))
+	for i, condition := range conditions {
+		predicates[i] = q
+		predicates[i].CredentialSubject = condition
+	}
+	return predicates
+}
+
+// SplitPredicates splits the field predicates of a credential subject query in one query for each of them,
+// sorted by field and operator. A query without fields is returned as is.
+func SplitPredicates(credentialSubject map[string]interface{}) []map[string]interface{} {
+	if len(credentialSubject) == 0 {
+		return []map[string]interface{}{credentialSubject}
+	}
+
+	fields := make([]string, 0, len(credentialSubject))
+	for field := range credentialSubject {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	predicates := make([]map[string]interface{}, 0, len(fields))
+	for _, field := range fields {
+		fieldPredicate, ok := credentialSubject[field].(map[string]interface{})
+		if !ok || len(fieldPredicate) <= 1 {
+			predicates = append(predicates, map[string]interface{}{field: credentialSubject[field]})
+			continue
+		}
+
+		operators := make([]string, 0, len(fieldPredicate))
+		for op := range fieldPredicate {
+			operators = append(operators, op)
+		}
+		sort.Strings(operators)
+		for _, op := range operators {
+			predicates = append(predicates, map[string]interface{}{field: map[string]interface{}{op: fieldPredicate[op]}})
+		}
+	}
+	return predicates
+}
+
 // Check checks if proof was created for this query.
 func (q Query) Check(
 	ctx context.Context,
 	loader loaders.SchemaLoader,
 	pubSig *CircuitOutputs,
 	verifiablePresentation json.RawMessage,
+	opts ...VerifyOpt,
 ) error {
-	// TODO
 	if err := q.verifyIssuer(pubSig); err != nil {
 		return err
 	}
 
-	if err := q.verifySchemaID(pubSig); err != nil {
+	schemaBytes, _, err := loader.Load(ctx, q.Context)
+	if err != nil {
+		return fmt.Errorf("failed load schema by context: %w", err)
+	}
+
+	if err := q.verifySchemaID(schemaBytes, pubSig); err != nil {
 		return err
 	}
 
@@ -81,12 +149,158 @@ func (q Query) Check(
 		return errors.New("check revocation is required")
 	}
 
+	if err := q.verifyClaim(ctx, schemaBytes, pubSig); err != nil {
+		return err
+	}
+
+	cfg := defaultProofVerifyOpts
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return verifyTimestamp(pubSig, cfg)
+}
+
+// OnChainCircuitOutputs pub signals from on-chain circuits, that output the hash of the query instead of the query.
+type OnChainCircuitOutputs struct {
+	IssuerID            *core.ID
+	QueryHash           *big.Int
+	Timestamp           int64
+	Merklized           int
+	IsRevocationChecked int
+}
+
+// CheckOnChain checks if an on-chain proof was created for this query, by comparing the query hash of the proof
+// with the hash of the query.
+func (q Query) CheckOnChain(
+	ctx context.Context,
+	loader loaders.SchemaLoader,
+	pubSig *OnChainCircuitOutputs,
+	opts ...VerifyOpt,
+) error {
+	if err := q.verifyIssuer(&CircuitOutputs{IssuerID: pubSig.IssuerID}); err != nil {
+		return err
+	}
+
 	schemaBytes, _, err := loader.Load(ctx, q.Context)
 	if err != nil {
 		return fmt.Errorf("failed load schema by context: %w", err)
 	}
 
-	return q.verifyClaim(ctx, schemaBytes, pubSig)
+	queryHash, err := q.onChainQueryHash(schemaBytes, pubSig.Merklized == 1)
+	if err != nil {
+		return err
+	}
+	if pubSig.QueryHash == nil || queryHash.Cmp(pubSig.QueryHash) != 0 {
+		return ErrQueryHash
+	}
+
+	if !q.SkipClaimRevocationCheck && pubSig.IsRevocationChecked == 0 {
+		return errors.New("check revocation is required")
+	}
+
+	cfg := defaultProofVerifyOpts
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return verifyTimestamp(&CircuitOutputs{Timestamp: pubSig.Timestamp}, cfg)
+}
+
+// onChainQueryHash returns the hash the on-chain circuits output for the query:
+// poseidon(claimSchema, slotIndex, operator, claimPathKey, claimPathNotExists, valuesHash).
+func (q Query) onChainQueryHash(schemaBytes []byte, merklized bool) (*big.Int, error) {
+	schemaHash, err := q.schemaHash(schemaBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	slotIndex, operator := 0, circuits.NOOP
+	claimPathKey := big.NewInt(0)
+	values := make([]*big.Int, 0)
+	if len(q.CredentialSubject) > 0 {
+		fieldName, fieldPredicate, err := extractQueryFields(q.CredentialSubject)
+		if err != nil {
+			return nil, err
+		}
+		if len(fieldPredicate) == 0 {
+			return nil, errors.New("selective disclosure not available for on-chain proofs")
+		}
+		values, operator, err = parseFieldPredicate(fieldPredicate)
+		if err != nil {
+			return nil, err
+		}
+
+		if merklized {
+			claimPathKey, err = q.claimPathKey(schemaBytes, fieldName)
+		} else {
+			slotIndex, err = jsonSuite.Parser{}.GetFieldSlotIndex(fieldName, schemaBytes)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(values) > onChainValueArraySize {
+		return nil, ErrValuesSize
+	}
+	padded := make([]*big.Int, onChainValueArraySize)
+	for i := range padded {
+		padded[i] = big.NewInt(0)
+		if i < len(values) {
+			padded[i] = values[i]
+		}
+	}
+	valuesHash, err := circuits.PoseidonHashValue(padded)
+	if err != nil {
+		return nil, err
+	}
+
+	return poseidon.Hash([]*big.Int{
+		schemaHash.BigInt(),
+		big.NewInt(int64(slotIndex)),
+		big.NewInt(int64(operator)),
+		claimPathKey,
+		big.NewInt(0),
+		valuesHash,
+	})
+}
+
+// CheckSybil checks if a sybil resistance proof was created for this query: for an allowed issuer and the schema
+// of the query, with the crs of the query, and within the accepted time window.
+func (q Query) CheckSybil(
+	ctx context.Context,
+	loader loaders.SchemaLoader,
+	pubSig *CircuitOutputs,
+	sybilID *big.Int,
+	crs *big.Int,
+	opts ...VerifyOpt,
+) error {
+	if err := q.verifyIssuer(pubSig); err != nil {
+		return err
+	}
+
+	schemaBytes, _, err := loader.Load(ctx, q.Context)
+	if err != nil {
+		return fmt.Errorf("failed load schema by context: %w", err)
+	}
+
+	if err := q.verifySchemaID(schemaBytes, pubSig); err != nil {
+		return err
+	}
+
+	queryCRS, ok := new(big.Int).SetString(q.CRS, 10)
+	if !ok || crs == nil || queryCRS.Cmp(crs) != 0 {
+		return ErrSybilCRS
+	}
+
+	if sybilID == nil || sybilID.Sign() == 0 {
+		return ErrSybilIDEmpty
+	}
+
+	cfg := defaultProofVerifyOpts
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return verifyTimestamp(pubSig, cfg)
 }
 
 func (q Query) verifyClaim(_ context.Context, schemaBytes []byte, pubSig *CircuitOutputs) error {
@@ -100,17 +314,7 @@ func (q Query) verifyClaim(_ context.Context, schemaBytes []byte, pubSig *Circui
 	}
 
 	if pubSig.Merklized == 1 {
-		path, err := merklize.NewFieldPathFromContext(schemaBytes, q.Type, fieldName)
-		if err != nil {
-			return err
-		}
-
-		err = path.Prepend(PathToSubjectType)
-		if err != nil {
-			return err
-		}
-
-		mkPath, err := path.MtEntry()
+		mkPath, err := q.claimPathKey(schemaBytes, fieldName)
 		if err != nil {
 			return err
 		}
@@ -148,13 +352,85 @@ func (q Query) verifyIssuer(pubSig *CircuitOutputs) error {
 	return ErrUnavailableIssuer
 }
 
-func (q Query) verifySchemaID(pubSig *CircuitOutputs) error {
-	schemaID := fmt.Sprintf("%s#%s", q.Context, q.Type)
-	querySchema := utils.CreateSchemaHash([]byte(schemaID))
+func (q Query) verifySchemaID(schemaBytes []byte, pubSig *CircuitOutputs) error {
+	querySchema, err := q.schemaHash(schemaBytes)
+	if err != nil {
+		return err
+	}
 	if querySchema.BigInt().Cmp(pubSig.ClaimSchema.BigInt()) == 0 {
 		return nil
 	}
-	// TODO: return ErrSchemaID
+	return ErrSchemaID
+}
+
+// schemaHash returns the hash of the schema of the query type.
+func (q Query) schemaHash(schemaBytes []byte) (core.SchemaHash, error) {
+	schemaID, err := typeIDFromContext(schemaBytes, q.Type)
+	if err != nil {
+		return core.SchemaHash{}, err
+	}
+	if schemaID == "" {
+		schemaID = fmt.Sprintf("%s#%s", q.Context, q.Type)
+	}
+	return utils.CreateSchemaHash([]byte(schemaID)), nil
+}
+
+// claimPathKey returns the merklized path of the given field of the credential subject of the query type.
+func (q Query) claimPathKey(schemaBytes []byte, fieldName string) (*big.Int, error) {
+	path, err := merklize.NewFieldPathFromContext(schemaBytes, q.Type, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = path.Prepend(PathToSubjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.MtEntry()
+}
+
+// typeIDFromContext returns the @id of the given type declared in the JSON-LD context,
+// or an empty string if the context does not declare it.
+func typeIDFromContext(schemaBytes []byte, typ string) (string, error) {
+	var doc struct {
+		Context interface{} `json:"@context"`
+	}
+	if err := json.Unmarshal(schemaBytes, &doc); err != nil {
+		return "", errors.Errorf("failed to parse schema context: %v", err)
+	}
+
+	contexts, ok := doc.Context.([]interface{})
+	if !ok {
+		contexts = []interface{}{doc.Context}
+	}
+
+	for _, c := range contexts {
+		terms, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		def, ok := terms[typ].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := def["@id"].(string); ok {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// verifyTimestamp checks the proof was generated neither before the accepted
+// proof generation delay nor in the future, beyond the accepted clock skew.
+func verifyTimestamp(pubSig *CircuitOutputs, cfg VerifyConfig) error {
+	generatedAt := time.Unix(pubSig.Timestamp, 0)
+	if time.Since(generatedAt) > cfg.acceptedProofGenerationDelay {
+		return ErrProofGenerationOutdated
+	}
+	if time.Until(generatedAt) > cfg.acceptedClockSkew {
+		return ErrProofGenerationOutdated
+	}
 	return nil
 }
 
@@ -177,28 +453,43 @@ func (q Query) verifyCredentialSubject(pubSig *CircuitOutputs, verifiablePresent
 		return err
 	}
 
-	// if operator != pubSig.Operator {
-	// 	return ErrRequestOperator
-	// }
+	if operator != pubSig.Operator {
+		return ErrRequestOperator
+	}
 
 	if operator == circuits.NOOP {
 		return nil
 	}
 
-	if len(values) > len(pubSig.Value) {
-		return ErrValuesSize
-	}
+	return verifyValues(operator, values, pubSig.Value)
+}
 
-	if len(values) < pubSig.ValueArraySize {
-		diff := pubSig.ValueArraySize - len(values)
-		for diff > 0 {
-			values = append(values, big.NewInt(0))
-			diff--
+// verifyValues checks the proof values are the query ones, padded with zeros.
+// $eq, $lt, $gt and $ne compare with a single value, $in and $nin with a list of values.
+func verifyValues(operator int, values, proofValues []*big.Int) error {
+	switch operator {
+	case circuits.EQ, circuits.LT, circuits.GT, circuits.NE:
+		if len(values) != 1 {
+			return errors.New("query operator supports only one value")
 		}
+	case circuits.IN, circuits.NIN:
+		if len(values) == 0 {
+			return errors.New("query operator requires at least one value")
+		}
+	default:
+		return errors.New("query operator is not supported")
 	}
 
-	for i := 0; i < len(values); i++ {
-		if values[i].Cmp(pubSig.Value[i]) != 0 {
+	if len(values) > len(proofValues) {
+		return ErrValuesSize
+	}
+
+	for i, proofValue := range proofValues {
+		value := big.NewInt(0)
+		if i < len(values) {
+			value = values[i]
+		}
+		if value.Cmp(proofValue) != 0 {
 			return ErrInvalidValues
 		}
 	}
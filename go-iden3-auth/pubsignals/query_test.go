@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/stretchr/testify/require"
@@ -129,6 +130,29 @@ func TestCheckRequest_Success(t *testing.T) {
 				Value:               []*big.Int{big.NewInt(800)},
 				Merklized:           1,
 				IsRevocationChecked: 1,
+				Timestamp:           time.Now().Unix(),
+			},
+		},
+		{
+			name: "Check not merklized query",
+			query: Query{
+				AllowedIssuers: []string{"*"},
+				CredentialSubject: map[string]interface{}{
+					"countryCode": map[string]interface{}{
+						"$in": []interface{}{float64(800), float64(900)},
+					},
+				},
+				Context: "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				Type:    "KYCCountryOfResidenceCredential",
+			},
+			pubSig: &CircuitOutputs{
+				IssuerID:            &issuerID,
+				ClaimSchema:         coreSchema,
+				Operator:            4,
+				Value:               []*big.Int{big.NewInt(800), big.NewInt(900), big.NewInt(0)},
+				SlotIndex:           7,
+				IsRevocationChecked: 1,
+				Timestamp:           time.Now().Unix(),
 			},
 		},
 		{
@@ -152,6 +176,7 @@ func TestCheckRequest_Success(t *testing.T) {
 				Value:               []*big.Int{big.NewInt(800)},
 				Merklized:           1,
 				IsRevocationChecked: 1,
+				Timestamp:           time.Now().Unix(),
 			},
 			vp: vp,
 		},
@@ -499,6 +524,92 @@ func TestCheckRequest_Error(t *testing.T) {
 			},
 			expErr: errors.New("check revocation is required"),
 		},
+		{
+			name: "Several values for a scalar operator",
+			query: Query{
+				AllowedIssuers: []string{issuerDID},
+				Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				Type:           "KYCCountryOfResidenceCredential",
+				CredentialSubject: map[string]interface{}{
+					"countryCode": map[string]interface{}{
+						"$lt": []interface{}{float64(20), float64(30)},
+					},
+				},
+			},
+			pubSig: &CircuitOutputs{
+				IssuerID:    &issuerID,
+				ClaimSchema: coreSchema,
+				Operator:    2,
+				Value:       []*big.Int{big.NewInt(20), big.NewInt(30)},
+			},
+			expErr: errors.New("query operator supports only one value"),
+		},
+		{
+			name: "Proof was generated for more values",
+			query: Query{
+				AllowedIssuers: []string{issuerDID},
+				Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				Type:           "KYCCountryOfResidenceCredential",
+				CredentialSubject: map[string]interface{}{
+					"countryCode": map[string]interface{}{
+						"$ne": float64(20),
+					},
+				},
+			},
+			pubSig: &CircuitOutputs{
+				IssuerID:    &issuerID,
+				ClaimSchema: coreSchema,
+				Operator:    6,
+				Value:       []*big.Int{big.NewInt(20), big.NewInt(30)},
+			},
+			expErr: ErrInvalidValues,
+		},
+		{
+			name: "Outdated proof",
+			query: Query{
+				AllowedIssuers: []string{issuerDID},
+				Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				Type:           "KYCCountryOfResidenceCredential",
+				CredentialSubject: map[string]interface{}{
+					"countryCode": map[string]interface{}{
+						"$gt": float64(20),
+					},
+				},
+			},
+			pubSig: &CircuitOutputs{
+				IssuerID:            &issuerID,
+				ClaimSchema:         coreSchema,
+				Operator:            3,
+				Value:               []*big.Int{big.NewInt(20)},
+				SlotIndex:           7,
+				IsRevocationChecked: 1,
+				Timestamp:           time.Now().Add(-48 * time.Hour).Unix(),
+			},
+			expErr: ErrProofGenerationOutdated,
+		},
+		{
+			name: "Proof generated in the future",
+			query: Query{
+				AllowedIssuers: []string{issuerDID},
+				Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+				Type:           "KYCCountryOfResidenceCredential",
+				CredentialSubject: map[string]interface{}{
+					"countryCode": map[string]interface{}{
+						"$gt": float64(20),
+					},
+				},
+			},
+			pubSig: &CircuitOutputs{
+				IssuerID:            &issuerID,
+				ClaimSchema:         coreSchema,
+				Operator:            3,
+				Value:               []*big.Int{big.NewInt(20)},
+				SlotIndex:           7,
+				IsRevocationChecked: 1,
+				Timestamp:           time.Now().Add(time.Hour).Unix(),
+			},
+			expErr: ErrProofGenerationOutdated,
+		},
 	}
 
 	for _, tt := range tests {
@@ -601,3 +712,139 @@ func TestVerifyQuery_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckSybil(t *testing.T) {
+	query := Query{
+		AllowedIssuers: []string{issuerDID},
+		Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		Type:           "KYCCountryOfResidenceCredential",
+		CRS:            "1234567890",
+	}
+	pubSig := &CircuitOutputs{
+		IssuerID:    &issuerID,
+		ClaimSchema: coreSchema,
+		Timestamp:   time.Now().Unix(),
+	}
+
+	tests := []struct {
+		name    string
+		sybilID *big.Int
+		crs     *big.Int
+		expErr  error
+	}{
+		{
+			name:    "Valid proof",
+			sybilID: big.NewInt(42),
+			crs:     big.NewInt(1234567890),
+		},
+		{
+			name:    "Another crs",
+			sybilID: big.NewInt(42),
+			crs:     big.NewInt(987654321),
+			expErr:  ErrSybilCRS,
+		},
+		{
+			name:    "Empty sybil id",
+			sybilID: big.NewInt(0),
+			crs:     big.NewInt(1234567890),
+			expErr:  ErrSybilIDEmpty,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := query.CheckSybil(context.Background(), &mockMemorySchemaLoader{}, pubSig, tc.sybilID, tc.crs)
+			if tc.expErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestCheckOnChain(t *testing.T) {
+	loader := &mockMemorySchemaLoader{}
+	schemaBytes, _, err := loader.Load(context.Background(), "")
+	require.NoError(t, err)
+
+	query := Query{
+		AllowedIssuers: []string{issuerDID},
+		Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		Type:           "KYCCountryOfResidenceCredential",
+		CredentialSubject: map[string]interface{}{
+			"countryCode": map[string]interface{}{
+				"$nin": []interface{}{float64(800)},
+			},
+		},
+	}
+	queryHash, err := query.onChainQueryHash(schemaBytes, false)
+	require.NoError(t, err)
+
+	pubSig := &OnChainCircuitOutputs{
+		IssuerID:            &issuerID,
+		QueryHash:           queryHash,
+		Timestamp:           time.Now().Unix(),
+		IsRevocationChecked: 1,
+	}
+	require.NoError(t, query.CheckOnChain(context.Background(), loader, pubSig))
+
+	t.Run("Another value", func(t *testing.T) {
+		another := query
+		another.CredentialSubject = map[string]interface{}{
+			"countryCode": map[string]interface{}{
+				"$nin": []interface{}{float64(840)},
+			},
+		}
+		require.ErrorIs(t, another.CheckOnChain(context.Background(), loader, pubSig), ErrQueryHash)
+	})
+
+	t.Run("Merklized proof of a non merklized query", func(t *testing.T) {
+		merklized := *pubSig
+		merklized.Merklized = 1
+		require.ErrorIs(t, query.CheckOnChain(context.Background(), loader, &merklized), ErrQueryHash)
+	})
+
+	t.Run("Selective disclosure", func(t *testing.T) {
+		disclosure := query
+		disclosure.CredentialSubject = map[string]interface{}{
+			"countryCode": map[string]interface{}{},
+		}
+		require.Error(t, disclosure.CheckOnChain(context.Background(), loader, pubSig))
+	})
+}
+
+func TestQuery_Predicates(t *testing.T) {
+	query := Query{
+		AllowedIssuers: []string{issuerDID},
+		Context:        "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+		Type:           "KYCAgeCredential",
+		ClaimID:        "f621070e-caf5-11ed-a093-000c2949382b",
+		CredentialSubject: map[string]interface{}{
+			"countryCode": map[string]interface{}{
+				"$in": []interface{}{float64(800), float64(840)},
+			},
+			"birthday": map[string]interface{}{
+				"$lt": float64(20050101),
+				"$gt": float64(19500101),
+			},
+			"documentType": map[string]interface{}{},
+		},
+	}
+
+	predicates := query.Predicates()
+	require.Len(t, predicates, 4)
+	require.Equal(t, map[string]interface{}{"birthday": map[string]interface{}{"$gt": float64(19500101)}}, predicates[0].CredentialSubject)
+	require.Equal(t, map[string]interface{}{"birthday": map[string]interface{}{"$lt": float64(20050101)}}, predicates[1].CredentialSubject)
+	require.Equal(t, map[string]interface{}{"countryCode": map[string]interface{}{"$in": []interface{}{float64(800), float64(840)}}}, predicates[2].CredentialSubject)
+	require.Equal(t, map[string]interface{}{"documentType": map[string]interface{}{}}, predicates[3].CredentialSubject)
+	for _, predicate := range predicates {
+		require.Equal(t, query.ClaimID, predicate.ClaimID)
+		require.Equal(t, query.Type, predicate.Type)
+	}
+
+	t.Run("Single predicate", func(t *testing.T) {
+		single := query
+		single.CredentialSubject = map[string]interface{}{"birthday": map[string]interface{}{"$lt": float64(20050101)}}
+		require.Equal(t, []Query{single}, single.Predicates())
+	})
+}
@@ -35,6 +35,9 @@ func init() {
 	RegisterVerifier(circuits.AtomicQuerySigV2CircuitID, reflect.TypeOf(AtomicQuerySigV2{}))
 	RegisterVerifier(circuits.AtomicQueryMTPV2CircuitID, reflect.TypeOf(AtomicQueryMTPV2{}))
 	RegisterVerifier(circuits.AtomicQueryMTPV2OnChainCircuitID, reflect.TypeOf(AtomicQueryMTPV2OnChain{}))
+	RegisterVerifier(circuits.AtomicQuerySigV2OnChainCircuitID, reflect.TypeOf(AtomicQuerySigV2OnChain{}))
+	RegisterVerifier(circuits.SybilMTPCircuitID, reflect.TypeOf(SybilMTP{}))
+	RegisterVerifier(circuits.SybilSigCircuitID, reflect.TypeOf(SybilSig{}))
 }
 
 // GetVerifier return specific public signals verifier
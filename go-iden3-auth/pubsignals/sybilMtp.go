@@ -0,0 +1,120 @@
+package pubsignals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/lastingasset/wallet-service/go-circuits"
+	"github.com/lastingasset/wallet-service/go-iden3-auth/loaders"
+	"github.com/pkg/errors"
+)
+
+// SybilMTP is a wrapper for circuits.SybilAtomicMTPPubSignals.
+type SybilMTP struct {
+	circuits.SybilAtomicMTPPubSignals
+}
+
+// VerifyQuery verifies query for sybil resistance mtp circuit.
+func (c *SybilMTP) VerifyQuery(
+	ctx context.Context,
+	query Query,
+	schemaLoader loaders.SchemaLoader,
+	_ json.RawMessage,
+	opts ...VerifyOpt,
+) error {
+	return query.CheckSybil(ctx, schemaLoader, &CircuitOutputs{
+		IssuerID:    c.IssuerID,
+		ClaimSchema: c.ClaimSchema,
+		Timestamp:   c.Timestamp,
+	}, c.SybilID, c.CRS, opts...)
+}
+
+// VerifyStates verifies the issuer claim issuance and non-revocation states and the global state of the user in the smart contract.
+func (c *SybilMTP) VerifyStates(ctx context.Context, stateResolvers map[string]StateResolver, opts ...VerifyOpt) error {
+	issuerDID, err := core.ParseDIDFromID(*c.IssuerID)
+	if err != nil {
+		return err
+	}
+	resolver, err := GetStateResolver(stateResolvers, issuerDID)
+	if err != nil {
+		return err
+	}
+
+	issuerStateResolved, err := resolver.Resolve(ctx, c.IssuerID.BigInt(), c.IssuerClaimIdenState.BigInt())
+	if err != nil {
+		return err
+	}
+	if issuerStateResolved == nil {
+		return ErrIssuerClaimStateIsNotValid
+	}
+
+	cfg := defaultProofVerifyOpts
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	if err := verifyIssuerNonRevState(ctx, resolver, c.IssuerID, c.IssuerClaimNonRevState.BigInt(), cfg); err != nil {
+		return err
+	}
+
+	return verifyGISTRoot(ctx, stateResolvers, c.UserID, c.GISTRoot.BigInt(), cfg)
+}
+
+// VerifyIDOwnership returns error if ownership id wasn't verified in circuit.
+func (c *SybilMTP) VerifyIDOwnership(sender string, requestID *big.Int) error {
+	if c.RequestID.Cmp(requestID) != 0 {
+		return errors.New("invalid requestID in proof")
+	}
+
+	userDID, err := core.ParseDIDFromID(*c.UserID)
+	if err != nil {
+		return err
+	}
+	if sender != userDID.String() {
+		return fmt.Errorf("sender is not used for proof creation, expected %s, user from public signals: %s}", sender, c.UserID.String())
+	}
+	return nil
+}
+
+// verifyIssuerNonRevState checks the state the issuer claim non-revocation was proved in is the latest one
+// of the issuer, or was replaced within the accepted state transition delay.
+func verifyIssuerNonRevState(ctx context.Context, resolver StateResolver, issuerID *core.ID, state *big.Int, cfg VerifyConfig) error {
+	issuerNonRevStateResolved, err := resolver.Resolve(ctx, issuerID.BigInt(), state)
+	if err != nil {
+		return err
+	}
+
+	if !issuerNonRevStateResolved.Latest && time.Since(
+		time.Unix(issuerNonRevStateResolved.TransitionTimestamp, 0),
+	) > cfg.acceptedStateTransitionDelay {
+		return ErrIssuerNonRevocationClaimStateIsNotValid
+	}
+	return nil
+}
+
+// verifyGISTRoot checks the global state the user was proved in is the latest one, or was replaced
+// within the accepted state transition delay.
+func verifyGISTRoot(ctx context.Context, stateResolvers map[string]StateResolver, userID *core.ID, root *big.Int, cfg VerifyConfig) error {
+	userDID, err := core.ParseDIDFromID(*userID)
+	if err != nil {
+		return err
+	}
+	resolver, err := GetStateResolver(stateResolvers, userDID)
+	if err != nil {
+		return err
+	}
+
+	resolvedState, err := resolver.ResolveGlobalRoot(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	if !resolvedState.Latest && time.Since(time.Unix(resolvedState.TransitionTimestamp, 0)) > cfg.acceptedStateTransitionDelay {
+		return ErrGlobalStateIsNotValid
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package pubsignals
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/lastingasset/wallet-service/go-circuits"
+	"github.com/lastingasset/wallet-service/go-iden3-auth/loaders"
+	"github.com/pkg/errors"
+)
+
+// SybilSig is a wrapper for circuits.SybilAtomicSigPubSignals.
+type SybilSig struct {
+	circuits.SybilAtomicSigPubSignals
+}
+
+// VerifyQuery verifies query for sybil resistance sig circuit.
+func (c *SybilSig) VerifyQuery(
+	ctx context.Context,
+	query Query,
+	schemaLoader loaders.SchemaLoader,
+	_ json.RawMessage,
+	opts ...VerifyOpt,
+) error {
+	return query.CheckSybil(ctx, schemaLoader, &CircuitOutputs{
+		IssuerID:    c.IssuerID,
+		ClaimSchema: c.ClaimSchema,
+		Timestamp:   c.Timestamp,
+	}, c.SybilID, c.CRS, opts...)
+}
+
+// VerifyStates verifies the issuer auth claim and non-revocation states and the global state of the user in the smart contract.
+func (c *SybilSig) VerifyStates(ctx context.Context, stateResolvers map[string]StateResolver, opts ...VerifyOpt) error {
+	issuerDID, err := core.ParseDIDFromID(*c.IssuerID)
+	if err != nil {
+		return err
+	}
+	resolver, err := GetStateResolver(stateResolvers, issuerDID)
+	if err != nil {
+		return err
+	}
+
+	issuerStateResolved, err := resolver.Resolve(ctx, c.IssuerID.BigInt(), c.IssuerAuthState.BigInt())
+	if err != nil {
+		return err
+	}
+	if issuerStateResolved == nil {
+		return ErrIssuerClaimStateIsNotValid
+	}
+
+	cfg := defaultProofVerifyOpts
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	if err := verifyIssuerNonRevState(ctx, resolver, c.IssuerID, c.IssuerClaimNonRevState.BigInt(), cfg); err != nil {
+		return err
+	}
+
+	return verifyGISTRoot(ctx, stateResolvers, c.UserID, c.GISTRoot.BigInt(), cfg)
+}
+
+// VerifyIDOwnership returns error if ownership id wasn't verified in circuit.
+func (c *SybilSig) VerifyIDOwnership(sender string, requestID *big.Int) error {
+	if c.RequestID.Cmp(requestID) != 0 {
+		return errors.New("invalid requestID in proof")
+	}
+
+	userDID, err := core.ParseDIDFromID(*c.UserID)
+	if err != nil {
+		return err
+	}
+	if sender != userDID.String() {
+		return errors.Errorf("sender is not used for proof creation, expected %s, user from public signals: %s}", sender, c.UserID.String())
+	}
+	return nil
+}
@@ -4,7 +4,7 @@ import "time"
 
 var (
 	defaultAuthVerifyOpts  = VerifyConfig{acceptedStateTransitionDelay: time.Minute * 5}
-	defaultProofVerifyOpts = VerifyConfig{acceptedStateTransitionDelay: time.Hour}
+	defaultProofVerifyOpts = VerifyConfig{acceptedStateTransitionDelay: time.Hour, acceptedProofGenerationDelay: time.Hour * 24, acceptedClockSkew: time.Minute * 5}
 )
 
 // WithAcceptedStateTransitionDelay sets the delay of the revoked state.
@@ -14,6 +14,20 @@ func WithAcceptedStateTransitionDelay(duration time.Duration) VerifyOpt {
 	}
 }
 
+// WithAcceptedProofGenerationDelay sets the maximum age of a proof.
+func WithAcceptedProofGenerationDelay(duration time.Duration) VerifyOpt {
+	return func(v *VerifyConfig) {
+		v.acceptedProofGenerationDelay = duration
+	}
+}
+
+// WithAcceptedClockSkew sets how far in the future the generation time of a proof can be.
+func WithAcceptedClockSkew(duration time.Duration) VerifyOpt {
+	return func(v *VerifyConfig) {
+		v.acceptedClockSkew = duration
+	}
+}
+
 // VerifyOpt sets options.
 type VerifyOpt func(v *VerifyConfig)
 
@@ -21,4 +35,8 @@ type VerifyOpt func(v *VerifyConfig)
 type VerifyConfig struct {
 	// is the period of time that a revoked state remains valid.
 	acceptedStateTransitionDelay time.Duration
+	// is the period of time that a generated proof remains valid.
+	acceptedProofGenerationDelay time.Duration
+	// is the difference allowed between the clocks of the prover and the verifier.
+	acceptedClockSkew time.Duration
 }
@@ -21,6 +21,9 @@ const (
 
 	// CredentialIssuanceResponseMessageType is type for message with a credential issuance
 	CredentialIssuanceResponseMessageType iden3comm.ProtocolMessage = iden3comm.Iden3Protocol + "credentials/1.0/issuance-response"
+
+	// CredentialIssuanceRequestStatusMessageType is type for message with the status of an issuance request waiting for approval
+	CredentialIssuanceRequestStatusMessageType iden3comm.ProtocolMessage = iden3comm.Iden3Protocol + "credentials/1.0/issuance-request-status"
 )
 
 // CredentialIssuanceRequestMessage represent Iden3message for credential request
@@ -109,3 +112,24 @@ type Schema struct {
 	URL  string `json:"url"`
 	Type string `json:"type"`
 }
+
+// CredentialIssuanceRequestStatusMessage represent Iden3message for the status of a credential issuance request
+// that waits for the approval of the issuer
+type CredentialIssuanceRequestStatusMessage struct {
+	ID       string                    `json:"id"`
+	Typ      iden3comm.MediaType       `json:"typ,omitempty"`
+	Type     iden3comm.ProtocolMessage `json:"type"`
+	ThreadID string                    `json:"thid,omitempty"`
+
+	Body CredentialIssuanceRequestStatusMessageBody `json:"body,omitempty"`
+
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// CredentialIssuanceRequestStatusMessageBody is msg body for the status of an issuance request
+type CredentialIssuanceRequestStatusMessageBody struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
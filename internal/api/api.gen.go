@@ -18,14 +18,70 @@ import (
 	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	uuid "github.com/google/uuid"
 )
 
 const (
 	BasicAuthScopes = "basicAuth.Scopes"
 )
 
+// Defines values for CallbackResponseStatus.
+const (
+	CallbackResponseStatusCreated  CallbackResponseStatus = "created"
+	CallbackResponseStatusExpired  CallbackResponseStatus = "expired"
+	CallbackResponseStatusPending  CallbackResponseStatus = "pending"
+	CallbackResponseStatusRejected CallbackResponseStatus = "rejected"
+	CallbackResponseStatusVerified CallbackResponseStatus = "verified"
+)
+
+// Defines values for CredentialRequestStatus.
+const (
+	CredentialRequestStatusApproved  CredentialRequestStatus = "approved"
+	CredentialRequestStatusApproving CredentialRequestStatus = "approving"
+	CredentialRequestStatusPending   CredentialRequestStatus = "pending"
+	CredentialRequestStatusRejected  CredentialRequestStatus = "rejected"
+)
+
+// Defines values for HolderCredentialSource.
+const (
+	HolderCredentialSourceImport     HolderCredentialSource = "import"
+	HolderCredentialSourceOffer      HolderCredentialSource = "offer"
+	HolderCredentialSourceSelfIssued HolderCredentialSource = "self-issued"
+)
+
+// Defines values for ProofJobStatus.
+const (
+	ProofJobStatusCancelled ProofJobStatus = "cancelled"
+	ProofJobStatusDone      ProofJobStatus = "done"
+	ProofJobStatusFailed    ProofJobStatus = "failed"
+	ProofJobStatusPending   ProofJobStatus = "pending"
+	ProofJobStatusRunning   ProofJobStatus = "running"
+)
+
+// Defines values for GetClaimsParamsSortBy.
+const (
+	CreatedAt  GetClaimsParamsSortBy = "created_at"
+	Expiration GetClaimsParamsSortBy = "expiration"
+)
+
+// Defines values for GetCredentialRequestsParamsStatus.
+const (
+	GetCredentialRequestsParamsStatusApproved  GetCredentialRequestsParamsStatus = "approved"
+	GetCredentialRequestsParamsStatusApproving GetCredentialRequestsParamsStatus = "approving"
+	GetCredentialRequestsParamsStatusPending   GetCredentialRequestsParamsStatus = "pending"
+	GetCredentialRequestsParamsStatusRejected  GetCredentialRequestsParamsStatus = "rejected"
+)
+
+// Defines values for GetHolderCredentialsParamsSource.
+const (
+	GetHolderCredentialsParamsSourceImport     GetHolderCredentialsParamsSource = "import"
+	GetHolderCredentialsParamsSourceOffer      GetHolderCredentialsParamsSource = "offer"
+	GetHolderCredentialsParamsSourceSelfIssued GetHolderCredentialsParamsSource = "self-issued"
+)
+
 // AgentResponse defines model for AgentResponse.
 type AgentResponse struct {
 	Body     interface{} `json:"body"`
@@ -37,6 +93,18 @@ type AgentResponse struct {
 	Type     string      `json:"type"`
 }
 
+// CallbackResponse defines model for CallbackResponse.
+type CallbackResponse struct {
+	// Disclosed Verifiable presentations disclosed by the holder, by scope id
+	Disclosed   *map[string]interface{} `json:"disclosed,omitempty"`
+	Id          string                  `json:"id"`
+	Status      CallbackResponseStatus  `json:"status"`
+	VerifiedDID string                  `json:"verifiedDID"`
+}
+
+// CallbackResponseStatus defines model for CallbackResponse.Status.
+type CallbackResponseStatus string
+
 // CreateAuthRequestRequest defines model for CreateAuthRequestRequest.
 type CreateAuthRequestRequest struct {
 	CredentialSchema      string                 `json:"credentialSchema"`
@@ -51,9 +119,26 @@ type CreateAuthRequestRequest struct {
 
 // CreateAuthRequestResponse defines model for CreateAuthRequestResponse.
 type CreateAuthRequestResponse struct {
+	// Id Session of the auth request, the sessionId of its callback url
 	Id string `json:"id"`
 }
 
+// CreateAuthorizationRequestRequest defines model for CreateAuthorizationRequestRequest.
+type CreateAuthorizationRequestRequest struct {
+	// AllowedIssuers Replaces the allowed issuers of the template
+	AllowedIssuers *[]string `json:"allowedIssuers,omitempty"`
+
+	// CredentialSubject Replaces the credential subject of the template, with a single field predicate
+	CredentialSubject *map[string]interface{} `json:"credentialSubject,omitempty"`
+
+	// Reason Replaces the reason of the template
+	Reason     *string   `json:"reason,omitempty"`
+	TemplateId uuid.UUID `json:"templateId"`
+
+	// To DID of the holder the request is sent to
+	To *string `json:"to,omitempty"`
+}
+
 // CreateClaimRequest defines model for CreateClaimRequest.
 type CreateClaimRequest struct {
 	CredentialSchema      string                 `json:"credentialSchema"`
@@ -63,6 +148,7 @@ type CreateClaimRequest struct {
 	RevNonce              *uint64                `json:"revNonce,omitempty"`
 	SubjectPosition       *string                `json:"subjectPosition,omitempty"`
 	Type                  string                 `json:"type"`
+	Updatable             *bool                  `json:"updatable,omitempty"`
 	Version               *uint32                `json:"version,omitempty"`
 }
 
@@ -71,6 +157,23 @@ type CreateClaimResponse struct {
 	Id string `json:"id"`
 }
 
+// CreateClaimsBatchRequest defines model for CreateClaimsBatchRequest.
+type CreateClaimsBatchRequest struct {
+	Claims []CreateClaimRequest `json:"claims"`
+}
+
+// CreateClaimsBatchResponse defines model for CreateClaimsBatchResponse.
+type CreateClaimsBatchResponse struct {
+	Claims []CreateClaimsBatchResult `json:"claims"`
+}
+
+// CreateClaimsBatchResult defines model for CreateClaimsBatchResult.
+type CreateClaimsBatchResult struct {
+	Error     *string `json:"error,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	RequestId *string `json:"requestId,omitempty"`
+}
+
 // CreateIdentityRequest defines model for CreateIdentityRequest.
 type CreateIdentityRequest struct {
 	DidMetadata struct {
@@ -86,16 +189,44 @@ type CreateIdentityResponse struct {
 	State      *IdentityState `json:"state,omitempty"`
 }
 
+// CreatePresentationRequest defines model for CreatePresentationRequest.
+type CreatePresentationRequest struct {
+	Challenge string `json:"challenge"`
+
+	// Credentials Ids of the held credentials to present
+	Credentials []openapi_types.UUID `json:"credentials"`
+	Domain      string               `json:"domain"`
+}
+
+// CreateProfileRequest defines model for CreateProfileRequest.
+type CreateProfileRequest struct {
+	// Nonce Decimal nonce the profile is derived with, a random one is used when it is not set
+	Nonce *string `json:"nonce,omitempty"`
+
+	// Verifier The verifier the profile is for
+	Verifier *string `json:"verifier,omitempty"`
+}
+
 // CreateQueryRequestRequest defines model for CreateQueryRequestRequest.
 type CreateQueryRequestRequest struct {
-	CredentialSchema      string                 `json:"credentialSchema"`
+	// AllowedIssuers Issuers of the credential, any issuer when empty
+	AllowedIssuers *[]string `json:"allowedIssuers,omitempty"`
+
+	// CircuitId Circuit the credential is proved with, credentialAtomicQueryMTPV2OnChain when empty
+	CircuitId        *string `json:"circuitId,omitempty"`
+	CredentialSchema string  `json:"credentialSchema"`
+
+	// CredentialSubject Field predicate the credential must meet, as {"birthday":{"$lt":20050101}}. A request has a single predicate for each scope, as the proofs of several are not bound to the same credential
 	CredentialSubject     map[string]interface{} `json:"credentialSubject"`
 	Expiration            *int64                 `json:"expiration,omitempty"`
 	MerklizedRootPosition *string                `json:"merklizedRootPosition,omitempty"`
 	RevNonce              *uint64                `json:"revNonce,omitempty"`
-	SubjectPosition       *string                `json:"subjectPosition,omitempty"`
-	Type                  string                 `json:"type"`
-	Version               *uint32                `json:"version,omitempty"`
+
+	// Scopes Other proof requests of the query request, with the ids that follow the one of the credential
+	Scopes          *[]CreateQueryRequestScope `json:"scopes,omitempty"`
+	SubjectPosition *string                    `json:"subjectPosition,omitempty"`
+	Type            string                     `json:"type"`
+	Version         *uint32                    `json:"version,omitempty"`
 }
 
 // CreateQueryRequestResponse defines model for CreateQueryRequestResponse.
@@ -103,6 +234,30 @@ type CreateQueryRequestResponse struct {
 	Id string `json:"id"`
 }
 
+// CreateQueryRequestScope defines model for CreateQueryRequestScope.
+type CreateQueryRequestScope struct {
+	CircuitId string                    `json:"circuitId"`
+	Query     GenerateProofRequestQuery `json:"query"`
+}
+
+// CredentialRequest defines model for CredentialRequest.
+type CredentialRequest struct {
+	ClaimId           *openapi_types.UUID     `json:"claimId,omitempty"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	CredentialSubject map[string]interface{}  `json:"credentialSubject"`
+	Expiration        *time.Time              `json:"expiration,omitempty"`
+	Holder            string                  `json:"holder"`
+	Id                openapi_types.UUID      `json:"id"`
+	Reason            *string                 `json:"reason,omitempty"`
+	SchemaType        string                  `json:"schemaType"`
+	SchemaUrl         string                  `json:"schemaUrl"`
+	Status            CredentialRequestStatus `json:"status"`
+	ThreadID          string                  `json:"threadID"`
+}
+
+// CredentialRequestStatus defines model for CredentialRequest.Status.
+type CredentialRequestStatus string
+
 // CredentialSchema defines model for CredentialSchema.
 type CredentialSchema struct {
 	Id   string `json:"id"`
@@ -130,14 +285,23 @@ type GenerateProofRequestBody struct {
 
 // GenerateProofRequestQuery defines model for GenerateProofRequestQuery.
 type GenerateProofRequestQuery struct {
-	AllowedIssuers    []string               `json:"allowedIssuers"`
-	Context           string                 `json:"context"`
+	AllowedIssuers []string `json:"allowedIssuers"`
+
+	// Challenge Challenge of the verifier request, as a decimal number. Required by the credentialAtomicQueryMTPV2OnChain and credentialAtomicQuerySigV2OnChain circuits, that sign it with the auth key of the holder
+	Challenge *string `json:"challenge,omitempty"`
+	Context   string  `json:"context"`
+
+	// CredentialSubject Field predicates the credential must meet, as {"birthday":{"$lt":20050101}}. Several fields, or several operators for a field, are proved with one proof each, all of the same credential
 	CredentialSubject map[string]interface{} `json:"credentialSubject"`
-	Type              string                 `json:"type"`
+
+	// Crs Common reference string of the verifier, as a decimal number. Required by the sybilCredentialAtomicMTP and sybilCredentialAtomicSig circuits, that derive the nullifier of the holder from it
+	Crs  *string `json:"crs,omitempty"`
+	Type string  `json:"type"`
 }
 
 // GenerateProofRequestScope defines model for GenerateProofRequestScope.
 type GenerateProofRequestScope struct {
+	// CircuitId Circuit used to prove the query. When empty, the wallet chooses between credentialAtomicQuerySigV2 and credentialAtomicQueryMTPV2 depending on the proofs of the matching claim
 	CircuitId string                    `json:"circuitId"`
 	Id        string                    `json:"id"`
 	Query     GenerateProofRequestQuery `json:"query"`
@@ -147,6 +311,9 @@ type GenerateProofRequestScope struct {
 type GenerateProofResponse struct {
 	Proof      *GenerateProofResponseProof `json:"proof,omitempty"`
 	PubSignals *[]string                   `json:"pub_signals"`
+
+	// Scope One proof for each scope of the request, one for each predicate of the scopes with several of them
+	Scope *[]GenerateProofResponseScope `json:"scope,omitempty"`
 }
 
 // GenerateProofResponseProof defines model for GenerateProofResponseProof.
@@ -157,11 +324,27 @@ type GenerateProofResponseProof struct {
 	Protocol string        `json:"protocol"`
 }
 
+// GenerateProofResponseScope defines model for GenerateProofResponseScope.
+type GenerateProofResponseScope struct {
+	CircuitId  string                     `json:"circuitId"`
+	Id         uint32                     `json:"id"`
+	Proof      GenerateProofResponseProof `json:"proof"`
+	PubSignals []string                   `json:"pub_signals"`
+
+	// Vp JSON-LD verifiable presentation of the disclosed field, only set when the scope asks for a field without predicate
+	Vp *map[string]interface{} `json:"vp,omitempty"`
+}
+
 // GenericErrorMessage defines model for GenericErrorMessage.
 type GenericErrorMessage struct {
 	Message string `json:"message"`
 }
 
+// GenericMessage defines model for GenericMessage.
+type GenericMessage struct {
+	Message string `json:"message"`
+}
+
 // GetClaimQrCodeResponse defines model for GetClaimQrCodeResponse.
 type GetClaimQrCodeResponse struct {
 	Body struct {
@@ -196,9 +379,37 @@ type GetClaimResponse struct {
 // GetClaimsResponse defines model for GetClaimsResponse.
 type GetClaimsResponse = []GetClaimResponse
 
+// GetCredentialRequestsResponse defines model for GetCredentialRequestsResponse.
+type GetCredentialRequestsResponse = []CredentialRequest
+
+// GetHolderCredentialsResponse defines model for GetHolderCredentialsResponse.
+type GetHolderCredentialsResponse = []HolderCredential
+
+// GetProfilesResponse defines model for GetProfilesResponse.
+type GetProfilesResponse = []Profile
+
 // Health defines model for Health.
 type Health map[string]bool
 
+// HolderCredential defines model for HolderCredential.
+type HolderCredential struct {
+	Credential   GetClaimResponse       `json:"credential"`
+	CredentialId string                 `json:"credentialId"`
+	Expiration   *time.Time             `json:"expiration,omitempty"`
+	Id           openapi_types.UUID     `json:"id"`
+	Issuer       string                 `json:"issuer"`
+	ProofTypes   []string               `json:"proofTypes"`
+	ReceivedAt   time.Time              `json:"receivedAt"`
+	Revoked      bool                   `json:"revoked"`
+	SchemaHash   string                 `json:"schemaHash"`
+	SchemaType   string                 `json:"schemaType"`
+	SchemaUrl    string                 `json:"schemaUrl"`
+	Source       HolderCredentialSource `json:"source"`
+}
+
+// HolderCredentialSource defines model for HolderCredential.Source.
+type HolderCredentialSource string
+
 // IdentityState defines model for IdentityState.
 type IdentityState struct {
 	BlockNumber        *int      `json:"blockNumber,omitempty"`
@@ -216,6 +427,35 @@ type IdentityState struct {
 	TxID               *string   `json:"txID,omitempty"`
 }
 
+// Profile defines model for Profile.
+type Profile struct {
+	CreatedAt  time.Time          `json:"createdAt"`
+	Id         openapi_types.UUID `json:"id"`
+	Identifier string             `json:"identifier"`
+	Nonce      string             `json:"nonce"`
+	ProfileDID string             `json:"profileDID"`
+	Verifier   *string            `json:"verifier,omitempty"`
+}
+
+// ProofJob defines model for ProofJob.
+type ProofJob struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error Why the proofs could not be generated, only set when the job failed
+	Error *string `json:"error,omitempty"`
+
+	// ExpiresAt When the job and its proofs are deleted
+	ExpiresAt  *time.Time             `json:"expiresAt,omitempty"`
+	FinishedAt *time.Time             `json:"finishedAt,omitempty"`
+	Id         openapi_types.UUID     `json:"id"`
+	Result     *GenerateProofResponse `json:"result,omitempty"`
+	StartedAt  *time.Time             `json:"startedAt,omitempty"`
+	Status     ProofJobStatus         `json:"status"`
+}
+
+// ProofJobStatus defines model for ProofJob.Status.
+type ProofJobStatus string
+
 // PublishIdentityStateResponse defines model for PublishIdentityStateResponse.
 type PublishIdentityStateResponse struct {
 	ClaimsTreeRoot     *string `json:"claimsTreeRoot,omitempty"`
@@ -225,6 +465,11 @@ type PublishIdentityStateResponse struct {
 	TxID               *string `json:"txID,omitempty"`
 }
 
+// RejectCredentialRequestRequest defines model for RejectCredentialRequestRequest.
+type RejectCredentialRequestRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
 // ReturnCreateIdentityOptionsRequest defines model for ReturnCreateIdentityOptionsRequest.
 type ReturnCreateIdentityOptionsRequest = map[string]interface{}
 
@@ -254,6 +499,38 @@ type RevokeClaimResponse struct {
 	Message string `json:"message"`
 }
 
+// UpdateClaimRequest defines model for UpdateClaimRequest.
+type UpdateClaimRequest struct {
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	Expiration        *int64                 `json:"expiration,omitempty"`
+}
+
+// UpdateClaimResponse defines model for UpdateClaimResponse.
+type UpdateClaimResponse struct {
+	Id       string  `json:"id"`
+	Replaces *string `json:"replaces,omitempty"`
+	Version  uint32  `json:"version"`
+}
+
+// VerifiablePresentation W3C Verifiable Presentation with the holder proof
+type VerifiablePresentation map[string]interface{}
+
+// VerifyPresentationRequest defines model for VerifyPresentationRequest.
+type VerifyPresentationRequest struct {
+	Challenge string `json:"challenge"`
+	Domain    string `json:"domain"`
+
+	// Presentation W3C Verifiable Presentation with the holder proof
+	Presentation VerifiablePresentation `json:"presentation"`
+}
+
+// VerifyPresentationResponse defines model for VerifyPresentationResponse.
+type VerifyPresentationResponse struct {
+	// Reason Why the presentation is not valid
+	Reason   *string `json:"reason,omitempty"`
+	Verified bool    `json:"verified"`
+}
+
 // VerifyProofRequest defines model for VerifyProofRequest.
 type VerifyProofRequest struct {
 	GenerateProofRequest  GenerateProofRequest  `json:"generateProofRequest"`
@@ -271,21 +548,36 @@ type StringArray = []string
 // PathClaim defines model for pathClaim.
 type PathClaim = string
 
+// PathCredential defines model for pathCredential.
+type PathCredential = openapi_types.UUID
+
+// PathCredentialRequest defines model for pathCredentialRequest.
+type PathCredentialRequest = openapi_types.UUID
+
 // PathIdentifier defines model for pathIdentifier.
 type PathIdentifier = string
 
 // PathNonce defines model for pathNonce.
 type PathNonce = int64
 
+// PathProofJob defines model for pathProofJob.
+type PathProofJob = openapi_types.UUID
+
 // N400 defines model for 400.
 type N400 = GenericErrorMessage
 
 // N401 defines model for 401.
 type N401 = GenericErrorMessage
 
+// N403 defines model for 403.
+type N403 = GenericErrorMessage
+
 // N404 defines model for 404.
 type N404 = GenericErrorMessage
 
+// N409 defines model for 409.
+type N409 = GenericErrorMessage
+
 // N422 defines model for 422.
 type N422 = GenericErrorMessage
 
@@ -302,6 +594,15 @@ type N500CreateIdentity struct {
 // AgentTextBody defines parameters for Agent.
 type AgentTextBody = string
 
+// CallbackTextBody defines parameters for Callback.
+type CallbackTextBody = string
+
+// CallbackParams defines parameters for Callback.
+type CallbackParams struct {
+	// SessionId Auth request session identifier
+	SessionId string `form:"sessionId" json:"sessionId"`
+}
+
 // GetClaimsParams defines parameters for GetClaims.
 type GetClaimsParams struct {
 	// SchemaType Filter per schema type. Example - KYCAgeCredential
@@ -316,13 +617,67 @@ type GetClaimsParams struct {
 	// Revoked Filter per claims revoked or not - Example - true.
 	Revoked *bool `form:"revoked,omitempty" json:"revoked,omitempty"`
 
+	// Expired Filter per claims expired or not - Example - true.
+	Expired *bool `form:"expired,omitempty" json:"expired,omitempty"`
+
 	// Self Filter per retrieve claims of the provided identifier. Example - true
 	Self *bool `form:"self,omitempty" json:"self,omitempty"`
 
 	// QueryField Filter inside the data of the claim.
 	QueryField *string `form:"query_field,omitempty" json:"query_field,omitempty"`
+
+	// Search Full text search over the values of the credential subject. Example - 19960424
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// SortBy Field the claims are sorted by. The claims without expiration come first when sorted by expiration.
+	SortBy *GetClaimsParamsSortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// Descending Sort the claims in descending order.
+	Descending *bool `form:"descending,omitempty" json:"descending,omitempty"`
+
+	// Limit Maximum number of claims of the page. All the claims are returned without limit.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The X-Next-Cursor header of the previous page, with the same filters and sorting.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetClaimsParamsSortBy defines parameters for GetClaims.
+type GetClaimsParamsSortBy string
+
+// GetCredentialRequestsParams defines parameters for GetCredentialRequests.
+type GetCredentialRequestsParams struct {
+	// Status Filter per status of the request. Example - pending
+	Status *GetCredentialRequestsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetCredentialRequestsParamsStatus defines parameters for GetCredentialRequests.
+type GetCredentialRequestsParamsStatus string
+
+// GetHolderCredentialsParams defines parameters for GetHolderCredentials.
+type GetHolderCredentialsParams struct {
+	// SchemaType Filter per schema type. Example - KYCAgeCredential
+	SchemaType *string `form:"schemaType,omitempty" json:"schemaType,omitempty"`
+
+	// SchemaHash Filter per schema hash. Example - c9b2370371b7fa8b3dab2a5ba81b6838
+	SchemaHash *string `form:"schemaHash,omitempty" json:"schemaHash,omitempty"`
+
+	// Issuer Filter per issuer. Example - did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ
+	Issuer *string `form:"issuer,omitempty" json:"issuer,omitempty"`
+
+	// Source Filter per the way the credential was received. Example - offer
+	Source *GetHolderCredentialsParamsSource `form:"source,omitempty" json:"source,omitempty"`
+
+	// Revoked Filter per credentials revoked or not - Example - true.
+	Revoked *bool `form:"revoked,omitempty" json:"revoked,omitempty"`
+
+	// QueryField Filter the credentials with the field in their subject. Example - birthday
+	QueryField *string `form:"query_field,omitempty" json:"query_field,omitempty"`
 }
 
+// GetHolderCredentialsParamsSource defines parameters for GetHolderCredentials.
+type GetHolderCredentialsParamsSource string
+
 // AgentTextRequestBody defines body for Agent for text/plain ContentType.
 type AgentTextRequestBody = AgentTextBody
 
@@ -332,15 +687,48 @@ type ReturnCreateIdentityOptionsJSONRequestBody = ReturnCreateIdentityOptionsReq
 // CreateIdentityJSONRequestBody defines body for CreateIdentity for application/json ContentType.
 type CreateIdentityJSONRequestBody = CreateIdentityRequest
 
+// VerifyPresentationJSONRequestBody defines body for VerifyPresentation for application/json ContentType.
+type VerifyPresentationJSONRequestBody = VerifyPresentationRequest
+
 // CreateAuthRequestJSONRequestBody defines body for CreateAuthRequest for application/json ContentType.
 type CreateAuthRequestJSONRequestBody = CreateAuthRequestRequest
 
+// CreateAuthorizationRequestJSONRequestBody defines body for CreateAuthorizationRequest for application/json ContentType.
+type CreateAuthorizationRequestJSONRequestBody = CreateAuthorizationRequestRequest
+
+// CallbackTextRequestBody defines body for Callback for text/plain ContentType.
+type CallbackTextRequestBody = CallbackTextBody
+
 // CreateClaimJSONRequestBody defines body for CreateClaim for application/json ContentType.
 type CreateClaimJSONRequestBody = CreateClaimRequest
 
+// CreateClaimsBatchJSONRequestBody defines body for CreateClaimsBatch for application/json ContentType.
+type CreateClaimsBatchJSONRequestBody = CreateClaimsBatchRequest
+
+// UpdateClaimJSONRequestBody defines body for UpdateClaim for application/json ContentType.
+type UpdateClaimJSONRequestBody = UpdateClaimRequest
+
+// RejectCredentialRequestJSONRequestBody defines body for RejectCredentialRequest for application/json ContentType.
+type RejectCredentialRequestJSONRequestBody = RejectCredentialRequestRequest
+
+// ImportCredentialJSONRequestBody defines body for ImportCredential for application/json ContentType.
+type ImportCredentialJSONRequestBody = GetClaimResponse
+
 // GenerateProofJSONRequestBody defines body for GenerateProof for application/json ContentType.
 type GenerateProofJSONRequestBody = GenerateProofRequest
 
+// AcceptCredentialOfferJSONRequestBody defines body for AcceptCredentialOffer for application/json ContentType.
+type AcceptCredentialOfferJSONRequestBody = GetClaimQrCodeResponse
+
+// CreatePresentationJSONRequestBody defines body for CreatePresentation for application/json ContentType.
+type CreatePresentationJSONRequestBody = CreatePresentationRequest
+
+// CreateProfileJSONRequestBody defines body for CreateProfile for application/json ContentType.
+type CreateProfileJSONRequestBody = CreateProfileRequest
+
+// CreateProofJobJSONRequestBody defines body for CreateProofJob for application/json ContentType.
+type CreateProofJobJSONRequestBody = GenerateProofRequest
+
 // CreateQueryRequestJSONRequestBody defines body for CreateQueryRequest for application/json ContentType.
 type CreateQueryRequestJSONRequestBody = CreateQueryRequestRequest
 
@@ -370,15 +758,27 @@ type ServerInterface interface {
 	// Create Identity
 	// (POST /v1/identities)
 	CreateIdentity(w http.ResponseWriter, r *http.Request)
+	// Verify Presentation
+	// (POST /v1/presentations/verify)
+	VerifyPresentation(w http.ResponseWriter, r *http.Request)
 	// Create Auth Request
 	// (POST /v1/{identifier}/auth-reqs)
 	CreateAuthRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Authorization Request from a template
+	// (POST /v1/{identifier}/authorization-requests)
+	CreateAuthorizationRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Verifier callback
+	// (POST /v1/{identifier}/callback)
+	Callback(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CallbackParams)
 	// Get Claims
 	// (GET /v1/{identifier}/claims)
 	GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams)
 	// Create Claim
 	// (POST /v1/{identifier}/claims)
 	CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Claims Batch
+	// (POST /v1/{identifier}/claims/batch)
+	CreateClaimsBatch(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
 	// Get Revocation Status
 	// (GET /v1/{identifier}/claims/revocation/status/{nonce})
 	GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce)
@@ -388,12 +788,57 @@ type ServerInterface interface {
 	// Get Claim
 	// (GET /v1/{identifier}/claims/{id})
 	GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	// Update Claim
+	// (PUT /v1/{identifier}/claims/{id})
+	UpdateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
 	// Get Claim QR code
 	// (GET /v1/{identifier}/claims/{id}/qrcode)
 	GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim)
+	// Get Credential Requests
+	// (GET /v1/{identifier}/credential-requests)
+	GetCredentialRequests(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetCredentialRequestsParams)
+	// Approve Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/approve)
+	ApproveCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest)
+	// Reject Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/reject)
+	RejectCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest)
+	// Get Held Credentials
+	// (GET /v1/{identifier}/credentials)
+	GetHolderCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetHolderCredentialsParams)
+	// Import Credential
+	// (POST /v1/{identifier}/credentials)
+	ImportCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Delete Held Credential
+	// (DELETE /v1/{identifier}/credentials/{id})
+	DeleteHolderCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredential)
+	// Get Held Credential
+	// (GET /v1/{identifier}/credentials/{id})
+	GetHolderCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredential)
 	// Request a proof generation
 	// (POST /v1/{identifier}/generate-proof)
 	GenerateProof(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Accept Credential Offer
+	// (POST /v1/{identifier}/offers)
+	AcceptCredentialOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Presentation
+	// (POST /v1/{identifier}/presentations)
+	CreatePresentation(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Get Profiles
+	// (GET /v1/{identifier}/profiles)
+	GetProfiles(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Profile
+	// (POST /v1/{identifier}/profiles)
+	CreateProfile(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create a proof job
+	// (POST /v1/{identifier}/proof-jobs)
+	CreateProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Cancel a proof job
+	// (DELETE /v1/{identifier}/proof-jobs/{id})
+	CancelProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathProofJob)
+	// Get a proof job
+	// (GET /v1/{identifier}/proof-jobs/{id})
+	GetProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathProofJob)
 	// Create Query Request
 	// (POST /v1/{identifier}/query-reqs)
 	CreateQueryRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
@@ -525,6 +970,23 @@ func (siw *ServerInterfaceWrapper) CreateIdentity(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// VerifyPresentation operation middleware
+func (siw *ServerInterfaceWrapper) VerifyPresentation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyPresentation(w, r)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // CreateAuthRequest operation middleware
 func (siw *ServerInterfaceWrapper) CreateAuthRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -553,6 +1015,78 @@ func (siw *ServerInterfaceWrapper) CreateAuthRequest(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// CreateAuthorizationRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreateAuthorizationRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAuthorizationRequest(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Callback operation middleware
+func (siw *ServerInterfaceWrapper) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CallbackParams
+
+	// ------------- Required query parameter "sessionId" -------------
+
+	if paramValue := r.URL.Query().Get("sessionId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sessionId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sessionId", r.URL.Query(), &params.SessionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Callback(w, r, identifier, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetClaims operation middleware
 func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -605,6 +1139,14 @@ func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "expired" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expired", r.URL.Query(), &params.Expired)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expired", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "self" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "self", r.URL.Query(), &params.Self)
@@ -621,6 +1163,46 @@ func (siw *ServerInterfaceWrapper) GetClaims(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "search" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "search", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "descending" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "descending", r.URL.Query(), &params.Descending)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "descending", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetClaims(w, r, identifier, params)
 	})
@@ -660,8 +1242,8 @@ func (siw *ServerInterfaceWrapper) CreateClaim(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetRevocationStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r *http.Request) {
+// CreateClaimsBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateClaimsBatch(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -675,17 +1257,10 @@ func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r
 		return
 	}
 
-	// ------------- Path parameter "nonce" -------------
-	var nonce PathNonce
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "nonce", runtime.ParamLocationPath, chi.URLParam(r, "nonce"), &nonce)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
-		return
-	}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRevocationStatus(w, r, identifier, nonce)
+		siw.Handler.CreateClaimsBatch(w, r, identifier)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -695,8 +1270,8 @@ func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RevokeClaim operation middleware
-func (siw *ServerInterfaceWrapper) RevokeClaim(w http.ResponseWriter, r *http.Request) {
+// GetRevocationStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetRevocationStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -719,10 +1294,45 @@ func (siw *ServerInterfaceWrapper) RevokeClaim(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
-
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RevokeClaim(w, r, identifier, nonce)
+		siw.Handler.GetRevocationStatus(w, r, identifier, nonce)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RevokeClaim operation middleware
+func (siw *ServerInterfaceWrapper) RevokeClaim(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "nonce" -------------
+	var nonce PathNonce
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "nonce", runtime.ParamLocationPath, chi.URLParam(r, "nonce"), &nonce)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nonce", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeClaim(w, r, identifier, nonce)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -769,8 +1379,8 @@ func (siw *ServerInterfaceWrapper) GetClaim(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetClaimQrCode operation middleware
-func (siw *ServerInterfaceWrapper) GetClaimQrCode(w http.ResponseWriter, r *http.Request) {
+// UpdateClaim operation middleware
+func (siw *ServerInterfaceWrapper) UpdateClaim(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -796,7 +1406,7 @@ func (siw *ServerInterfaceWrapper) GetClaimQrCode(w http.ResponseWriter, r *http
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetClaimQrCode(w, r, identifier, id)
+		siw.Handler.UpdateClaim(w, r, identifier, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -806,8 +1416,8 @@ func (siw *ServerInterfaceWrapper) GetClaimQrCode(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GenerateProof operation middleware
-func (siw *ServerInterfaceWrapper) GenerateProof(w http.ResponseWriter, r *http.Request) {
+// GetClaimQrCode operation middleware
+func (siw *ServerInterfaceWrapper) GetClaimQrCode(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -821,10 +1431,19 @@ func (siw *ServerInterfaceWrapper) GenerateProof(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Path parameter "id" -------------
+	var id PathClaim
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GenerateProof(w, r, identifier)
+		siw.Handler.GetClaimQrCode(w, r, identifier, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -834,8 +1453,8 @@ func (siw *ServerInterfaceWrapper) GenerateProof(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateQueryRequest operation middleware
-func (siw *ServerInterfaceWrapper) CreateQueryRequest(w http.ResponseWriter, r *http.Request) {
+// GetCredentialRequests operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentialRequests(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -851,8 +1470,19 @@ func (siw *ServerInterfaceWrapper) CreateQueryRequest(w http.ResponseWriter, r *
 
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCredentialRequestsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateQueryRequest(w, r, identifier)
+		siw.Handler.GetCredentialRequests(w, r, identifier, params)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -862,8 +1492,8 @@ func (siw *ServerInterfaceWrapper) CreateQueryRequest(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PublishIdentityState operation middleware
-func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+// ApproveCredentialRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveCredentialRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -877,10 +1507,19 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 		return
 	}
 
+	// ------------- Path parameter "id" -------------
+	var id PathCredentialRequest
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PublishIdentityState(w, r, identifier)
+		siw.Handler.ApproveCredentialRequest(w, r, identifier, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -890,8 +1529,8 @@ func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// VerifyProof operation middleware
-func (siw *ServerInterfaceWrapper) VerifyProof(w http.ResponseWriter, r *http.Request) {
+// RejectCredentialRequest operation middleware
+func (siw *ServerInterfaceWrapper) RejectCredentialRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -905,10 +1544,19 @@ func (siw *ServerInterfaceWrapper) VerifyProof(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// ------------- Path parameter "id" -------------
+	var id PathCredentialRequest
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
 	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.VerifyProof(w, r, identifier)
+		siw.Handler.RejectCredentialRequest(w, r, identifier, id)
 	})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -918,1048 +1566,3261 @@ func (siw *ServerInterfaceWrapper) VerifyProof(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetHolderCredentials operation middleware
+func (siw *ServerInterfaceWrapper) GetHolderCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type UnmarshallingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (e *UnmarshallingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *UnmarshallingParamError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHolderCredentialsParams
 
-type RequiredParamError struct {
-	ParamName string
-}
+	// ------------- Optional query parameter "schemaType" -------------
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "schemaType", r.URL.Query(), &params.SchemaType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schemaType", Err: err})
+		return
+	}
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "schemaHash" -------------
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "schemaHash", r.URL.Query(), &params.SchemaHash)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schemaHash", Err: err})
+		return
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "issuer" -------------
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", true, false, "issuer", r.URL.Query(), &params.Issuer)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "issuer", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "source" -------------
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "source", r.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	// ------------- Optional query parameter "revoked" -------------
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	err = runtime.BindQueryParameter("form", true, false, "revoked", r.URL.Query(), &params.Revoked)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revoked", Err: err})
+		return
+	}
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	// ------------- Optional query parameter "query_field" -------------
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindQueryParameter("form", true, false, "query_field", r.URL.Query(), &params.QueryField)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query_field", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHolderCredentials(w, r, identifier, params)
 	})
-}
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// ImportCredential operation middleware
+func (siw *ServerInterfaceWrapper) ImportCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	if r == nil {
-		r = chi.NewRouter()
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportCredential(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteHolderCredential operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHolderCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/static/docs/api/api.yaml", wrapper.GetYaml)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/identities", wrapper.GetIdentities)
-	})
-	r.Group(func(r chi.Router) {
-		r.Options(options.BaseURL+"/v1/identities", wrapper.ReturnCreateIdentityOptions)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/identities", wrapper.CreateIdentity)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/auth-reqs", wrapper.CreateAuthRequest)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims", wrapper.GetClaims)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/claims", wrapper.CreateClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/claims/revoke/{nonce}", wrapper.RevokeClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}", wrapper.GetClaim)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/qrcode", wrapper.GetClaimQrCode)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/generate-proof", wrapper.GenerateProof)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/query-reqs", wrapper.CreateQueryRequest)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/state/publish", wrapper.PublishIdentityState)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/{identifier}/verify-proof", wrapper.VerifyProof)
-	})
+	// ------------- Path parameter "id" -------------
+	var id PathCredential
 
-	return r
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type N400JSONResponse GenericErrorMessage
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type N401JSONResponse GenericErrorMessage
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteHolderCredential(w, r, identifier, id)
+	})
 
-type N404JSONResponse GenericErrorMessage
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type N422JSONResponse GenericErrorMessage
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
 
-type N500JSONResponse GenericErrorMessage
+// GetHolderCredential operation middleware
+func (siw *ServerInterfaceWrapper) GetHolderCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type N500CreateIdentityJSONResponse struct {
-	Code      *int    `json:"code,omitempty"`
-	Error     *string `json:"error,omitempty"`
-	RequestID *string `json:"requestID,omitempty"`
-}
+	var err error
 
-type GetDocumentationRequestObject struct {
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetDocumentationResponseObject interface {
-	VisitGetDocumentationResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetDocumentation200Response struct {
-}
+	// ------------- Path parameter "id" -------------
+	var id PathCredential
 
-func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type GetYamlRequestObject struct {
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetYamlResponseObject interface {
-	VisitGetYamlResponse(w http.ResponseWriter) error
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHolderCredential(w, r, identifier, id)
+	})
 
-type GetYaml200Response struct {
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type HealthRequestObject struct {
-}
+// GenerateProof operation middleware
+func (siw *ServerInterfaceWrapper) GenerateProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type Health200JSONResponse Health
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type Health500JSONResponse struct{ N500JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateProof(w, r, identifier)
+	})
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type AgentRequestObject struct {
-	Body *AgentTextRequestBody
-}
+// AcceptCredentialOffer operation middleware
+func (siw *ServerInterfaceWrapper) AcceptCredentialOffer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type AgentResponseObject interface {
-	VisitAgentResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type Agent200JSONResponse AgentResponse
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type Agent400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptCredentialOffer(w, r, identifier)
+	})
 
-func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type Agent500JSONResponse struct{ N500JSONResponse }
+// CreatePresentation operation middleware
+func (siw *ServerInterfaceWrapper) CreatePresentation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetIdentitiesRequestObject struct {
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetIdentitiesResponseObject interface {
-	VisitGetIdentitiesResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type GetIdentities200JSONResponse []string
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePresentation(w, r, identifier)
+	})
 
-func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetIdentities401JSONResponse struct{ N401JSONResponse }
+// GetProfiles operation middleware
+func (siw *ServerInterfaceWrapper) GetProfiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetIdentities500JSONResponse struct{ N500JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProfiles(w, r, identifier)
+	})
 
-type ReturnCreateIdentityOptionsRequestObject struct {
-	Body *ReturnCreateIdentityOptionsJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ReturnCreateIdentityOptionsResponseObject interface {
-	VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type ReturnCreateIdentityOptions200ResponseHeaders struct {
-	AccessControlAllowOrigin interface{}
-}
-
-type ReturnCreateIdentityOptions200JSONResponse struct {
-	Body    ReturnCreateIdentityOptionsResponse
-	Headers ReturnCreateIdentityOptions200ResponseHeaders
-}
+// CreateProfile operation middleware
+func (siw *ServerInterfaceWrapper) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response ReturnCreateIdentityOptions200JSONResponse) VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Access-Control-Allow-Origin", fmt.Sprint(response.Headers.AccessControlAllowOrigin))
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type ReturnCreateIdentityOptions500JSONResponse struct{ N500CreateIdentityJSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response ReturnCreateIdentityOptions500JSONResponse) VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProfile(w, r, identifier)
+	})
 
-type CreateIdentityRequestObject struct {
-	Body *CreateIdentityJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreateIdentityResponseObject interface {
-	VisitCreateIdentityResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateIdentity201JSONResponse CreateIdentityResponse
-
-func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+// CreateProofJob operation middleware
+func (siw *ServerInterfaceWrapper) CreateProofJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type CreateIdentity400JSONResponse struct{ N400JSONResponse }
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type CreateIdentity401JSONResponse struct{ N401JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProofJob(w, r, identifier)
+	})
 
-func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
-
-func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// CancelProofJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelProofJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type CreateAuthRequestRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateAuthRequestJSONRequestBody
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type CreateAuthRequestResponseObject interface {
-	VisitCreateAuthRequestResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type CreateAuthRequest201JSONResponse CreateAuthRequestResponse
+	// ------------- Path parameter "id" -------------
+	var id PathProofJob
 
-func (response CreateAuthRequest201JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type CreateAuthRequest400JSONResponse struct{ N400JSONResponse }
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelProofJob(w, r, identifier, id)
+	})
 
-func (response CreateAuthRequest400JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateAuthRequest401JSONResponse struct{ N401JSONResponse }
+// GetProofJob operation middleware
+func (siw *ServerInterfaceWrapper) GetProofJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response CreateAuthRequest401JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type CreateAuthRequest422JSONResponse struct{ N422JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response CreateAuthRequest422JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	// ------------- Path parameter "id" -------------
+	var id PathProofJob
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type CreateAuthRequest500JSONResponse struct{ N500JSONResponse }
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (response CreateAuthRequest500JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProofJob(w, r, identifier, id)
+	})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetClaimsRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Params     GetClaimsParams
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetClaimsResponseObject interface {
-	VisitGetClaimsResponse(w http.ResponseWriter) error
-}
+// CreateQueryRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreateQueryRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetClaims200JSONResponse GetClaimsResponse
+	var err error
 
-func (response GetClaims200JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-type GetClaims400JSONResponse struct{ N400JSONResponse }
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (response GetClaims400JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateQueryRequest(w, r, identifier)
+	})
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetClaims401JSONResponse struct{ N401JSONResponse }
+// PublishIdentityState operation middleware
+func (siw *ServerInterfaceWrapper) PublishIdentityState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetClaims401JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetClaims500JSONResponse struct{ N500JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response GetClaims500JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishIdentityState(w, r, identifier)
+	})
 
-type CreateClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateClaimJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreateClaimResponseObject interface {
-	VisitCreateClaimResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateClaim201JSONResponse CreateClaimResponse
+// VerifyProof operation middleware
+func (siw *ServerInterfaceWrapper) VerifyProof(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response CreateClaim201JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type CreateClaim400JSONResponse struct{ N400JSONResponse }
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response CreateClaim400JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyProof(w, r, identifier)
+	})
 
-type CreateClaim401JSONResponse struct{ N401JSONResponse }
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (response CreateClaim401JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
 
-	return json.NewEncoder(w).Encode(response)
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type CreateClaim422JSONResponse struct{ N422JSONResponse }
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
 
-func (response CreateClaim422JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
 }
 
-type CreateClaim500JSONResponse struct{ N500JSONResponse }
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
 
-func (response CreateClaim500JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type RequiredParamError struct {
+	ParamName string
 }
 
-type GetRevocationStatusRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type GetRevocationStatusResponseObject interface {
-	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
 }
 
-type GetRevocationStatus200JSONResponse RevocationStatusResponse
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
 
-func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
 }
 
-type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
 
-func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
 }
 
-type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
 
-func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type RevokeClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Nonce      PathNonce      `json:"nonce"`
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
 }
 
-type RevokeClaimResponseObject interface {
-	VisitRevokeClaimResponse(w http.ResponseWriter) error
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
 }
 
-type RevokeClaim202JSONResponse RevokeClaimResponse
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
 
-func (response RevokeClaim202JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/static/docs/api/api.yaml", wrapper.GetYaml)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/agent", wrapper.Agent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/identities", wrapper.GetIdentities)
+	})
+	r.Group(func(r chi.Router) {
+		r.Options(options.BaseURL+"/v1/identities", wrapper.ReturnCreateIdentityOptions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/identities", wrapper.CreateIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/presentations/verify", wrapper.VerifyPresentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/auth-reqs", wrapper.CreateAuthRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/authorization-requests", wrapper.CreateAuthorizationRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/callback", wrapper.Callback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims", wrapper.GetClaims)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims", wrapper.CreateClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/batch", wrapper.CreateClaimsBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/revocation/status/{nonce}", wrapper.GetRevocationStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/claims/revoke/{nonce}", wrapper.RevokeClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}", wrapper.GetClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/{identifier}/claims/{id}", wrapper.UpdateClaim)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/claims/{id}/qrcode", wrapper.GetClaimQrCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/credential-requests", wrapper.GetCredentialRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/credential-requests/{id}/approve", wrapper.ApproveCredentialRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/credential-requests/{id}/reject", wrapper.RejectCredentialRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/credentials", wrapper.GetHolderCredentials)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/credentials", wrapper.ImportCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/{identifier}/credentials/{id}", wrapper.DeleteHolderCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/credentials/{id}", wrapper.GetHolderCredential)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/generate-proof", wrapper.GenerateProof)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/offers", wrapper.AcceptCredentialOffer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/presentations", wrapper.CreatePresentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/profiles", wrapper.GetProfiles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/profiles", wrapper.CreateProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/proof-jobs", wrapper.CreateProofJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/{identifier}/proof-jobs/{id}", wrapper.CancelProofJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/proof-jobs/{id}", wrapper.GetProofJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/query-reqs", wrapper.CreateQueryRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/state/publish", wrapper.PublishIdentityState)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/verify-proof", wrapper.VerifyProof)
+	})
+
+	return r
 }
 
-type RevokeClaim401JSONResponse struct{ N401JSONResponse }
+type N400JSONResponse GenericErrorMessage
 
-func (response RevokeClaim401JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+type N401JSONResponse GenericErrorMessage
 
-	return json.NewEncoder(w).Encode(response)
-}
+type N403JSONResponse GenericErrorMessage
 
-type RevokeClaim404JSONResponse struct{ N404JSONResponse }
+type N404JSONResponse GenericErrorMessage
 
-func (response RevokeClaim404JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+type N409JSONResponse GenericErrorMessage
 
-	return json.NewEncoder(w).Encode(response)
-}
+type N422JSONResponse GenericErrorMessage
 
-type RevokeClaim500JSONResponse struct{ N500JSONResponse }
+type N500JSONResponse GenericErrorMessage
 
-func (response RevokeClaim500JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+type N500CreateIdentityJSONResponse struct {
+	Code      *int    `json:"code,omitempty"`
+	Error     *string `json:"error,omitempty"`
+	RequestID *string `json:"requestID,omitempty"`
+}
 
-	return json.NewEncoder(w).Encode(response)
+type GetDocumentationRequestObject struct {
 }
 
-type GetClaimRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
+type GetDocumentationResponseObject interface {
+	VisitGetDocumentationResponse(w http.ResponseWriter) error
 }
 
-type GetClaimResponseObject interface {
-	VisitGetClaimResponse(w http.ResponseWriter) error
+type GetDocumentation200Response struct {
 }
 
-type GetClaim200JSONResponse GetClaimResponse
+func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
 
-func (response GetClaim200JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+type GetYamlRequestObject struct {
+}
+
+type GetYamlResponseObject interface {
+	VisitGetYamlResponse(w http.ResponseWriter) error
+}
+
+type GetYaml200Response struct {
+}
+
+func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaim400JSONResponse struct{ N400JSONResponse }
+type Health500JSONResponse struct{ N500JSONResponse }
 
-func (response GetClaim400JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaim401JSONResponse struct{ N401JSONResponse }
+type AgentRequestObject struct {
+	Body *AgentTextRequestBody
+}
 
-func (response GetClaim401JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+type AgentResponseObject interface {
+	VisitAgentResponse(w http.ResponseWriter) error
+}
+
+type Agent200JSONResponse AgentResponse
+
+func (response Agent200JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaim404JSONResponse struct{ N404JSONResponse }
+type Agent400JSONResponse struct{ N400JSONResponse }
 
-func (response GetClaim404JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+func (response Agent400JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaim500JSONResponse struct{ N500JSONResponse }
+type Agent500JSONResponse struct{ N500JSONResponse }
 
-func (response GetClaim500JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+func (response Agent500JSONResponse) VisitAgentResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCodeRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Id         PathClaim      `json:"id"`
+type GetIdentitiesRequestObject struct {
 }
 
-type GetClaimQrCodeResponseObject interface {
-	VisitGetClaimQrCodeResponse(w http.ResponseWriter) error
+type GetIdentitiesResponseObject interface {
+	VisitGetIdentitiesResponse(w http.ResponseWriter) error
 }
 
-type GetClaimQrCode200JSONResponse GetClaimQrCodeResponse
+type GetIdentities200JSONResponse []string
 
-func (response GetClaimQrCode200JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+func (response GetIdentities200JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode400JSONResponse struct{ N400JSONResponse }
+type GetIdentities401JSONResponse struct{ N401JSONResponse }
 
-func (response GetClaimQrCode400JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+func (response GetIdentities401JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode404JSONResponse struct{ N404JSONResponse }
+type GetIdentities500JSONResponse struct{ N500JSONResponse }
 
-func (response GetClaimQrCode404JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+func (response GetIdentities500JSONResponse) VisitGetIdentitiesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetClaimQrCode500JSONResponse struct{ N500JSONResponse }
+type ReturnCreateIdentityOptionsRequestObject struct {
+	Body *ReturnCreateIdentityOptionsJSONRequestBody
+}
 
-func (response GetClaimQrCode500JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+type ReturnCreateIdentityOptionsResponseObject interface {
+	VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error
+}
+
+type ReturnCreateIdentityOptions200ResponseHeaders struct {
+	AccessControlAllowOrigin interface{}
+}
+
+type ReturnCreateIdentityOptions200JSONResponse struct {
+	Body    ReturnCreateIdentityOptionsResponse
+	Headers ReturnCreateIdentityOptions200ResponseHeaders
+}
+
+func (response ReturnCreateIdentityOptions200JSONResponse) VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Access-Control-Allow-Origin", fmt.Sprint(response.Headers.AccessControlAllowOrigin))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReturnCreateIdentityOptions500JSONResponse struct{ N500CreateIdentityJSONResponse }
+
+func (response ReturnCreateIdentityOptions500JSONResponse) VisitReturnCreateIdentityOptionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateProofRequestObject struct {
-	Identifier PathIdentifier `json:"identifier"`
-	Body       *GenerateProofJSONRequestBody
+type CreateIdentityRequestObject struct {
+	Body *CreateIdentityJSONRequestBody
 }
 
-type GenerateProofResponseObject interface {
-	VisitGenerateProofResponse(w http.ResponseWriter) error
+type CreateIdentityResponseObject interface {
+	VisitCreateIdentityResponse(w http.ResponseWriter) error
 }
 
-type GenerateProof200JSONResponse GenerateProofResponse
+type CreateIdentity201JSONResponse CreateIdentityResponse
 
-func (response GenerateProof200JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+func (response CreateIdentity201JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateProof400JSONResponse struct{ N400JSONResponse }
+type CreateIdentity400JSONResponse struct{ N400JSONResponse }
 
-func (response GenerateProof400JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+func (response CreateIdentity400JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateProof401JSONResponse struct{ N401JSONResponse }
+type CreateIdentity401JSONResponse struct{ N401JSONResponse }
 
-func (response GenerateProof401JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+func (response CreateIdentity401JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateProof422JSONResponse struct{ N422JSONResponse }
+type CreateIdentity500JSONResponse struct{ N500CreateIdentityJSONResponse }
 
-func (response GenerateProof422JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+func (response CreateIdentity500JSONResponse) VisitCreateIdentityResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GenerateProof500JSONResponse struct{ N500JSONResponse }
+type VerifyPresentationRequestObject struct {
+	Body *VerifyPresentationJSONRequestBody
+}
 
-func (response GenerateProof500JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+type VerifyPresentationResponseObject interface {
+	VisitVerifyPresentationResponse(w http.ResponseWriter) error
+}
+
+type VerifyPresentation200JSONResponse VerifyPresentationResponse
+
+func (response VerifyPresentation200JSONResponse) VisitVerifyPresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyPresentation400JSONResponse struct{ N400JSONResponse }
+
+func (response VerifyPresentation400JSONResponse) VisitVerifyPresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyPresentation401JSONResponse struct{ N401JSONResponse }
+
+func (response VerifyPresentation401JSONResponse) VisitVerifyPresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyPresentation500JSONResponse struct{ N500JSONResponse }
+
+func (response VerifyPresentation500JSONResponse) VisitVerifyPresentationResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateQueryRequestRequestObject struct {
+type CreateAuthRequestRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Body       *CreateQueryRequestJSONRequestBody
+	Body       *CreateAuthRequestJSONRequestBody
 }
 
-type CreateQueryRequestResponseObject interface {
-	VisitCreateQueryRequestResponse(w http.ResponseWriter) error
+type CreateAuthRequestResponseObject interface {
+	VisitCreateAuthRequestResponse(w http.ResponseWriter) error
 }
 
-type CreateQueryRequest201JSONResponse CreateQueryRequestResponse
+type CreateAuthRequest201JSONResponse CreateAuthRequestResponse
 
-func (response CreateQueryRequest201JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+func (response CreateAuthRequest201JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateQueryRequest400JSONResponse struct{ N400JSONResponse }
+type CreateAuthRequest400JSONResponse struct{ N400JSONResponse }
 
-func (response CreateQueryRequest400JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+func (response CreateAuthRequest400JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateQueryRequest401JSONResponse struct{ N401JSONResponse }
+type CreateAuthRequest401JSONResponse struct{ N401JSONResponse }
 
-func (response CreateQueryRequest401JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+func (response CreateAuthRequest401JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateQueryRequest422JSONResponse struct{ N422JSONResponse }
+type CreateAuthRequest404JSONResponse struct{ N404JSONResponse }
 
-func (response CreateQueryRequest422JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+func (response CreateAuthRequest404JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthRequest422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateAuthRequest422JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateQueryRequest500JSONResponse struct{ N500JSONResponse }
+type CreateAuthRequest500JSONResponse struct{ N500JSONResponse }
 
-func (response CreateQueryRequest500JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+func (response CreateAuthRequest500JSONResponse) VisitCreateAuthRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityStateRequestObject struct {
+type CreateAuthorizationRequestRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateAuthorizationRequestJSONRequestBody
 }
 
-type PublishIdentityStateResponseObject interface {
-	VisitPublishIdentityStateResponse(w http.ResponseWriter) error
+type CreateAuthorizationRequestResponseObject interface {
+	VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error
 }
 
-type PublishIdentityState200JSONResponse GenericErrorMessage
+type CreateAuthorizationRequest201JSONResponse GenerateProofRequest
 
-func (response PublishIdentityState200JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationRequest201JSONResponse) VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState202JSONResponse PublishIdentityStateResponse
+type CreateAuthorizationRequest400JSONResponse struct{ N400JSONResponse }
 
-func (response PublishIdentityState202JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationRequest400JSONResponse) VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState400JSONResponse struct{ N400JSONResponse }
+type CreateAuthorizationRequest401JSONResponse struct{ N401JSONResponse }
 
-func (response PublishIdentityState400JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationRequest401JSONResponse) VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState401JSONResponse struct{ N401JSONResponse }
+type CreateAuthorizationRequest404JSONResponse struct{ N404JSONResponse }
 
-func (response PublishIdentityState401JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationRequest404JSONResponse) VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PublishIdentityState500JSONResponse struct{ N500JSONResponse }
+type CreateAuthorizationRequest500JSONResponse struct{ N500JSONResponse }
 
-func (response PublishIdentityState500JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationRequest500JSONResponse) VisitCreateAuthorizationRequestResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VerifyProofRequestObject struct {
+type CallbackRequestObject struct {
 	Identifier PathIdentifier `json:"identifier"`
-	Body       *VerifyProofJSONRequestBody
+	Params     CallbackParams
+	Body       *CallbackTextRequestBody
 }
 
-type VerifyProofResponseObject interface {
-	VisitVerifyProofResponse(w http.ResponseWriter) error
+type CallbackResponseObject interface {
+	VisitCallbackResponse(w http.ResponseWriter) error
 }
 
-type VerifyProof200JSONResponse VerifyProofResponse
+type Callback200JSONResponse CallbackResponse
 
-func (response VerifyProof200JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+func (response Callback200JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VerifyProof400JSONResponse struct{ N400JSONResponse }
+type Callback400JSONResponse struct{ N400JSONResponse }
 
-func (response VerifyProof400JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+func (response Callback400JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VerifyProof401JSONResponse struct{ N401JSONResponse }
+type Callback404JSONResponse struct{ N404JSONResponse }
 
-func (response VerifyProof401JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+func (response Callback404JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VerifyProof422JSONResponse struct{ N422JSONResponse }
+type Callback500JSONResponse struct{ N500JSONResponse }
 
-func (response VerifyProof422JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+func (response Callback500JSONResponse) VisitCallbackResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type VerifyProof500JSONResponse struct{ N500JSONResponse }
+type GetClaimsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetClaimsParams
+}
 
-func (response VerifyProof500JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+type GetClaimsResponseObject interface {
+	VisitGetClaimsResponse(w http.ResponseWriter) error
+}
+
+type GetClaims200ResponseHeaders struct {
+	XNextCursor string
+	XTotalCount int
+}
+
+type GetClaims200JSONResponse struct {
+	Body    GetClaimsResponse
+	Headers GetClaims200ResponseHeaders
+}
+
+func (response GetClaims200JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetClaims400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaims400JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaims401JSONResponse struct{ N401JSONResponse }
+
+func (response GetClaims401JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaims500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaims500JSONResponse) VisitGetClaimsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get the documentation
-	// (GET /)
-	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
-	// Get the documentation yaml file
-	// (GET /static/docs/api/api.yaml)
-	GetYaml(ctx context.Context, request GetYamlRequestObject) (GetYamlResponseObject, error)
-	// Healthcheck
-	// (GET /status)
-	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
-	// Agent
-	// (POST /v1/agent)
-	Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error)
-	// Get Identities
-	// (GET /v1/identities)
-	GetIdentities(ctx context.Context, request GetIdentitiesRequestObject) (GetIdentitiesResponseObject, error)
-	// Options request
-	// (OPTIONS /v1/identities)
-	ReturnCreateIdentityOptions(ctx context.Context, request ReturnCreateIdentityOptionsRequestObject) (ReturnCreateIdentityOptionsResponseObject, error)
-	// Create Identity
-	// (POST /v1/identities)
-	CreateIdentity(ctx context.Context, request CreateIdentityRequestObject) (CreateIdentityResponseObject, error)
-	// Create Auth Request
-	// (POST /v1/{identifier}/auth-reqs)
-	CreateAuthRequest(ctx context.Context, request CreateAuthRequestRequestObject) (CreateAuthRequestResponseObject, error)
-	// Get Claims
-	// (GET /v1/{identifier}/claims)
-	GetClaims(ctx context.Context, request GetClaimsRequestObject) (GetClaimsResponseObject, error)
-	// Create Claim
-	// (POST /v1/{identifier}/claims)
-	CreateClaim(ctx context.Context, request CreateClaimRequestObject) (CreateClaimResponseObject, error)
-	// Get Revocation Status
-	// (GET /v1/{identifier}/claims/revocation/status/{nonce})
-	GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error)
-	// Revoke Claim
-	// (POST /v1/{identifier}/claims/revoke/{nonce})
-	RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error)
-	// Get Claim
-	// (GET /v1/{identifier}/claims/{id})
-	GetClaim(ctx context.Context, request GetClaimRequestObject) (GetClaimResponseObject, error)
-	// Get Claim QR code
-	// (GET /v1/{identifier}/claims/{id}/qrcode)
-	GetClaimQrCode(ctx context.Context, request GetClaimQrCodeRequestObject) (GetClaimQrCodeResponseObject, error)
-	// Request a proof generation
-	// (POST /v1/{identifier}/generate-proof)
-	GenerateProof(ctx context.Context, request GenerateProofRequestObject) (GenerateProofResponseObject, error)
-	// Create Query Request
-	// (POST /v1/{identifier}/query-reqs)
-	CreateQueryRequest(ctx context.Context, request CreateQueryRequestRequestObject) (CreateQueryRequestResponseObject, error)
-	// Publish Identity State
-	// (POST /v1/{identifier}/state/publish)
-	PublishIdentityState(ctx context.Context, request PublishIdentityStateRequestObject) (PublishIdentityStateResponseObject, error)
-	// Request a proof verification
-	// (POST /v1/{identifier}/verify-proof)
-	VerifyProof(ctx context.Context, request VerifyProofRequestObject) (VerifyProofResponseObject, error)
+type CreateClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateClaimJSONRequestBody
+}
+
+type CreateClaimResponseObject interface {
+	VisitCreateClaimResponse(w http.ResponseWriter) error
+}
+
+type CreateClaim201JSONResponse CreateClaimResponse
+
+func (response CreateClaim201JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim202JSONResponse CredentialRequest
+
+func (response CreateClaim202JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateClaim400JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateClaim401JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim403JSONResponse struct{ N403JSONResponse }
+
+func (response CreateClaim403JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateClaim422JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateClaim500JSONResponse) VisitCreateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimsBatchRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateClaimsBatchJSONRequestBody
+}
+
+type CreateClaimsBatchResponseObject interface {
+	VisitCreateClaimsBatchResponse(w http.ResponseWriter) error
+}
+
+type CreateClaimsBatch201JSONResponse CreateClaimsBatchResponse
+
+func (response CreateClaimsBatch201JSONResponse) VisitCreateClaimsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimsBatch207JSONResponse CreateClaimsBatchResponse
+
+func (response CreateClaimsBatch207JSONResponse) VisitCreateClaimsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(207)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimsBatch400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateClaimsBatch400JSONResponse) VisitCreateClaimsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimsBatch401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateClaimsBatch401JSONResponse) VisitCreateClaimsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateClaimsBatch500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateClaimsBatch500JSONResponse) VisitCreateClaimsBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatusRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Nonce      PathNonce      `json:"nonce"`
+}
+
+type GetRevocationStatusResponseObject interface {
+	VisitGetRevocationStatusResponse(w http.ResponseWriter) error
+}
+
+type GetRevocationStatus200JSONResponse RevocationStatusResponse
+
+func (response GetRevocationStatus200JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus400JSONResponse struct{ N400JSONResponse }
+
+func (response GetRevocationStatus400JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRevocationStatus500JSONResponse struct{ N500JSONResponse }
+
+func (response GetRevocationStatus500JSONResponse) VisitGetRevocationStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Nonce      PathNonce      `json:"nonce"`
+}
+
+type RevokeClaimResponseObject interface {
+	VisitRevokeClaimResponse(w http.ResponseWriter) error
+}
+
+type RevokeClaim202JSONResponse RevokeClaimResponse
+
+func (response RevokeClaim202JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response RevokeClaim401JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim404JSONResponse struct{ N404JSONResponse }
+
+func (response RevokeClaim404JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response RevokeClaim500JSONResponse) VisitRevokeClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+}
+
+type GetClaimResponseObject interface {
+	VisitGetClaimResponse(w http.ResponseWriter) error
+}
+
+type GetClaim200JSONResponse GetClaimResponse
+
+func (response GetClaim200JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaim400JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response GetClaim401JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaim404JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaim500JSONResponse) VisitGetClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClaimRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+	Body       *UpdateClaimJSONRequestBody
+}
+
+type UpdateClaimResponseObject interface {
+	VisitUpdateClaimResponse(w http.ResponseWriter) error
+}
+
+type UpdateClaim200JSONResponse UpdateClaimResponse
+
+func (response UpdateClaim200JSONResponse) VisitUpdateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClaim400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateClaim400JSONResponse) VisitUpdateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClaim401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateClaim401JSONResponse) VisitUpdateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClaim404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateClaim404JSONResponse) VisitUpdateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateClaim500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateClaim500JSONResponse) VisitUpdateClaimResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCodeRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathClaim      `json:"id"`
+}
+
+type GetClaimQrCodeResponseObject interface {
+	VisitGetClaimQrCodeResponse(w http.ResponseWriter) error
+}
+
+type GetClaimQrCode200JSONResponse GetClaimQrCodeResponse
+
+func (response GetClaimQrCode200JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode400JSONResponse struct{ N400JSONResponse }
+
+func (response GetClaimQrCode400JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode404JSONResponse struct{ N404JSONResponse }
+
+func (response GetClaimQrCode404JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClaimQrCode500JSONResponse struct{ N500JSONResponse }
+
+func (response GetClaimQrCode500JSONResponse) VisitGetClaimQrCodeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequestsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetCredentialRequestsParams
+}
+
+type GetCredentialRequestsResponseObject interface {
+	VisitGetCredentialRequestsResponse(w http.ResponseWriter) error
+}
+
+type GetCredentialRequests200JSONResponse GetCredentialRequestsResponse
+
+func (response GetCredentialRequests200JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredentialRequests400JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCredentialRequests401JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCredentialRequests500JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequestRequestObject struct {
+	Identifier PathIdentifier        `json:"identifier"`
+	Id         PathCredentialRequest `json:"id"`
+}
+
+type ApproveCredentialRequestResponseObject interface {
+	VisitApproveCredentialRequestResponse(w http.ResponseWriter) error
+}
+
+type ApproveCredentialRequest200JSONResponse GetClaimQrCodeResponse
+
+func (response ApproveCredentialRequest200JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest400JSONResponse struct{ N400JSONResponse }
+
+func (response ApproveCredentialRequest400JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest401JSONResponse struct{ N401JSONResponse }
+
+func (response ApproveCredentialRequest401JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest404JSONResponse struct{ N404JSONResponse }
+
+func (response ApproveCredentialRequest404JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest409JSONResponse struct{ N409JSONResponse }
+
+func (response ApproveCredentialRequest409JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest500JSONResponse struct{ N500JSONResponse }
+
+func (response ApproveCredentialRequest500JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequestRequestObject struct {
+	Identifier PathIdentifier        `json:"identifier"`
+	Id         PathCredentialRequest `json:"id"`
+	Body       *RejectCredentialRequestJSONRequestBody
+}
+
+type RejectCredentialRequestResponseObject interface {
+	VisitRejectCredentialRequestResponse(w http.ResponseWriter) error
+}
+
+type RejectCredentialRequest200JSONResponse CredentialRequest
+
+func (response RejectCredentialRequest200JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest400JSONResponse struct{ N400JSONResponse }
+
+func (response RejectCredentialRequest400JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest401JSONResponse struct{ N401JSONResponse }
+
+func (response RejectCredentialRequest401JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest404JSONResponse struct{ N404JSONResponse }
+
+func (response RejectCredentialRequest404JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest409JSONResponse struct{ N409JSONResponse }
+
+func (response RejectCredentialRequest409JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest500JSONResponse struct{ N500JSONResponse }
+
+func (response RejectCredentialRequest500JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredentialsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetHolderCredentialsParams
+}
+
+type GetHolderCredentialsResponseObject interface {
+	VisitGetHolderCredentialsResponse(w http.ResponseWriter) error
+}
+
+type GetHolderCredentials200JSONResponse GetHolderCredentialsResponse
+
+func (response GetHolderCredentials200JSONResponse) VisitGetHolderCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredentials400JSONResponse struct{ N400JSONResponse }
+
+func (response GetHolderCredentials400JSONResponse) VisitGetHolderCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredentials401JSONResponse struct{ N401JSONResponse }
+
+func (response GetHolderCredentials401JSONResponse) VisitGetHolderCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredentials500JSONResponse struct{ N500JSONResponse }
+
+func (response GetHolderCredentials500JSONResponse) VisitGetHolderCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredentialRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *ImportCredentialJSONRequestBody
+}
+
+type ImportCredentialResponseObject interface {
+	VisitImportCredentialResponse(w http.ResponseWriter) error
+}
+
+type ImportCredential201JSONResponse HolderCredential
+
+func (response ImportCredential201JSONResponse) VisitImportCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response ImportCredential400JSONResponse) VisitImportCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response ImportCredential401JSONResponse) VisitImportCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredential422JSONResponse struct{ N422JSONResponse }
+
+func (response ImportCredential422JSONResponse) VisitImportCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ImportCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response ImportCredential500JSONResponse) VisitImportCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteHolderCredentialRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathCredential `json:"id"`
+}
+
+type DeleteHolderCredentialResponseObject interface {
+	VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error
+}
+
+type DeleteHolderCredential200JSONResponse GenericMessage
+
+func (response DeleteHolderCredential200JSONResponse) VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteHolderCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteHolderCredential400JSONResponse) VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteHolderCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteHolderCredential401JSONResponse) VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteHolderCredential404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteHolderCredential404JSONResponse) VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteHolderCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteHolderCredential500JSONResponse) VisitDeleteHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredentialRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathCredential `json:"id"`
+}
+
+type GetHolderCredentialResponseObject interface {
+	VisitGetHolderCredentialResponse(w http.ResponseWriter) error
+}
+
+type GetHolderCredential200JSONResponse HolderCredential
+
+func (response GetHolderCredential200JSONResponse) VisitGetHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredential400JSONResponse struct{ N400JSONResponse }
+
+func (response GetHolderCredential400JSONResponse) VisitGetHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredential401JSONResponse struct{ N401JSONResponse }
+
+func (response GetHolderCredential401JSONResponse) VisitGetHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredential404JSONResponse struct{ N404JSONResponse }
+
+func (response GetHolderCredential404JSONResponse) VisitGetHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHolderCredential500JSONResponse struct{ N500JSONResponse }
+
+func (response GetHolderCredential500JSONResponse) VisitGetHolderCredentialResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateProofRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *GenerateProofJSONRequestBody
+}
+
+type GenerateProofResponseObject interface {
+	VisitGenerateProofResponse(w http.ResponseWriter) error
+}
+
+type GenerateProof200JSONResponse GenerateProofResponse
+
+func (response GenerateProof200JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateProof400JSONResponse struct{ N400JSONResponse }
+
+func (response GenerateProof400JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateProof401JSONResponse struct{ N401JSONResponse }
+
+func (response GenerateProof401JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateProof422JSONResponse struct{ N422JSONResponse }
+
+func (response GenerateProof422JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GenerateProof500JSONResponse struct{ N500JSONResponse }
+
+func (response GenerateProof500JSONResponse) VisitGenerateProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptCredentialOfferRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *AcceptCredentialOfferJSONRequestBody
+}
+
+type AcceptCredentialOfferResponseObject interface {
+	VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error
+}
+
+type AcceptCredentialOffer201JSONResponse GetHolderCredentialsResponse
+
+func (response AcceptCredentialOffer201JSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptCredentialOffer400JSONResponse struct{ N400JSONResponse }
+
+func (response AcceptCredentialOffer400JSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptCredentialOffer401JSONResponse struct{ N401JSONResponse }
+
+func (response AcceptCredentialOffer401JSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptCredentialOffer422JSONResponse struct{ N422JSONResponse }
+
+func (response AcceptCredentialOffer422JSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptCredentialOffer500JSONResponse struct{ N500JSONResponse }
+
+func (response AcceptCredentialOffer500JSONResponse) VisitAcceptCredentialOfferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePresentationRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreatePresentationJSONRequestBody
+}
+
+type CreatePresentationResponseObject interface {
+	VisitCreatePresentationResponse(w http.ResponseWriter) error
+}
+
+type CreatePresentation201JSONResponse VerifiablePresentation
+
+func (response CreatePresentation201JSONResponse) VisitCreatePresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePresentation400JSONResponse struct{ N400JSONResponse }
+
+func (response CreatePresentation400JSONResponse) VisitCreatePresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePresentation401JSONResponse struct{ N401JSONResponse }
+
+func (response CreatePresentation401JSONResponse) VisitCreatePresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePresentation500JSONResponse struct{ N500JSONResponse }
+
+func (response CreatePresentation500JSONResponse) VisitCreatePresentationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProfilesRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetProfilesResponseObject interface {
+	VisitGetProfilesResponse(w http.ResponseWriter) error
+}
+
+type GetProfiles200JSONResponse GetProfilesResponse
+
+func (response GetProfiles200JSONResponse) VisitGetProfilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProfiles400JSONResponse struct{ N400JSONResponse }
+
+func (response GetProfiles400JSONResponse) VisitGetProfilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProfiles401JSONResponse struct{ N401JSONResponse }
+
+func (response GetProfiles401JSONResponse) VisitGetProfilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProfiles500JSONResponse struct{ N500JSONResponse }
+
+func (response GetProfiles500JSONResponse) VisitGetProfilesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfileRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateProfileJSONRequestBody
+}
+
+type CreateProfileResponseObject interface {
+	VisitCreateProfileResponse(w http.ResponseWriter) error
+}
+
+type CreateProfile201JSONResponse Profile
+
+func (response CreateProfile201JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfile400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateProfile400JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfile401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateProfile401JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfile404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateProfile404JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfile409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateProfile409JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProfile500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateProfile500JSONResponse) VisitCreateProfileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofJobRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateProofJobJSONRequestBody
+}
+
+type CreateProofJobResponseObject interface {
+	VisitCreateProofJobResponse(w http.ResponseWriter) error
+}
+
+type CreateProofJob202JSONResponse ProofJob
+
+func (response CreateProofJob202JSONResponse) VisitCreateProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofJob400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateProofJob400JSONResponse) VisitCreateProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofJob401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateProofJob401JSONResponse) VisitCreateProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofJob422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateProofJob422JSONResponse) VisitCreateProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofJob500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateProofJob500JSONResponse) VisitCreateProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJobRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathProofJob   `json:"id"`
+}
+
+type CancelProofJobResponseObject interface {
+	VisitCancelProofJobResponse(w http.ResponseWriter) error
+}
+
+type CancelProofJob200JSONResponse ProofJob
+
+func (response CancelProofJob200JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJob400JSONResponse struct{ N400JSONResponse }
+
+func (response CancelProofJob400JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJob401JSONResponse struct{ N401JSONResponse }
+
+func (response CancelProofJob401JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJob404JSONResponse struct{ N404JSONResponse }
+
+func (response CancelProofJob404JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJob409JSONResponse struct{ N409JSONResponse }
+
+func (response CancelProofJob409JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelProofJob500JSONResponse struct{ N500JSONResponse }
+
+func (response CancelProofJob500JSONResponse) VisitCancelProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofJobRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Id         PathProofJob   `json:"id"`
+}
+
+type GetProofJobResponseObject interface {
+	VisitGetProofJobResponse(w http.ResponseWriter) error
+}
+
+type GetProofJob200JSONResponse ProofJob
+
+func (response GetProofJob200JSONResponse) VisitGetProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofJob400JSONResponse struct{ N400JSONResponse }
+
+func (response GetProofJob400JSONResponse) VisitGetProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofJob401JSONResponse struct{ N401JSONResponse }
+
+func (response GetProofJob401JSONResponse) VisitGetProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofJob404JSONResponse struct{ N404JSONResponse }
+
+func (response GetProofJob404JSONResponse) VisitGetProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofJob500JSONResponse struct{ N500JSONResponse }
+
+func (response GetProofJob500JSONResponse) VisitGetProofJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequestRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateQueryRequestJSONRequestBody
+}
+
+type CreateQueryRequestResponseObject interface {
+	VisitCreateQueryRequestResponse(w http.ResponseWriter) error
+}
+
+type CreateQueryRequest201JSONResponse CreateQueryRequestResponse
+
+func (response CreateQueryRequest201JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequest400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateQueryRequest400JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequest401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateQueryRequest401JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequest404JSONResponse struct{ N404JSONResponse }
+
+func (response CreateQueryRequest404JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequest422JSONResponse struct{ N422JSONResponse }
+
+func (response CreateQueryRequest422JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateQueryRequest500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateQueryRequest500JSONResponse) VisitCreateQueryRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityStateRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type PublishIdentityStateResponseObject interface {
+	VisitPublishIdentityStateResponse(w http.ResponseWriter) error
+}
+
+type PublishIdentityState200JSONResponse GenericErrorMessage
+
+func (response PublishIdentityState200JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState202JSONResponse PublishIdentityStateResponse
+
+func (response PublishIdentityState202JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState400JSONResponse struct{ N400JSONResponse }
+
+func (response PublishIdentityState400JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState401JSONResponse struct{ N401JSONResponse }
+
+func (response PublishIdentityState401JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PublishIdentityState500JSONResponse struct{ N500JSONResponse }
+
+func (response PublishIdentityState500JSONResponse) VisitPublishIdentityStateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyProofRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *VerifyProofJSONRequestBody
+}
+
+type VerifyProofResponseObject interface {
+	VisitVerifyProofResponse(w http.ResponseWriter) error
+}
+
+type VerifyProof200JSONResponse VerifyProofResponse
+
+func (response VerifyProof200JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyProof400JSONResponse struct{ N400JSONResponse }
+
+func (response VerifyProof400JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyProof401JSONResponse struct{ N401JSONResponse }
+
+func (response VerifyProof401JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyProof422JSONResponse struct{ N422JSONResponse }
+
+func (response VerifyProof422JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyProof500JSONResponse struct{ N500JSONResponse }
+
+func (response VerifyProof500JSONResponse) VisitVerifyProofResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the documentation
+	// (GET /)
+	GetDocumentation(ctx context.Context, request GetDocumentationRequestObject) (GetDocumentationResponseObject, error)
+	// Get the documentation yaml file
+	// (GET /static/docs/api/api.yaml)
+	GetYaml(ctx context.Context, request GetYamlRequestObject) (GetYamlResponseObject, error)
+	// Healthcheck
+	// (GET /status)
+	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+	// Agent
+	// (POST /v1/agent)
+	Agent(ctx context.Context, request AgentRequestObject) (AgentResponseObject, error)
+	// Get Identities
+	// (GET /v1/identities)
+	GetIdentities(ctx context.Context, request GetIdentitiesRequestObject) (GetIdentitiesResponseObject, error)
+	// Options request
+	// (OPTIONS /v1/identities)
+	ReturnCreateIdentityOptions(ctx context.Context, request ReturnCreateIdentityOptionsRequestObject) (ReturnCreateIdentityOptionsResponseObject, error)
+	// Create Identity
+	// (POST /v1/identities)
+	CreateIdentity(ctx context.Context, request CreateIdentityRequestObject) (CreateIdentityResponseObject, error)
+	// Verify Presentation
+	// (POST /v1/presentations/verify)
+	VerifyPresentation(ctx context.Context, request VerifyPresentationRequestObject) (VerifyPresentationResponseObject, error)
+	// Create Auth Request
+	// (POST /v1/{identifier}/auth-reqs)
+	CreateAuthRequest(ctx context.Context, request CreateAuthRequestRequestObject) (CreateAuthRequestResponseObject, error)
+	// Create Authorization Request from a template
+	// (POST /v1/{identifier}/authorization-requests)
+	CreateAuthorizationRequest(ctx context.Context, request CreateAuthorizationRequestRequestObject) (CreateAuthorizationRequestResponseObject, error)
+	// Verifier callback
+	// (POST /v1/{identifier}/callback)
+	Callback(ctx context.Context, request CallbackRequestObject) (CallbackResponseObject, error)
+	// Get Claims
+	// (GET /v1/{identifier}/claims)
+	GetClaims(ctx context.Context, request GetClaimsRequestObject) (GetClaimsResponseObject, error)
+	// Create Claim
+	// (POST /v1/{identifier}/claims)
+	CreateClaim(ctx context.Context, request CreateClaimRequestObject) (CreateClaimResponseObject, error)
+	// Create Claims Batch
+	// (POST /v1/{identifier}/claims/batch)
+	CreateClaimsBatch(ctx context.Context, request CreateClaimsBatchRequestObject) (CreateClaimsBatchResponseObject, error)
+	// Get Revocation Status
+	// (GET /v1/{identifier}/claims/revocation/status/{nonce})
+	GetRevocationStatus(ctx context.Context, request GetRevocationStatusRequestObject) (GetRevocationStatusResponseObject, error)
+	// Revoke Claim
+	// (POST /v1/{identifier}/claims/revoke/{nonce})
+	RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error)
+	// Get Claim
+	// (GET /v1/{identifier}/claims/{id})
+	GetClaim(ctx context.Context, request GetClaimRequestObject) (GetClaimResponseObject, error)
+	// Update Claim
+	// (PUT /v1/{identifier}/claims/{id})
+	UpdateClaim(ctx context.Context, request UpdateClaimRequestObject) (UpdateClaimResponseObject, error)
+	// Get Claim QR code
+	// (GET /v1/{identifier}/claims/{id}/qrcode)
+	GetClaimQrCode(ctx context.Context, request GetClaimQrCodeRequestObject) (GetClaimQrCodeResponseObject, error)
+	// Get Credential Requests
+	// (GET /v1/{identifier}/credential-requests)
+	GetCredentialRequests(ctx context.Context, request GetCredentialRequestsRequestObject) (GetCredentialRequestsResponseObject, error)
+	// Approve Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/approve)
+	ApproveCredentialRequest(ctx context.Context, request ApproveCredentialRequestRequestObject) (ApproveCredentialRequestResponseObject, error)
+	// Reject Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/reject)
+	RejectCredentialRequest(ctx context.Context, request RejectCredentialRequestRequestObject) (RejectCredentialRequestResponseObject, error)
+	// Get Held Credentials
+	// (GET /v1/{identifier}/credentials)
+	GetHolderCredentials(ctx context.Context, request GetHolderCredentialsRequestObject) (GetHolderCredentialsResponseObject, error)
+	// Import Credential
+	// (POST /v1/{identifier}/credentials)
+	ImportCredential(ctx context.Context, request ImportCredentialRequestObject) (ImportCredentialResponseObject, error)
+	// Delete Held Credential
+	// (DELETE /v1/{identifier}/credentials/{id})
+	DeleteHolderCredential(ctx context.Context, request DeleteHolderCredentialRequestObject) (DeleteHolderCredentialResponseObject, error)
+	// Get Held Credential
+	// (GET /v1/{identifier}/credentials/{id})
+	GetHolderCredential(ctx context.Context, request GetHolderCredentialRequestObject) (GetHolderCredentialResponseObject, error)
+	// Request a proof generation
+	// (POST /v1/{identifier}/generate-proof)
+	GenerateProof(ctx context.Context, request GenerateProofRequestObject) (GenerateProofResponseObject, error)
+	// Accept Credential Offer
+	// (POST /v1/{identifier}/offers)
+	AcceptCredentialOffer(ctx context.Context, request AcceptCredentialOfferRequestObject) (AcceptCredentialOfferResponseObject, error)
+	// Create Presentation
+	// (POST /v1/{identifier}/presentations)
+	CreatePresentation(ctx context.Context, request CreatePresentationRequestObject) (CreatePresentationResponseObject, error)
+	// Get Profiles
+	// (GET /v1/{identifier}/profiles)
+	GetProfiles(ctx context.Context, request GetProfilesRequestObject) (GetProfilesResponseObject, error)
+	// Create Profile
+	// (POST /v1/{identifier}/profiles)
+	CreateProfile(ctx context.Context, request CreateProfileRequestObject) (CreateProfileResponseObject, error)
+	// Create a proof job
+	// (POST /v1/{identifier}/proof-jobs)
+	CreateProofJob(ctx context.Context, request CreateProofJobRequestObject) (CreateProofJobResponseObject, error)
+	// Cancel a proof job
+	// (DELETE /v1/{identifier}/proof-jobs/{id})
+	CancelProofJob(ctx context.Context, request CancelProofJobRequestObject) (CancelProofJobResponseObject, error)
+	// Get a proof job
+	// (GET /v1/{identifier}/proof-jobs/{id})
+	GetProofJob(ctx context.Context, request GetProofJobRequestObject) (GetProofJobResponseObject, error)
+	// Create Query Request
+	// (POST /v1/{identifier}/query-reqs)
+	CreateQueryRequest(ctx context.Context, request CreateQueryRequestRequestObject) (CreateQueryRequestResponseObject, error)
+	// Publish Identity State
+	// (POST /v1/{identifier}/state/publish)
+	PublishIdentityState(ctx context.Context, request PublishIdentityStateRequestObject) (PublishIdentityStateResponseObject, error)
+	// Request a proof verification
+	// (POST /v1/{identifier}/verify-proof)
+	VerifyProof(ctx context.Context, request VerifyProofRequestObject) (VerifyProofResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetDocumentation operation middleware
+func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
+	var request GetDocumentationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDocumentation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
+		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetYaml operation middleware
+func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
+	var request GetYamlRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetYaml")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
+		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Agent operation middleware
+func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
+	var request AgentRequestObject
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := AgentTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Agent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AgentResponseObject); ok {
+		if err := validResponse.VisitAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIdentities operation middleware
+func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	var request GetIdentitiesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdentities(ctx, request.(GetIdentitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdentities")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdentitiesResponseObject); ok {
+		if err := validResponse.VisitGetIdentitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ReturnCreateIdentityOptions operation middleware
+func (sh *strictHandler) ReturnCreateIdentityOptions(w http.ResponseWriter, r *http.Request) {
+	var request ReturnCreateIdentityOptionsRequestObject
+
+	var body ReturnCreateIdentityOptionsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReturnCreateIdentityOptions(ctx, request.(ReturnCreateIdentityOptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReturnCreateIdentityOptions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReturnCreateIdentityOptionsResponseObject); ok {
+		if err := validResponse.VisitReturnCreateIdentityOptionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateIdentity operation middleware
+func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request) {
+	var request CreateIdentityRequestObject
+
+	var body CreateIdentityJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateIdentity(ctx, request.(CreateIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateIdentity")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateIdentityResponseObject); ok {
+		if err := validResponse.VisitCreateIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// VerifyPresentation operation middleware
+func (sh *strictHandler) VerifyPresentation(w http.ResponseWriter, r *http.Request) {
+	var request VerifyPresentationRequestObject
+
+	var body VerifyPresentationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyPresentation(ctx, request.(VerifyPresentationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyPresentation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifyPresentationResponseObject); ok {
+		if err := validResponse.VisitVerifyPresentationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateAuthRequest operation middleware
+func (sh *strictHandler) CreateAuthRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateAuthRequestRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateAuthRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAuthRequest(ctx, request.(CreateAuthRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAuthRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAuthRequestResponseObject); ok {
+		if err := validResponse.VisitCreateAuthRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateAuthorizationRequest operation middleware
+func (sh *strictHandler) CreateAuthorizationRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateAuthorizationRequestRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateAuthorizationRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAuthorizationRequest(ctx, request.(CreateAuthorizationRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAuthorizationRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAuthorizationRequestResponseObject); ok {
+		if err := validResponse.VisitCreateAuthorizationRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// Callback operation middleware
+func (sh *strictHandler) Callback(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params CallbackParams) {
+	var request CallbackRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+		return
+	}
+	body := CallbackTextRequestBody(data)
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Callback(ctx, request.(CallbackRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Callback")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CallbackResponseObject); ok {
+		if err := validResponse.VisitCallbackResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetClaims operation middleware
+func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams) {
+	var request GetClaimsRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClaims(ctx, request.(GetClaimsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClaims")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetClaimsResponseObject); ok {
+		if err := validResponse.VisitGetClaimsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateClaim operation middleware
+func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateClaimRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateClaimJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateClaim(ctx, request.(CreateClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateClaimResponseObject); ok {
+		if err := validResponse.VisitCreateClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateClaimsBatch operation middleware
+func (sh *strictHandler) CreateClaimsBatch(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateClaimsBatchRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateClaimsBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateClaimsBatch(ctx, request.(CreateClaimsBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateClaimsBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateClaimsBatchResponseObject); ok {
+		if err := validResponse.VisitCreateClaimsBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetRevocationStatus operation middleware
+func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
+	var request GetRevocationStatusRequestObject
+
+	request.Identifier = identifier
+	request.Nonce = nonce
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRevocationStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
+		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// RevokeClaim operation middleware
+func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
+	var request RevokeClaimRequestObject
+
+	request.Identifier = identifier
+	request.Nonce = nonce
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeClaim(ctx, request.(RevokeClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeClaimResponseObject); ok {
+		if err := validResponse.VisitRevokeClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetClaim operation middleware
+func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request GetClaimRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClaim(ctx, request.(GetClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClaim")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetClaimResponseObject); ok {
+		if err := validResponse.VisitGetClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
+// UpdateClaim operation middleware
+func (sh *strictHandler) UpdateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request UpdateClaimRequestObject
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	request.Identifier = identifier
+	request.Id = id
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var body UpdateClaimJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateClaim(ctx, request.(UpdateClaimRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateClaim")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateClaimResponseObject); ok {
+		if err := validResponse.VisitUpdateClaimResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
 }
 
-// GetDocumentation operation middleware
-func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request) {
-	var request GetDocumentationRequestObject
+// GetClaimQrCode operation middleware
+func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
+	var request GetClaimQrCodeRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDocumentation(ctx, request.(GetDocumentationRequestObject))
+		return sh.ssi.GetClaimQrCode(ctx, request.(GetClaimQrCodeRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDocumentation")
+		handler = middleware(handler, "GetClaimQrCode")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDocumentationResponseObject); ok {
-		if err := validResponse.VisitGetDocumentationResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetClaimQrCodeResponseObject); ok {
+		if err := validResponse.VisitGetClaimQrCodeResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1967,23 +4828,26 @@ func (sh *strictHandler) GetDocumentation(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// GetYaml operation middleware
-func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
-	var request GetYamlRequestObject
+// GetCredentialRequests operation middleware
+func (sh *strictHandler) GetCredentialRequests(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetCredentialRequestsParams) {
+	var request GetCredentialRequestsRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetYaml(ctx, request.(GetYamlRequestObject))
+		return sh.ssi.GetCredentialRequests(ctx, request.(GetCredentialRequestsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetYaml")
+		handler = middleware(handler, "GetCredentialRequests")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetYamlResponseObject); ok {
-		if err := validResponse.VisitGetYamlResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetCredentialRequestsResponseObject); ok {
+		if err := validResponse.VisitGetCredentialRequestsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1991,23 +4855,26 @@ func (sh *strictHandler) GetYaml(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Health operation middleware
-func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
-	var request HealthRequestObject
+// ApproveCredentialRequest operation middleware
+func (sh *strictHandler) ApproveCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest) {
+	var request ApproveCredentialRequestRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+		return sh.ssi.ApproveCredentialRequest(ctx, request.(ApproveCredentialRequestRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "ApproveCredentialRequest")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(w); err != nil {
+	} else if validResponse, ok := response.(ApproveCredentialRequestResponseObject); ok {
+		if err := validResponse.VisitApproveCredentialRequestResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2015,31 +4882,33 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Agent operation middleware
-func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
-	var request AgentRequestObject
+// RejectCredentialRequest operation middleware
+func (sh *strictHandler) RejectCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest) {
+	var request RejectCredentialRequestRequestObject
 
-	data, err := io.ReadAll(r.Body)
-	if err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't read body: %w", err))
+	request.Identifier = identifier
+	request.Id = id
+
+	var body RejectCredentialRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
 	}
-	body := AgentTextRequestBody(data)
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Agent(ctx, request.(AgentRequestObject))
+		return sh.ssi.RejectCredentialRequest(ctx, request.(RejectCredentialRequestRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Agent")
+		handler = middleware(handler, "RejectCredentialRequest")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AgentResponseObject); ok {
-		if err := validResponse.VisitAgentResponse(w); err != nil {
+	} else if validResponse, ok := response.(RejectCredentialRequestResponseObject); ok {
+		if err := validResponse.VisitRejectCredentialRequestResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2047,23 +4916,26 @@ func (sh *strictHandler) Agent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetIdentities operation middleware
-func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
-	var request GetIdentitiesRequestObject
+// GetHolderCredentials operation middleware
+func (sh *strictHandler) GetHolderCredentials(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetHolderCredentialsParams) {
+	var request GetHolderCredentialsRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetIdentities(ctx, request.(GetIdentitiesRequestObject))
+		return sh.ssi.GetHolderCredentials(ctx, request.(GetHolderCredentialsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetIdentities")
+		handler = middleware(handler, "GetHolderCredentials")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetIdentitiesResponseObject); ok {
-		if err := validResponse.VisitGetIdentitiesResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetHolderCredentialsResponseObject); ok {
+		if err := validResponse.VisitGetHolderCredentialsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2071,11 +4943,13 @@ func (sh *strictHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ReturnCreateIdentityOptions operation middleware
-func (sh *strictHandler) ReturnCreateIdentityOptions(w http.ResponseWriter, r *http.Request) {
-	var request ReturnCreateIdentityOptionsRequestObject
+// ImportCredential operation middleware
+func (sh *strictHandler) ImportCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request ImportCredentialRequestObject
 
-	var body ReturnCreateIdentityOptionsJSONRequestBody
+	request.Identifier = identifier
+
+	var body ImportCredentialJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -2083,18 +4957,18 @@ func (sh *strictHandler) ReturnCreateIdentityOptions(w http.ResponseWriter, r *h
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReturnCreateIdentityOptions(ctx, request.(ReturnCreateIdentityOptionsRequestObject))
+		return sh.ssi.ImportCredential(ctx, request.(ImportCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReturnCreateIdentityOptions")
+		handler = middleware(handler, "ImportCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReturnCreateIdentityOptionsResponseObject); ok {
-		if err := validResponse.VisitReturnCreateIdentityOptionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ImportCredentialResponseObject); ok {
+		if err := validResponse.VisitImportCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2102,30 +4976,53 @@ func (sh *strictHandler) ReturnCreateIdentityOptions(w http.ResponseWriter, r *h
 	}
 }
 
-// CreateIdentity operation middleware
-func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request) {
-	var request CreateIdentityRequestObject
+// DeleteHolderCredential operation middleware
+func (sh *strictHandler) DeleteHolderCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredential) {
+	var request DeleteHolderCredentialRequestObject
 
-	var body CreateIdentityJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteHolderCredential(ctx, request.(DeleteHolderCredentialRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteHolderCredential")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteHolderCredentialResponseObject); ok {
+		if err := validResponse.VisitDeleteHolderCredentialResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetHolderCredential operation middleware
+func (sh *strictHandler) GetHolderCredential(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredential) {
+	var request GetHolderCredentialRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateIdentity(ctx, request.(CreateIdentityRequestObject))
+		return sh.ssi.GetHolderCredential(ctx, request.(GetHolderCredentialRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateIdentity")
+		handler = middleware(handler, "GetHolderCredential")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateIdentityResponseObject); ok {
-		if err := validResponse.VisitCreateIdentityResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetHolderCredentialResponseObject); ok {
+		if err := validResponse.VisitGetHolderCredentialResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2133,13 +5030,13 @@ func (sh *strictHandler) CreateIdentity(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// CreateAuthRequest operation middleware
-func (sh *strictHandler) CreateAuthRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
-	var request CreateAuthRequestRequestObject
+// GenerateProof operation middleware
+func (sh *strictHandler) GenerateProof(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GenerateProofRequestObject
 
 	request.Identifier = identifier
 
-	var body CreateAuthRequestJSONRequestBody
+	var body GenerateProofJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -2147,18 +5044,18 @@ func (sh *strictHandler) CreateAuthRequest(w http.ResponseWriter, r *http.Reques
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateAuthRequest(ctx, request.(CreateAuthRequestRequestObject))
+		return sh.ssi.GenerateProof(ctx, request.(GenerateProofRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateAuthRequest")
+		handler = middleware(handler, "GenerateProof")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateAuthRequestResponseObject); ok {
-		if err := validResponse.VisitCreateAuthRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(GenerateProofResponseObject); ok {
+		if err := validResponse.VisitGenerateProofResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2166,26 +5063,32 @@ func (sh *strictHandler) CreateAuthRequest(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// GetClaims operation middleware
-func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetClaimsParams) {
-	var request GetClaimsRequestObject
+// AcceptCredentialOffer operation middleware
+func (sh *strictHandler) AcceptCredentialOffer(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request AcceptCredentialOfferRequestObject
 
 	request.Identifier = identifier
-	request.Params = params
+
+	var body AcceptCredentialOfferJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaims(ctx, request.(GetClaimsRequestObject))
+		return sh.ssi.AcceptCredentialOffer(ctx, request.(AcceptCredentialOfferRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaims")
+		handler = middleware(handler, "AcceptCredentialOffer")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimsResponseObject); ok {
-		if err := validResponse.VisitGetClaimsResponse(w); err != nil {
+	} else if validResponse, ok := response.(AcceptCredentialOfferResponseObject); ok {
+		if err := validResponse.VisitAcceptCredentialOfferResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2193,13 +5096,13 @@ func (sh *strictHandler) GetClaims(w http.ResponseWriter, r *http.Request, ident
 	}
 }
 
-// CreateClaim operation middleware
-func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
-	var request CreateClaimRequestObject
+// CreatePresentation operation middleware
+func (sh *strictHandler) CreatePresentation(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreatePresentationRequestObject
 
 	request.Identifier = identifier
 
-	var body CreateClaimJSONRequestBody
+	var body CreatePresentationJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -2207,18 +5110,18 @@ func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, ide
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateClaim(ctx, request.(CreateClaimRequestObject))
+		return sh.ssi.CreatePresentation(ctx, request.(CreatePresentationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateClaim")
+		handler = middleware(handler, "CreatePresentation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateClaimResponseObject); ok {
-		if err := validResponse.VisitCreateClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreatePresentationResponseObject); ok {
+		if err := validResponse.VisitCreatePresentationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2226,26 +5129,25 @@ func (sh *strictHandler) CreateClaim(w http.ResponseWriter, r *http.Request, ide
 	}
 }
 
-// GetRevocationStatus operation middleware
-func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
-	var request GetRevocationStatusRequestObject
+// GetProfiles operation middleware
+func (sh *strictHandler) GetProfiles(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetProfilesRequestObject
 
 	request.Identifier = identifier
-	request.Nonce = nonce
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRevocationStatus(ctx, request.(GetRevocationStatusRequestObject))
+		return sh.ssi.GetProfiles(ctx, request.(GetProfilesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRevocationStatus")
+		handler = middleware(handler, "GetProfiles")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetRevocationStatusResponseObject); ok {
-		if err := validResponse.VisitGetRevocationStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetProfilesResponseObject); ok {
+		if err := validResponse.VisitGetProfilesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2253,26 +5155,32 @@ func (sh *strictHandler) GetRevocationStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// RevokeClaim operation middleware
-func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, nonce PathNonce) {
-	var request RevokeClaimRequestObject
+// CreateProfile operation middleware
+func (sh *strictHandler) CreateProfile(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateProfileRequestObject
 
 	request.Identifier = identifier
-	request.Nonce = nonce
+
+	var body CreateProfileJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RevokeClaim(ctx, request.(RevokeClaimRequestObject))
+		return sh.ssi.CreateProfile(ctx, request.(CreateProfileRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RevokeClaim")
+		handler = middleware(handler, "CreateProfile")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RevokeClaimResponseObject); ok {
-		if err := validResponse.VisitRevokeClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateProfileResponseObject); ok {
+		if err := validResponse.VisitCreateProfileResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2280,26 +5188,32 @@ func (sh *strictHandler) RevokeClaim(w http.ResponseWriter, r *http.Request, ide
 	}
 }
 
-// GetClaim operation middleware
-func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
-	var request GetClaimRequestObject
+// CreateProofJob operation middleware
+func (sh *strictHandler) CreateProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateProofJobRequestObject
 
 	request.Identifier = identifier
-	request.Id = id
+
+	var body CreateProofJobJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaim(ctx, request.(GetClaimRequestObject))
+		return sh.ssi.CreateProofJob(ctx, request.(CreateProofJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaim")
+		handler = middleware(handler, "CreateProofJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimResponseObject); ok {
-		if err := validResponse.VisitGetClaimResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateProofJobResponseObject); ok {
+		if err := validResponse.VisitCreateProofJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2307,26 +5221,26 @@ func (sh *strictHandler) GetClaim(w http.ResponseWriter, r *http.Request, identi
 	}
 }
 
-// GetClaimQrCode operation middleware
-func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathClaim) {
-	var request GetClaimQrCodeRequestObject
+// CancelProofJob operation middleware
+func (sh *strictHandler) CancelProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathProofJob) {
+	var request CancelProofJobRequestObject
 
 	request.Identifier = identifier
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetClaimQrCode(ctx, request.(GetClaimQrCodeRequestObject))
+		return sh.ssi.CancelProofJob(ctx, request.(CancelProofJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetClaimQrCode")
+		handler = middleware(handler, "CancelProofJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetClaimQrCodeResponseObject); ok {
-		if err := validResponse.VisitGetClaimQrCodeResponse(w); err != nil {
+	} else if validResponse, ok := response.(CancelProofJobResponseObject); ok {
+		if err := validResponse.VisitCancelProofJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2334,32 +5248,26 @@ func (sh *strictHandler) GetClaimQrCode(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// GenerateProof operation middleware
-func (sh *strictHandler) GenerateProof(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
-	var request GenerateProofRequestObject
+// GetProofJob operation middleware
+func (sh *strictHandler) GetProofJob(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathProofJob) {
+	var request GetProofJobRequestObject
 
 	request.Identifier = identifier
-
-	var body GenerateProofJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GenerateProof(ctx, request.(GenerateProofRequestObject))
+		return sh.ssi.GetProofJob(ctx, request.(GetProofJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GenerateProof")
+		handler = middleware(handler, "GetProofJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GenerateProofResponseObject); ok {
-		if err := validResponse.VisitGenerateProofResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetProofJobResponseObject); ok {
+		if err := validResponse.VisitGetProofJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2462,67 +5370,140 @@ func (sh *strictHandler) VerifyProof(w http.ResponseWriter, r *http.Request, ide
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xcWXLbOBq+CorTTy7J2uzY1lM7Tpx4upN4S6qStCsFkb8kxCRBA6BstUtnmPd5mmPM",
-	"eeYCc4UpLNxBifKWTCcPqVgiln/58G/4qVvHpUFEQwgFd4a3ToQZDkAAM5/E9MDHJJAfPOAuI5EgNHSG",
-	"jvoaEQ9CQcYEmNNyiPxeTnFaTogDcIYO8ZyWw+AqJgw8ZyhYDC2Hu1MIsFxSzCM5igtGwomzWLTU9KNs",
-	"0cq2R5zHwBrsm3u+7v5vaehCHcehemjdNHlUv9+YsgALSV8onm05rYQAEgqYAHMWkgQGPKIhB6WBrW5X",
-	"/ufSUEAo5J84inziYklU5yuXlN3mdviFwdgZOn/rZGrt6Ke88wpCYMR9yRhlb4BzPAG9Y5HP59hDp3AV",
-	"AxfOouVsdXtPTcH7EMdiShn5EzxNwtZTk/CWCjSmcaj37/efXgQRo658PvIBHZidFy1n++kBcRQKYCH2",
-	"0RmwGTAEcryhpX3AAAvQR1bM1yItYjQCJohGuks9yJ3J9Ei0HL1f9bjqkwZcHL2wH2bzDR19BVeswdki",
-	"ObOKsP0JhOLUHMoq3SPqSbYXLWfMaGAlk3jWr8WUAfasxLccQe1fz6O678EuhMwafda0trRVliuZeTlS",
-	"DBdq/4uKBFuOVvd+LKbGRCSWYnjrwA0OIl+R4TJQiMD+mVG3MxUi4sNOh+HrzQkR03gUc2AGLJsuDTrS",
-	"aA86rjSzbS3/9oy6eNQJMAlT0EocdX77eLA/gYN0l/ZssKkA1spvHWuypZIIE1MPz51hb2/vWXerv9Vy",
-	"POrGAYTiXPHY12pyxsT30TURU+QRdfjhJiIMa8D0dnrd7e7u9tZeKpoyJXJKCdgVWZTV1HJu2jQgAoJI",
-	"HqEx9jksajgpaqRmZp7mBl6n5QTALn1pbk8pFceUk2Su5cjNUv+YLhzXr8w16UvXtIO3hrcZMF5mTO4/",
-	"6Nv8aRH/FVWkB6Aq64borzMLtjNv5ahEIvGW7KxCkJ8n7ueJ+8FOnMH9k561JKTJHbfirh7x3oDAHhaW",
-	"aGbkU/fSnWISFs6pE1F/PlGnppHoAxBT6lmXSJz46kVCENeUXRZXCeJghEmzJUpiM0S18kxmu1QlWpqe",
-	"l1sT8ddrPZ8mViDOBRawKgJOdjlTg6v4MFGtXi3mS+g9iYHNf8ZEPy30j2mhi/B/OkNdQdEdN1xL4FXq",
-	"zHQbkSrJxgKOGaVju2UwKaTjYt8fYffyPfONcRj61MX+lHIx3O12ex05oi2HOBKfOmMfpn9JsrBKs51e",
-	"f7C1Le2WSzVbLmFuTMSRPM+ZYvcFDYirlPfm/PhD/114YMy5Ovi9bstpOVfysUrnfZ9eg6drcNwZSlM+",
-	"TN1R8tdQu5Zh/+rw6/zg1SHfmr99Gb4/2LoGd+cjPXfpyauD1/ujcPCe7bKtvcMziTVp827EA9vEtu91",
-	"LuduYgnbvrfaGN46v/jCGfa7/X6v2+vm6ghVs7ZIs/5Vovj7+eA0/H330/X7nWByAh++Tq6fXb2N5h/P",
-	"Pww+7RxMxE0Mzz2+n0j+2c7unsrL1aed8WAX9/YG7e5eb7e9hbd223tj7LYHz7A39ka7o+2tvs7ZVxJy",
-	"HL4evNgdxb3Dm3i/vzsZBIJNg1dvb9ztDzP30+Rrv+/OD5/3TIVgWKjhKMm7NAjakY9J2Dbuxcgn0Zwa",
-	"1ZbD4tDM3CS0k9T09FK9zW6HZVVGa01ldfGqdK6ey3m1lZiag7+OjZiuM5iuY3ue0E7lyz7qO1MWWl75",
-	"qZV3tZKXN2O3TQNdY8wajk8MXcPhqRmUT/hdoHWmVsiMAWYMz6vONMd6SmTGXVO5niQWtyjYsv3NsVMT",
-	"Pxgy64KpxOg+evB1d9CWeM6ItpGzphs+S1BRwm/mKh/ehKTOdF0AakxYz3RGcLJ+AwnUxWiRfLwmgXot",
-	"9UEZ83j0hZNJiP17g3TRlJHjhOwSN+QLvv9BiciXUWProZfe1/ah8fruA1DJqKAu9e920JSkDKuGotyS",
-	"tYAqXR9VFJAz7Fnx4YwGIKYknKApjiKolkOqJYdlBlSo+tAJO6AerLyoqc1Ei1gt1Xry10ZNb3hsRzW/",
-	"kI2ZondpObHNh5ZXjpW3yXNiW3r966m6B098NaW+WxWciBU1wl9zzu6eXtOSdS6zB5UstbiGLiypC8QH",
-	"L254WEBbkACqB2wtp0U4j3HowgtTUmu4gfLZjTdJHE8OMvf0HJXT92sWPNTWOCzKSVlp2aolmu5lqOR5",
-	"WDaMQEt4tpiG14B9MVVxoeepQhL2jwuYNzNGlPqAQ6fiS2sUUSyK2kvbb+NgVNBtrrylBpyTALjAQWQf",
-	"o7J3fs4ATikVVhW7qrDk7Yt1IF1fEpbMTqhKWdtkElIGuj9HJh/Uk5PW2ipiMCM05qmQbFVBqrPfpWwy",
-	"SsW7sXzMl9ex7U90+8CqcuYS5nligCyH7KausyJ/shIyWsWuK56cnZx080q1HZjjeOQTPi0AsN6kN8DQ",
-	"o+ugXkQV5k5BxCws3m68U5EAz5UF15uWicYyL2FdW7ElVdnUTn9v8rWJMRBRlVS4IVyAKcFXzV5IPfiC",
-	"45vqxEuYWymaYT9uShEnI5+EE/7AHivjaeWVWuqepHAuWnYwXK66RrWG6hGEnjafd7korI/aPwAj43m5",
-	"KF4kaFJTOl83dZb7Teqy37XT3Ir0rWTW7bhSFnXamclBBDwLwpvoIp1uIyCfsjYHseqTAzdm0lRLiZn4",
-	"AHPi7sc6NFGSVLTKb4u1Yl0/J+GYVpttX5iLSGVb0JgyJKaAzsAfo9eUC/DQ0Qt07GMhPd8fKoEkQueW",
-	"9jFO7pbM6W72NruSHxpBiCPiDJ2B+ko3ACs2OhqBCnBSD4oSdX3yCkSBPKfUs9vXLZpFhnjsusA5wqGH",
-	"mDLpXLHkFRglIXp9/uZ3ZPy5knAcBJjN9b7VKUr5xPQxGqe+aDkdaVKJ2/Goyzs4IvLf5hwH/jKuPsrn",
-	"D8qMXHENZtBcjSc+LGMr5rVMmJjYzsOD9MyaHSzNpPu+jziwGXGBI8wAsTgMzbkxXbu2hVNKO3JQUUh6",
-	"M3cK7qV60pn1OnhieIiotoZFKt7QEfEBqVEIQi+iJJTWqCgn1dPqpA20SR0/JyGZJnXULU9RNpln+Hr9",
-	"Z1vQS2v1ZlHugl88okqKDboWzZw/f6HbyBsoQQ66u8ISuQosw4HP5vNFojvTUJK6N4v+XhqVIUHRBATC",
-	"vq/OSW5qq3pyj/JP7yXoewUwFclL6ieUerkXCVaJv7e++I0PcoafC97n88XiomxyCoJKtJR2r18ol6Bi",
-	"6+Wa0UYPYW0GpXAZ9eUDdV2BZtgnHrqGEScCuPze1XokHDHgNGbqRZGiGpcE+ktP6t0PToOMZFF9JeUh",
-	"j26T5MYCqwPsy4gbqWxS/pF0aqUWDxGOzNWR03KmgD1zY7avVNY+0Cpr78sx7XeMTLSh82CMY19m0ht2",
-	"s9YUmeUXI9YBqpEAyt+PW419HpI6s0Y4RLm2tSLCSiQ9Dqjs3ZONXELv0Yioh1IyxsjPW9dRPIZVuw92",
-	"9FSUU7LFyhl3dJsVbBaqPaPN4IrXxxZ2uMmJKVTtkMt1zKsQO3vH8LNdHNmQTumNQMnt48HW8mLLN0Gu",
-	"7RUDW8iZE/1TANi8CLdibL//aC7coFvxnQEqjbZyMLODXJe0GgVfDAQjMAOk56A/wj/ibncA6D///Nd/",
-	"//0PtLHBwR9vbKgkaGPD9HRubMj0RQBDLg5RSAUaAYo5eEjQCYgpMJWsVgI4fU9w78PRKnN0qImJgCGN",
-	"MCR92iZ6qcN41EaVrjbzbqtuJEhfbtWzz/WtyZI3aFcTMMV8mifA3Rv1BzvdwU5vtDPGu6OBh0d9vD3C",
-	"u73Rs93B7lKCXmM+vTtBWmd5YlZ0z73sPf+0M3GDl/S4/1sUzQ6PDz5dzf+cj7bFb3vnz16Bu/36cP/t",
-	"SR3N6R3T3Qg2UGSqmuchyhTC2jn6pXnarNndTLPtntZKl25fPhJ0rFKTiNEZ8cDLvZW9WSKpTh7gj+9G",
-	"Dgk58UBXELDACSmKsDr+1ccvYwK+t1QDF48Y8FavBG3hrRZv9v7xN45H1s6yUmuWWGb9IwIXawWyepWa",
-	"iCJ59h3HEoWX9b5JFFG6Qq4B2o8UOCSwKeOyPlboZFdcpvjYuVU/9LBoXMKRhilbBaUXo5UgoHxl9xDh",
-	"QIMZ+t2VRzV7tZeRFlCmbYRPUK+TxiqjDaViXxMfl5AHxWoTp+cgrP2VpRKU3tZ9awj0HxQCl6tN0r7r",
-	"QpRaozUsjP6NkFVjtx7Nwmj+7mJhbom3WC8lwYnNrvGRSZTxROjRez1J3LTamz161PTNkZZGWOvDrHPF",
-	"kt9ZsaLt1FzmYYSRVBASUyxUKpulsUmAJt3aySk6oDIOp4jLUWPKVFE8UlVZnMXjdnzqlt2/HkpLrcgW",
-	"rNLL9SH6vcBOat3VemsCv6QPop2+WbDaQXIIPYSRmoHMAtJB15UZC40a32taYG9Nedq70rqOFnsUloge",
-	"PGSu/Mex78//0pmCUYwFfTm8F/Fmx70qN9ypoq5mriip51+4/r7zYNsvI3yTdNj6jroF+id58f9I2bFm",
-	"vFpXL2DNDnbVwdmJdP9uM7ybwdndre4CLcPd1hP8MIB/TBPb5BcGkWA45NjVl71SIvon/+SfMriakBnk",
-	"bnMXrQfNx5b2WtuaaDJi0dGLtParVwEPGfX9v5UrjRjSC1OUAKzhtanqq5zfObbRbZnu8ugm1x/6vZp6",
-	"SzvvE0c2tiba2rgm6Yb9ccOaPPByaM9D7WKhN2CzBGtFUf5OXXVdGGc/V9LpZD9YMuhK1i/StSu/p0t9",
-	"H7Q9oeO0cYcjBr6KOAXNdzGYO5ysJ6J1h/XSewmzmkkJ77KU7rPMltKdfouLxf8CAAD//4chvMpSWQAA",
+	"H4sIAAAAAAAC/+x923bbNtPoq2Bxf1dZlCX5kDi++hy7adxDjk77tbVXF0RCEmKKUADQjprlZ9j3+2o/",
+	"xv88/wv8r/AvDAASJEGJlCXHSXvR1VgkgcFgZjBnfA4iNpuzlKRSBEefgznmeEYk4eYvOT1JMJ2pP2Ii",
+	"Ik7nkrI0OArgZ0Rjkko6poQHYUDV7+qTIAxSPCPBUUDjIAw4+ZhRTuLgSPKMhIGIpmSG1ZByMVdvCclp",
+	"Oglub0M9IycwLE7q074gSYyi/IW7AjBmfIZlcBRkGby5CqC35GNGhPSgowCJ63fuB7SzYpIaTGdCZIS3",
+	"gMN53nWvXrI0Ik3UkcJD76T2UYv101Q+3i8QQFNJJoTnELzmjI1/YKM6EPAEfWCjbW/FrfpYzFkqCLDN",
+	"/mCg/hexVJIUiAXP5wmNsAKs/0Eo6D474/+Lk3FwFPyffsGLff1U9L8nKeE0+o5zxn8mQuAJ0TOW1/oM",
+	"x8gS520Y7A+G9w3B+xRncso4/YvEGoS9+wbhOeMjGsck1fPv3/f8L5lEY5alZv1P73v+E5aOExppCtjd",
+	"vX8KmHMWqeejhKATM/NtGBzcPz+cpZLwFCfoHeHXhCOi3jew9E44wZJo0SkXnUCbczYnXFLN6BGLiSMb",
+	"c9EUBnq+utjUYoYIeXbqF6rmFzb6QNRGtl7ZrRVYANjxhKTyrZFJdbhHLFbLvg2DMWczL5g09v4sp5zg",
+	"2At8GEjm/3kxb/qd+JFQiOI/NKxhYAVvYL5zQDGrgPkvaxgMgxOcJCMcXTXjI6YiSpggsGYcx1ThGiev",
+	"nZf0mVDeil8Ip2MK1D7nRJBUAtkIlI+HRgskpwRNWRITHqo/RcTmBLnnSAGpD+lh8KnHZlSS2VzR6hgn",
+	"gqjNllhmADxJs5lCVARUrcadkzTWn14DhEQfb2oW+Cf5NAfsXobt5rKjnOp9J5/wbJ6oz2IaH81Zspiw",
+	"tPjX0SybjTA92v34/MPi5PvnYn/x8rv0/cn+DYme/MbOI/bm+5MXx6N07z0/5PtPn78LWsFRoQvAoMFC",
+	"GUQvDQByjjM5Naeko8nl6/kcFJrlO8PywVTKuTjq9zm+2ZlQOc1GmSDcCIydiM36SrvY60dK5elpHuxd",
+	"swiP+jNM01xwKVnS//G3k+MJKZTF3vXejnoQhO7UmQZbMSrlchrjRXA0fPr08WB/dz8MYhZlM5LKc1jj",
+	"rqaaYEyTBN1QOUUxhfMHNhlrSh0+GQ4OBocH+09z1FQhUZ9UhFsNFy1J07uS8o40fOnC3EIDDIMZ4VeJ",
+	"0jjeMiZfM0Httx6xe53rqoU+1zyy0KAvHdMvwJqZSFQXpubf2/Xrti6t17YiF4J1XLek/iZRqEVQWc69",
+	"I0KBjtgYhJnS86yVE8IvQr9wFqtXqBQoMiIXZTxZl7uXL0QpmkApbfnZx1Sfg38lMjjaHQwOBsPBEM5f",
+	"MpsnoB0ER8EhiePD4XC3F+0PD3rDIYl7o8He415MRmTvCRlGo/ixPndWysLX6Yu908NRNnz+KTvePZzs",
+	"zSSfzr5/+Sk6+OU6+n3yYXc3Wjx/NqzzIU4SdkNibc2J+u68JfMER0TovdEvI6rftltmVxWEgcK7WELO",
+	"AeYcL4ImPl4yd/E+MtxTnT7UEgojQdNJQtCYKnN+zklMIw1dbcc5wUYJWzKzfsmz2Poane31Me6E9cyP",
+	"ytbbef/+7NT9vUdnc8YBEcZ0NCYh2JNHgT4g4FSYMDZJSB+e3+bKUXkRp2enFmitIZjVGAeCQIKkEknm",
+	"Nf9ddnGW1cw2YJT/c+79c+7d67mXzWMslY7sfDFiLCE4DR7EsWjYYvmBuOETDOYUz7CMpg5DVugQ3lH/",
+	"ykX2MsPYw+M1mV7Fn56hJZhNCFofznzkLNk4sGrIGqjNVnmDuWuN9biVsR4GZc9C49bGNP6ZSKwYo/5w",
+	"lLDoKppimpaNLaNSBC2NthmRUxZ7h6Bx20FSIm8YvyqPonWatdQ6A1ToLrKYpb63lc9dvF22QH8zT7te",
+	"89quC4klWUXHdpZ38HKd+/XTwkZthve14ztolgZTnCQknZDyXgzJ+PEoGuHeaDCKe/uj+EnvEO8f9PbG",
+	"B/ggig5GB4+9elAhID0a5VmcK47TcrxFIMmsr8PVJVd4yOu6ZcxmNQI3NjzfMT8pJWOl5uMuJHSwlE+x",
+	"DO9sTBPSiPLUH9s4JRGd4URHNwBJcz2QUthiwuk1iUEjCRFGHKcxmyGWwtNMuYRupiRFFNS7lEkkiASX",
+	"jMXC7uO9/SfDQx8SLYLqMJ1PCbJPqyCNGS9NYBW71uhuQN+bjPDFUuurarn8sTGH0WX4gFVWj1XZpJSC",
+	"M1Bh5nMQUR5lFI4aZ4JjyWY0AlS/o5NflFL0Uf3hR+8jQIxa7ye5YXz0krh/tYgsFnpJ3ISIiGWp5IsT",
+	"4xv/V6r4/I/D/cGlQ0w//nZyot97NX5LhIIjKmnlt5cdVPZVJvJZ2RouwA4RThfGWNaMqU+vTmZysXG1",
+	"MKh+VLWOqVD8WYgJ73b/fP76l91X6Yk6I8ugLRHmm7BYKjG1soleXcosExLNCJEhwgJ9vsjZ4CI4+nyh",
+	"GOHC5YQddJybt1MsCk9AMcGYcURwNNWOchjWCDQ2hh0U5JpwnCDMCQjQkYq4qXNJvSbwzIWv5lH4Cgww",
+	"IxKqG/FKTgnXaLAozAkaRELhkgNzWP1OY4U8rKKSikPgN3UU1fjAJfjVyrsr+d8pcH1s8Q06UMsn3r0a",
+	"jHWU1+YtyaFWGM1PkpUBV60tsbGBAKCpIzkHwA7dsJ56Mo3HojyLyzvdoFiaqNexLL0dY0l6ks5IW0Nn",
+	"476bZghuw0A7+1rvE22BioZPC/dpDQi9weddWFB/8p4ndwhTFsFJPFenoPvvcqCybXiyOSbdOo5oNqQU",
+	"VC4WW8KVj1acQGRBj8uJ/11DSkGHEHAH6elbM3zmA9LH8RXN3iQQBDbOAyQB+uZRwiKcTJmQR4eDwbCv",
+	"3uipV4IwmJl8jaP8XwWBBsPdvf2DwJx/7TRiUJF0bsBRMBws1403aXp8YQ3bY2rs7g4Hw8Ht7RKl+Tbs",
+	"jNLh12xuhMPdriZHnhCzilp+ON97m/50+PvN+yezyRvyy4fJzeOPL+eL385/2fv9yclEfsrIs1gcW0w+",
+	"fnL4FKQL/PVkvHeIh0/3eoOnw8PePt4/7D0d46i39xjH43h0ODrY3910WNGk4ZTSm2A7Ijab9eYJpmnP",
+	"mLcGX3Y74a2eei1LzZc7lPWxG4TtD3cGfV64nb3pRp3VjGfqu8YkpaWHZcuDo8vLrItgvkch7mZEwW8m",
+	"Y2p5UlQjvuvamCvjP7d1PhtJ//luakqjHmI04FZGi2+pDWZLVaN1lp4DWayuLV7fWBG6ymvR0unQpMO6",
+	"LuKKI8I+sqZf7izMjUawxmPr28xmI8J30FuDDJvHttpTgdMYNbuv7FvmHBKhNk8FnYBLNDdcIcXkiizK",
+	"0fGSGxP0BSVXB16zwJ5KW/OEiDu7Qt4ZRwZkQYgQMZ77NhSZYMk4eG8R1q+E4PJwPEdgy2uPgPKYhCr7",
+	"w2JsTUdI5HOfnbDZjKWIkzHhRDm9NS6rtNSShsRiRJOTCon8fP4aSMf78B2dVClG+9lhvDRLEk3L5VQK",
+	"Jf8QlR2IZn1ZXGHlgv781kIn1buNue93O0K4AQI2zOAKlLkd9GvuTtT5WzdKOkgUTRkTRKARkTeEpEv4",
+	"uJnNQRigmBgzD7G04r5Tf81UkFY9BVWwrS1Lv5hnA47Vdu6NyqBNTiLAR0cA9Vjwh5ppno3+VILThPDu",
+	"dnbkJ2rF6ZgLmLJb1m5kfnooSZS/UjhzzWvwjdAyKxdx8GjW1vPoxUXzKd5uX17bXahsDv0T3x2lc/rn",
+	"qLWOooc+ts7TluNHG4CSM8kilqwn9wBTZqkGImfI1vyxMY8mjVu5h5tx8aCY8nrerR7hh3evXvZ+OjUH",
+	"cq0uwXJjUZ1g1AqWJgskiNRhppxhERZXJQUEGJhlclnG5irJqXFcRlQjnVTqfGoE4pgZxRn/js2IhNNl",
+	"iudzkq5MIVipztOoGwSOUhiThOiii3WSZZbBJSHf6Q1Xjo+VlT6NKY5lKi2/VSKutjlTvv13B/Itpho6",
+	"yniyeuQMbDJ3Jb6hu9c3NT2459om+G2VCS9X5C7+2zGD7mhbehzXKwKF5ffLY9iowL1HXjrokFSIDKcR",
+	"OTXJYC0nABOg9ST2yHFI5i4b5eO+fxe2iGwOYNQ2J19K6IueariXUaVwybKlilehZ49oUO9UA4jdZ6oN",
+	"0TDVC7Aki7e7z1QdoWEik4rWfXzzoW/YFwQnctqsQfiysVvxXW1NS86Xdfa8+LqD5re+MFgrrroOm6vw",
+	"4Qa0Qk4iQq/vHvLm5JpdkdhDCEujvy+wmHYMGG87xswyHhE3xszGYxBcplonDARJxj3Ys7ZhZa8e6xKm",
+	"Ix8bI8UOxko0kMNc7EJpX92pvEK2nHzsTyF/Cf43f3k6vHBOZ0RIPJv739EJ9+ecEJXj5CXXrskXwHPN",
+	"qdem0AoCfnSSMk60naMCCixWH3Waas7JNWWZyJHky81iOqK1dJmcMflqrB6L5fni/idnpyWYG1K/liy+",
+	"SKKoTSA/NTUScKnXghGWm73kSQsOdldlMNgDxyfzN5GIcxeZvIK0PJ/kKd6r86+bJbvCR9fS+O+Gz35/",
+	"Molm37HXuz/O59fPX5/8/nHx12J0IH98ev74exIdvHh+/PJN0K1En7e0yEpk4CwhzBvzrKSCvOnOVsgg",
+	"r9Qpezh+nS5cd3LEsiTWSaAETYxjxuvQUF2AxpgmxJtEBsoDEcfSN6MzgvJ6Uyns9JgTx8BvJ5TGNKVi",
+	"2lVm3iH/y1ZCdfZpGfnFOwr4ZRlfPEtT/a+YperrfEsiZWklyZ2O55Y5WK+zUULFtHSErqpuW348bPsU",
+	"aRbytcW9heS5mn3TmGpZBN4L0QU+Qixxmb2iKYmuSNyuQuQtkRlPy+VYr4ClhANKt8+KHfJ8Z3dAG6/N",
+	"21no7Q9tm31onMl5HVTyiQpJzLlVN+FSFpM/cfap/uEVWXghusZJ1hYiQUcJTSdiw46KYk0rawBzrVsh",
+	"5zL0E8PVqqper9+2EFSbdda+VyXQLcvvt1gDv6zSfbsF683Z+Ety7ktYu3OyvYJAd4zw80CH4oLWB5Id",
+	"1Le6oneUW/7ZLd7z694JKsZB7kBFKo3JhrBxFz8gi+41qDUUFiWdPkustMRlukgDYmqRR/dh68JP32Kb",
+	"SKup80ihgzroNsWc1zjxlynkjbhaelsqq80/X7aocrZ2eTGThpzurvkRar5JU4rDGipmZZ1eMJtmXImL",
+	"pp3d5ma4gfz2Z6SaQpAo40ohVRgzfhwsaKS6HeVNCAFW9Ws5T1c3CqTp2NdkxpwZmlBVOFdR7zuSjNEL",
+	"JiSJ0dkpep1gqQTfhUKBpFJHUv3vOJLtKBjsDHcGaj1sTlI8p8FRsAc/6Z44sIy+pkAgOJ3ZBs2igiPl",
+	"+i6BF1Q6qe4OBvUFiSyKiBBgjXHQGHUuXlxaKE3Ri/Off0JGnAOGs9kM84Wet/4JbD417RWN8+U2DPpC",
+	"PY76MYtEH8+p+m9ngWfJslX9pp5vdDFqxA6LQQt4nyZk2bIy0bgIEz7wr2EjrTzNDJ4el8dJggTh1zQi",
+	"2sq2hmPRTNQ3cA5pX71URpKeDIwYeNK/HvbxxKxhznw9nX9mI5oQBG8hksZzRlO5g84kwqm4IbyWATom",
+	"MpoWxZlFGSY4nitlx3Hl64vUBhw9I+i9qmRdoZupgk/lzWIqRc7aupYqT7HS03PIL7UDXqQO2OAtR9DN",
+	"wJZh7YAcKBME9BQN8p4oNlncIQUVZexDKUGZCAoN+8PNXz3JrrxJGbfVFsy3W6S9coNUDwmePzvVXXxb",
+	"UJt6aX3KtHiVeAJVNfrvS0ukppNIfo57CPU7Q5sq13NCJKQB6/Lf/NOwLqLO3Kd3QvSdDMEa5hX0E8Zi",
+	"p4/1KvQPu6PfHLZQaOYcs39c3l66m6NkawlRdpfMj4vgEs4+BbtYvjNauiOs5b1CLmeJegDJw1p1RDdk",
+	"JCgklzNooojklArESR4vKm/jEofJUk5dn3FaeHZu6x3RN8m6bZxEvubYOEkg5Vh9qP5hW/Tkol0p8SaR",
+	"OwiDKcGxKcs4hi3rnegt6x2rd3qvOJ1QYx6MMThZg0d+sdaWMquNqbsQqsEAcouwvKeaS5KAC4Jwipx+",
+	"RWUKq4C0HaLyt81qdSQMtwZEMynZd5BtudzxoNiGVLsL7ehPkbPJHilnjqNSo2vdQ2jRrELlxGZ1Exun",
+	"Mn0pYgZmcyYI+otwhq5SdpOQeJJHeCTTnywQRkvcHTsX6Xnh64C8UplxomtyRiSffkKvSYpyZwGoYdpd",
+	"ELrOElV5ZD9VNU0oyjhXWiA4bxEbX6TO22oQck34olYNNCIIj1TCbOVtU8MzWkA0SetnPpWr7q7YEgM2",
+	"O4HuWS9b4qDxMKL7HsrN8gfAjZ3YT68ZVbbZsmDp55wNPxcR3Fsoxe1x8lG0YMSS1Hc7OTdIfqdpdBCW",
+	"rgf6w4+H4pV+5YIatertnR6e3u5f5ADxddn2mbgO6u/jHDHXoax6d9+5O2TFu7u7W+MIcyABjgriyw0k",
+	"hySbGSKvTe9Zc3od7shHyfcKygpxuRFT3nIazXCKJyRWpwaY4vGMpuj49Zk5oCrtuUNfz2ztCfI1tEYR",
+	"TtWZwq4J53DRDZoXJby+A6S5X/rDZ+amDu/3zNV+L7ifoevU8sA4e9vcWiDgbZld3J7srdnYlt+3YNyb",
+	"KeG2jhXapmv32g+//o7swsDUNmce4+WeaXXGsVPflU3Cz8sEv7k4wXNBGYBX3FCW37DQ6Y62y2/EW1e7",
+	"waf5QDV7vb4yuC1mKqt7lPD8koxuPJF3217pCuREckquia6yFugivcgGgz2C/vv//f//+a//ix49EiQZ",
+	"P3oEJ86jR+b8efRIRQ0kwJfa5B9TST4hckq455zJiz42zy/PNTDqoNPkgBQp7qDvNJmiHqq1OmpgoXpm",
+	"tjfpZTUAUyymLgDR09Hu3pPB3pPh6MkYH472YjzaxQcjfDgcPT7cO1wKkMkOXxMgvWcuMJtLPPXCXHQ8",
+	"WwtgQ4om613JYUVhPQd+JUt2GmYvkuVrs+cZUG2mNxdgdZ3efLb+9FWONPodtMGLlVqYM8JOBaTGYyEZ",
+	"rwcOTQWNCcpz/QwoAFjT+uHPP6EUuCMBZMqTTT6pAw/zaAoarPYHqdwzT2NeH2XbNKhGXKihu1KmKmvO",
+	"F66DjYJxCd6ZHXRePLG1z0XqE4rYjKAx5cIkGucfOi814VK9++doUQK3cCQbtfFPLIOwesGb+TGfoZ4x",
+	"61noO8alu06aIvWCbdLBY8KbIC3e8wOrlZDVhPcz/kRn2cz0hVFbXuECPCE76NjErpz90IETUpSfJ3RG",
+	"ZRO48LAE6UxPHBwNB4NBGMxoav70Jar5Oqv/p/eSfJK9k4wLxpEODBS8q4tMAHyn9S7039EHqQ7uqw2n",
+	"6aQJ7AgGD1Zqc1vSsOpVm74Qit4TfcVpKUJSwpCnIY3GnEFZqgSBRheEAm2PmAQL/ftyHg7+0ztnEic9",
+	"6Obn6YWvHtapDPzN0HgGJjNb45uqoIbbr82VqMKVuSJmlUr4AeKU7b0fehQtAPO8hDlLaEQLcZ1H0JSm",
+	"qNzbBefqthEmESHUKQe2JZBzv1XO2jQVkuAYkhRionqyL/FnnJiuQQ/YgVG+h+dL+CErZbUN3Ow6KHYH",
+	"u5uEoVph3QQBXDHikos6QHFaIbvFdl0oe23e3XtIzlHLBFUubzYa+yMl/dp5QRXywZu5yE9iCSlC3hMa",
+	"chcw6D5kzLgWGYrV1fem0VOR8wRvX6QsNcldQjLumJdq+hFBc12mU/hS4UTVATjJcarbyldFkfGz6rIn",
+	"ZBrjGZGkLhwgFKaAsFscmk/zs7wioECpq1MiiDqBqLxIy1KOcfc2QsjL0mnBOgEstCsBdauSzFUArh0Y",
+	"VLXS2x08sUCYfbBfwVZWxl8hMPXVWF+B2Czfi/blhGfl4rNmhUhvhrn83MrSJw8JpBAJZau4Rp5AN4QT",
+	"h3y+uripKwgFstTdQR4WFV0mGbb/Gcpfb1tn2mkGtqOgvAKx5h2rVqhtwk/W4gt988hWLYfG2jtvvN60",
+	"SbuHtEqlChewoRztHenjirhEsfrg1N+olBFzPlcT9vLitC9NArsbJYGr1QqnSqOb58GwryjApde3jsb1",
+	"mca33Xz12Grk6MRLQNZQvyfq0XPdi+thta2iHQ/fcig1t9/95nu2koq03m6kj71tOyU3XtfqcYry23nN",
+	"F1Ton7TaDQWTehSlMZuqn4uUpopIBYnVGAvEQKfOB7DOfZ3IAGOAU9QAAq+BNyah6RWk+FFpVWNur/UG",
+	"L7NRiqMpTifGWKiZBeBNamEW1DjJqS/9Asy0eQ3aU2V8z2FaX8VuIzMbMvum2VkjZN2Do/+RR+ZWE+/5",
+	"8daUi2GEkdoozVQmR8j2/jYOPcUMb94i1SxU/SrUW2C8wpkMpnoR+/GfOLrV6Ld37lRaqHoIll09nDSC",
+	"rgeJ2vVI71sr8ivuO3XT5lbqLwkV1WsuhZP1nNclkPymxtx5LKecZRNz78OEpNKpjgNm0MnWdS+0Grru",
+	"Z0ZqQiF1XK4hVaHWQXK7aQu+4j03sFn0nfBGCq3lUpD1Bu5S2344qblNp+9MyN8uqh+3ru5tj/OK1TgU",
+	"tib/6bPAbGo7I9S8rNJUjUszquHXBLdLt9KaQlXLfrowVDJT2EpLIZuwVAMxIVIUXlr9oY6JGwBKLGwd",
+	"rHiCaVry7+r797zlp3pNNbK6rwOpNu8DOZxO3B3cth+vUxb54Gmbd59ujRMNxXi48a7MqMVqW4eQzupe",
+	"wooh8nGSDSOkxclo2KNylHicTN4mYF+YU7ZRi7q02dl9J6m2CnnWNh/lZ/Q/fGvdbcAxG2DbdrqryUmr",
+	"aq8QlzZ3VlllNUQ3U0ZU8po5KuWUzLSXQhDwT/h04FKehO1lYysclXdlx6+n1tqP/5Nde6/ZtaYw896S",
+	"aysNpbuCq2/yql7UB0Fi21vaXUveJNuHPNtuoG5zdOutvRxil1G+REpwlVnz7D193Y0+eqk3zTrvzne3",
+	"VNkt65DNFxgsPZu+Ztvrhdq5sszMDw2nqXqrbDhN46b0PKoq2lVPhvVWV4rApxgsMd3foyhN1wWEcLnS",
+	"jPCrhCDJSf7zeEVl+BlAVpLKDzKtwx/cub90jvr9G8stKEDrlnWxL51ApmkHlYjHyyIrlKs8uql7YK8y",
+	"g2bMhjcttqE2sI3aVSP/U5ixtrn3beBs2wdQuntsOeXaPuTfcjhF73pVwjcL+I5Rd4vKVhToOVy/MfLr",
+	"KDr/HsH5lrTnk5zW8Ovl10yuVkCgkNk2GzADOOXlHqp0itUfrk7QVFF/f86apna0/pS14m4JZPp1jrMk",
+	"WXzTWoIt4K9Tn0P0ZXrz0z0YjC2bb+hwtLlIXDtEw7JwhtFMV4F0UWjI5xU1AnOiwxYk1m8XTlTjPTVB",
+	"Cp0ioxoFlNp3VjNILlIo4tKp6sYn64AFD6rfqKxbHZSXU7KoRuYrjiBvzAPQUUiWV8b2ftCqvi9kcZ/t",
+	"OroZvYDSUnNWYXb5m+ZuTVmuj9XSVhsPa6kJXDvOHmVpnKzW9HW7tdWd3qrN10dK+7AGebm/m26NPFOR",
+	"RsOftgkdPFYWuakthYfPfvgB+r/5C0u0LIww55Ro5y70EynVZqiv85y5mm0vrPRIsFRyxraUq85mr4RU",
+	"YkM5xBScpWULEJN2Qj0A5QUmb+D2FpVjZ73UDdUhlcZjD7g8ZO0GdcPNNqjzXU+wvDndA+oUuU6Nxfq9",
+	"6czlYh07lpiuCJoBKrzhM8Uss2yGfLfnj63d8+rXOPW6v14frLMd3n7JHeqQDRXUVSuMTs9OUUw4vXZV",
+	"vEL8mdxnKFrUwrsUhzMhkbLSmMa1+JxSRXUVo7n5DVZWqHZUIjFlN+IixSimoE2kEmCTTFcf2uNmB+WX",
+	"ydlVUXu/vn0nLD0uLUiVL+KEExwv0ISZ4kMAIT/PnPScZeKemWsBHrSkByC/kJC3KGrmzgfXsvFLB+3z",
+	"g8ISV3ML4er5wMa9D2zUUo/8mJFMM4bjDin66CiOZWPTjldEbJ5LDt3619vTUafC6a8vUszzsQtTT/Xp",
+	"mnAlkMP8NkgqVC5qQmKUpZImJj8uZilRYR19teFSNtRXaH5zXprdTfKhxlGjY0btA5DEt22unTjHoV51",
+	"RydMwWadojb6Wk4neY1xexdLCZQKgcNXGyPwdm7xgla2qcO1o8jiNtN/jgdDwDkhtSDgsH3xdZFb7wwd",
+	"Vi/qhbttCvHcYEH8Xan17xG5WV92Qi7PWv3VV/SYhffeqHe+iqbMLqRftDtHGZBmS/qNi/5/mqz7dQqN",
+	"pHqGbYku/YwBrsu+qchtxxvm5cKqhTFqrOG7o/uB+3YgV+M7zhlfkrDxkukiZRxJuK1HMtujxfqutZlC",
+	"82tcNtuWa+nd575b0Apg1Q2Q1s7Ka7DN9n1tPiqDhvzGG2QJrKXRqq+kWTuQD5+bDWw8HZybTB/qseC5",
+	"ePYLXRfTKohve4L/fWP4LuE51O6S2uWtnoBfW1oro/InFkHaS8YTc/fsUb+fqB+nTMijvYFa+mU+dvXz",
+	"E+UwiazTxt68JhAnCTbFwc41VCaZ+qyQhmuMl/fDNKPB3+sNpYTfyfIIJiXuVLxInlpnPn0DaTGevhpy",
+	"bdAbQqpgPsFSolLatJm0HOe6vP3fAQA/DuGrM9AAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -32,6 +32,7 @@ func LogMiddleware(ctx context.Context) StrictMiddlewareFunc {
 func BasicAuthMiddleware(ctx context.Context, user, pass string) StrictMiddlewareFunc {
 	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
 		return func(ctxReq context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error) {
+			ctx := ctx
 			if ctxReq.Value(BasicAuthScopes) != nil && user != "" && pass != "" {
 				userReq, passReq, ok := r.BasicAuth()
 				if !ok {
@@ -40,8 +41,20 @@ func BasicAuthMiddleware(ctx context.Context, user, pass string) StrictMiddlewar
 				if subtle.ConstantTimeCompare([]byte(user), []byte(userReq)) != 1 || subtle.ConstantTimeCompare([]byte(pass), []byte(passReq)) != 1 {
 					return nil, apiErrors.AuthError{Err: errors.New("unauthorized")}
 				}
+				ctx = context.WithValue(ctx, basicAuthUserKey{}, userReq)
 			}
 			return f(ctx, w, r, args)
 		}
 	}
 }
+
+type basicAuthUserKey struct{}
+
+// basicAuthUser returns the user authenticated in the request, anonymous when the endpoint has no basic auth
+// or it is not configured
+func basicAuthUser(ctx context.Context) string {
+	if user, ok := ctx.Value(basicAuthUserKey{}).(string); ok {
+		return user
+	}
+	return "anonymous"
+}
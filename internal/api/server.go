@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -39,6 +40,11 @@ type Server struct {
 	proofService     ports.ProofService
 	claimService     ports.ClaimsService
 	reqService       ports.ReqsService
+	offerService     ports.OfferService
+	holderService    ports.HolderCredentialService
+	presentations    ports.PresentationService
+	credentialReqs   ports.CredentialRequestService
+	proofJobs        ports.ProofJobService
 	schemaService    ports.SchemaService
 	publisherGateway ports.Publisher
 	packageManager   *iden3comm.PackageManager
@@ -46,13 +52,18 @@ type Server struct {
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IdentityService, proofService ports.ProofService, claimsService ports.ClaimsService, reqsService ports.ReqsService, schemaService ports.SchemaService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
+func NewServer(cfg *config.Configuration, identityService ports.IdentityService, proofService ports.ProofService, claimsService ports.ClaimsService, reqsService ports.ReqsService, offerService ports.OfferService, holderCredentialService ports.HolderCredentialService, presentationService ports.PresentationService, credentialRequestService ports.CredentialRequestService, proofJobService ports.ProofJobService, schemaService ports.SchemaService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
 	return &Server{
 		cfg:              cfg,
 		identityService:  identityService,
 		proofService:     proofService,
 		claimService:     claimsService,
 		reqService:       reqsService,
+		offerService:     offerService,
+		holderService:    holderCredentialService,
+		presentations:    presentationService,
+		credentialReqs:   credentialRequestService,
+		proofJobs:        proofJobService,
 		schemaService:    schemaService,
 		publisherGateway: publisherGateway,
 		packageManager:   packageManager,
@@ -113,6 +124,59 @@ func (s *Server) CreateIdentity(ctx context.Context, request CreateIdentityReque
 	}, nil
 }
 
+// CreateProfile is the controller to create a profile of the identity
+func (s *Server) CreateProfile(ctx context.Context, request CreateProfileRequestObject) (CreateProfileResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateProfile400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	var nonce *domain.ProfileNonce
+	if request.Body.Nonce != nil {
+		value, err := strconv.ParseUint(*request.Body.Nonce, 10, 64)
+		if err != nil {
+			return CreateProfile400JSONResponse{N400JSONResponse{"invalid nonce"}}, nil
+		}
+		profileNonce := domain.ProfileNonce(value)
+		nonce = &profileNonce
+	}
+
+	profile, err := s.identityService.CreateProfile(ctx, did, nonce, request.Body.Verifier)
+	if err != nil {
+		if errors.Is(err, services.ErrIdentityNotFound) {
+			return CreateProfile404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProfileInvalid) {
+			return CreateProfile400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProfileDuplicate) {
+			return CreateProfile409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		return CreateProfile500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	return CreateProfile201JSONResponse(toProfileResponse(profile)), nil
+}
+
+// GetProfiles is the controller to get the profiles of the identity
+func (s *Server) GetProfiles(ctx context.Context, request GetProfilesRequestObject) (GetProfilesResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetProfiles400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	profiles, err := s.identityService.GetProfiles(ctx, did)
+	if err != nil {
+		return GetProfiles500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp := make(GetProfiles200JSONResponse, 0, len(profiles))
+	for i := range profiles {
+		resp = append(resp, toProfileResponse(&profiles[i]))
+	}
+	return resp, nil
+}
+
 // CreateIdentity is created identity controller
 func (s *Server) ReturnCreateIdentityOptions(ctx context.Context, request ReturnCreateIdentityOptionsRequestObject) (ReturnCreateIdentityOptionsResponseObject, error) {
 	headers := new(ReturnCreateIdentityOptions200ResponseHeaders)
@@ -142,15 +206,24 @@ func (s *Server) CreateAuthRequest(ctx context.Context, request CreateAuthReques
 		if errors.Is(err, services.ErrLoadingSchema) {
 			return CreateAuthRequest422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
+		if errors.Is(err, services.ErrVerifierNotFound) {
+			return CreateAuthRequest404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
 		if errors.Is(err, services.ErrMalformedURL) {
 			return CreateAuthRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
 		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
 
-	authProof, err := s.proofService.GenerateAuthProof(ctx, did, big.NewInt(12345))
-	// ageProof, err := s.proofService.GenerateAgeProof(ctx, did, resp.Body.Scope[0].Query)
-	//s.reqService.VerifyAuthRequestResponse(resp, )
+	challenge, err := domain.ScopeChallenge(resp.Body.Scope[0])
+	if err != nil {
+		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	authProof, err := s.proofService.GenerateAuthProof(ctx, did, challenge)
+	if err != nil {
+		log.Error(ctx, "generating auth proof", err, "thid", resp.ThreadID)
+		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
 
 	var message protocol.AuthorizationResponseMessage
 	message.Typ = packers.MediaTypePlainMessage
@@ -163,7 +236,7 @@ func (s *Server) CreateAuthRequest(ctx context.Context, request CreateAuthReques
 		Message: resp.Body.Message,
 		Scope: []protocol.ZeroKnowledgeProofResponse{
 			{
-				ID:        10,
+				ID:        resp.Body.Scope[0].ID,
 				CircuitID: string(circuits.AuthV2CircuitID),
 				ZKProof: types.ZKProof{
 					Proof:      (*types.ProofData)(authProof.Proof),
@@ -173,26 +246,93 @@ func (s *Server) CreateAuthRequest(ctx context.Context, request CreateAuthReques
 		},
 	}
 
+	sessionID, err := domain.CallbackSession(resp)
+	if err != nil {
+		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
 	verified := s.reqService.VerifyAuthRequestResponse(ctx, &resp, &message)
 	if verified {
-		return CreateAuthRequest201JSONResponse{Id: authProof.Proof.Protocol + resp.ID}, nil
+		return CreateAuthRequest201JSONResponse{Id: sessionID.String()}, nil
 	} else {
-		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		return CreateAuthRequest500JSONResponse{N500JSONResponse{Message: "auth request proof could not be verified"}}, nil
 	}
 }
 
-// CreateAuthRequest is QueryRequest creation controller
+// CreateAuthorizationRequest is the controller to create an authorization request from a proof request template
+func (s *Server) CreateAuthorizationRequest(ctx context.Context, request CreateAuthorizationRequestRequestObject) (CreateAuthorizationRequestResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateAuthorizationRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	req := &ports.CreateAuthRequestFromTemplateRequest{
+		VerifierDID: did,
+		TemplateID:  request.Body.TemplateId,
+		Overrides: domain.ProofRequestOverrides{
+			Reason: request.Body.Reason,
+		},
+	}
+	if request.Body.To != nil {
+		if _, err := core.ParseDID(*request.Body.To); err != nil {
+			return CreateAuthorizationRequest400JSONResponse{N400JSONResponse{Message: "invalid holder did"}}, nil
+		}
+		req.To = *request.Body.To
+	}
+	if request.Body.AllowedIssuers != nil {
+		req.Overrides.AllowedIssuers = *request.Body.AllowedIssuers
+	}
+	if request.Body.CredentialSubject != nil {
+		req.Overrides.CredentialSubject = *request.Body.CredentialSubject
+	}
+
+	resp, err := s.reqService.CreateAuthRequestFromTemplate(ctx, req)
+	if err != nil {
+		if errors.Is(err, services.ErrProofRequestTemplateNotFound) || errors.Is(err, services.ErrVerifierNotFound) {
+			return CreateAuthorizationRequest404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrQueryRequestInvalid) {
+			return CreateAuthorizationRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateAuthorizationRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	message, err := toAuthorizationRequestResponse(resp)
+	if err != nil {
+		return CreateAuthorizationRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateAuthorizationRequest201JSONResponse(message), nil
+}
+
+// CreateQueryRequest is QueryRequest creation controller
 func (s *Server) CreateQueryRequest(ctx context.Context, request CreateQueryRequestRequestObject) (CreateQueryRequestResponseObject, error) {
 	did, err := core.ParseDID(request.Identifier)
 	if err != nil {
 		return CreateQueryRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	
+
 	req := ports.NewCreateQueryRequestRequest(did, request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition)
+	if request.Body.CircuitId != nil {
+		req.CircuitID = *request.Body.CircuitId
+	}
+	if request.Body.AllowedIssuers != nil {
+		req.AllowedIssuers = *request.Body.AllowedIssuers
+	}
+	if request.Body.Scopes != nil {
+		for _, scope := range *request.Body.Scopes {
+			req.Scopes = append(req.Scopes, ports.QueryRequestScope{
+				CircuitID:         scope.CircuitId,
+				AllowedIssuers:    scope.Query.AllowedIssuers,
+				Context:           scope.Query.Context,
+				Type:              scope.Query.Type,
+				CredentialSubject: scope.Query.CredentialSubject,
+			})
+		}
+	}
 
 	queryRequest, err := s.reqService.CreateQueryRequest(ctx, req)
 	if err != nil {
-		if errors.Is(err, services.ErrJSONLdContext) {
+		if errors.Is(err, services.ErrJSONLdContext) || errors.Is(err, services.ErrQueryRequestInvalid) {
 			return CreateQueryRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
 		if errors.Is(err, services.ErrProcessSchema) {
@@ -201,49 +341,36 @@ func (s *Server) CreateQueryRequest(ctx context.Context, request CreateQueryRequ
 		if errors.Is(err, services.ErrLoadingSchema) {
 			return CreateQueryRequest422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
 		}
+		if errors.Is(err, services.ErrVerifierNotFound) {
+			return CreateQueryRequest404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
 		if errors.Is(err, services.ErrMalformedURL) {
 			return CreateQueryRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 		}
 		return CreateQueryRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
 	}
 
-	// q := ports.Query{}
-	// q.CircuitID = string(circuits.AtomicQueryMTPV2OnChainCircuitID)
-	// q.SkipClaimRevocationCheck = false
-	// q.AllowedIssuers = "did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS"
-	// q.Type = "KYCAgeCredential"
-	// q.Context = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"
-	// q.Req = map[string]interface{}{
-	// 	"birthday": map[string]interface{}{
-	// 		"$lt": float64(20221010),
-	// 	},
-	// }
-	// q.Challenge = big.NewInt(6789)
-	// q.ClaimID = "f621070e-caf5-11ed-a093-000c2949382b"
+	log.Debug(ctx, "query request created", "request", queryRequest)
 
+	scopes := make([]protocol.ZeroKnowledgeProofResponse, 0, len(queryRequest.Body.Scope))
+	for _, scope := range queryRequest.Body.Scope {
+		q, err := toProofQuery(scope)
+		if err != nil {
+			return CreateQueryRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
 
-	q := ports.Query{}
-	requestQuery := queryRequest.Body.Scope[0].Query;
-	q.CircuitID = string(circuits.AtomicQueryMTPV2OnChainCircuitID)
-	q.SkipClaimRevocationCheck = false
-	q.Challenge = big.NewInt(6789)
-	// TODO
-	q.ClaimID = "f621070e-caf5-11ed-a093-000c2949382b"
-	q.AllowedIssuers = requestQuery["allowedIssuers"].([]string)[0]
-	q.Type = requestQuery["type"].(string)
-	q.Context = requestQuery["context"].(string)
-	q.Req = requestQuery["credentialSubject"].(map[string]interface{})
-
-	formatted_data, err := json.MarshalIndent(queryRequest, "", " ")
-	if err != nil {
-		fmt.Println(err)
+		proofs, err := s.proofService.GenerateQueryProofs(ctx, did, q)
+		if errors.Is(err, services.ErrClaimExpired) {
+			return CreateQueryRequest422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		if err != nil {
+			log.Error(ctx, "generating query request proof", err, "scope", scope.ID)
+			return CreateQueryRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		for _, proof := range proofs {
+			scopes = append(scopes, toZeroKnowledgeProofResponse(scope, proof))
+		}
 	}
-	fmt.Println(string(formatted_data))
-
-
-	authProof, err := s.proofService.GenerateAgeProof(ctx, did, q)
-	// ageProof, err := s.proofService.GenerateAgeProof(ctx, did, resp.Body.Scope[0].Query)
-	//s.reqService.VerifyAuthRequestResponse(resp, )
 
 	var authorizationResponseMessage protocol.AuthorizationResponseMessage
 	authorizationResponseMessage.Typ = packers.MediaTypePlainMessage
@@ -254,155 +381,523 @@ func (s *Server) CreateQueryRequest(ctx context.Context, request CreateQueryRequ
 	authorizationResponseMessage.ThreadID = queryRequest.ThreadID
 	authorizationResponseMessage.Body = protocol.AuthorizationMessageResponseBody{
 		Message: queryRequest.Body.Message,
-		Scope: []protocol.ZeroKnowledgeProofResponse{
-			{
-				ID:        10,
-				CircuitID: string(circuits.AtomicQueryMTPV2OnChainCircuitID),
-				ZKProof: types.ZKProof{
-					Proof:      (*types.ProofData)(authProof.Proof),
-					PubSignals: authProof.PubSignals,
-				},
-			},
-		},
+		Scope:   scopes,
 	}
 
 	verified := s.reqService.VerifyAuthRequestResponse(ctx, &queryRequest, &authorizationResponseMessage)
-	if verified {
-		return CreateQueryRequest201JSONResponse{Id: authProof.Proof.Protocol + queryRequest.ID}, nil
-	} else {
-		return CreateQueryRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	if !verified || len(scopes) == 0 {
+		return CreateQueryRequest500JSONResponse{N500JSONResponse{Message: "query request proofs could not be verified"}}, nil
 	}
+	return CreateQueryRequest201JSONResponse{Id: scopes[0].Proof.Protocol + queryRequest.ID}, nil
 }
 
+// GenerateProof generates a proof for every scope of the given authorization request
 func (s *Server) GenerateProof(ctx context.Context, request GenerateProofRequestObject) (GenerateProofResponseObject, error) {
 	did, err := core.ParseDID(request.Identifier)
 	if err != nil {
 		return GenerateProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
-	
-	const CallBackUrl = "http:localhost:8001/call-back"
-	verifierIdentity := request.Body.From
-	reason := request.Body.Body.Reason
-	message := request.Body.Body.Message
-	allowedIssuers := (*request.Body.Body.Scope)[0].Query.AllowedIssuers
-	context := (*request.Body.Body.Scope)[0].Query.Context
-	_type := (*request.Body.Body.Scope)[0].Query.Type
-	credentialSubject := (*request.Body.Body.Scope)[0].Query.CredentialSubject
-	
-	authorizationRequestWithMessage := auth.CreateAuthorizationRequestWithMessage(reason, message, verifierIdentity, CallBackUrl)
-	authorizationRequestWithMessage.To = request.Body.To
-	authorizationRequestWithMessage.From = did.String()
-	authorizationRequestWithMessage.ID = request.Body.Id
-	authorizationRequestWithMessage.ThreadID = request.Body.Thid
-
-	var mtpProofRequest protocol.ZeroKnowledgeProofRequest
-	mtpProofRequest.ID = 10
-	// TODO: AtomicQueryMTPV2OnChainCircuitID
-	mtpProofRequest.CircuitID = string(circuits.AtomicQueryMTPV2CircuitID)
-	mtpProofRequest.Query = map[string]interface{}{
-		"allowedIssuers": allowedIssuers,
-		"credentialSubject": credentialSubject,
-		"context": context,
-		"type":    _type,
-	}
-	authorizationRequestWithMessage.Body.Scope = append(authorizationRequestWithMessage.Body.Scope, mtpProofRequest)
-
-	q := ports.Query{}
-	requestQuery := authorizationRequestWithMessage.Body.Scope[0].Query;
-	// TODO: AtomicQueryMTPV2OnChainCircuitID
-	q.CircuitID = string(circuits.AtomicQueryMTPV2CircuitID)
-	q.SkipClaimRevocationCheck = false
-	// TODO
-	q.Challenge = big.NewInt(6789)
-	// TODO
-	// q.ClaimID = "f621070e-caf5-11ed-a093-000c2949382b"
-	q.AllowedIssuers = requestQuery["allowedIssuers"].([]string)[0]
-	q.Type = requestQuery["type"].(string)
-	q.Context = requestQuery["context"].(string)
-	q.Req = requestQuery["credentialSubject"].(map[string]interface{})
-
-	generatedProof, err := s.proofService.GenerateAgeProof(ctx, did, q)
-	
-	f_data, err := json.MarshalIndent(generatedProof, "", " ")
-	if err != nil {
-		fmt.Println(err)
-	}
-	fmt.Println(string(f_data))
-
-	var proof GenerateProofResponseProof
-	proof.PiA = generatedProof.Proof.A
-	proof.PiB = generatedProof.Proof.B
-	proof.PiC = generatedProof.Proof.C
-	proof.Protocol = generatedProof.Proof.Protocol
-	
-	if (err != nil) {
-		return GenerateProof500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
-	} 
-	return GenerateProof200JSONResponse{Proof: &proof, PubSignals: &generatedProof.PubSignals}, nil
+
+	authorizationRequestMessage, err := toAuthorizationRequestMessage(*request.Body, s.verifierCallbackURL(request.Body.From))
+	if err != nil {
+		return GenerateProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if len(authorizationRequestMessage.Body.Scope) == 0 {
+		return GenerateProof400JSONResponse{N400JSONResponse{Message: "the request has no scopes"}}, nil
+	}
+
+	resp := GenerateProof200JSONResponse{}
+	scopes := make([]GenerateProofResponseScope, 0, len(authorizationRequestMessage.Body.Scope))
+	for _, scope := range authorizationRequestMessage.Body.Scope {
+		q, err := toProofQuery(scope)
+		if err != nil {
+			return GenerateProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		q.CircuitID, err = s.proofService.SelectCircuit(ctx, did, q)
+		if err != nil {
+			log.Error(ctx, "selecting proof circuit", err, "scope", scope.ID)
+			return GenerateProof422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+
+		// a scope with several predicates is answered with one proof for each of them, all of the same credential
+		generatedProofs, err := s.proofService.GenerateQueryProofs(ctx, did, q)
+		if errors.Is(err, services.ErrStateCommitmentNotPublished) || errors.Is(err, services.ErrSybilCRSRequired) || errors.Is(err, services.ErrChallengeRequired) || errors.Is(err, services.ErrClaimExpired) {
+			return GenerateProof422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		if err != nil {
+			log.Error(ctx, "generating proof", err, "scope", scope.ID)
+			return GenerateProof500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+
+		for _, generatedProof := range generatedProofs {
+			log.Debug(ctx, "proof generated", "scope", scope.ID, "proof", generatedProof)
+
+			vp, err := toPresentationObject(generatedProof.VerifiablePresentation)
+			if err != nil {
+				log.Error(ctx, "reading verifiable presentation", err, "scope", scope.ID)
+				return GenerateProof500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+			}
+
+			scopes = append(scopes, GenerateProofResponseScope{
+				Id:         scope.ID,
+				CircuitId:  q.CircuitID,
+				Proof:      toGenerateProofResponseProof(generatedProof),
+				PubSignals: generatedProof.PubSignals,
+				Vp:         vp,
+			})
+		}
+	}
+
+	resp.Scope = &scopes
+	resp.Proof = &scopes[0].Proof
+	resp.PubSignals = &scopes[0].PubSignals
+	return resp, nil
 }
 
+// VerifyProof verifies the proofs of every scope of the given authorization request
 func (s *Server) VerifyProof(ctx context.Context, request VerifyProofRequestObject) (VerifyProofResponseObject, error) {
+	proofRequest := request.Body.GenerateProofRequest
+	authorizationRequestMessage, err := toAuthorizationRequestMessage(proofRequest, s.verifierCallbackURL(proofRequest.From))
+	if err != nil {
+		return VerifyProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	scopes, err := toVerifyProofScopes(authorizationRequestMessage.Body.Scope, request.Body.GenerateProofResponse)
+	if err != nil {
+		return VerifyProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if err := fillWalletSelectedCircuits(authorizationRequestMessage.Body.Scope, scopes); err != nil {
+		return VerifyProof400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
 
-	const CallBackUrl = "http:localhost:8001/call-back"
-	verifierIdentity := request.Body.GenerateProofRequest.From
-	reason := request.Body.GenerateProofRequest.Body.Reason
-	message := request.Body.GenerateProofRequest.Body.Message
-	allowedIssuers := (*request.Body.GenerateProofRequest.Body.Scope)[0].Query.AllowedIssuers
-	context := (*request.Body.GenerateProofRequest.Body.Scope)[0].Query.Context
-	_type := (*request.Body.GenerateProofRequest.Body.Scope)[0].Query.Type
-	credentialSubject := (*request.Body.GenerateProofRequest.Body.Scope)[0].Query.CredentialSubject
-	
-	authorizationRequestWithMessage := auth.CreateAuthorizationRequestWithMessage(reason, message, verifierIdentity, CallBackUrl)
-	authorizationRequestWithMessage.To = request.Body.GenerateProofRequest.To
-	authorizationRequestWithMessage.ID = request.Body.GenerateProofRequest.Id
-	authorizationRequestWithMessage.ThreadID = request.Body.GenerateProofRequest.Thid
-
-	var mtpProofRequest protocol.ZeroKnowledgeProofRequest
-	mtpProofRequest.ID = 10
-	// TODO OnChain
-	mtpProofRequest.CircuitID = string(circuits.AtomicQueryMTPV2CircuitID)
-	mtpProofRequest.Query = map[string]interface{}{
-		"allowedIssuers": allowedIssuers,
-		"credentialSubject": credentialSubject,
-		"context": context,
-		"type":    _type,
-	}
-	authorizationRequestWithMessage.Body.Scope = append(authorizationRequestWithMessage.Body.Scope, mtpProofRequest)
-
-    var authorizationResponseMessage protocol.AuthorizationResponseMessage
+	var authorizationResponseMessage protocol.AuthorizationResponseMessage
 	authorizationResponseMessage.Typ = packers.MediaTypePlainMessage
 	authorizationResponseMessage.Type = protocol.AuthorizationResponseMessageType
-	authorizationResponseMessage.From = request.Body.GenerateProofRequest.To
-	authorizationResponseMessage.To = request.Body.GenerateProofRequest.From
+	authorizationResponseMessage.From = proofRequest.To
+	authorizationResponseMessage.To = proofRequest.From
 	authorizationResponseMessage.ID = uuid.New().String()
-	authorizationResponseMessage.ThreadID = request.Body.GenerateProofRequest.Thid
-	var proofData types.ProofData
-	proofData.A = request.Body.GenerateProofResponse.Proof.PiA
-	proofData.B = request.Body.GenerateProofResponse.Proof.PiB
-	proofData.C = request.Body.GenerateProofResponse.Proof.PiC
-	proofData.Protocol = request.Body.GenerateProofResponse.Proof.Protocol
-
+	authorizationResponseMessage.ThreadID = proofRequest.Thid
 	authorizationResponseMessage.Body = protocol.AuthorizationMessageResponseBody{
-		Message: request.Body.GenerateProofRequest.Body.Message,
-		Scope: []protocol.ZeroKnowledgeProofResponse{
-			{
-				ID:        10,
-				// TODO OnChain
-				CircuitID: string(circuits.AtomicQueryMTPV2CircuitID),
+		Message: proofRequest.Body.Message,
+		Scope:   scopes,
+	}
+
+	verified := s.reqService.VerifyAuthRequestResponse(ctx, &authorizationRequestMessage, &authorizationResponseMessage)
+	if !verified {
+		return VerifyProof500JSONResponse{N500JSONResponse{}}, nil
+	}
+	return VerifyProof200JSONResponse{Verified: verified}, nil
+}
+
+// CreateProofJob queues the generation of the proofs of every scope of the given authorization request
+func (s *Server) CreateProofJob(ctx context.Context, request CreateProofJobRequestObject) (CreateProofJobResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateProofJob400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	authorizationRequestMessage, err := toAuthorizationRequestMessage(*request.Body, s.verifierCallbackURL(request.Body.From))
+	if err != nil {
+		return CreateProofJob400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+	if len(authorizationRequestMessage.Body.Scope) == 0 {
+		return CreateProofJob400JSONResponse{N400JSONResponse{Message: "the request has no scopes"}}, nil
+	}
+
+	queries := make([]ports.Query, 0, len(authorizationRequestMessage.Body.Scope))
+	for _, scope := range authorizationRequestMessage.Body.Scope {
+		q, err := toProofQuery(scope)
+		if err != nil {
+			return CreateProofJob400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		q.CircuitID, err = s.proofService.SelectCircuit(ctx, did, q)
+		if err != nil {
+			log.Error(ctx, "selecting proof circuit", err, "scope", scope.ID)
+			return CreateProofJob422JSONResponse{N422JSONResponse{Message: err.Error()}}, nil
+		}
+		queries = append(queries, q)
+	}
+
+	job, err := s.proofJobs.Create(ctx, did, queries)
+	if err != nil {
+		return CreateProofJob500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toProofJobResponse(job)
+	if err != nil {
+		return CreateProofJob500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateProofJob202JSONResponse(resp), nil
+}
+
+// GetProofJob returns the status of a proof job, and its proofs once it is done
+func (s *Server) GetProofJob(ctx context.Context, request GetProofJobRequestObject) (GetProofJobResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetProofJob400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	job, err := s.proofJobs.GetByID(ctx, did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrProofJobNotFound) {
+			return GetProofJob404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		return GetProofJob500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toProofJobResponse(job)
+	if err != nil {
+		return GetProofJob500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return GetProofJob200JSONResponse(resp), nil
+}
+
+// CancelProofJob cancels a pending or running proof job
+func (s *Server) CancelProofJob(ctx context.Context, request CancelProofJobRequestObject) (CancelProofJobResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CancelProofJob400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	job, err := s.proofJobs.Cancel(ctx, did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrProofJobNotFound) {
+			return CancelProofJob404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProofJobFinished) {
+			return CancelProofJob409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		return CancelProofJob500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toProofJobResponse(job)
+	if err != nil {
+		return CancelProofJob500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+	return CancelProofJob200JSONResponse(resp), nil
+}
+
+// toAuthorizationRequestMessage builds the protocol authorization request with one proof request for each scope
+func toAuthorizationRequestMessage(request GenerateProofRequest, callbackURL string) (protocol.AuthorizationRequestMessage, error) {
+	message := auth.CreateAuthorizationRequestWithMessage(request.Body.Reason, request.Body.Message, request.From, callbackURL)
+	message.To = request.To
+	message.ID = request.Id
+	message.ThreadID = request.Thid
+
+	if request.Body.Scope == nil {
+		return message, nil
+	}
+
+	for _, scope := range *request.Body.Scope {
+		id, err := strconv.ParseUint(scope.Id, 10, 32)
+		if err != nil {
+			return message, fmt.Errorf("invalid scope id <%s>: %w", scope.Id, err)
+		}
+		query := map[string]interface{}{
+			"allowedIssuers":    scope.Query.AllowedIssuers,
+			"credentialSubject": scope.Query.CredentialSubject,
+			"context":           scope.Query.Context,
+			"type":              scope.Query.Type,
+		}
+		if scope.Query.Crs != nil {
+			query["crs"] = *scope.Query.Crs
+		}
+		if scope.Query.Challenge != nil {
+			query[domain.RequestChallengeField] = *scope.Query.Challenge
+		}
+		message.Body.Scope = append(message.Body.Scope, protocol.ZeroKnowledgeProofRequest{
+			ID:        uint32(id),
+			CircuitID: scope.CircuitId,
+			Query:     query,
+		})
+	}
+	return message, nil
+}
+
+// toProofQuery builds the wallet proof query for the given proof request
+func toProofQuery(scope protocol.ZeroKnowledgeProofRequest) (ports.Query, error) {
+	q := ports.Query{
+		CircuitID:                scope.CircuitID,
+		RequestID:                scope.ID,
+		SkipClaimRevocationCheck: false,
+	}
+
+	allowedIssuers, err := toAllowedIssuers(scope.Query["allowedIssuers"])
+	if err != nil || len(allowedIssuers) == 0 {
+		return q, fmt.Errorf("scope %d: allowedIssuers must be a non empty list of strings", scope.ID)
+	}
+	q.AllowedIssuers = allowedIssuers
+
+	var ok bool
+	if q.Type, ok = scope.Query["type"].(string); !ok {
+		return q, fmt.Errorf("scope %d: type must be a string", scope.ID)
+	}
+	if q.Context, ok = scope.Query["context"].(string); !ok {
+		return q, fmt.Errorf("scope %d: context must be a string", scope.ID)
+	}
+	if q.Req, ok = scope.Query["credentialSubject"].(map[string]interface{}); !ok {
+		return q, fmt.Errorf("scope %d: credentialSubject must be an object", scope.ID)
+	}
+	if q.Challenge, err = domain.ScopeChallenge(scope); err != nil {
+		return q, err
+	}
+	if q.Challenge == nil && isOnChainCircuit(scope.CircuitID) {
+		return q, fmt.Errorf("scope %d: the %s circuit needs the challenge of the request", scope.ID, scope.CircuitID)
+	}
+	if crs, ok := scope.Query["crs"].(string); ok {
+		if q.CRS, ok = new(big.Int).SetString(crs, 10); !ok {
+			return q, fmt.Errorf("scope %d: crs must be a decimal number", scope.ID)
+		}
+	}
+	return q, nil
+}
+
+// isOnChainCircuit tells whether the proofs of the circuit sign the challenge of the request, to be verified on chain
+func isOnChainCircuit(circuitID string) bool {
+	switch circuits.CircuitID(circuitID) {
+	case circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQuerySigV2OnChainCircuitID:
+		return true
+	default:
+		return false
+	}
+}
+
+// toAllowedIssuers reads the allowed issuers of a scope query, built by this service or decoded from json
+func toAllowedIssuers(value interface{}) ([]string, error) {
+	switch issuers := value.(type) {
+	case []string:
+		return issuers, nil
+	case []interface{}:
+		allowedIssuers := make([]string, 0, len(issuers))
+		for _, issuer := range issuers {
+			did, ok := issuer.(string)
+			if !ok {
+				return nil, fmt.Errorf("allowed issuer %v is not a string", issuer)
+			}
+			allowedIssuers = append(allowedIssuers, did)
+		}
+		return allowedIssuers, nil
+	default:
+		return nil, fmt.Errorf("unexpected allowed issuers %T", value)
+	}
+}
+
+// toVerifyProofScopes returns the proof responses of the request scopes. Responses with a single proof
+// are taken as the answer to the first scope.
+func toVerifyProofScopes(requestScopes []protocol.ZeroKnowledgeProofRequest, response GenerateProofResponse) ([]protocol.ZeroKnowledgeProofResponse, error) {
+	if response.Scope != nil {
+		scopes := make([]protocol.ZeroKnowledgeProofResponse, 0, len(*response.Scope))
+		for _, scope := range *response.Scope {
+			vp, err := toPresentationRaw(scope.Vp)
+			if err != nil {
+				return nil, fmt.Errorf("scope %d: %w", scope.Id, err)
+			}
+			scopes = append(scopes, protocol.ZeroKnowledgeProofResponse{
+				ID:        scope.Id,
+				CircuitID: scope.CircuitId,
 				ZKProof: types.ZKProof{
-					Proof:      &proofData,
-					PubSignals: *request.Body.GenerateProofResponse.PubSignals,
+					Proof:      toProofData(scope.Proof),
+					PubSignals: scope.PubSignals,
 				},
+				VerifiablePresentation: vp,
+			})
+		}
+		return scopes, nil
+	}
+
+	if response.Proof == nil || response.PubSignals == nil || len(requestScopes) == 0 {
+		return nil, errors.New("the response has no proofs")
+	}
+	return []protocol.ZeroKnowledgeProofResponse{
+		{
+			ID:        requestScopes[0].ID,
+			CircuitID: requestScopes[0].CircuitID,
+			ZKProof: types.ZKProof{
+				Proof:      toProofData(*response.Proof),
+				PubSignals: *response.PubSignals,
 			},
 		},
+	}, nil
+}
+
+// fillWalletSelectedCircuits sets, on the request scopes that left the circuit to the wallet,
+// the circuit the wallet answered with. Only the Sig and MTP atomic query circuits can be chosen.
+func fillWalletSelectedCircuits(requestScopes []protocol.ZeroKnowledgeProofRequest, responseScopes []protocol.ZeroKnowledgeProofResponse) error {
+	for i := range requestScopes {
+		if requestScopes[i].CircuitID != "" {
+			continue
+		}
+		for _, response := range responseScopes {
+			if response.ID != requestScopes[i].ID {
+				continue
+			}
+			switch circuits.CircuitID(response.CircuitID) {
+			case circuits.AtomicQuerySigV2CircuitID, circuits.AtomicQueryMTPV2CircuitID:
+				requestScopes[i].CircuitID = response.CircuitID
+			default:
+				return fmt.Errorf("scope %d: circuit %s cannot be chosen by the wallet", response.ID, response.CircuitID)
+			}
+		}
 	}
+	return nil
+}
 
-	verified := s.reqService.VerifyAuthRequestResponse(ctx, &authorizationRequestWithMessage, &authorizationResponseMessage)
-		
-	if !verified {
-		return VerifyProof500JSONResponse{N500JSONResponse{}}, nil
-	} 
-	return VerifyProof200JSONResponse{Verified: verified}, nil
+func toZeroKnowledgeProofResponse(scope protocol.ZeroKnowledgeProofRequest, proof *domain.FullProof) protocol.ZeroKnowledgeProofResponse {
+	return protocol.ZeroKnowledgeProofResponse{
+		ID:        scope.ID,
+		CircuitID: scope.CircuitID,
+		ZKProof: types.ZKProof{
+			Proof:      (*types.ProofData)(proof.Proof),
+			PubSignals: proof.PubSignals,
+		},
+		VerifiablePresentation: proof.VerifiablePresentation,
+	}
+}
+
+// toPresentationObject returns the verifiable presentation as a JSON object, nil if there is none
+func toPresentationObject(vp json.RawMessage) (*map[string]interface{}, error) {
+	if len(vp) == 0 {
+		return nil, nil
+	}
+	object := make(map[string]interface{})
+	if err := json.Unmarshal(vp, &object); err != nil {
+		return nil, err
+	}
+	return &object, nil
+}
+
+// toPresentationRaw returns the JSON encoding of the verifiable presentation, nil if there is none
+func toPresentationRaw(vp *map[string]interface{}) (json.RawMessage, error) {
+	if vp == nil {
+		return nil, nil
+	}
+	return json.Marshal(*vp)
+}
+
+// toProofJobResponse returns the proof job with its proofs, in the format of the GenerateProof response, once it is done
+func toProofJobResponse(job *domain.ProofJob) (ProofJob, error) {
+	resp := ProofJob{
+		Id:         job.ID,
+		Status:     ProofJobStatus(job.Status),
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		ExpiresAt:  job.ExpiresAt,
+	}
+	if job.Status != domain.ProofJobDone {
+		return resp, nil
+	}
+
+	result, err := job.GetResult()
+	if err != nil {
+		return resp, err
+	}
+	scopes := make([]GenerateProofResponseScope, 0, len(result))
+	for _, scope := range result {
+		vp, err := toPresentationObject(scope.Proof.VerifiablePresentation)
+		if err != nil {
+			return resp, err
+		}
+		scopes = append(scopes, GenerateProofResponseScope{
+			Id:         scope.ID,
+			CircuitId:  scope.CircuitID,
+			Proof:      toGenerateProofResponseProof(scope.Proof),
+			PubSignals: scope.Proof.PubSignals,
+			Vp:         vp,
+		})
+	}
+	resp.Result = &GenerateProofResponse{Scope: &scopes}
+	if len(scopes) > 0 {
+		resp.Result.Proof = &scopes[0].Proof
+		resp.Result.PubSignals = &scopes[0].PubSignals
+	}
+	return resp, nil
+}
+
+func toGenerateProofResponseProof(proof *domain.FullProof) GenerateProofResponseProof {
+	return GenerateProofResponseProof{
+		PiA:      proof.Proof.A,
+		PiB:      proof.Proof.B,
+		PiC:      proof.Proof.C,
+		Protocol: proof.Proof.Protocol,
+	}
+}
+
+func toProofData(proof GenerateProofResponseProof) *types.ProofData {
+	return &types.ProofData{
+		A:        proof.PiA,
+		B:        proof.PiB,
+		C:        proof.PiC,
+		Protocol: proof.Protocol,
+	}
+}
+
+// verifierCallbackURL returns the callback url of the verifier profile with the given DID
+func (s *Server) verifierCallbackURL(verifierDID string) string {
+	profile, err := s.cfg.Verifier.ProfileByDID(verifierDID)
+	if err != nil {
+		return ""
+	}
+	return profile.CallbackURL
+}
+
+// Callback is the verifier callback controller. Wallets send here their JWZ responses to auth and query requests
+func (s *Server) Callback(ctx context.Context, request CallbackRequestObject) (CallbackResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return Callback400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	sessionID, err := uuid.Parse(request.Params.SessionId)
+	if err != nil {
+		return Callback400JSONResponse{N400JSONResponse{Message: "invalid sessionId"}}, nil
+	}
+
+	if request.Body == nil || *request.Body == "" {
+		return Callback400JSONResponse{N400JSONResponse{Message: "cannot proceed with an empty request"}}, nil
+	}
+
+	basicMessage, err := s.packageManager.UnpackWithType(packers.MediaTypeZKPMessage, []byte(*request.Body))
+	if err != nil {
+		log.Debug(ctx, "callback bad request", "err", err, "body", *request.Body)
+		return Callback400JSONResponse{N400JSONResponse{Message: "cannot proceed with the given request"}}, nil
+	}
+
+	if basicMessage.Type != protocol.AuthorizationResponseMessageType {
+		return Callback400JSONResponse{N400JSONResponse{Message: fmt.Sprintf("invalid message type <%s>", basicMessage.Type)}}, nil
+	}
+
+	authRequest, err := s.reqService.Callback(ctx, did, sessionID, *request.Body)
+	if err != nil {
+		if errors.Is(err, services.ErrAuthRequestNotFound) || errors.Is(err, services.ErrVerifierNotFound) {
+			return Callback404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrAuthRequestNotPending) || errors.Is(err, services.ErrAuthResponseNotValid) {
+			return Callback400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		log.Error(ctx, "callback error", err, "session", sessionID)
+		return Callback500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	return toCallback200JSONResponse(authRequest)
+}
+
+func toCallback200JSONResponse(authRequest *domain.AuthRequest) (CallbackResponseObject, error) {
+	disclosed, err := authRequest.GetDisclosed()
+	if err != nil {
+		return Callback500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := Callback200JSONResponse{
+		Id:        authRequest.ID.String(),
+		Status:    CallbackResponseStatus(authRequest.Status),
+		Disclosed: &disclosed,
+	}
+	if authRequest.VerifiedDID != nil {
+		resp.VerifiedDID = *authRequest.VerifiedDID
+	}
+	return resp, nil
 }
 
 // CreateClaim is claim creation controller
@@ -412,7 +907,22 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 		return CreateClaim400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
 	}
 
-	req := ports.NewCreateClaimRequest(did, request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition)
+	req := ports.NewCreateClaimRequest(did, request.Body.CredentialSchema, request.Body.CredentialSubject, request.Body.Expiration, request.Body.Type, request.Body.Version, request.Body.SubjectPosition, request.Body.MerklizedRootPosition, request.Body.Updatable)
+
+	held, err := s.credentialReqs.Submit(ctx, req, basicAuthUser(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrIssuanceDenied) {
+			return CreateClaim403JSONResponse{N403JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	if held != nil {
+		credentialReq, err := toCredentialRequestResponse(held)
+		if err != nil {
+			return CreateClaim500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateClaim202JSONResponse(credentialReq), nil
+	}
 
 	resp, err := s.claimService.CreateClaim(ctx, req)
 	if err != nil {
@@ -433,6 +943,91 @@ func (s *Server) CreateClaim(ctx context.Context, request CreateClaimRequestObje
 	return CreateClaim201JSONResponse{Id: resp.ID.String()}, nil
 }
 
+// CreateClaimsBatch is the controller to issue many claims at once
+func (s *Server) CreateClaimsBatch(ctx context.Context, request CreateClaimsBatchRequestObject) (CreateClaimsBatchResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateClaimsBatch400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+	}
+
+	if len(request.Body.Claims) == 0 || len(request.Body.Claims) > services.MaxClaimsBatchSize {
+		msg := fmt.Sprintf("%s: it must have between 1 and %d claims", services.ErrClaimsBatchInvalid, services.MaxClaimsBatchSize)
+		return CreateClaimsBatch400JSONResponse{N400JSONResponse{Message: msg}}, nil
+	}
+
+	reqs := make([]*ports.CreateClaimRequest, len(request.Body.Claims))
+	for i, claim := range request.Body.Claims {
+		reqs[i] = ports.NewCreateClaimRequest(did, claim.CredentialSchema, claim.CredentialSubject, claim.Expiration, claim.Type, claim.Version, claim.SubjectPosition, claim.MerklizedRootPosition, claim.Updatable)
+	}
+
+	results, err := s.credentialReqs.SubmitBatch(ctx, did, reqs, basicAuthUser(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrClaimsBatchInvalid) {
+			return CreateClaimsBatch400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateClaimsBatch500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := CreateClaimsBatchResponse{Claims: make([]CreateClaimsBatchResult, len(results))}
+	for i, result := range results {
+		item := &resp.Claims[i]
+		switch {
+		case result.Err != nil:
+			msg := result.Err.Error()
+			item.Error = &msg
+		case result.Request != nil:
+			id := result.Request.ID.String()
+			item.RequestId = &id
+		default:
+			id := result.Claim.ID.String()
+			item.Id = &id
+		}
+	}
+	return toCreateClaimsBatchResponse(resp), nil
+}
+
+// toCreateClaimsBatchResponse returns the batch results as a 201 when every claim was issued or held for approval,
+// and as a 207 otherwise
+func toCreateClaimsBatchResponse(resp CreateClaimsBatchResponse) CreateClaimsBatchResponseObject {
+	for _, claim := range resp.Claims {
+		if claim.Error != nil {
+			return CreateClaimsBatch207JSONResponse(resp)
+		}
+	}
+	return CreateClaimsBatch201JSONResponse(resp)
+}
+
+// UpdateClaim is the controller to reissue a claim with a new credential subject
+func (s *Server) UpdateClaim(ctx context.Context, request UpdateClaimRequestObject) (UpdateClaimResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return UpdateClaim400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	clID, err := uuid.Parse(request.Id)
+	if err != nil {
+		return UpdateClaim400JSONResponse{N400JSONResponse{"invalid claim id"}}, nil
+	}
+
+	claim, err := s.claimService.UpdateClaim(ctx, ports.NewUpdateClaimRequest(did, clID, request.Body.CredentialSubject, request.Body.Expiration))
+	if err != nil {
+		if errors.Is(err, services.ErrClaimNotFound) {
+			return UpdateClaim404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrClaimUpdateInvalid) || errors.Is(err, services.ErrProcessSchema) || errors.Is(err, services.ErrJSONLdContext) {
+			return UpdateClaim400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		return UpdateClaim500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp := UpdateClaim200JSONResponse{Id: claim.ID.String(), Version: claim.Version}
+	if claim.Replaces != nil {
+		replaces := claim.Replaces.String()
+		resp.Replaces = &replaces
+	}
+	return resp, nil
+}
+
 // RevokeClaim is the revocation claim controller
 func (s *Server) RevokeClaim(ctx context.Context, request RevokeClaimRequestObject) (RevokeClaimResponseObject, error) {
 	if err := s.claimService.Revoke(ctx, request.Identifier, uint64(request.Nonce), ""); err != nil {
@@ -537,17 +1132,267 @@ func (s *Server) GetClaims(ctx context.Context, request GetClaimsRequestObject)
 		return GetClaims400JSONResponse{N400JSONResponse{"invalid did"}}, nil
 	}
 
-	filter, err := ports.NewClaimsFilter(request.Params.SchemaHash, request.Params.SchemaType, request.Params.Subject, request.Params.QueryField, request.Params.Self, request.Params.Revoked)
+	filter, err := ports.NewClaimsFilter(request.Params.SchemaHash, request.Params.SchemaType, request.Params.Subject, request.Params.QueryField, request.Params.Search, request.Params.Self, request.Params.Revoked, request.Params.Expired)
 	if err != nil {
 		return GetClaims400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
 
-	claims, err := s.claimService.GetAll(ctx, did, filter)
+	if err := filter.SetPagination((*string)(request.Params.SortBy), request.Params.Descending, request.Params.Cursor, request.Params.Limit); err != nil {
+		return GetClaims400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+
+	page, err := s.claimService.GetAll(ctx, did, filter)
 	if err != nil {
 		return GetClaims500JSONResponse{N500JSONResponse{"there was an internal error trying to retrieve claims for the requested identifier"}}, nil
 	}
 
-	return toGetClaims200Response(claims), nil
+	return toGetClaims200Response(page), nil
+}
+
+// AcceptCredentialOffer is the controller to accept, as a holder, a credential offer from any issuer
+func (s *Server) AcceptCredentialOffer(ctx context.Context, request AcceptCredentialOfferRequestObject) (AcceptCredentialOfferResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return AcceptCredentialOffer400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	credentials, err := s.offerService.Accept(ctx, ports.NewAcceptOfferRequest(did, toCredentialsOfferMessage(request.Body)))
+	if err != nil {
+		if errors.Is(err, services.ErrOfferInvalid) {
+			return AcceptCredentialOffer400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrOfferAgentResponse) || errors.Is(err, services.ErrOfferCredentialProof) {
+			return AcceptCredentialOffer422JSONResponse{N422JSONResponse{err.Error()}}, nil
+		}
+		return AcceptCredentialOffer500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toGetHolderCredentialsResponse(credentials)
+	if err != nil {
+		return AcceptCredentialOffer500JSONResponse{N500JSONResponse{"invalid credential format"}}, nil
+	}
+	return AcceptCredentialOffer201JSONResponse(resp), nil
+}
+
+// GetHolderCredentials is the controller to search the credentials held by the identity
+func (s *Server) GetHolderCredentials(ctx context.Context, request GetHolderCredentialsRequestObject) (GetHolderCredentialsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetHolderCredentials400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	filter, err := ports.NewHolderCredentialFilter(
+		request.Params.SchemaHash,
+		request.Params.SchemaType,
+		request.Params.Issuer,
+		(*string)(request.Params.Source),
+		request.Params.QueryField,
+		request.Params.Revoked)
+	if err != nil {
+		return GetHolderCredentials400JSONResponse{N400JSONResponse{err.Error()}}, nil
+	}
+
+	credentials, err := s.holderService.GetAll(ctx, did, filter)
+	if err != nil {
+		return GetHolderCredentials500JSONResponse{N500JSONResponse{"there was an internal error trying to retrieve credentials for the requested identifier"}}, nil
+	}
+
+	resp, err := toGetHolderCredentialsResponse(credentials)
+	if err != nil {
+		return GetHolderCredentials500JSONResponse{N500JSONResponse{"invalid credential format"}}, nil
+	}
+	return GetHolderCredentials200JSONResponse(resp), nil
+}
+
+// ImportCredential is the controller to import a credential issued to the identity
+func (s *Server) ImportCredential(ctx context.Context, request ImportCredentialRequestObject) (ImportCredentialResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return ImportCredential400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	credential, err := toW3CCredential(request.Body)
+	if err != nil {
+		return ImportCredential400JSONResponse{N400JSONResponse{"invalid credential format"}}, nil
+	}
+
+	held, err := s.holderService.Import(ctx, ports.NewImportCredentialRequest(did, *credential))
+	if err != nil {
+		if errors.Is(err, services.ErrHolderCredentialInvalid) {
+			return ImportCredential422JSONResponse{N422JSONResponse{err.Error()}}, nil
+		}
+		return ImportCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toHolderCredentialResponse(held)
+	if err != nil {
+		return ImportCredential500JSONResponse{N500JSONResponse{"invalid credential format"}}, nil
+	}
+	return ImportCredential201JSONResponse(resp), nil
+}
+
+// GetHolderCredential is the controller to get a credential held by the identity
+func (s *Server) GetHolderCredential(ctx context.Context, request GetHolderCredentialRequestObject) (GetHolderCredentialResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetHolderCredential400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	held, err := s.holderService.GetByID(ctx, did, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrHolderCredentialNotFound) {
+			return GetHolderCredential404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		return GetHolderCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toHolderCredentialResponse(held)
+	if err != nil {
+		return GetHolderCredential500JSONResponse{N500JSONResponse{"invalid credential format"}}, nil
+	}
+	return GetHolderCredential200JSONResponse(resp), nil
+}
+
+// DeleteHolderCredential is the controller to remove a credential held by the identity
+func (s *Server) DeleteHolderCredential(ctx context.Context, request DeleteHolderCredentialRequestObject) (DeleteHolderCredentialResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return DeleteHolderCredential400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	if err := s.holderService.Delete(ctx, did, request.Id); err != nil {
+		if errors.Is(err, services.ErrHolderCredentialNotFound) {
+			return DeleteHolderCredential404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		return DeleteHolderCredential500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	return DeleteHolderCredential200JSONResponse{Message: "credential deleted"}, nil
+}
+
+// CreatePresentation is the controller to present credentials held by the identity to a verifier
+func (s *Server) CreatePresentation(ctx context.Context, request CreatePresentationRequestObject) (CreatePresentationResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreatePresentation400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	presentation, err := s.presentations.Create(ctx, ports.NewCreatePresentationRequest(did, request.Body.Credentials, request.Body.Challenge, request.Body.Domain))
+	if err != nil {
+		if errors.Is(err, services.ErrPresentationInvalid) {
+			return CreatePresentation400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		return CreatePresentation500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toVerifiablePresentationResponse(presentation)
+	if err != nil {
+		return CreatePresentation500JSONResponse{N500JSONResponse{"invalid presentation format"}}, nil
+	}
+	return CreatePresentation201JSONResponse(resp), nil
+}
+
+// VerifyPresentation is the controller to verify a presentation for the verifiers that do not use zero knowledge proofs
+func (s *Server) VerifyPresentation(ctx context.Context, request VerifyPresentationRequestObject) (VerifyPresentationResponseObject, error) {
+	presentation, err := toVerifiablePresentation(request.Body.Presentation)
+	if err != nil {
+		return VerifyPresentation400JSONResponse{N400JSONResponse{"invalid presentation format"}}, nil
+	}
+
+	err = s.presentations.Verify(ctx, ports.NewVerifyPresentationRequest(*presentation, request.Body.Challenge, request.Body.Domain))
+	if err != nil {
+		if errors.Is(err, services.ErrPresentationNotValid) {
+			reason := err.Error()
+			return VerifyPresentation200JSONResponse{Verified: false, Reason: &reason}, nil
+		}
+		return VerifyPresentation500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	return VerifyPresentation200JSONResponse{Verified: true}, nil
+}
+
+// GetCredentialRequests is the controller to list the credentials the holders requested to the identity
+func (s *Server) GetCredentialRequests(ctx context.Context, request GetCredentialRequestsRequestObject) (GetCredentialRequestsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetCredentialRequests400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	var status *domain.CredentialRequestStatus
+	if request.Params.Status != nil {
+		st := domain.CredentialRequestStatus(*request.Params.Status)
+		status = &st
+	}
+
+	requests, err := s.credentialReqs.GetAll(ctx, did, status)
+	if err != nil {
+		return GetCredentialRequests500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp := make(GetCredentialRequests200JSONResponse, 0, len(requests))
+	for _, req := range requests {
+		credentialRequest, err := toCredentialRequestResponse(req)
+		if err != nil {
+			return GetCredentialRequests500JSONResponse{N500JSONResponse{"invalid credential request format"}}, nil
+		}
+		resp = append(resp, credentialRequest)
+	}
+	return resp, nil
+}
+
+// ApproveCredentialRequest is the controller to issue the credential of a pending request
+func (s *Server) ApproveCredentialRequest(ctx context.Context, request ApproveCredentialRequestRequestObject) (ApproveCredentialRequestResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return ApproveCredentialRequest400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	offer, err := s.credentialReqs.Approve(ctx, did, request.Id, basicAuthUser(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialRequestNotFound) {
+			return ApproveCredentialRequest404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrCredentialRequestDecided) {
+			return ApproveCredentialRequest409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrLoadingSchema) || errors.Is(err, services.ErrProcessSchema) ||
+			errors.Is(err, services.ErrMalformedURL) || errors.Is(err, services.ErrJSONLdContext) {
+			return ApproveCredentialRequest400JSONResponse{N400JSONResponse{err.Error()}}, nil
+		}
+		return ApproveCredentialRequest500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	return ApproveCredentialRequest200JSONResponse(toGetClaimQrCodeResponse(offer)), nil
+}
+
+// RejectCredentialRequest is the controller to reject a pending credential request
+func (s *Server) RejectCredentialRequest(ctx context.Context, request RejectCredentialRequestRequestObject) (RejectCredentialRequestResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return RejectCredentialRequest400JSONResponse{N400JSONResponse{"invalid did"}}, nil
+	}
+
+	var reason string
+	if request.Body.Reason != nil {
+		reason = *request.Body.Reason
+	}
+
+	rejected, err := s.credentialReqs.Reject(ctx, did, request.Id, basicAuthUser(ctx), reason)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialRequestNotFound) {
+			return RejectCredentialRequest404JSONResponse{N404JSONResponse{err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrCredentialRequestDecided) {
+			return RejectCredentialRequest409JSONResponse{N409JSONResponse{err.Error()}}, nil
+		}
+		return RejectCredentialRequest500JSONResponse{N500JSONResponse{err.Error()}}, nil
+	}
+
+	resp, err := toCredentialRequestResponse(rejected)
+	if err != nil {
+		return RejectCredentialRequest500JSONResponse{N500JSONResponse{"invalid credential request format"}}, nil
+	}
+	return RejectCredentialRequest200JSONResponse(resp), nil
 }
 
 // GetClaimQrCode returns a GetClaimQrCodeResponseObject that can be used with any QR generator to create a QR and
@@ -613,7 +1458,12 @@ func (s *Server) Agent(ctx context.Context, request AgentRequestObject) (AgentRe
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
 	}
 
-	agent, err := s.claimService.Agent(ctx, req)
+	var agent *domain.Agent
+	if req.Type == protocol.CredentialIssuanceRequestMessageType {
+		agent, err = s.credentialReqs.Agent(ctx, req)
+	} else {
+		agent, err = s.claimService.Agent(ctx, req)
+	}
 	if err != nil {
 		log.Error(ctx, "agent error", err)
 		return Agent400JSONResponse{N400JSONResponse{err.Error()}}, nil
@@ -660,10 +1510,16 @@ func RegisterStatic(mux *chi.Mux) {
 	mux.Get("/static/docs/api/api.yaml", swagger)
 }
 
-func toGetClaims200Response(claims []*verifiable.W3CCredential) GetClaims200JSONResponse {
-	response := make(GetClaims200JSONResponse, len(claims))
-	for i := range claims {
-		response[i] = toGetClaim200Response(claims[i])
+func toGetClaims200Response(page *ports.ClaimsPage) GetClaims200JSONResponse {
+	response := GetClaims200JSONResponse{
+		Body:    make(GetClaimsResponse, len(page.Credentials)),
+		Headers: GetClaims200ResponseHeaders{XTotalCount: page.Total},
+	}
+	for i := range page.Credentials {
+		response.Body[i] = toGetClaim200Response(page.Credentials[i])
+	}
+	if page.NextCursor != nil {
+		response.Headers.XNextCursor = page.NextCursor.String()
 	}
 
 	return response
@@ -687,6 +1543,124 @@ func toGetClaim200Response(claim *verifiable.W3CCredential) GetClaimResponse {
 	}
 }
 
+func toProfileResponse(profile *domain.Profile) Profile {
+	return Profile{
+		CreatedAt:  profile.CreatedAt,
+		Id:         profile.ID,
+		Identifier: profile.Identifier,
+		Nonce:      strconv.FormatUint(uint64(profile.Nonce), 10),
+		ProfileDID: profile.ProfileDID,
+		Verifier:   profile.Verifier,
+	}
+}
+
+func toGetHolderCredentialsResponse(credentials []*domain.HolderCredential) (GetHolderCredentialsResponse, error) {
+	resp := make(GetHolderCredentialsResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		held, err := toHolderCredentialResponse(credential)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, held)
+	}
+	return resp, nil
+}
+
+func toHolderCredentialResponse(held *domain.HolderCredential) (HolderCredential, error) {
+	credential, err := held.GetCredential()
+	if err != nil {
+		return HolderCredential{}, err
+	}
+	return HolderCredential{
+		Credential:   toGetClaim200Response(credential),
+		CredentialId: held.CredentialID,
+		Expiration:   held.Expiration,
+		Id:           held.ID,
+		Issuer:       held.Issuer,
+		ProofTypes:   held.ProofTypes,
+		ReceivedAt:   held.ReceivedAt,
+		Revoked:      held.Revoked,
+		SchemaHash:   held.SchemaHash,
+		SchemaType:   held.SchemaType,
+		SchemaUrl:    held.SchemaURL,
+		Source:       HolderCredentialSource(held.Source),
+	}, nil
+}
+
+func toCredentialRequestResponse(req *domain.CredentialRequest) (CredentialRequest, error) {
+	subject, err := req.GetCredentialSubject()
+	if err != nil {
+		return CredentialRequest{}, err
+	}
+	return CredentialRequest{
+		ClaimId:           req.ClaimID,
+		CreatedAt:         req.CreatedAt,
+		CredentialSubject: subject,
+		Expiration:        req.Expiration,
+		Holder:            req.Holder,
+		Id:                req.ID,
+		Reason:            req.Reason,
+		SchemaType:        req.SchemaType,
+		SchemaUrl:         req.SchemaURL,
+		Status:            CredentialRequestStatus(req.Status),
+		ThreadID:          req.ThreadID,
+	}, nil
+}
+
+func toGetClaimQrCodeResponse(offer *protocol.CredentialsOfferMessage) GetClaimQrCodeResponse {
+	var resp GetClaimQrCodeResponse
+	for _, credential := range offer.Body.Credentials {
+		resp.Body.Credentials = append(resp.Body.Credentials, struct {
+			Description string `json:"description"`
+			Id          string `json:"id"`
+		}{Description: credential.Description, Id: credential.ID})
+	}
+	resp.Body.Url = offer.Body.URL
+	resp.From = offer.From
+	resp.Id = offer.ID
+	resp.Thid = offer.ThreadID
+	resp.To = offer.To
+	resp.Typ = string(offer.Typ)
+	resp.Type = string(offer.Type)
+	return resp
+}
+
+func toW3CCredential(credential *GetClaimResponse) (*verifiable.W3CCredential, error) {
+	raw, err := json.Marshal(credential)
+	if err != nil {
+		return nil, err
+	}
+	var w3c verifiable.W3CCredential
+	if err := json.Unmarshal(raw, &w3c); err != nil {
+		return nil, err
+	}
+	return &w3c, nil
+}
+
+func toVerifiablePresentationResponse(presentation *domain.VerifiablePresentation) (VerifiablePresentation, error) {
+	raw, err := json.Marshal(presentation)
+	if err != nil {
+		return nil, err
+	}
+	var resp VerifiablePresentation
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func toVerifiablePresentation(presentation VerifiablePresentation) (*domain.VerifiablePresentation, error) {
+	raw, err := json.Marshal(presentation)
+	if err != nil {
+		return nil, err
+	}
+	var vp domain.VerifiablePresentation
+	if err := json.Unmarshal(raw, &vp); err != nil {
+		return nil, err
+	}
+	return &vp, nil
+}
+
 func toGetClaimQrCode200JSONResponse(claim *domain.Claim, hostURL string) *GetClaimQrCode200JSONResponse {
 	id := uuid.New()
 	return &GetClaimQrCode200JSONResponse{
@@ -717,6 +1691,25 @@ func toGetClaimQrCode200JSONResponse(claim *domain.Claim, hostURL string) *GetCl
 	}
 }
 
+func toCredentialsOfferMessage(offer *GetClaimQrCodeResponse) protocol.CredentialsOfferMessage {
+	credentials := make([]protocol.CredentialOffer, 0, len(offer.Body.Credentials))
+	for _, credential := range offer.Body.Credentials {
+		credentials = append(credentials, protocol.CredentialOffer{ID: credential.Id, Description: credential.Description})
+	}
+	return protocol.CredentialsOfferMessage{
+		ID:       offer.Id,
+		Typ:      iden3comm.MediaType(offer.Typ),
+		Type:     iden3comm.ProtocolMessage(offer.Type),
+		ThreadID: offer.Thid,
+		Body: protocol.CredentialsOfferMessageBody{
+			URL:         offer.Body.Url,
+			Credentials: credentials,
+		},
+		From: offer.From,
+		To:   offer.To,
+	}
+}
+
 func documentation(w http.ResponseWriter, _ *http.Request) {
 	writeFile("/home/zakwan/wallet-service/api/spec.html", w)
 }
@@ -735,3 +1728,47 @@ func writeFile(path string, w http.ResponseWriter) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(f)
 }
+
+// toAuthorizationRequestResponse returns the authorization request message in the format the generate-proof endpoint takes.
+// The scope queries keep the challenge of the request, the proofs generated from them must be bound to it.
+func toAuthorizationRequestResponse(message protocol.AuthorizationRequestMessage) (GenerateProofRequest, error) {
+	scopes := make([]GenerateProofRequestScope, 0, len(message.Body.Scope))
+	for _, scope := range message.Body.Scope {
+		queryBytes, err := json.Marshal(scope.Query)
+		if err != nil {
+			return GenerateProofRequest{}, err
+		}
+		var query GenerateProofRequestQuery
+		if err := json.Unmarshal(queryBytes, &query); err != nil {
+			return GenerateProofRequest{}, err
+		}
+		challenge, err := domain.ScopeChallenge(scope)
+		if err != nil {
+			return GenerateProofRequest{}, err
+		}
+		if challenge != nil {
+			value := challenge.String()
+			query.Challenge = &value
+		}
+		scopes = append(scopes, GenerateProofRequestScope{
+			Id:        strconv.FormatUint(uint64(scope.ID), 10),
+			CircuitId: scope.CircuitID,
+			Query:     query,
+		})
+	}
+
+	return GenerateProofRequest{
+		Id:   message.ID,
+		Typ:  string(message.Typ),
+		Type: string(message.Type),
+		Thid: message.ThreadID,
+		From: message.From,
+		To:   message.To,
+		Body: GenerateProofRequestBody{
+			CallbackUrl: message.Body.CallbackURL,
+			Reason:      message.Body.Reason,
+			Message:     message.Body.Message,
+			Scope:       &scopes,
+		},
+	}, nil
+}
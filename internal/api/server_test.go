@@ -1,5 +1,31 @@
 package api
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/go-circuits"
+	auth "github.com/lastingasset/wallet-service/go-iden3-auth"
+	"github.com/lastingasset/wallet-service/iden3comm"
+	"github.com/lastingasset/wallet-service/iden3comm/packers"
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/config"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/services"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
 // import (
 // 	"context"
 // 	"encoding/json"
@@ -1370,7 +1396,7 @@ package api
 // 	expiration := int64(12345)
 
 // 	merklizedRootPosition := "value"
-// 	claim, err := claimsService.CreateClaim(context.Background(), ports.NewCreateClaimRequest(did, schema, credentialSubject, &expiration, typeC, nil, nil, &merklizedRootPosition))
+// 	claim, err := claimsService.CreateClaim(context.Background(), ports.NewCreateClaimRequest(did, schema, credentialSubject, &expiration, typeC, nil, nil, &merklizedRootPosition, nil))
 // 	assert.NoError(t, err)
 
 // 	type expected struct {
@@ -1505,3 +1531,202 @@ package api
 // 	tURL.RawQuery = q.Encode()
 // 	return tURL.String()
 // }
+
+func TestServer_GenerateProofChallenge(t *testing.T) {
+	ctx := context.Background()
+	server := &Server{cfg: &config.Configuration{}}
+	holder := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+
+	proofRequest := func(circuitID string, challenge *string) GenerateProofRequest {
+		return GenerateProofRequest{
+			Id:   "7f38a193-0918-4a48-9fac-36adfdb8b542",
+			Thid: "7f38a193-0918-4a48-9fac-36adfdb8b542",
+			From: "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5",
+			To:   holder,
+			Body: GenerateProofRequestBody{
+				Scope: &[]GenerateProofRequestScope{{
+					Id:        "1",
+					CircuitId: circuitID,
+					Query: GenerateProofRequestQuery{
+						AllowedIssuers:    []string{"*"},
+						Context:           "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+						Type:              "KYCAgeCredential",
+						CredentialSubject: map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}},
+						Challenge:         challenge,
+					},
+				}},
+			},
+		}
+	}
+
+	t.Run("should not prove an on chain query without challenge", func(t *testing.T) {
+		for _, circuitID := range []circuits.CircuitID{circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQuerySigV2OnChainCircuitID} {
+			body := proofRequest(string(circuitID), nil)
+			resp, err := server.GenerateProof(ctx, GenerateProofRequestObject{Identifier: PathIdentifier(holder), Body: &body})
+			require.NoError(t, err)
+			badRequest, ok := resp.(GenerateProof400JSONResponse)
+			require.True(t, ok, "%s: %T", circuitID, resp)
+			assert.Contains(t, badRequest.Message, "challenge")
+		}
+	})
+
+	t.Run("should carry the challenge of the request to the proof query", func(t *testing.T) {
+		challenge := "1234567890"
+		message, err := toAuthorizationRequestMessage(proofRequest(string(circuits.AtomicQueryMTPV2OnChainCircuitID), &challenge), "")
+		require.NoError(t, err)
+		require.Len(t, message.Body.Scope, 1)
+		assert.Equal(t, challenge, message.Body.Scope[0].Query[domain.RequestChallengeField])
+
+		q, err := toProofQuery(message.Body.Scope[0])
+		require.NoError(t, err)
+		require.NotNil(t, q.Challenge)
+		assert.Equal(t, challenge, q.Challenge.String())
+	})
+}
+
+// zkpPackerMock takes the JWZ responses of the wallets as plain messages, so no proof is verified on unpack
+type zkpPackerMock struct{}
+
+func (p *zkpPackerMock) Pack(payload []byte, _ iden3comm.PackerParams) ([]byte, error) {
+	return payload, nil
+}
+
+func (p *zkpPackerMock) Unpack(envelope []byte) (*iden3comm.BasicMessage, error) {
+	var msg iden3comm.BasicMessage
+	err := json.Unmarshal(envelope, &msg)
+	return &msg, err
+}
+
+func (p *zkpPackerMock) MediaType() iden3comm.MediaType {
+	return packers.MediaTypeZKPMessage
+}
+
+func TestServer_AuthorizationRequestCallback(t *testing.T) {
+	ctx := context.Background()
+	verifierDID := "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5"
+	holder := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	cfg := &config.Configuration{
+		Verifier: config.Verifier{
+			DefaultProfile: "default",
+			Profiles: map[string]config.VerifierProfile{
+				"default": {DID: verifierDID, CallbackURL: fmt.Sprintf("https://testing.env/v1/%s/callback", verifierDID)},
+			},
+		},
+	}
+
+	reqsRepo := repositories.NewAuthRequests()
+	templatesRepo := repositories.NewProofRequestTemplates()
+	template, err := domain.NewProofRequestTemplate("age-"+uuid.NewString(), string(circuits.AtomicQueryMTPV2CircuitID),
+		"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld", "KYCAgeCredential",
+		[]string{"*"}, map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}}, "age check")
+	require.NoError(t, err)
+	templateID, err := templatesRepo.Save(ctx, storage.Pgx, template)
+	require.NoError(t, err)
+
+	reqsService := services.NewAuthRequest(reqsRepo, nil, nil, nil, repositories.NewIdentityState(), templatesRepo, repositories.NewSybilNullifiers(), storage, services.AuthRequestCfg{Verifier: cfg.Verifier})
+	packageManager := iden3comm.NewPackageManager()
+	require.NoError(t, packageManager.RegisterPackers(&packers.PlainMessagePacker{}, &zkpPackerMock{}))
+	server := NewServer(cfg, nil, nil, nil, reqsService, nil, nil, nil, nil, nil, nil, NewPublisherMock(), packageManager, nil)
+	handler := getHandler(ctx, server)
+
+	// the wallet receives the request created from the template
+	body, err := json.Marshal(CreateAuthorizationRequestRequest{TemplateId: templateID, To: &holder})
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/%s/authorization-requests", verifierDID), bytes.NewReader(body))
+	require.NoError(t, err)
+	req.SetBasicAuth(authOk())
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var request CreateAuthorizationRequest201JSONResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &request))
+	callbackURL, err := url.Parse(request.Body.CallbackUrl)
+	require.NoError(t, err)
+	sessionID, err := uuid.Parse(callbackURL.Query().Get(domain.CallbackSessionParam))
+	require.NoError(t, err)
+
+	stored, err := reqsRepo.GetByID(ctx, storage.Pgx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, request.Thid, stored.ThreadID)
+	assert.Equal(t, domain.AuthRequestStatusCreated, stored.Status)
+
+	// callback posts the wallet response to the given url, without any valid proof
+	callback := func(t *testing.T, target string) *httptest.ResponseRecorder {
+		response, err := json.Marshal(protocol.AuthorizationResponseMessage{
+			ID:       uuid.NewString(),
+			Typ:      packers.MediaTypePlainMessage,
+			Type:     protocol.AuthorizationResponseMessageType,
+			ThreadID: request.Thid,
+			From:     holder,
+			To:       verifierDID,
+		})
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(string(response)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "text/plain")
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should not find the session of another callback url", func(t *testing.T) {
+		rr := callback(t, fmt.Sprintf("%s?%s=%s", callbackURL.Path, domain.CallbackSessionParam, uuid.NewString()))
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+
+	t.Run("should verify the response sent to the callback url of the request", func(t *testing.T) {
+		rr := callback(t, callbackURL.RequestURI())
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), services.ErrAuthResponseNotValid.Error())
+
+		answered, err := reqsRepo.GetByID(ctx, storage.Pgx, sessionID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AuthRequestStatusRejected, answered.Status)
+	})
+}
+
+func TestToAuthorizationRequestResponse_TemplateRoundTrip(t *testing.T) {
+	verifierDID := "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5"
+	holder := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+
+	for _, circuitID := range []circuits.CircuitID{circuits.AtomicQueryMTPV2CircuitID, circuits.AtomicQueryMTPV2OnChainCircuitID, circuits.AtomicQuerySigV2OnChainCircuitID} {
+		t.Run(string(circuitID), func(t *testing.T) {
+			template, err := domain.NewProofRequestTemplate("age", string(circuitID),
+				"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld", "KYCAgeCredential",
+				[]string{verifierDID}, map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}}, "age check")
+			require.NoError(t, err)
+			proofRequest, err := template.ProofRequest(1, domain.ProofRequestOverrides{})
+			require.NoError(t, err)
+
+			// the request as stored by the service, bound to its challenge
+			stored := auth.CreateAuthorizationRequestWithMessage("age check", "", verifierDID, "https://testing.env/callback")
+			stored.To = holder
+			stored.Body.Scope = append(stored.Body.Scope, proofRequest)
+			challenge, err := domain.NewRequestChallenge()
+			require.NoError(t, err)
+			domain.SetRequestChallenge(&stored, challenge)
+
+			// the wallet gets the request as json and sends it back to generate the proof
+			resp, err := toAuthorizationRequestResponse(stored)
+			require.NoError(t, err)
+			body, err := json.Marshal(resp)
+			require.NoError(t, err)
+			var received GenerateProofRequest
+			require.NoError(t, json.Unmarshal(body, &received))
+
+			message, err := toAuthorizationRequestMessage(received, stored.Body.CallbackURL)
+			require.NoError(t, err)
+			require.Len(t, message.Body.Scope, 1)
+			assert.Equal(t, stored.ThreadID, message.ThreadID)
+
+			q, err := toProofQuery(message.Body.Scope[0])
+			require.NoError(t, err)
+			require.NotNil(t, q.Challenge)
+			assert.Equal(t, challenge.String(), q.Challenge.String(), "the proof must be bound to the challenge of the stored request")
+			assert.Equal(t, []string{verifierDID}, q.AllowedIssuers)
+			assert.Equal(t, "KYCAgeCredential", q.Type)
+			assert.Contains(t, q.Req, "birthday")
+		})
+	}
+}
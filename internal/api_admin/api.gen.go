@@ -14,31 +14,231 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	uuid "github.com/google/uuid"
 )
 
 const (
 	BasicAuthScopes = "basicAuth.Scopes"
 )
 
+// Defines values for CredentialRequestStatus.
+const (
+	CredentialRequestStatusApproved  CredentialRequestStatus = "approved"
+	CredentialRequestStatusApproving CredentialRequestStatus = "approving"
+	CredentialRequestStatusPending   CredentialRequestStatus = "pending"
+	CredentialRequestStatusRejected  CredentialRequestStatus = "rejected"
+)
+
+// Defines values for IssuanceAuditAction.
+const (
+	IssuanceAuditActionApproved  IssuanceAuditAction = "approved"
+	IssuanceAuditActionDenied    IssuanceAuditAction = "denied"
+	IssuanceAuditActionRejected  IssuanceAuditAction = "rejected"
+	IssuanceAuditActionRequested IssuanceAuditAction = "requested"
+)
+
+// Defines values for IssuancePolicyAction.
+const (
+	IssuancePolicyActionAutoApprove IssuancePolicyAction = "auto-approve"
+	IssuancePolicyActionDeny        IssuancePolicyAction = "deny"
+	IssuancePolicyActionManual      IssuancePolicyAction = "manual"
+)
+
+// Defines values for IssuancePolicyRequestAction.
+const (
+	IssuancePolicyRequestActionAutoApprove IssuancePolicyRequestAction = "auto-approve"
+	IssuancePolicyRequestActionDeny        IssuancePolicyRequestAction = "deny"
+	IssuancePolicyRequestActionManual      IssuancePolicyRequestAction = "manual"
+)
+
+// Defines values for GetCredentialRequestsParamsStatus.
+const (
+	Approved  GetCredentialRequestsParamsStatus = "approved"
+	Approving GetCredentialRequestsParamsStatus = "approving"
+	Pending   GetCredentialRequestsParamsStatus = "pending"
+	Rejected  GetCredentialRequestsParamsStatus = "rejected"
+)
+
+// CredentialRequest defines model for CredentialRequest.
+type CredentialRequest struct {
+	ClaimId           *uuid.UUID              `json:"claimId,omitempty"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	CredentialSubject map[string]interface{}  `json:"credentialSubject"`
+	Expiration        *time.Time              `json:"expiration,omitempty"`
+	Holder            string                  `json:"holder"`
+	Id                uuid.UUID               `json:"id"`
+	Reason            *string                 `json:"reason,omitempty"`
+	SchemaType        string                  `json:"schemaType"`
+	SchemaUrl         string                  `json:"schemaUrl"`
+	Status            CredentialRequestStatus `json:"status"`
+	ThreadID          string                  `json:"threadID"`
+}
+
+// CredentialRequestStatus defines model for CredentialRequest.Status.
+type CredentialRequestStatus string
+
 // GenericErrorMessage defines model for GenericErrorMessage.
 type GenericErrorMessage struct {
 	Message string `json:"message"`
 }
 
+// GenericMessage defines model for GenericMessage.
+type GenericMessage struct {
+	Message string `json:"message"`
+}
+
+// GetCredentialRequestsResponse defines model for GetCredentialRequestsResponse.
+type GetCredentialRequestsResponse = []CredentialRequest
+
+// GetIssuanceAuditResponse defines model for GetIssuanceAuditResponse.
+type GetIssuanceAuditResponse = []IssuanceAudit
+
+// GetIssuancePoliciesResponse defines model for GetIssuancePoliciesResponse.
+type GetIssuancePoliciesResponse = []IssuancePolicy
+
+// GetProofRequestTemplatesResponse defines model for GetProofRequestTemplatesResponse.
+type GetProofRequestTemplatesResponse = []ProofRequestTemplate
+
 // Health defines model for Health.
 type Health map[string]bool
 
+// IssuanceAudit defines model for IssuanceAudit.
+type IssuanceAudit struct {
+	Action     IssuanceAuditAction `json:"action"`
+	Actor      string              `json:"actor"`
+	ClaimId    *uuid.UUID          `json:"claimId,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	Holder     string              `json:"holder"`
+	Id         uuid.UUID           `json:"id"`
+	PolicyId   *uuid.UUID          `json:"policyId,omitempty"`
+	Reason     *string             `json:"reason,omitempty"`
+	RequestId  *uuid.UUID          `json:"requestId,omitempty"`
+	SchemaType string              `json:"schemaType"`
+}
+
+// IssuanceAuditAction defines model for IssuanceAudit.Action.
+type IssuanceAuditAction string
+
+// IssuancePolicy defines model for IssuancePolicy.
+type IssuancePolicy struct {
+	Action           IssuancePolicyAction   `json:"action"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	FieldConstraints map[string]interface{} `json:"fieldConstraints"`
+	Id               uuid.UUID              `json:"id"`
+	Priority         int                    `json:"priority"`
+	SchemaType       string                 `json:"schemaType"`
+	SubjectAllowlist []string               `json:"subjectAllowlist"`
+}
+
+// IssuancePolicyAction defines model for IssuancePolicy.Action.
+type IssuancePolicyAction string
+
+// IssuancePolicyRequest defines model for IssuancePolicyRequest.
+type IssuancePolicyRequest struct {
+	Action           IssuancePolicyRequestAction `json:"action"`
+	FieldConstraints *map[string]interface{}     `json:"fieldConstraints,omitempty"`
+	Priority         *int                        `json:"priority,omitempty"`
+	SchemaType       *string                     `json:"schemaType,omitempty"`
+	SubjectAllowlist *[]string                   `json:"subjectAllowlist,omitempty"`
+}
+
+// IssuancePolicyRequestAction defines model for IssuancePolicyRequest.Action.
+type IssuancePolicyRequestAction string
+
+// ProofRequestTemplate defines model for ProofRequestTemplate.
+type ProofRequestTemplate struct {
+	AllowedIssuers    []string                `json:"allowedIssuers"`
+	CircuitId         string                  `json:"circuitId"`
+	Context           string                  `json:"context"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	CredentialSubject *map[string]interface{} `json:"credentialSubject,omitempty"`
+	Id                uuid.UUID               `json:"id"`
+	ModifiedAt        time.Time               `json:"modifiedAt"`
+	Name              string                  `json:"name"`
+	Reason            string                  `json:"reason"`
+	Type              string                  `json:"type"`
+}
+
+// ProofRequestTemplateRequest defines model for ProofRequestTemplateRequest.
+type ProofRequestTemplateRequest struct {
+	AllowedIssuers    *[]string               `json:"allowedIssuers,omitempty"`
+	CircuitId         string                  `json:"circuitId"`
+	Context           string                  `json:"context"`
+	CredentialSubject *map[string]interface{} `json:"credentialSubject,omitempty"`
+	Name              string                  `json:"name"`
+	Reason            *string                 `json:"reason,omitempty"`
+	Type              string                  `json:"type"`
+}
+
+// RejectCredentialRequestRequest defines model for RejectCredentialRequestRequest.
+type RejectCredentialRequestRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
 // SayHi defines model for SayHi.
 type SayHi struct {
 	Message string `json:"message"`
 }
 
+// PathCredentialRequest defines model for pathCredentialRequest.
+type PathCredentialRequest = uuid.UUID
+
+// PathIdentifier defines model for pathIdentifier.
+type PathIdentifier = string
+
+// PathIssuancePolicy defines model for pathIssuancePolicy.
+type PathIssuancePolicy = uuid.UUID
+
+// PathProofRequestTemplate defines model for pathProofRequestTemplate.
+type PathProofRequestTemplate = uuid.UUID
+
+// N400 defines model for 400.
+type N400 = GenericErrorMessage
+
+// N401 defines model for 401.
+type N401 = GenericErrorMessage
+
+// N404 defines model for 404.
+type N404 = GenericErrorMessage
+
+// N409 defines model for 409.
+type N409 = GenericErrorMessage
+
 // N500 defines model for 500.
 type N500 = GenericErrorMessage
 
+// GetCredentialRequestsParams defines parameters for GetCredentialRequests.
+type GetCredentialRequestsParams struct {
+	// Status Filter per status of the request. Example - pending
+	Status *GetCredentialRequestsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// GetCredentialRequestsParamsStatus defines parameters for GetCredentialRequests.
+type GetCredentialRequestsParamsStatus string
+
+// GetIssuanceAuditParams defines parameters for GetIssuanceAudit.
+type GetIssuanceAuditParams struct {
+	// RequestId Filter per credential request
+	RequestId *uuid.UUID `form:"requestId,omitempty" json:"requestId,omitempty"`
+}
+
+// CreateProofRequestTemplateJSONRequestBody defines body for CreateProofRequestTemplate for application/json ContentType.
+type CreateProofRequestTemplateJSONRequestBody = ProofRequestTemplateRequest
+
+// UpdateProofRequestTemplateJSONRequestBody defines body for UpdateProofRequestTemplate for application/json ContentType.
+type UpdateProofRequestTemplateJSONRequestBody = ProofRequestTemplateRequest
+
+// RejectCredentialRequestJSONRequestBody defines body for RejectCredentialRequest for application/json ContentType.
+type RejectCredentialRequestJSONRequestBody = RejectCredentialRequestRequest
+
+// CreateIssuancePolicyJSONRequestBody defines body for CreateIssuancePolicy for application/json ContentType.
+type CreateIssuancePolicyJSONRequestBody = IssuancePolicyRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get the documentation
@@ -53,6 +253,42 @@ type ServerInterface interface {
 	// Healthcheck
 	// (GET /status)
 	Health(w http.ResponseWriter, r *http.Request)
+	// Get Proof Request Templates
+	// (GET /v1/proof-request-templates)
+	GetProofRequestTemplates(w http.ResponseWriter, r *http.Request)
+	// Create Proof Request Template
+	// (POST /v1/proof-request-templates)
+	CreateProofRequestTemplate(w http.ResponseWriter, r *http.Request)
+	// Delete Proof Request Template
+	// (DELETE /v1/proof-request-templates/{id})
+	DeleteProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate)
+	// Get Proof Request Template
+	// (GET /v1/proof-request-templates/{id})
+	GetProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate)
+	// Update Proof Request Template
+	// (PUT /v1/proof-request-templates/{id})
+	UpdateProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate)
+	// Get Credential Requests
+	// (GET /v1/{identifier}/credential-requests)
+	GetCredentialRequests(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetCredentialRequestsParams)
+	// Approve Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/approve)
+	ApproveCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest)
+	// Reject Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/reject)
+	RejectCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest)
+	// Get Issuance Audit
+	// (GET /v1/{identifier}/issuance-audit)
+	GetIssuanceAudit(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetIssuanceAuditParams)
+	// Get Issuance Policies
+	// (GET /v1/{identifier}/issuance-policies)
+	GetIssuancePolicies(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Create Issuance Policy
+	// (POST /v1/{identifier}/issuance-policies)
+	CreateIssuancePolicy(w http.ResponseWriter, r *http.Request, identifier PathIdentifier)
+	// Delete Issuance Policy
+	// (DELETE /v1/{identifier}/issuance-policies/{id})
+	DeleteIssuancePolicy(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathIssuancePolicy)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -126,211 +362,1234 @@ func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetProofRequestTemplates operation middleware
+func (siw *ServerInterfaceWrapper) GetProofRequestTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProofRequestTemplates(w, r)
+	})
 
-type UnmarshallingParamError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *UnmarshallingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *UnmarshallingParamError) Unwrap() error {
-	return e.Err
-}
+// CreateProofRequestTemplate operation middleware
+func (siw *ServerInterfaceWrapper) CreateProofRequestTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type RequiredParamError struct {
-	ParamName string
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProofRequestTemplate(w, r)
+	})
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// DeleteProofRequestTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProofRequestTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "id" -------------
+	var id PathProofRequestTemplate
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProofRequestTemplate(w, r, id)
+	})
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
+// GetProofRequestTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetProofRequestTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathProofRequestTemplate
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProofRequestTemplate(w, r, id)
 	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
+// UpdateProofRequestTemplate operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProofRequestTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PathProofRequestTemplate
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateProofRequestTemplate(w, r, id)
 	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetCredentialRequests operation middleware
+func (siw *ServerInterfaceWrapper) GetCredentialRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCredentialRequestsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/say-hi", wrapper.SayHi)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/static/docs/api_admin/api.yaml", wrapper.GetYaml)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/status", wrapper.Health)
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCredentialRequests(w, r, identifier, params)
 	})
 
-	return r
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type N500JSONResponse GenericErrorMessage
+// ApproveCredentialRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveCredentialRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetDocumentationRequestObject struct {
-}
+	var err error
 
-type GetDocumentationResponseObject interface {
-	VisitGetDocumentationResponse(w http.ResponseWriter) error
-}
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
 
-type GetDocumentation200Response struct {
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
 
-func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	// ------------- Path parameter "id" -------------
+	var id PathCredentialRequest
 
-type SayHiRequestObject struct {
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type SayHiResponseObject interface {
-	VisitSayHiResponse(w http.ResponseWriter) error
-}
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
 
-type SayHi200JSONResponse SayHi
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveCredentialRequest(w, r, identifier, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RejectCredentialRequest operation middleware
+func (siw *ServerInterfaceWrapper) RejectCredentialRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id PathCredentialRequest
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectCredentialRequest(w, r, identifier, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIssuanceAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetIssuanceAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIssuanceAuditParams
+
+	// ------------- Optional query parameter "requestId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "requestId", r.URL.Query(), &params.RequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		return
+	}
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIssuanceAudit(w, r, identifier, params)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIssuancePolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetIssuancePolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIssuancePolicies(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateIssuancePolicy operation middleware
+func (siw *ServerInterfaceWrapper) CreateIssuancePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateIssuancePolicy(w, r, identifier)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteIssuancePolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIssuancePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "identifier" -------------
+	var identifier PathIdentifier
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "identifier", runtime.ParamLocationPath, chi.URLParam(r, "identifier"), &identifier)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "identifier", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id PathIssuancePolicy
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{""})
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteIssuancePolicy(w, r, identifier, id)
+	})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshallingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshallingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshalling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshallingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/", wrapper.GetDocumentation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/say-hi", wrapper.SayHi)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/static/docs/api_admin/api.yaml", wrapper.GetYaml)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/status", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/proof-request-templates", wrapper.GetProofRequestTemplates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/proof-request-templates", wrapper.CreateProofRequestTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/proof-request-templates/{id}", wrapper.DeleteProofRequestTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/proof-request-templates/{id}", wrapper.GetProofRequestTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/proof-request-templates/{id}", wrapper.UpdateProofRequestTemplate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/credential-requests", wrapper.GetCredentialRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/credential-requests/{id}/approve", wrapper.ApproveCredentialRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/credential-requests/{id}/reject", wrapper.RejectCredentialRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/issuance-audit", wrapper.GetIssuanceAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/{identifier}/issuance-policies", wrapper.GetIssuancePolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/{identifier}/issuance-policies", wrapper.CreateIssuancePolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/{identifier}/issuance-policies/{id}", wrapper.DeleteIssuancePolicy)
+	})
+
+	return r
+}
+
+type N400JSONResponse GenericErrorMessage
+
+type N401JSONResponse GenericErrorMessage
+
+type N404JSONResponse GenericErrorMessage
+
+type N409JSONResponse GenericErrorMessage
+
+type N500JSONResponse GenericErrorMessage
+
+type GetDocumentationRequestObject struct {
+}
+
+type GetDocumentationResponseObject interface {
+	VisitGetDocumentationResponse(w http.ResponseWriter) error
+}
+
+type GetDocumentation200Response struct {
+}
+
+func (response GetDocumentation200Response) VisitGetDocumentationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type SayHiRequestObject struct {
+}
+
+type SayHiResponseObject interface {
+	VisitSayHiResponse(w http.ResponseWriter) error
+}
+
+type SayHi200JSONResponse SayHi
+
+func (response SayHi200JSONResponse) VisitSayHiResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SayHi500JSONResponse struct{ N500JSONResponse }
+
+func (response SayHi500JSONResponse) VisitSayHiResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetYamlRequestObject struct {
+}
+
+type GetYamlResponseObject interface {
+	VisitGetYamlResponse(w http.ResponseWriter) error
+}
+
+type GetYaml200Response struct {
+}
+
+func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse Health
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Health500JSONResponse struct{ N500JSONResponse }
+
+func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplatesRequestObject struct {
+}
+
+type GetProofRequestTemplatesResponseObject interface {
+	VisitGetProofRequestTemplatesResponse(w http.ResponseWriter) error
+}
+
+type GetProofRequestTemplates200JSONResponse GetProofRequestTemplatesResponse
+
+func (response GetProofRequestTemplates200JSONResponse) VisitGetProofRequestTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplates401JSONResponse struct{ N401JSONResponse }
+
+func (response GetProofRequestTemplates401JSONResponse) VisitGetProofRequestTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplates500JSONResponse struct{ N500JSONResponse }
+
+func (response GetProofRequestTemplates500JSONResponse) VisitGetProofRequestTemplatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofRequestTemplateRequestObject struct {
+	Body *CreateProofRequestTemplateJSONRequestBody
+}
+
+type CreateProofRequestTemplateResponseObject interface {
+	VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error
+}
+
+type CreateProofRequestTemplate201JSONResponse ProofRequestTemplate
+
+func (response CreateProofRequestTemplate201JSONResponse) VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofRequestTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateProofRequestTemplate400JSONResponse) VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofRequestTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response CreateProofRequestTemplate401JSONResponse) VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofRequestTemplate409JSONResponse struct{ N409JSONResponse }
+
+func (response CreateProofRequestTemplate409JSONResponse) VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProofRequestTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response CreateProofRequestTemplate500JSONResponse) VisitCreateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProofRequestTemplateRequestObject struct {
+	Id PathProofRequestTemplate `json:"id"`
+}
+
+type DeleteProofRequestTemplateResponseObject interface {
+	VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error
+}
+
+type DeleteProofRequestTemplate200JSONResponse GenericMessage
+
+func (response DeleteProofRequestTemplate200JSONResponse) VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProofRequestTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteProofRequestTemplate400JSONResponse) VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProofRequestTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteProofRequestTemplate401JSONResponse) VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProofRequestTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response DeleteProofRequestTemplate404JSONResponse) VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProofRequestTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response DeleteProofRequestTemplate500JSONResponse) VisitDeleteProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplateRequestObject struct {
+	Id PathProofRequestTemplate `json:"id"`
+}
+
+type GetProofRequestTemplateResponseObject interface {
+	VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error
+}
+
+type GetProofRequestTemplate200JSONResponse ProofRequestTemplate
+
+func (response GetProofRequestTemplate200JSONResponse) VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response GetProofRequestTemplate400JSONResponse) VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response GetProofRequestTemplate401JSONResponse) VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response GetProofRequestTemplate404JSONResponse) VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProofRequestTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response GetProofRequestTemplate500JSONResponse) VisitGetProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplateRequestObject struct {
+	Id   PathProofRequestTemplate `json:"id"`
+	Body *UpdateProofRequestTemplateJSONRequestBody
+}
+
+type UpdateProofRequestTemplateResponseObject interface {
+	VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error
+}
+
+type UpdateProofRequestTemplate200JSONResponse ProofRequestTemplate
+
+func (response UpdateProofRequestTemplate200JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplate400JSONResponse struct{ N400JSONResponse }
+
+func (response UpdateProofRequestTemplate400JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplate401JSONResponse struct{ N401JSONResponse }
+
+func (response UpdateProofRequestTemplate401JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplate404JSONResponse struct{ N404JSONResponse }
+
+func (response UpdateProofRequestTemplate404JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplate409JSONResponse struct{ N409JSONResponse }
+
+func (response UpdateProofRequestTemplate409JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateProofRequestTemplate500JSONResponse struct{ N500JSONResponse }
+
+func (response UpdateProofRequestTemplate500JSONResponse) VisitUpdateProofRequestTemplateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequestsRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetCredentialRequestsParams
+}
+
+type GetCredentialRequestsResponseObject interface {
+	VisitGetCredentialRequestsResponse(w http.ResponseWriter) error
+}
+
+type GetCredentialRequests200JSONResponse GetCredentialRequestsResponse
+
+func (response GetCredentialRequests200JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCredentialRequests400JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCredentialRequests401JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCredentialRequests500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCredentialRequests500JSONResponse) VisitGetCredentialRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequestRequestObject struct {
+	Identifier PathIdentifier        `json:"identifier"`
+	Id         PathCredentialRequest `json:"id"`
+}
+
+type ApproveCredentialRequestResponseObject interface {
+	VisitApproveCredentialRequestResponse(w http.ResponseWriter) error
+}
+
+type ApproveCredentialRequest200JSONResponse CredentialRequest
+
+func (response ApproveCredentialRequest200JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest400JSONResponse struct{ N400JSONResponse }
+
+func (response ApproveCredentialRequest400JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest401JSONResponse struct{ N401JSONResponse }
+
+func (response ApproveCredentialRequest401JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest404JSONResponse struct{ N404JSONResponse }
+
+func (response ApproveCredentialRequest404JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest409JSONResponse struct{ N409JSONResponse }
+
+func (response ApproveCredentialRequest409JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCredentialRequest500JSONResponse struct{ N500JSONResponse }
+
+func (response ApproveCredentialRequest500JSONResponse) VisitApproveCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequestRequestObject struct {
+	Identifier PathIdentifier        `json:"identifier"`
+	Id         PathCredentialRequest `json:"id"`
+	Body       *RejectCredentialRequestJSONRequestBody
+}
+
+type RejectCredentialRequestResponseObject interface {
+	VisitRejectCredentialRequestResponse(w http.ResponseWriter) error
+}
+
+type RejectCredentialRequest200JSONResponse CredentialRequest
+
+func (response RejectCredentialRequest200JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest400JSONResponse struct{ N400JSONResponse }
+
+func (response RejectCredentialRequest400JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest401JSONResponse struct{ N401JSONResponse }
+
+func (response RejectCredentialRequest401JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest404JSONResponse struct{ N404JSONResponse }
+
+func (response RejectCredentialRequest404JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest409JSONResponse struct{ N409JSONResponse }
+
+func (response RejectCredentialRequest409JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectCredentialRequest500JSONResponse struct{ N500JSONResponse }
+
+func (response RejectCredentialRequest500JSONResponse) VisitRejectCredentialRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuanceAuditRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Params     GetIssuanceAuditParams
+}
+
+type GetIssuanceAuditResponseObject interface {
+	VisitGetIssuanceAuditResponse(w http.ResponseWriter) error
+}
+
+type GetIssuanceAudit200JSONResponse GetIssuanceAuditResponse
+
+func (response GetIssuanceAudit200JSONResponse) VisitGetIssuanceAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuanceAudit400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIssuanceAudit400JSONResponse) VisitGetIssuanceAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuanceAudit401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIssuanceAudit401JSONResponse) VisitGetIssuanceAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuanceAudit500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIssuanceAudit500JSONResponse) VisitGetIssuanceAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuancePoliciesRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+}
+
+type GetIssuancePoliciesResponseObject interface {
+	VisitGetIssuancePoliciesResponse(w http.ResponseWriter) error
+}
+
+type GetIssuancePolicies200JSONResponse GetIssuancePoliciesResponse
+
+func (response GetIssuancePolicies200JSONResponse) VisitGetIssuancePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuancePolicies400JSONResponse struct{ N400JSONResponse }
+
+func (response GetIssuancePolicies400JSONResponse) VisitGetIssuancePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuancePolicies401JSONResponse struct{ N401JSONResponse }
+
+func (response GetIssuancePolicies401JSONResponse) VisitGetIssuancePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIssuancePolicies500JSONResponse struct{ N500JSONResponse }
+
+func (response GetIssuancePolicies500JSONResponse) VisitGetIssuancePoliciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIssuancePolicyRequestObject struct {
+	Identifier PathIdentifier `json:"identifier"`
+	Body       *CreateIssuancePolicyJSONRequestBody
+}
+
+type CreateIssuancePolicyResponseObject interface {
+	VisitCreateIssuancePolicyResponse(w http.ResponseWriter) error
+}
+
+type CreateIssuancePolicy201JSONResponse IssuancePolicy
+
+func (response CreateIssuancePolicy201JSONResponse) VisitCreateIssuancePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIssuancePolicy400JSONResponse struct{ N400JSONResponse }
+
+func (response CreateIssuancePolicy400JSONResponse) VisitCreateIssuancePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateIssuancePolicy401JSONResponse struct{ N401JSONResponse }
 
-func (response SayHi200JSONResponse) VisitSayHiResponse(w http.ResponseWriter) error {
+func (response CreateIssuancePolicy401JSONResponse) VisitCreateIssuancePolicyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SayHi500JSONResponse struct{ N500JSONResponse }
+type CreateIssuancePolicy500JSONResponse struct{ N500JSONResponse }
 
-func (response SayHi500JSONResponse) VisitSayHiResponse(w http.ResponseWriter) error {
+func (response CreateIssuancePolicy500JSONResponse) VisitCreateIssuancePolicyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetYamlRequestObject struct {
+type DeleteIssuancePolicyRequestObject struct {
+	Identifier PathIdentifier     `json:"identifier"`
+	Id         PathIssuancePolicy `json:"id"`
 }
 
-type GetYamlResponseObject interface {
-	VisitGetYamlResponse(w http.ResponseWriter) error
+type DeleteIssuancePolicyResponseObject interface {
+	VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error
 }
 
-type GetYaml200Response struct {
-}
+type DeleteIssuancePolicy200JSONResponse GenericMessage
 
-func (response GetYaml200Response) VisitGetYamlResponse(w http.ResponseWriter) error {
+func (response DeleteIssuancePolicy200JSONResponse) VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type HealthRequestObject struct {
+type DeleteIssuancePolicy400JSONResponse struct{ N400JSONResponse }
+
+func (response DeleteIssuancePolicy400JSONResponse) VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type HealthResponseObject interface {
-	VisitHealthResponse(w http.ResponseWriter) error
+type DeleteIssuancePolicy401JSONResponse struct{ N401JSONResponse }
+
+func (response DeleteIssuancePolicy401JSONResponse) VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type Health200JSONResponse Health
+type DeleteIssuancePolicy404JSONResponse struct{ N404JSONResponse }
 
-func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+func (response DeleteIssuancePolicy404JSONResponse) VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Health500JSONResponse struct{ N500JSONResponse }
+type DeleteIssuancePolicy500JSONResponse struct{ N500JSONResponse }
 
-func (response Health500JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+func (response DeleteIssuancePolicy500JSONResponse) VisitDeleteIssuancePolicyResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -351,6 +1610,42 @@ type StrictServerInterface interface {
 	// Healthcheck
 	// (GET /status)
 	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+	// Get Proof Request Templates
+	// (GET /v1/proof-request-templates)
+	GetProofRequestTemplates(ctx context.Context, request GetProofRequestTemplatesRequestObject) (GetProofRequestTemplatesResponseObject, error)
+	// Create Proof Request Template
+	// (POST /v1/proof-request-templates)
+	CreateProofRequestTemplate(ctx context.Context, request CreateProofRequestTemplateRequestObject) (CreateProofRequestTemplateResponseObject, error)
+	// Delete Proof Request Template
+	// (DELETE /v1/proof-request-templates/{id})
+	DeleteProofRequestTemplate(ctx context.Context, request DeleteProofRequestTemplateRequestObject) (DeleteProofRequestTemplateResponseObject, error)
+	// Get Proof Request Template
+	// (GET /v1/proof-request-templates/{id})
+	GetProofRequestTemplate(ctx context.Context, request GetProofRequestTemplateRequestObject) (GetProofRequestTemplateResponseObject, error)
+	// Update Proof Request Template
+	// (PUT /v1/proof-request-templates/{id})
+	UpdateProofRequestTemplate(ctx context.Context, request UpdateProofRequestTemplateRequestObject) (UpdateProofRequestTemplateResponseObject, error)
+	// Get Credential Requests
+	// (GET /v1/{identifier}/credential-requests)
+	GetCredentialRequests(ctx context.Context, request GetCredentialRequestsRequestObject) (GetCredentialRequestsResponseObject, error)
+	// Approve Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/approve)
+	ApproveCredentialRequest(ctx context.Context, request ApproveCredentialRequestRequestObject) (ApproveCredentialRequestResponseObject, error)
+	// Reject Credential Request
+	// (POST /v1/{identifier}/credential-requests/{id}/reject)
+	RejectCredentialRequest(ctx context.Context, request RejectCredentialRequestRequestObject) (RejectCredentialRequestResponseObject, error)
+	// Get Issuance Audit
+	// (GET /v1/{identifier}/issuance-audit)
+	GetIssuanceAudit(ctx context.Context, request GetIssuanceAuditRequestObject) (GetIssuanceAuditResponseObject, error)
+	// Get Issuance Policies
+	// (GET /v1/{identifier}/issuance-policies)
+	GetIssuancePolicies(ctx context.Context, request GetIssuancePoliciesRequestObject) (GetIssuancePoliciesResponseObject, error)
+	// Create Issuance Policy
+	// (POST /v1/{identifier}/issuance-policies)
+	CreateIssuancePolicy(ctx context.Context, request CreateIssuancePolicyRequestObject) (CreateIssuancePolicyResponseObject, error)
+	// Delete Issuance Policy
+	// (DELETE /v1/{identifier}/issuance-policies/{id})
+	DeleteIssuancePolicy(ctx context.Context, request DeleteIssuancePolicyRequestObject) (DeleteIssuancePolicyResponseObject, error)
 }
 
 type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, args interface{}) (interface{}, error)
@@ -479,21 +1774,398 @@ func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetProofRequestTemplates operation middleware
+func (sh *strictHandler) GetProofRequestTemplates(w http.ResponseWriter, r *http.Request) {
+	var request GetProofRequestTemplatesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProofRequestTemplates(ctx, request.(GetProofRequestTemplatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProofRequestTemplates")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProofRequestTemplatesResponseObject); ok {
+		if err := validResponse.VisitGetProofRequestTemplatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateProofRequestTemplate operation middleware
+func (sh *strictHandler) CreateProofRequestTemplate(w http.ResponseWriter, r *http.Request) {
+	var request CreateProofRequestTemplateRequestObject
+
+	var body CreateProofRequestTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProofRequestTemplate(ctx, request.(CreateProofRequestTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProofRequestTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateProofRequestTemplateResponseObject); ok {
+		if err := validResponse.VisitCreateProofRequestTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteProofRequestTemplate operation middleware
+func (sh *strictHandler) DeleteProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate) {
+	var request DeleteProofRequestTemplateRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProofRequestTemplate(ctx, request.(DeleteProofRequestTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProofRequestTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteProofRequestTemplateResponseObject); ok {
+		if err := validResponse.VisitDeleteProofRequestTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetProofRequestTemplate operation middleware
+func (sh *strictHandler) GetProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate) {
+	var request GetProofRequestTemplateRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProofRequestTemplate(ctx, request.(GetProofRequestTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProofRequestTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProofRequestTemplateResponseObject); ok {
+		if err := validResponse.VisitGetProofRequestTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// UpdateProofRequestTemplate operation middleware
+func (sh *strictHandler) UpdateProofRequestTemplate(w http.ResponseWriter, r *http.Request, id PathProofRequestTemplate) {
+	var request UpdateProofRequestTemplateRequestObject
+
+	request.Id = id
+
+	var body UpdateProofRequestTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateProofRequestTemplate(ctx, request.(UpdateProofRequestTemplateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateProofRequestTemplate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateProofRequestTemplateResponseObject); ok {
+		if err := validResponse.VisitUpdateProofRequestTemplateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetCredentialRequests operation middleware
+func (sh *strictHandler) GetCredentialRequests(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetCredentialRequestsParams) {
+	var request GetCredentialRequestsRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCredentialRequests(ctx, request.(GetCredentialRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCredentialRequests")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCredentialRequestsResponseObject); ok {
+		if err := validResponse.VisitGetCredentialRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// ApproveCredentialRequest operation middleware
+func (sh *strictHandler) ApproveCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest) {
+	var request ApproveCredentialRequestRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveCredentialRequest(ctx, request.(ApproveCredentialRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveCredentialRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveCredentialRequestResponseObject); ok {
+		if err := validResponse.VisitApproveCredentialRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// RejectCredentialRequest operation middleware
+func (sh *strictHandler) RejectCredentialRequest(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathCredentialRequest) {
+	var request RejectCredentialRequestRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	var body RejectCredentialRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RejectCredentialRequest(ctx, request.(RejectCredentialRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RejectCredentialRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RejectCredentialRequestResponseObject); ok {
+		if err := validResponse.VisitRejectCredentialRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIssuanceAudit operation middleware
+func (sh *strictHandler) GetIssuanceAudit(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, params GetIssuanceAuditParams) {
+	var request GetIssuanceAuditRequestObject
+
+	request.Identifier = identifier
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIssuanceAudit(ctx, request.(GetIssuanceAuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIssuanceAudit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIssuanceAuditResponseObject); ok {
+		if err := validResponse.VisitGetIssuanceAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// GetIssuancePolicies operation middleware
+func (sh *strictHandler) GetIssuancePolicies(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request GetIssuancePoliciesRequestObject
+
+	request.Identifier = identifier
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIssuancePolicies(ctx, request.(GetIssuancePoliciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIssuancePolicies")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIssuancePoliciesResponseObject); ok {
+		if err := validResponse.VisitGetIssuancePoliciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// CreateIssuancePolicy operation middleware
+func (sh *strictHandler) CreateIssuancePolicy(w http.ResponseWriter, r *http.Request, identifier PathIdentifier) {
+	var request CreateIssuancePolicyRequestObject
+
+	request.Identifier = identifier
+
+	var body CreateIssuancePolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateIssuancePolicy(ctx, request.(CreateIssuancePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateIssuancePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateIssuancePolicyResponseObject); ok {
+		if err := validResponse.VisitCreateIssuancePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
+// DeleteIssuancePolicy operation middleware
+func (sh *strictHandler) DeleteIssuancePolicy(w http.ResponseWriter, r *http.Request, identifier PathIdentifier, id PathIssuancePolicy) {
+	var request DeleteIssuancePolicyRequestObject
+
+	request.Identifier = identifier
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteIssuancePolicy(ctx, request.(DeleteIssuancePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteIssuancePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteIssuancePolicyResponseObject); ok {
+		if err := validResponse.VisitDeleteIssuancePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("Unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RVQW/zNgz9Kxq3o786+z7s4lvQDk2AFiiQXYYuGBiZidXJkibRRY3C/32g7CU1lgxr",
-	"0V1aWaIe9fgemVfQvg3ekeME1StESsG7RPnjp8VC/mnvmBzLEkOwRiMb78qn5J3sJd1Qi7L6IdIeKvi+",
-	"PGGW42kqb8lRNPrnGH28p5TwQDAMQwE1JR1NEEioYO2YokOrNhSfKSqSeJC4CUjynMOqXiFEHyiyGR/f",
-	"ng7oBdtgCSrY+Ja4Me6gGgyBHBTAfZCTxNG4Q84U6c/ORKqhejyibI+BfvdEmmEoYEVoucllqWsjBNA+",
-	"zN4wXdl5bwmd3JmDFPDyxbeGqQ3cQ7VHm2goYIP9yvxHRivz3cdJnM0vtSbdRcP9Rmo+Jt9hMnrZjXyz",
-	"FpmZ7J7yN8xhVNW4vZfIubo3XnctOc4GUnsfFTekNmT3auUTU63WN2pZt8apB4u897H9LWtkeJRvHvl3",
-	"DBTwTDGNORZXP14tpNQ+kMNgoIJveauAgNxkMqX8OVC2tFQ4v2ddQwW3xLNHQjFvia9jS8xppU5rSkmh",
-	"q1Uk7qJLmVg9o2ucWv1yfye0W+TR013bYuzHvP+8kvUxU0dAxbHL6pQJ+y+NuchhtM/5h39KL48JznTv",
-	"BnvVGEWuDt643CTTDDkHd3xfKUFvfQfV48xxj9th+7ZaY+fphvQfMFZECqbL2utUYjC/o3hIVlc9tvbf",
-	"1P5Vzj9VZEF8h8iqz/HG0kW5GblLF0lMY+h/1HvKcEbwpbUqUXw2mpLCSCp2zuUR9F7lL2ibTSG/BCl7",
-	"Yp79zmsU8bpop+FTlaWVzcYnrr4tFl9BjMN4OHf92ltLOmvg90fTJhXJokwY9mpdk2MxZAEO88Q77gzF",
-	"B/CuLZo2ndDy98eg7v1u9MwEtTyIzsN2+CsAAP//vWiagdMHAAA=",
+	"H4sIAAAAAAAC/+xc63PbNhL/V3C4frqhXombtvqmJu3F0+YmrZPetHbmBiJWIhoSYAFQDs+j//0GD75E",
+	"0qJkS0l6/dJaJIBd7P72gcUydzgUSSo4cK3w/A6nRJIENEj/S0fPJVDgmpH4Z/gjA6XNCwoqlCzVTHA8",
+	"x9UQJN0YxOyDFQOJA8zMILMWDjAnCeA5ZhQH2AxmEiiea5lBgFUYQULM+jpPzSilJeNrHOAPo7UY+YdZ",
+	"xuj47dvLF/XnI5akQlrePAUzDAeO7ByvmY6y5TgUyWQtxDqGiX2/3W7dkMuK39b2LpXKQA7YUu390K2V",
+	"9JXKCA/htYhZmHfzYN6j1A747OT7Wgqx8gB6A0kaEw3tXdpRJYa0H/cZbXZr+FCp4AqsAV1Mp+Z/oeAa",
+	"uF2QpGnMQmI2PPldmV3f1Vj9QsIKz/HfJ5VVTtxbNfkncJAs/E5KIV+BUmQNTr5NGX5LKCosdRvgi+ns",
+	"3By85STTkZDsv0AdCxfnZuFfQqOVyLin/8256T8XfBWz0Grgy/Nj4JJrkJzE6ArkBiQCM976Gr+QodPp",
+	"2FMpUpCaOfiGMWHJJT2vEQU4lEA00IWdvBIyIRrPMSUaRpolgIMObkTCjL/QOZ6vSKzALeM3eJUtf4dQ",
+	"1/Yh3IOemfAhZZI4YQ7iYBvgSMTURY9BzLGzS1UC8Vhr8e5Q8cY+Hsi/m/JWxsNnaKIzCyvgWYLn1zgF",
+	"Tt0EkqZSbOp/g/PpRktA8buBKteRBEIvXwxkalsPG9cujng91taqb7Yhqy6Ilfusw/hdsIO7bYC7TLll",
+	"gEn1Aj6QJI3NGlciAR0xvkYRSVPgbTTu7KtY5R42DuOgJ05TiEFbxT2EH91yTOpnH1MNF0aFap+fbC2B",
+	"tyUtIiXJPakirVpklOmDqTRm76FgEzsG6mgiPjPsptKVXh1OqmuVLoIvgcTG29xhQikzbpLErxuo8TOW",
+	"QsRAON7uKrrHfJsCbWGRhIVLLjyIhyDQHr9hAiNnBzgQEmohm2AnNGF8cMz5rCPmJx/D3Onn8lOKnR6C",
+	"5+fp4KDdFe0a0awMfd7QCnPYF8rah9d9dksyLUbeYHGAE8IzEjtrzQfb6qGg3wZ4xSCmzwVXWhLmSx6D",
+	"PBOjTafwNVD69Wz2ZBRezL4czWZAR8vp02cjCkt4+hXMwiV91ml1J7QNyYRkOq/tiXENa5B9mzom7XNp",
+	"ziKOxW3M3JmhjC0tcTcCx3GYbBHsUGINsKUQDsNs7wnocOhWGClfDYJhOe8OL5nUESVWk1/EGs+fTKdf",
+	"TmfTmTnoiTBLgOtCa18wjufXs+DJu+22Y5t1UJQUZtNgFyEtNFTb+OHX54s1VBlV14a6YFGucI0po3NT",
+	"xHk6T0WcrwWfJ1myJGx+m3/Pfrm4/Wr20/rfr/mz5a8/qt9Err4n+bNn6x/0L+Sb96vsWf7qNyPZgTjb",
+	"xZRXYRcI+qpSOxgwuwLqyoDqoYgPcMhkmLHeiNE1Q3ANH/Tw8Yd7xgGH5c/DDSaCmlLhQZt3NAYKty8X",
+	"6BmuHxagLWt1zFRo8GsHuwAtWawDoSGYobbQ7xZbJlGz9n8cYqw75lAuUwPkQouEhT9lIPMrtv7lSSd8",
+	"Kwuploi0TtV8MpHkduwQkymQvhBnwWOd0sQm7SPn/0YbEZLlJCGMl+ciU6MbxXTyPg9Hm6dj/3OwFQ3y",
+	"7F3Ou4BltSOyhpHYgBzNvu4iX0GzMQVtQLKVrzh2zdNHOP0dsO7HaRfofrYHtdZpvRd3XRvUESBKNEGh",
+	"yGKKuNBoCSiMIHzfU4tosXFF8pdsYO3jJfvb8QWOPrNXEGYmTF8ZxDniS6JYuMjcOdsi0Z6ozdOKvkG4",
+	"KwAzvhLtC5UXPluwmkcrIZGR1hXEK/RSKA0UXb5AC3PARa9joo23vLEIYdqVmpojizE4wBuQytGYjmfj",
+	"qZGiSIGTlOE5fmofOW9tNzMx/1mD1aiRsOXHWLwpXjSYxDs3KE+m0/a2VBaGoBQinCIJOpNc2Y3RxnYZ",
+	"Ry/fvPoR+Rhg5ZwlCZG5o9ueYvXDfPHcXSSZWRNF8lHEevfg4NPN+KOU/R2BjkL/FclRxBBwmgrG69cN",
+	"XcuV/E3MoDru8Py6gbjrd9t3dWm5io+1KewkYgQWTqgI1YSk7D+2SGL+Guckie/T9q/m/aMq2ax4gJJR",
+	"bsezGHrVXVaqOzfhhHFKfXsKHQpfxDFSIDcsBIWIBCQzzr0XP0zz/brdzCapSQZGvrQxKgq8dZE02frO",
+	"AxBpYXQlGWwAkTi20k87q8UKB21wdNYxTynpvbXTDh10V79V/cJxtl8RZtCpzNXg3nHp94XqwtRkrUyU",
+	"6to4fmerbGqPjl1eiQgyYZ/uaNhlGyAVCglHCjhFKykSh4VsGbMQLV5fttT/3K7ZyVRZZftW0PzRdH9f",
+	"xrtthnTrGlownJ2UleHQ8+rw2JsOwd70UJz6S/R9Y785GaYdPHpgvR/V9zu2yR2jWwf5GDTcD343BpEe",
+	"x9YC9gs7vgfY9Yar626ZVUMmva00RlYndJKNO8LhuCwuBE+Ky4shYy9Ohkun3aNxGRwSUIcirieqfSZw",
+	"e6AzrIXhPynk+sP7oOie7cVbGpMQbLzekDgDhcRqOPjeppScx919ginBJ2MFmdXCJ2MHHz19cKh8aPpw",
+	"V7WibidVsa9IKIYdkMwFiTWuagF3snX3rwqVXQ1mtHnhqOoc6UiKbB3Zh2QNXAf2eGwXM0VMdcPt+Ea3",
+	"MANll7b1H3dzReLATjIElUYrJpW+4S1T7mzGOcqKa/3V22BXON+zWINEKUjkjt7G3xj2vCDG6DtXfkMj",
+	"VPWM2UbgP0xVuOoELnuvKht7hGaz7Ymzq/s6nrqaS1vt9ur0Qe9kgay2mxrCCnPcaS0YbIhmwHbitWsY",
+	"33+m9YNNoHNQQWFL0GP0pmG3iClrbEBLOywMzLySEApJgZoqlY7ghpeGSUyHE7pl2ttypiOzYmh8NsoU",
+	"yHGHOS4chy20PIZFDpjRpntSu+ggd68tOD38FfC8bXmwdNjXo5mX85jDrMuNHWBcbqAt7bYMCPXYzw0f",
+	"ZkA9100f2X4eP43dc6125kz2UEMuctkyIP9l0s6knVofwaILMxqRotF2eBHiNhJVghoUUZMGpbqQkMh1",
+	"3baSXJ/WFelsPQe94TYJdT6AQsgUE1whTd4DR8sc9aS1ZOPOqf6zOKKQbVkcdyezzf7iU+axbe/Wk7FW",
+	"7aMf6ZO1E6e23R32Xd8sNV3755vQlhspUHacaRYYP8w6u81kx+5MNAVT2XH3oSbCyvvMpfh24cEWcyas",
+	"tb61uA9upZD+BIirKaoXdAdd78ksBkSWIusuWFSAUioD5Zx3KVAioYAZUOPDi2bUwFUpIraOyupDVciw",
+	"P5HggBKiQ/udU5O2DQ8UFGIrxHTtCCbZOtKI3JI8QBH4godLD4tDWWEJ7pPuMlKN0aIIIZao4b5OURhK",
+	"CjmsIeNwDb85uo2AI9vME5jAqOCG+1ZYy5ZLWknRFRsYjgnP3V7t+DqRYqbtDlYoAd+9YH+jsGoXLtYZ",
+	"3/DntuqDuGixL6FLLIZwXYu1UtNuackuUYqxkGBXZHVXcztIexRf8fipcXff95mve3e/6drnnvJz3PCe",
+	"8ta26aHyBwfFo+5r+U5kzHtuah8ZyMMOh7uQ+Lj3ubvw+z+6yD0EqnZ184W9A0ZThj+K0PbNZjL2nZrz",
+	"ySQ2DyOh9PzpdPrEqtkvf9f6JwTi2BdCxKrs8DMeOyb+auDSh9/qNFE+2QZHrOeCSbWa/X3cUq/E0jXY",
+	"+aUWJqIct1R/I5Nfu+ez1WMEuptItau6YlWPnzXJ79rv9n8DALL77WRbRwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,14 +2,19 @@ package api_admin
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 
 	"github.com/go-chi/chi/v5"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgtype"
 	"github.com/lastingasset/wallet-service/iden3comm"
 
 	"github.com/lastingasset/wallet-service/internal/config"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/core/services"
 	"github.com/lastingasset/wallet-service/internal/health"
 )
 
@@ -21,19 +26,25 @@ type Server struct {
 	claimService     ports.ClaimsService
 	reqService       ports.ReqsService
 	schemaService    ports.SchemaService
+	templateService  ports.ProofRequestTemplateService
+	policyService    ports.IssuancePolicyService
+	credentialReqs   ports.CredentialRequestService
 	publisherGateway ports.Publisher
 	packageManager   *iden3comm.PackageManager
 	health           *health.Status
 }
 
 // NewServer is a Server constructor
-func NewServer(cfg *config.Configuration, identityService ports.IdentityService, claimsService ports.ClaimsService, reqsService ports.ReqsService, schemaService ports.SchemaService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
+func NewServer(cfg *config.Configuration, identityService ports.IdentityService, claimsService ports.ClaimsService, reqsService ports.ReqsService, schemaService ports.SchemaService, templateService ports.ProofRequestTemplateService, policyService ports.IssuancePolicyService, credentialRequestService ports.CredentialRequestService, publisherGateway ports.Publisher, packageManager *iden3comm.PackageManager, health *health.Status) *Server {
 	return &Server{
 		cfg:              cfg,
 		identityService:  identityService,
 		claimService:     claimsService,
 		reqService:       reqsService,
 		schemaService:    schemaService,
+		templateService:  templateService,
+		policyService:    policyService,
+		credentialReqs:   credentialRequestService,
 		publisherGateway: publisherGateway,
 		packageManager:   packageManager,
 		health:           health,
@@ -62,6 +73,363 @@ func (s *Server) GetYaml(_ context.Context, _ GetYamlRequestObject) (GetYamlResp
 	return nil, nil
 }
 
+// CreateProofRequestTemplate is the controller to create a proof request template
+func (s *Server) CreateProofRequestTemplate(ctx context.Context, request CreateProofRequestTemplateRequestObject) (CreateProofRequestTemplateResponseObject, error) {
+	template, err := s.templateService.Create(ctx, toProofRequestTemplateRequest(request.Body))
+	if err != nil {
+		if errors.Is(err, services.ErrProofRequestTemplateInvalid) || errors.Is(err, services.ErrMalformedURL) {
+			return CreateProofRequestTemplate400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProofRequestTemplateDuplicate) {
+			return CreateProofRequestTemplate409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toProofRequestTemplateResponse(template)
+	if err != nil {
+		return CreateProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateProofRequestTemplate201JSONResponse(resp), nil
+}
+
+// GetProofRequestTemplates is the controller to get all the proof request templates
+func (s *Server) GetProofRequestTemplates(ctx context.Context, _ GetProofRequestTemplatesRequestObject) (GetProofRequestTemplatesResponseObject, error) {
+	templates, err := s.templateService.GetAll(ctx)
+	if err != nil {
+		return GetProofRequestTemplates500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetProofRequestTemplates200JSONResponse, 0, len(templates))
+	for i := range templates {
+		template, err := toProofRequestTemplateResponse(&templates[i])
+		if err != nil {
+			return GetProofRequestTemplates500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		resp = append(resp, template)
+	}
+	return resp, nil
+}
+
+// GetProofRequestTemplate is the controller to get a proof request template
+func (s *Server) GetProofRequestTemplate(ctx context.Context, request GetProofRequestTemplateRequestObject) (GetProofRequestTemplateResponseObject, error) {
+	template, err := s.templateService.GetByID(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, services.ErrProofRequestTemplateNotFound) {
+			return GetProofRequestTemplate404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		return GetProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toProofRequestTemplateResponse(template)
+	if err != nil {
+		return GetProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return GetProofRequestTemplate200JSONResponse(resp), nil
+}
+
+// UpdateProofRequestTemplate is the controller to replace the values of a proof request template
+func (s *Server) UpdateProofRequestTemplate(ctx context.Context, request UpdateProofRequestTemplateRequestObject) (UpdateProofRequestTemplateResponseObject, error) {
+	template, err := s.templateService.Update(ctx, request.Id, toProofRequestTemplateRequest(request.Body))
+	if err != nil {
+		if errors.Is(err, services.ErrProofRequestTemplateInvalid) || errors.Is(err, services.ErrMalformedURL) {
+			return UpdateProofRequestTemplate400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProofRequestTemplateNotFound) {
+			return UpdateProofRequestTemplate404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrProofRequestTemplateDuplicate) {
+			return UpdateProofRequestTemplate409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		return UpdateProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toProofRequestTemplateResponse(template)
+	if err != nil {
+		return UpdateProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return UpdateProofRequestTemplate200JSONResponse(resp), nil
+}
+
+// DeleteProofRequestTemplate is the controller to delete a proof request template
+func (s *Server) DeleteProofRequestTemplate(ctx context.Context, request DeleteProofRequestTemplateRequestObject) (DeleteProofRequestTemplateResponseObject, error) {
+	if err := s.templateService.Delete(ctx, request.Id); err != nil {
+		if errors.Is(err, services.ErrProofRequestTemplateNotFound) {
+			return DeleteProofRequestTemplate404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		return DeleteProofRequestTemplate500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return DeleteProofRequestTemplate200JSONResponse{Message: "proof request template deleted"}, nil
+}
+
+// CreateIssuancePolicy is the controller to create an issuance policy of an identity
+func (s *Server) CreateIssuancePolicy(ctx context.Context, request CreateIssuancePolicyRequestObject) (CreateIssuancePolicyResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return CreateIssuancePolicy400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	body := request.Body
+	policy, err := s.policyService.Create(ctx, ports.NewCreateIssuancePolicyRequest(did, body.SchemaType, body.SubjectAllowlist, body.FieldConstraints, domain.IssuancePolicyAction(body.Action), body.Priority))
+	if err != nil {
+		if errors.Is(err, services.ErrIssuancePolicyInvalid) {
+			return CreateIssuancePolicy400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return CreateIssuancePolicy500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toIssuancePolicyResponse(policy)
+	if err != nil {
+		return CreateIssuancePolicy500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return CreateIssuancePolicy201JSONResponse(resp), nil
+}
+
+// GetIssuancePolicies is the controller to get the issuance policies of an identity
+func (s *Server) GetIssuancePolicies(ctx context.Context, request GetIssuancePoliciesRequestObject) (GetIssuancePoliciesResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIssuancePolicies400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	policies, err := s.policyService.GetAll(ctx, did)
+	if err != nil {
+		return GetIssuancePolicies500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetIssuancePolicies200JSONResponse, 0, len(policies))
+	for _, policy := range policies {
+		item, err := toIssuancePolicyResponse(policy)
+		if err != nil {
+			return GetIssuancePolicies500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// DeleteIssuancePolicy is the controller to delete an issuance policy of an identity
+func (s *Server) DeleteIssuancePolicy(ctx context.Context, request DeleteIssuancePolicyRequestObject) (DeleteIssuancePolicyResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return DeleteIssuancePolicy400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	if err := s.policyService.Delete(ctx, did, request.Id); err != nil {
+		if errors.Is(err, services.ErrIssuancePolicyNotFound) {
+			return DeleteIssuancePolicy404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		return DeleteIssuancePolicy500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return DeleteIssuancePolicy200JSONResponse{Message: "issuance policy deleted"}, nil
+}
+
+// GetCredentialRequests is the controller to list the credential requests of an identity
+func (s *Server) GetCredentialRequests(ctx context.Context, request GetCredentialRequestsRequestObject) (GetCredentialRequestsResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetCredentialRequests400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	var status *domain.CredentialRequestStatus
+	if request.Params.Status != nil {
+		st := domain.CredentialRequestStatus(*request.Params.Status)
+		status = &st
+	}
+
+	requests, err := s.credentialReqs.GetAll(ctx, did, status)
+	if err != nil {
+		return GetCredentialRequests500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetCredentialRequests200JSONResponse, 0, len(requests))
+	for _, req := range requests {
+		item, err := toCredentialRequestResponse(req)
+		if err != nil {
+			return GetCredentialRequests500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// ApproveCredentialRequest is the controller to issue the credential of a pending request on behalf of the authenticated user
+func (s *Server) ApproveCredentialRequest(ctx context.Context, request ApproveCredentialRequestRequestObject) (ApproveCredentialRequestResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return ApproveCredentialRequest400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	if _, err := s.credentialReqs.Approve(ctx, did, request.Id, basicAuthUser(ctx)); err != nil {
+		if errors.Is(err, services.ErrCredentialRequestNotFound) {
+			return ApproveCredentialRequest404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrCredentialRequestDecided) {
+			return ApproveCredentialRequest409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrLoadingSchema) || errors.Is(err, services.ErrProcessSchema) ||
+			errors.Is(err, services.ErrMalformedURL) || errors.Is(err, services.ErrJSONLdContext) {
+			return ApproveCredentialRequest400JSONResponse{N400JSONResponse{Message: err.Error()}}, nil
+		}
+		return ApproveCredentialRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	approved, err := s.credentialReqs.GetByID(ctx, did, request.Id)
+	if err != nil {
+		return ApproveCredentialRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	resp, err := toCredentialRequestResponse(approved)
+	if err != nil {
+		return ApproveCredentialRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return ApproveCredentialRequest200JSONResponse(resp), nil
+}
+
+// RejectCredentialRequest is the controller to reject a pending credential request on behalf of the authenticated user
+func (s *Server) RejectCredentialRequest(ctx context.Context, request RejectCredentialRequestRequestObject) (RejectCredentialRequestResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return RejectCredentialRequest400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	var reason string
+	if request.Body.Reason != nil {
+		reason = *request.Body.Reason
+	}
+
+	rejected, err := s.credentialReqs.Reject(ctx, did, request.Id, basicAuthUser(ctx), reason)
+	if err != nil {
+		if errors.Is(err, services.ErrCredentialRequestNotFound) {
+			return RejectCredentialRequest404JSONResponse{N404JSONResponse{Message: err.Error()}}, nil
+		}
+		if errors.Is(err, services.ErrCredentialRequestDecided) {
+			return RejectCredentialRequest409JSONResponse{N409JSONResponse{Message: err.Error()}}, nil
+		}
+		return RejectCredentialRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp, err := toCredentialRequestResponse(rejected)
+	if err != nil {
+		return RejectCredentialRequest500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+	return RejectCredentialRequest200JSONResponse(resp), nil
+}
+
+// GetIssuanceAudit is the controller to get the issuance audit of an identity
+func (s *Server) GetIssuanceAudit(ctx context.Context, request GetIssuanceAuditRequestObject) (GetIssuanceAuditResponseObject, error) {
+	did, err := core.ParseDID(request.Identifier)
+	if err != nil {
+		return GetIssuanceAudit400JSONResponse{N400JSONResponse{Message: "invalid did"}}, nil
+	}
+
+	records, err := s.policyService.GetAudit(ctx, did, request.Params.RequestId)
+	if err != nil {
+		return GetIssuanceAudit500JSONResponse{N500JSONResponse{Message: err.Error()}}, nil
+	}
+
+	resp := make(GetIssuanceAudit200JSONResponse, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, IssuanceAudit{
+			Id:         record.ID,
+			RequestId:  record.RequestID,
+			SchemaType: record.SchemaType,
+			Holder:     record.Holder,
+			Action:     IssuanceAuditAction(record.Action),
+			Actor:      record.Actor,
+			PolicyId:   record.PolicyID,
+			Reason:     record.Reason,
+			ClaimId:    record.ClaimID,
+			CreatedAt:  record.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func toProofRequestTemplateRequest(body *ProofRequestTemplateRequest) *ports.ProofRequestTemplateRequest {
+	req := &ports.ProofRequestTemplateRequest{
+		Name:      body.Name,
+		CircuitID: body.CircuitId,
+		Context:   body.Context,
+		Type:      body.Type,
+	}
+	if body.AllowedIssuers != nil {
+		req.AllowedIssuers = *body.AllowedIssuers
+	}
+	if body.CredentialSubject != nil {
+		req.CredentialSubject = *body.CredentialSubject
+	}
+	if body.Reason != nil {
+		req.Reason = *body.Reason
+	}
+	return req
+}
+
+func toProofRequestTemplateResponse(template *domain.ProofRequestTemplate) (ProofRequestTemplate, error) {
+	allowedIssuers, err := template.GetAllowedIssuers()
+	if err != nil {
+		return ProofRequestTemplate{}, err
+	}
+	resp := ProofRequestTemplate{
+		Id:             template.ID,
+		Name:           template.Name,
+		CircuitId:      template.CircuitID,
+		Context:        template.Context,
+		Type:           template.Type,
+		AllowedIssuers: allowedIssuers,
+		Reason:         template.Reason,
+		CreatedAt:      template.CreatedAt,
+		ModifiedAt:     template.ModifiedAt,
+	}
+	if template.CredentialSubject.Status == pgtype.Present {
+		credentialSubject, err := template.GetCredentialSubject()
+		if err != nil {
+			return ProofRequestTemplate{}, err
+		}
+		resp.CredentialSubject = &credentialSubject
+	}
+	return resp, nil
+}
+
+func toIssuancePolicyResponse(policy *domain.IssuancePolicy) (IssuancePolicy, error) {
+	subjectAllowlist, err := policy.GetSubjectAllowlist()
+	if err != nil {
+		return IssuancePolicy{}, err
+	}
+	fieldConstraints, err := policy.GetFieldConstraints()
+	if err != nil {
+		return IssuancePolicy{}, err
+	}
+	return IssuancePolicy{
+		Id:               policy.ID,
+		SchemaType:       policy.SchemaType,
+		SubjectAllowlist: subjectAllowlist,
+		FieldConstraints: fieldConstraints,
+		Action:           IssuancePolicyAction(policy.Action),
+		Priority:         policy.Priority,
+		CreatedAt:        policy.CreatedAt,
+	}, nil
+}
+
+func toCredentialRequestResponse(req *domain.CredentialRequest) (CredentialRequest, error) {
+	subject, err := req.GetCredentialSubject()
+	if err != nil {
+		return CredentialRequest{}, err
+	}
+	return CredentialRequest{
+		Id:                req.ID,
+		Holder:            req.Holder,
+		ThreadID:          req.ThreadID,
+		SchemaUrl:         req.SchemaURL,
+		SchemaType:        req.SchemaType,
+		CredentialSubject: subject,
+		Expiration:        req.Expiration,
+		Status:            CredentialRequestStatus(req.Status),
+		Reason:            req.Reason,
+		ClaimId:           req.ClaimID,
+		CreatedAt:         req.CreatedAt,
+	}, nil
+}
+
 // RegisterStatic add method to the mux that are not documented in the API.
 func RegisterStatic(mux *chi.Mux) {
 	mux.Get("/", documentation)
@@ -20,12 +20,15 @@ func TestServer_CheckStatus(t *testing.T) {
 	identityRepo := repositories.NewIdentity()
 	claimsRepo := repositories.NewClaims()
 	reqsRepo := repositories.NewAuthRequests()
+	templateRepo := repositories.NewProofRequestTemplates()
 	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
 	mtRepo := repositories.NewIdentityMerkleTreeRepository()
 	mtService := services.NewIdentityMerkleTrees(mtRepo)
 	revocationRepository := repositories.NewRevocation()
+	profileRepo := repositories.NewProfiles()
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
-	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, storage, rhsp)
+	identityService := services.NewIdentity(&KMSMock{}, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, profileRepo, storage, rhsp)
 	schemaService := services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex))
 
 	claimsConf := services.ClaimCfg{
@@ -36,10 +39,14 @@ func TestServer_CheckStatus(t *testing.T) {
 		RHSEnabled: false,
 		Host:       "host",
 	}
-	claimsService := services.NewClaim(claimsRepo, schemaService, identityService, mtService, identityStateRepo, storage, claimsConf)
-	reqsService := services.NewAuthRequest(reqsRepo, schemaService, identityService, mtService, identityStateRepo, storage, authRequestsConf)
+	claimsService := services.NewClaim(claimsRepo, schemaService, identityService, mtService, identityStateRepo, holderCredentialRepo, storage, claimsConf)
+	reqsService := services.NewAuthRequest(reqsRepo, schemaService, identityService, mtService, identityStateRepo, templateRepo, repositories.NewSybilNullifiers(), storage, authRequestsConf)
 
-	server := NewServer(&cfg, identityService, claimsService, reqsService, schemaService, NewPublisherMock(), NewPackageManagerMock(), &health.Status{})
+	templateService := services.NewProofRequestTemplates(templateRepo, storage)
+	policyService := services.NewIssuancePolicies(repositories.NewIssuancePolicies(), repositories.NewIssuanceAudit(), storage)
+	credentialRequestService := services.NewCredentialRequests(repositories.NewCredentialRequests(), repositories.NewIssuanceAudit(), policyService, claimsService, identityService, storage, "host")
+
+	server := NewServer(&cfg, identityService, claimsService, reqsService, schemaService, templateService, policyService, credentialRequestService, NewPublisherMock(), NewPackageManagerMock(), &health.Status{})
 	handler := getHandler(context.Background(), server)
 
 	t.Run("should return 200", func(t *testing.T) {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -36,6 +37,9 @@ type Configuration struct {
 	Circuit                      Circuit            `mapstructure:"Circuit"`
 	PublishingKeyPath            string             `mapstructure:"PublishingKeyPath"`
 	OnChainCheckStatusFrecuency  time.Duration      `mapstructure:"OnChainCheckStatusFrecuency"`
+	ExpiredClaimsCheckFrequency  time.Duration      `mapstructure:"ExpiredClaimsCheckFrequency"`
+	Verifier                     Verifier           `mapstructure:"Verifier"`
+	ProofJobs                    ProofJobs          `mapstructure:"ProofJobs"`
 }
 
 // Database has the database configuration
@@ -72,8 +76,11 @@ type Ethereum struct {
 
 // Prover struct
 type Prover struct {
-	ServerURL       string
-	ResponseTimeout time.Duration
+	ServerURL           string
+	ResponseTimeout     time.Duration
+	MaxConcurrentProofs int           `tip:"Proofs the native prover generates at the same time. 0 means the number of CPUs"`
+	PreloadCircuits     []string      `tip:"Circuits the native prover loads on start, the others are loaded on their first proof"`
+	StatsLogInterval    time.Duration `tip:"How often the native prover logs the timings of its proofs. 0 means never"`
 }
 
 // Circuit struct
@@ -81,6 +88,63 @@ type Circuit struct {
 	Path string `tip:"Circuit path"`
 }
 
+// ProofJobs configures the workers that generate the proofs of the proof jobs in the background
+type ProofJobs struct {
+	Workers      int           `mapstructure:"Workers" tip:"Number of proof jobs generated at the same time"`
+	PollInterval time.Duration `mapstructure:"PollInterval" tip:"Time an idle worker waits before looking for pending jobs again"`
+	ResultTTL    time.Duration `mapstructure:"ResultTTL" tip:"Time the results of a finished job are kept"`
+	LeaseTimeout time.Duration `mapstructure:"LeaseTimeout" tip:"Time after which a running job is taken by another worker, longer than any proof generation"`
+}
+
+// ErrVerifierProfileNotFound there is no verifier profile with the given name or DID
+var ErrVerifierProfileNotFound = errors.New("verifier profile not found")
+
+// Verifier holds the named verifier profiles used to build and verify authorization requests.
+// Profile names are case-insensitive.
+type Verifier struct {
+	DefaultProfile    string                     `mapstructure:"DefaultProfile" tip:"Name of the verifier profile used by default"`
+	RequestExpiration time.Duration              `mapstructure:"RequestExpiration" tip:"Time a wallet has to answer an authorization request. 0 means no expiration"`
+	Profiles          map[string]VerifierProfile `mapstructure:"Profiles"`
+}
+
+// VerifierProfile defines the verifier identity and the chains it trusts
+// Chains are keyed by "blockchain:network" or by "blockchain" for every network, e.g. "polygon:mumbai"
+type VerifierProfile struct {
+	DID          string                   `mapstructure:"DID" tip:"Verifier DID"`
+	CallbackURL  string                   `mapstructure:"CallbackURL" tip:"Callback url where the wallets send their responses, the callback endpoint of the server when empty"`
+	CircuitsPath string                   `mapstructure:"CircuitsPath" tip:"Path to the circuits verification keys"`
+	Chains       map[string]VerifierChain `mapstructure:"Chains"`
+}
+
+// VerifierChain holds the state contract a verifier resolves identity states from
+type VerifierChain struct {
+	RPCUrl          string `mapstructure:"RPCUrl" tip:"Ethereum RPC url"`
+	ContractAddress string `mapstructure:"ContractAddress" tip:"State contract address"`
+}
+
+// Profile returns the verifier profile with the given name. Empty name means the default profile.
+func (v *Verifier) Profile(name string) (VerifierProfile, error) {
+	if name == "" {
+		name = v.DefaultProfile
+	}
+	profile, ok := v.Profiles[strings.ToLower(name)]
+	if !ok {
+		return VerifierProfile{}, fmt.Errorf("%w: <%s>", ErrVerifierProfileNotFound, name)
+	}
+	return profile, nil
+}
+
+// ProfileByDID returns the verifier profile whose DID is the given one.
+// Callers that want a fallback must ask for the default profile explicitly.
+func (v *Verifier) ProfileByDID(did string) (VerifierProfile, error) {
+	for _, profile := range v.Profiles {
+		if profile.DID == did {
+			return profile, nil
+		}
+	}
+	return VerifierProfile{}, fmt.Errorf("%w: <%s>", ErrVerifierProfileNotFound, did)
+}
+
 // KeyStore defines the keystore
 type KeyStore struct {
 	Address              string `tip:"Keystore address"`
@@ -129,6 +193,31 @@ func (c *Configuration) Sanitize() error {
 		return fmt.Errorf("serverUrl is not a valid url <%s>: %w", c.ServerUrl, err)
 	}
 	c.ServerUrl = sUrl
+
+	if err := c.sanitizeVerifier(); err != nil {
+		return fmt.Errorf("verifier configuration is not valid: %w", err)
+	}
+	return nil
+}
+
+// sanitizeVerifier checks the default verifier profile exists and sets the circuits path
+// of the profiles that have not got one
+func (c *Configuration) sanitizeVerifier() error {
+	if len(c.Verifier.Profiles) == 0 {
+		return nil
+	}
+	if _, err := c.Verifier.Profile(""); err != nil {
+		return err
+	}
+	for name, profile := range c.Verifier.Profiles {
+		if profile.DID == "" {
+			return fmt.Errorf("verifier profile <%s> has no DID", name)
+		}
+		if profile.CircuitsPath == "" {
+			profile.CircuitsPath = c.Circuit.Path
+			c.Verifier.Profiles[name] = profile
+		}
+	}
 	return nil
 }
 
@@ -257,6 +346,7 @@ func bindEnv() {
 	_ = viper.BindEnv("NativeProofGenerationEnabled", "SH_ID_PLATFORM_NATIVE_PROOF_GENERATION_ENABLED")
 	_ = viper.BindEnv("PublishingKeyPath", "SH_ID_PLATFORM_PUBLISH_KEY_PATH")
 	_ = viper.BindEnv("OnChainCheckStatusFrecuency", "SH_ID_PLATFORM_ONCHAIN_CHECK_STATUS_FRECUENCY")
+	_ = viper.BindEnv("ExpiredClaimsCheckFrequency", "SH_ID_PLATFORM_EXPIRED_CLAIMS_CHECK_FREQUENCY")
 
 	_ = viper.BindEnv("Database.URL", "SH_ID_PLATFORM_DATABASE_URL")
 
@@ -287,11 +377,22 @@ func bindEnv() {
 
 	_ = viper.BindEnv("Prover.ServerURL", "SH_ID_PLATFORM_PROVER_SERVER_URL")
 	_ = viper.BindEnv("Prover.ResponseTimeout", "SH_ID_PLATFORM_PROVER_TIMEOUT")
+	_ = viper.BindEnv("Prover.MaxConcurrentProofs", "SH_ID_PLATFORM_PROVER_MAX_CONCURRENT_PROOFS")
+	_ = viper.BindEnv("Prover.PreloadCircuits", "SH_ID_PLATFORM_PROVER_PRELOAD_CIRCUITS")
+	_ = viper.BindEnv("Prover.StatsLogInterval", "SH_ID_PLATFORM_PROVER_STATS_LOG_INTERVAL")
 
 	_ = viper.BindEnv("Circuit.Path", "SH_ID_PLATFORM_CIRCUIT_PATH")
 
 	_ = viper.BindEnv("Cache.RedisUrl", "SH_ID_PLATFORM_REDIS_URL")
 
+	_ = viper.BindEnv("Verifier.DefaultProfile", "SH_ID_PLATFORM_VERIFIER_DEFAULT_PROFILE")
+	_ = viper.BindEnv("Verifier.RequestExpiration", "SH_ID_PLATFORM_VERIFIER_REQUEST_EXPIRATION")
+
+	_ = viper.BindEnv("ProofJobs.Workers", "SH_ID_PLATFORM_PROOF_JOBS_WORKERS")
+	_ = viper.BindEnv("ProofJobs.PollInterval", "SH_ID_PLATFORM_PROOF_JOBS_POLL_INTERVAL")
+	_ = viper.BindEnv("ProofJobs.ResultTTL", "SH_ID_PLATFORM_PROOF_JOBS_RESULT_TTL")
+	_ = viper.BindEnv("ProofJobs.LeaseTimeout", "SH_ID_PLATFORM_PROOF_JOBS_LEASE_TIMEOUT")
+
 	_ = viper.BindEnv("HTTPAdminAuth.User", "SH_ID_PLATFORM_HTTP_ADMIN_AUTH_USER")
 	_ = viper.BindEnv("HTTPAdminAuth.Password", "SH_ID_PLATFORM_HTTP_ADNMIN_AUTH_PASSWORD")
 
@@ -110,3 +110,31 @@ func TestConfiguration_validateServerUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestConfiguration_sanitizeVerifier(t *testing.T) {
+	cfg := Configuration{
+		Circuit: Circuit{Path: "/circuits"},
+		Verifier: Verifier{
+			DefaultProfile: "Default",
+			Profiles: map[string]VerifierProfile{
+				"default": {DID: "did:polygonid:polygon:mumbai:2qJT3RnL8ZwU7mgQeVjgw6qNpyYTV3Z7CgtxueBdsA"},
+				"other":   {DID: "did:polygonid:polygon:mumbai:2qHWXLmy3YR1Hh6pmzS5GVUUzWtNQi9xcAy2oKVm73", CircuitsPath: "/other"},
+			},
+		},
+	}
+	assert.NoError(t, cfg.sanitizeVerifier())
+
+	profile, err := cfg.Verifier.Profile("")
+	assert.NoError(t, err)
+	assert.Equal(t, "/circuits", profile.CircuitsPath)
+
+	profile, err = cfg.Verifier.ProfileByDID("did:polygonid:polygon:mumbai:2qHWXLmy3YR1Hh6pmzS5GVUUzWtNQi9xcAy2oKVm73")
+	assert.NoError(t, err)
+	assert.Equal(t, "/other", profile.CircuitsPath)
+
+	_, err = cfg.Verifier.ProfileByDID("did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS")
+	assert.ErrorIs(t, err, ErrVerifierProfileNotFound)
+
+	cfg.Verifier.DefaultProfile = "missing"
+	assert.Error(t, cfg.sanitizeVerifier())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -15,6 +16,19 @@ import (
 	"github.com/lastingasset/wallet-service/internal/common"
 )
 
+// EventClaimExpired is the topic of the events published when a claim issued by the service expires
+const EventClaimExpired = "claim.expired"
+
+// ClaimExpiredEvent is the payload of the EventClaimExpired events
+type ClaimExpiredEvent struct {
+	ClaimID    uuid.UUID `json:"claimId"`
+	Issuer     string    `json:"issuer"`
+	Subject    string    `json:"subject,omitempty"`
+	SchemaType string    `json:"schemaType"`
+	RevNonce   uint64    `json:"revNonce"`
+	Expiration time.Time `json:"expiration"`
+}
+
 // CoreClaim is an alias for the core.Claim struct
 type CoreClaim core.Claim
 
@@ -40,6 +54,8 @@ type Claim struct {
 	Status           *IdentityStatus `json:"status"`
 	CredentialStatus pgtype.JSONB    `json:"credential_status"`
 	HIndex           string          `json:"-"`
+	Replaces         *uuid.UUID      `json:"replaces,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
 }
 
 // FromClaimer TODO
@@ -134,6 +150,21 @@ func (c *Claim) GetBJJSignatureProof2021() (*verifiable.BJJSignatureProof2021, e
 	return &sigProof, nil
 }
 
+// HasSignatureProof returns true if the claim has a BJJSignatureProof2021
+func (c *Claim) HasSignatureProof() bool {
+	return c.SignatureProof.Status == pgtype.Present
+}
+
+// HasMTPProof returns true if the claim has been published and has a merkle tree proof
+func (c *Claim) HasMTPProof() bool {
+	return c.MTPProof.Status == pgtype.Present
+}
+
+// IsExpired returns true if the claim has an expiration that is not after now
+func (c *Claim) IsExpired(now time.Time) bool {
+	return c.Expiration > 0 && c.Expiration <= now.Unix()
+}
+
 // GetVerifiableCredential TBD
 func (c *Claim) GetVerifiableCredential() (verifiable.W3CCredential, error) {
 	var vc verifiable.W3CCredential
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+)
+
+// CredentialRequestStatus is the status of a credential request in the approval of the issuer
+type CredentialRequestStatus string
+
+const (
+	// CredentialRequestPending the request waits for the approval of the issuer
+	CredentialRequestPending CredentialRequestStatus = "pending"
+	// CredentialRequestApproving the issuer approved the request and its credential is being issued
+	CredentialRequestApproving CredentialRequestStatus = "approving"
+	// CredentialRequestApproved the issuer approved the request and issued the credential
+	CredentialRequestApproved CredentialRequestStatus = "approved"
+	// CredentialRequestRejected the issuer rejected the request
+	CredentialRequestRejected CredentialRequestStatus = "rejected"
+)
+
+// CredentialRequest is a credential a holder asked an issuer for through the agent, or a claim created through the api
+// that an issuance policy holds, queued for the approval of the issuer
+type CredentialRequest struct {
+	ID                    uuid.UUID               `json:"id"`
+	Issuer                string                  `json:"issuer"`
+	Holder                string                  `json:"holder"`
+	ThreadID              string                  `json:"thread_id"`
+	SchemaURL             string                  `json:"schema_url"`
+	SchemaType            string                  `json:"schema_type"`
+	CredentialSubject     pgtype.JSONB            `json:"credential_subject"`
+	Expiration            *time.Time              `json:"expiration,omitempty"`
+	Version               uint32                  `json:"version"`
+	SubjectPosition       string                  `json:"subject_position"`
+	MerklizedRootPosition string                  `json:"merklized_root_position"`
+	Updatable             bool                    `json:"updatable"`
+	Status                CredentialRequestStatus `json:"status"`
+	Reason                *string                 `json:"reason,omitempty"`
+	ClaimID               *uuid.UUID              `json:"claim_id,omitempty"`
+	CreatedAt             time.Time               `json:"created_at"`
+	ModifiedAt            time.Time               `json:"modified_at,omitempty"`
+}
+
+// GetCredentialSubject returns the subject data the holder asked for
+func (r *CredentialRequest) GetCredentialSubject() (map[string]interface{}, error) {
+	var subject map[string]interface{}
+	if err := json.Unmarshal(r.CredentialSubject.Bytes, &subject); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential subject: %w", err)
+	}
+	return subject, nil
+}
+
+// IsPending returns true if the issuer has not approved nor rejected the request yet
+func (r *CredentialRequest) IsPending() bool {
+	return r.Status == CredentialRequestPending
+}
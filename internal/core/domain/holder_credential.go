@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgtype"
+)
+
+// HolderCredentialSource represents how a credential got to the wallet of its holder
+type HolderCredentialSource string
+
+const (
+	// HolderCredentialSourceOffer is the source of the credentials fetched from a credential offer
+	HolderCredentialSourceOffer HolderCredentialSource = "offer"
+	// HolderCredentialSourceImport is the source of the credentials imported as they are
+	HolderCredentialSourceImport HolderCredentialSource = "import"
+	// HolderCredentialSourceSelfIssued is the source of the credentials issued by this service to one of its identities
+	HolderCredentialSourceSelfIssued HolderCredentialSource = "self-issued"
+)
+
+// ErrHolderCredentialProof the credential has not got any issuer proof the wallet can generate proofs with
+var ErrHolderCredentialProof = errors.New("the credential has no signature nor merkle tree proof")
+
+// HolderCredential is a W3C credential held by one of the identities of the service, whoever issued it
+type HolderCredential struct {
+	ID           uuid.UUID              `json:"id"`
+	Holder       string                 `json:"holder"`
+	Issuer       string                 `json:"issuer"`
+	CredentialID string                 `json:"credential_id"`
+	Credential   pgtype.JSONB           `json:"credential"`
+	SchemaURL    string                 `json:"schema_url"`
+	SchemaType   string                 `json:"schema_type"`
+	SchemaHash   string                 `json:"schema_hash"`
+	RevNonce     RevNonceUint64         `json:"rev_nonce"`
+	ProofTypes   []string               `json:"proof_types"`
+	Source       HolderCredentialSource `json:"source"`
+	Revoked      bool                   `json:"revoked"`
+	Expiration   *time.Time             `json:"expiration,omitempty"`
+	ReceivedAt   time.Time              `json:"received_at"`
+	ModifiedAt   time.Time              `json:"modified_at,omitempty"`
+}
+
+// NewHolderCredential returns the credential held by holder. The core claim of its proofs must be about the holder.
+func NewHolderCredential(holder *core.DID, credential verifiable.W3CCredential, source HolderCredentialSource) (*HolderCredential, error) {
+	if len(credential.Context) == 0 || len(credential.Type) == 0 {
+		return nil, errors.New("the credential has no context or type")
+	}
+
+	coreClaim, proofTypes, err := credentialCoreClaim(credential)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectID, err := coreClaim.GetID()
+	if err != nil {
+		return nil, fmt.Errorf("the credential has no subject: %w", err)
+	}
+	if subjectID != holder.ID {
+		return nil, errors.New("the credential subject is not the holder")
+	}
+
+	schemaHash := coreClaim.GetSchemaHash()
+	res := HolderCredential{
+		Holder:       holder.String(),
+		Issuer:       credential.Issuer,
+		CredentialID: credential.ID,
+		SchemaURL:    credential.CredentialSchema.ID,
+		SchemaType:   fmt.Sprintf("%s#%s", credential.Context[len(credential.Context)-1], credential.Type[len(credential.Type)-1]),
+		SchemaHash:   hex.EncodeToString(schemaHash[:]),
+		RevNonce:     RevNonceUint64(coreClaim.GetRevocationNonce()),
+		ProofTypes:   proofTypes,
+		Source:       source,
+		Expiration:   credential.Expiration,
+	}
+	if err := res.Credential.Set(credential); err != nil {
+		return nil, fmt.Errorf("failed to set credential: %w", err)
+	}
+
+	return &res, nil
+}
+
+// GetCredential returns the W3C credential, with its proofs
+func (h *HolderCredential) GetCredential() (*verifiable.W3CCredential, error) {
+	var credential verifiable.W3CCredential
+	if err := json.Unmarshal(h.Credential.Bytes, &credential); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential: %w", err)
+	}
+	return &credential, nil
+}
+
+// SetCredential replaces the stored credential, which must keep the same core claim
+func (h *HolderCredential) SetCredential(credential verifiable.W3CCredential) error {
+	_, proofTypes, err := credentialCoreClaim(credential)
+	if err != nil {
+		return err
+	}
+	if err := h.Credential.Set(credential); err != nil {
+		return fmt.Errorf("failed to set credential: %w", err)
+	}
+	h.ProofTypes = proofTypes
+	return nil
+}
+
+// IsExpired returns true if the credential has an expiration in the past
+func (h *HolderCredential) IsExpired(now time.Time) bool {
+	return h.Expiration != nil && h.Expiration.Before(now)
+}
+
+// Claim returns the credential as the claim the proof circuits take
+func (h *HolderCredential) Claim() (*Claim, error) {
+	credential, err := h.GetCredential()
+	if err != nil {
+		return nil, err
+	}
+	coreClaim, _, err := credentialCoreClaim(*credential)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := FromClaimer(coreClaim, h.SchemaURL, h.SchemaType)
+	if err != nil {
+		return nil, err
+	}
+	holder := h.Holder
+	claim.ID = h.ID
+	claim.Identifier = &holder
+	claim.Issuer = h.Issuer
+	claim.Revoked = h.Revoked
+
+	for _, proof := range credential.Proof {
+		switch proof.(type) {
+		case *verifiable.BJJSignatureProof2021:
+			if err := claim.SignatureProof.Set(proof); err != nil {
+				return nil, err
+			}
+		case *verifiable.Iden3SparseMerkleProof:
+			if err := claim.MTPProof.Set(proof); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if claim.SignatureProof.Status != pgtype.Present {
+		claim.SignatureProof.Status = pgtype.Null
+	}
+	if claim.MTPProof.Status != pgtype.Present {
+		claim.MTPProof.Status = pgtype.Null
+	}
+
+	data := *credential
+	data.Proof = nil
+	if err := claim.Data.Set(data); err != nil {
+		return nil, err
+	}
+	if err := claim.CredentialStatus.Set(credential.CredentialStatus); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// credentialCoreClaim returns the core claim proved by the signature and merkle tree proofs of
+// the credential, and the types of those proofs
+func credentialCoreClaim(credential verifiable.W3CCredential) (*core.Claim, []string, error) {
+	var coreClaim *core.Claim
+	proofTypes := make([]string, 0, len(credential.Proof))
+	for _, proof := range credential.Proof {
+		switch proof.(type) {
+		case *verifiable.BJJSignatureProof2021, *verifiable.Iden3SparseMerkleProof:
+		default:
+			continue
+		}
+
+		proofClaim, err := proof.GetCoreClaim()
+		if err != nil {
+			return nil, nil, err
+		}
+		if coreClaim != nil && !sameCoreClaim(coreClaim, proofClaim) {
+			return nil, nil, errors.New("the credential proofs are for different claims")
+		}
+		coreClaim = proofClaim
+		proofTypes = append(proofTypes, string(proof.ProofType()))
+	}
+
+	if coreClaim == nil {
+		return nil, nil, ErrHolderCredentialProof
+	}
+	return coreClaim, proofTypes, nil
+}
+
+// sameCoreClaim tells whether both core claims have the same index and value slots
+func sameCoreClaim(a, b *core.Claim) bool {
+	aSlots, bSlots := a.RawSlotsAsInts(), b.RawSlotsAsInts()
+	for i := range aSlots {
+		if aSlots[i].Cmp(bSlots[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
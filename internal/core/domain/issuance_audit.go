@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IssuanceAuditAction is the event recorded in the issuance audit
+type IssuanceAuditAction string
+
+const (
+	// IssuanceAuditRequested a credential was queued for the approval of the issuer
+	IssuanceAuditRequested IssuanceAuditAction = "requested"
+	// IssuanceAuditApproved a queued credential was approved and issued
+	IssuanceAuditApproved IssuanceAuditAction = "approved"
+	// IssuanceAuditRejected a queued credential was rejected
+	IssuanceAuditRejected IssuanceAuditAction = "rejected"
+	// IssuanceAuditDenied an issuance policy denied a credential
+	IssuanceAuditDenied IssuanceAuditAction = "denied"
+)
+
+// IssuanceAuditActorPolicy is the actor of the decisions taken by the issuance policies
+const IssuanceAuditActorPolicy = "policy"
+
+// IssuanceAudit is a record of who requested, approved, rejected or denied a credential of an issuer
+type IssuanceAudit struct {
+	ID         uuid.UUID           `json:"id"`
+	Issuer     string              `json:"issuer"`
+	RequestID  *uuid.UUID          `json:"request_id,omitempty"`
+	SchemaType string              `json:"schema_type"`
+	Holder     string              `json:"holder"`
+	Action     IssuanceAuditAction `json:"action"`
+	Actor      string              `json:"actor"`
+	PolicyID   *uuid.UUID          `json:"policy_id,omitempty"`
+	Reason     *string             `json:"reason,omitempty"`
+	ClaimID    *uuid.UUID          `json:"claim_id,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+)
+
+// IssuancePolicyAction is what an issuance policy decides on the credentials it matches
+type IssuancePolicyAction string
+
+const (
+	// IssuancePolicyAutoApprove the credential is issued right away
+	IssuancePolicyAutoApprove IssuancePolicyAction = "auto-approve"
+	// IssuancePolicyManual the credential is queued for the approval of the issuer
+	IssuancePolicyManual IssuancePolicyAction = "manual"
+	// IssuancePolicyDeny the credential is not issued
+	IssuancePolicyDeny IssuancePolicyAction = "deny"
+)
+
+// Operators of the field constraints of an issuance policy
+const (
+	IssuancePolicyOperatorEq  = "$eq"
+	IssuancePolicyOperatorNe  = "$ne"
+	IssuancePolicyOperatorLt  = "$lt"
+	IssuancePolicyOperatorGt  = "$gt"
+	IssuancePolicyOperatorIn  = "$in"
+	IssuancePolicyOperatorNin = "$nin"
+)
+
+// ErrIssuancePolicyConstraint the field constraints of the policy are not valid
+var ErrIssuancePolicyConstraint = errors.New("invalid field constraint")
+
+// IssuancePolicy is a rule of an issuer about the credentials it issues. A policy matches a credential when
+// its schema type is the one of the policy, or the policy has none, the subject is in the allowlist, if any,
+// and the credential subject fields meet the field constraints, if any.
+// The policies of an issuer are evaluated by priority, the first one matching decides.
+type IssuancePolicy struct {
+	ID               uuid.UUID            `json:"id"`
+	Issuer           string               `json:"issuer"`
+	SchemaType       string               `json:"schema_type"`
+	SubjectAllowlist pgtype.JSONB         `json:"subject_allowlist"`
+	FieldConstraints pgtype.JSONB         `json:"field_constraints"`
+	Action           IssuancePolicyAction `json:"action"`
+	Priority         int                  `json:"priority"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+// NewIssuancePolicy returns a new issuance policy
+func NewIssuancePolicy(issuer string, schemaType string, subjectAllowlist []string, fieldConstraints map[string]interface{}, action IssuancePolicyAction, priority int) (*IssuancePolicy, error) {
+	policy := &IssuancePolicy{
+		ID:         uuid.New(),
+		Issuer:     issuer,
+		SchemaType: schemaType,
+		Action:     action,
+		Priority:   priority,
+		CreatedAt:  time.Now(),
+	}
+	if err := policy.SetSubjectAllowlist(subjectAllowlist); err != nil {
+		return nil, err
+	}
+	if err := policy.SetFieldConstraints(fieldConstraints); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// IsValid returns true if the action is one of the known ones
+func (a IssuancePolicyAction) IsValid() bool {
+	return a == IssuancePolicyAutoApprove || a == IssuancePolicyManual || a == IssuancePolicyDeny
+}
+
+// SetSubjectAllowlist sets the DIDs of the subjects the policy applies to, none for any
+func (p *IssuancePolicy) SetSubjectAllowlist(subjectAllowlist []string) error {
+	if len(subjectAllowlist) == 0 {
+		p.SubjectAllowlist = pgtype.JSONB{Status: pgtype.Null}
+		return nil
+	}
+	if err := p.SubjectAllowlist.Set(subjectAllowlist); err != nil {
+		return fmt.Errorf("failed to set subject allowlist: %w", err)
+	}
+	return nil
+}
+
+// SetFieldConstraints sets the constraints on the credential subject fields, by field. A constraint is an object
+// with operators, as {"$lt": 20050101}, or a value the field must be equal to.
+func (p *IssuancePolicy) SetFieldConstraints(fieldConstraints map[string]interface{}) error {
+	if len(fieldConstraints) == 0 {
+		p.FieldConstraints = pgtype.JSONB{Status: pgtype.Null}
+		return nil
+	}
+	for field, constraint := range fieldConstraints {
+		if err := validateFieldConstraint(constraint); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrIssuancePolicyConstraint, field, err)
+		}
+	}
+	if err := p.FieldConstraints.Set(fieldConstraints); err != nil {
+		return fmt.Errorf("failed to set field constraints: %w", err)
+	}
+	return nil
+}
+
+// GetSubjectAllowlist returns the DIDs of the subjects the policy applies to
+func (p *IssuancePolicy) GetSubjectAllowlist() ([]string, error) {
+	subjectAllowlist := make([]string, 0)
+	if p.SubjectAllowlist.Status != pgtype.Present {
+		return subjectAllowlist, nil
+	}
+	if err := json.Unmarshal(p.SubjectAllowlist.Bytes, &subjectAllowlist); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subject allowlist: %w", err)
+	}
+	return subjectAllowlist, nil
+}
+
+// GetFieldConstraints returns the constraints on the credential subject fields, by field
+func (p *IssuancePolicy) GetFieldConstraints() (map[string]interface{}, error) {
+	fieldConstraints := make(map[string]interface{})
+	if p.FieldConstraints.Status != pgtype.Present {
+		return fieldConstraints, nil
+	}
+	if err := json.Unmarshal(p.FieldConstraints.Bytes, &fieldConstraints); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal field constraints: %w", err)
+	}
+	return fieldConstraints, nil
+}
+
+// Matches returns true if the policy applies to a credential of the given schema type and subject
+func (p *IssuancePolicy) Matches(schemaType string, credentialSubject map[string]interface{}) (bool, error) {
+	if p.SchemaType != "" && p.SchemaType != schemaType {
+		return false, nil
+	}
+
+	subjectAllowlist, err := p.GetSubjectAllowlist()
+	if err != nil {
+		return false, err
+	}
+	if len(subjectAllowlist) > 0 {
+		subject, _ := credentialSubject["id"].(string)
+		allowed := false
+		for _, did := range subjectAllowlist {
+			allowed = allowed || did == subject
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	fieldConstraints, err := p.GetFieldConstraints()
+	if err != nil {
+		return false, err
+	}
+	for field, constraint := range fieldConstraints {
+		value, ok := credentialSubject[field]
+		if !ok || !meetsFieldConstraint(value, constraint) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func validateFieldConstraint(constraint interface{}) error {
+	operators, ok := constraint.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for operator, operand := range operators {
+		switch operator {
+		case IssuancePolicyOperatorEq, IssuancePolicyOperatorNe:
+		case IssuancePolicyOperatorLt, IssuancePolicyOperatorGt:
+			if _, ok := toFloat(operand); !ok {
+				return fmt.Errorf("%s needs a number", operator)
+			}
+		case IssuancePolicyOperatorIn, IssuancePolicyOperatorNin:
+			if _, ok := operand.([]interface{}); !ok {
+				return fmt.Errorf("%s needs an array", operator)
+			}
+		default:
+			return fmt.Errorf("unknown operator %s", operator)
+		}
+	}
+	return nil
+}
+
+func meetsFieldConstraint(value interface{}, constraint interface{}) bool {
+	operators, ok := constraint.(map[string]interface{})
+	if !ok {
+		return equalValues(value, constraint)
+	}
+	for operator, operand := range operators {
+		var met bool
+		switch operator {
+		case IssuancePolicyOperatorEq:
+			met = equalValues(value, operand)
+		case IssuancePolicyOperatorNe:
+			met = !equalValues(value, operand)
+		case IssuancePolicyOperatorLt, IssuancePolicyOperatorGt:
+			number, okValue := toFloat(value)
+			limit, okLimit := toFloat(operand)
+			met = okValue && okLimit && ((operator == IssuancePolicyOperatorLt && number < limit) || (operator == IssuancePolicyOperatorGt && number > limit))
+		case IssuancePolicyOperatorIn, IssuancePolicyOperatorNin:
+			items, _ := operand.([]interface{})
+			in := false
+			for _, item := range items {
+				in = in || equalValues(value, item)
+			}
+			met = in == (operator == IssuancePolicyOperatorIn)
+		}
+		if !met {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValues compares two json values, the numbers by value whatever their go type
+func equalValues(a, b interface{}) bool {
+	numberA, okA := toFloat(a)
+	numberB, okB := toFloat(b)
+	if okA && okB {
+		return numberA == numberB
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuancePolicy_Matches(t *testing.T) {
+	holder := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	other := "did:polygonid:polygon:mumbai:2qD6cqGpLX2dibdFuKfrPxGiybi3wKa8RbR4onw49H"
+	issuer := "did:polygonid:polygon:mumbai:2qH7XAwYQzCp9VfhpNgeLtK2iCehDDrfMWUCEg5ig5"
+	subject := map[string]interface{}{"id": holder, "birthday": 19960424, "documentType": 2, "country": "ES"}
+
+	for _, tc := range []struct {
+		name             string
+		schemaType       string
+		subjectAllowlist []string
+		fieldConstraints map[string]interface{}
+		matches          bool
+	}{
+		{name: "any credential", matches: true},
+		{name: "same schema type", schemaType: "KYCAgeCredential", matches: true},
+		{name: "other schema type", schemaType: "KYCCountryOfResidenceCredential", matches: false},
+		{name: "subject in the allowlist", subjectAllowlist: []string{other, holder}, matches: true},
+		{name: "subject out of the allowlist", subjectAllowlist: []string{other}, matches: false},
+		{name: "equal value", fieldConstraints: map[string]interface{}{"documentType": 2}, matches: true},
+		{name: "different value", fieldConstraints: map[string]interface{}{"documentType": 3}, matches: false},
+		{name: "missing field", fieldConstraints: map[string]interface{}{"nationality": "ES"}, matches: false},
+		{name: "$eq", fieldConstraints: map[string]interface{}{"country": map[string]interface{}{"$eq": "ES"}}, matches: true},
+		{name: "$ne", fieldConstraints: map[string]interface{}{"country": map[string]interface{}{"$ne": "ES"}}, matches: false},
+		{name: "$lt", fieldConstraints: map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}}, matches: true},
+		{name: "$lt not met", fieldConstraints: map[string]interface{}{"birthday": map[string]interface{}{"$lt": 19900101}}, matches: false},
+		{name: "$gt", fieldConstraints: map[string]interface{}{"birthday": map[string]interface{}{"$gt": 19900101}}, matches: true},
+		{name: "$gt and $lt", fieldConstraints: map[string]interface{}{"birthday": map[string]interface{}{"$gt": 19900101, "$lt": 19950101}}, matches: false},
+		{name: "$lt on a string", fieldConstraints: map[string]interface{}{"country": map[string]interface{}{"$lt": 1}}, matches: false},
+		{name: "$in", fieldConstraints: map[string]interface{}{"country": map[string]interface{}{"$in": []interface{}{"FR", "ES"}}}, matches: true},
+		{name: "$in not met", fieldConstraints: map[string]interface{}{"documentType": map[string]interface{}{"$in": []interface{}{1, 3}}}, matches: false},
+		{name: "$nin", fieldConstraints: map[string]interface{}{"documentType": map[string]interface{}{"$nin": []interface{}{1, 3}}}, matches: true},
+		{name: "$nin not met", fieldConstraints: map[string]interface{}{"country": map[string]interface{}{"$nin": []interface{}{"ES"}}}, matches: false},
+		{
+			name:             "every condition met",
+			schemaType:       "KYCAgeCredential",
+			subjectAllowlist: []string{holder},
+			fieldConstraints: map[string]interface{}{"documentType": 2, "birthday": map[string]interface{}{"$lt": 20050101}},
+			matches:          true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewIssuancePolicy(issuer, tc.schemaType, tc.subjectAllowlist, tc.fieldConstraints, IssuancePolicyManual, 0)
+			require.NoError(t, err)
+			matches, err := policy.Matches("KYCAgeCredential", subject)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, matches)
+		})
+	}
+}
+
+func TestIssuancePolicy_SetFieldConstraints(t *testing.T) {
+	policy := &IssuancePolicy{}
+	for _, constraint := range []interface{}{
+		map[string]interface{}{"$lt": "20050101"},
+		map[string]interface{}{"$in": "ES"},
+		map[string]interface{}{"$regex": "^E"},
+	} {
+		err := policy.SetFieldConstraints(map[string]interface{}{"field": constraint})
+		assert.ErrorIs(t, err, ErrIssuancePolicyConstraint)
+	}
+}
+
+func TestMeetsFieldConstraint_Numbers(t *testing.T) {
+	// the constraints come from json, as float64, and the credential subject of the api may have any number type
+	for _, value := range []interface{}{2, int64(2), float32(2), float64(2), json.Number("2")} {
+		assert.True(t, meetsFieldConstraint(value, float64(2)), "%T", value)
+		assert.True(t, meetsFieldConstraint(value, map[string]interface{}{"$eq": float64(2)}), "%T", value)
+		assert.False(t, meetsFieldConstraint(value, map[string]interface{}{"$ne": float64(2)}), "%T", value)
+		assert.True(t, meetsFieldConstraint(value, map[string]interface{}{"$gt": float64(1), "$lt": float64(3)}), "%T", value)
+		assert.True(t, meetsFieldConstraint(value, map[string]interface{}{"$in": []interface{}{float64(1), float64(2)}}), "%T", value)
+	}
+	assert.False(t, meetsFieldConstraint("2", float64(2)))
+	assert.False(t, meetsFieldConstraint(json.Number("two"), map[string]interface{}{"$lt": float64(3)}))
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/verifiable"
+)
+
+const (
+	// PresentationType is the type of the W3C verifiable presentations
+	PresentationType = "VerifiablePresentation"
+	// PresentationProofPurpose is the purpose of the holder proof of a presentation
+	PresentationProofPurpose = "authentication"
+)
+
+// ErrPresentationNotSigned the presentation has no holder proof
+var ErrPresentationNotSigned = errors.New("the presentation has no holder proof")
+
+// VerifiablePresentation is a W3C verifiable presentation of credentials held by its holder
+type VerifiablePresentation struct {
+	Context              []string                   `json:"@context"`
+	ID                   string                     `json:"id"`
+	Type                 []string                   `json:"type"`
+	Holder               string                     `json:"holder"`
+	VerifiableCredential []verifiable.W3CCredential `json:"verifiableCredential"`
+	Proof                *PresentationProof         `json:"proof,omitempty"`
+}
+
+// PresentationProof is the holder BJJ signature of a presentation, bound to the challenge and domain of the verifier.
+// The holder data has the auth claim of the holder key and its merkle tree proof in the latest state of the holder,
+// the non revocation proof tells the auth claim was not revoked in that state.
+type PresentationProof struct {
+	Type             verifiable.ProofType  `json:"type"`
+	Created          time.Time             `json:"created"`
+	ProofPurpose     string                `json:"proofPurpose"`
+	Challenge        string                `json:"challenge"`
+	Domain           string                `json:"domain"`
+	HolderData       verifiable.IssuerData `json:"holderData"`
+	NonRevocationMTP *merkletree.Proof     `json:"nonRevocationMtp"`
+	Signature        string                `json:"signature,omitempty"`
+}
+
+// SigningInput returns the bytes the holder signs: the presentation and its proof without signature
+func (p *VerifiablePresentation) SigningInput() ([]byte, error) {
+	if p.Proof == nil {
+		return nil, ErrPresentationNotSigned
+	}
+	proof := *p.Proof
+	proof.Signature = ""
+	unsigned := *p
+	unsigned.Proof = &proof
+	return json.Marshal(unsigned)
+}
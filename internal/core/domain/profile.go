@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// ProfileNonce is the nonce the id of a profile is derived with from the genesis id of its identity
+type ProfileNonce uint64
+
+// Value returns the nonce as a numeric database value
+func (n ProfileNonce) Value() (driver.Value, error) {
+	return strconv.FormatUint(uint64(n), 10), nil
+}
+
+// BigInt returns the nonce as the circuits take it
+func (n ProfileNonce) BigInt() *big.Int {
+	return new(big.Int).SetUint64(uint64(n))
+}
+
+// Profile is a DID derived from an identity with a nonce, so that the identity can show a different DID to each verifier
+type Profile struct {
+	ID         uuid.UUID    `json:"id"`
+	Identifier string       `json:"identifier"`
+	Nonce      ProfileNonce `json:"nonce"`
+	ProfileDID string       `json:"profile_did"`
+	Verifier   *string      `json:"verifier,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NewProfile derives the profile of the identity with the given nonce. A zero nonce is the identity itself.
+func NewProfile(did *core.DID, nonce ProfileNonce, verifier *string) (*Profile, error) {
+	if nonce == 0 {
+		return nil, errors.New("the profile nonce can not be zero")
+	}
+
+	profileID, err := core.ProfileID(did.ID, nonce.BigInt())
+	if err != nil {
+		return nil, err
+	}
+	profileDID, err := core.ParseDIDFromID(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Profile{
+		Identifier: did.String(),
+		Nonce:      nonce,
+		ProfileDID: profileDID.String(),
+		Verifier:   verifier,
+	}, nil
+}
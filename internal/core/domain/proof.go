@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/lastingasset/wallet-service/internal/common"
@@ -14,10 +15,12 @@ type ZKProof struct {
 	Protocol string     `json:"protocol"`
 }
 
-// FullProof is ZKP proof with public signals
+// FullProof is ZKP proof with public signals.
+// VerifiablePresentation is only set for selective disclosure queries.
 type FullProof struct {
-	Proof      *ZKProof `json:"proof"`
-	PubSignals []string `json:"pub_signals"`
+	Proof                  *ZKProof        `json:"proof"`
+	PubSignals             []string        `json:"pub_signals"`
+	VerifiablePresentation json.RawMessage `json:"vp,omitempty"`
 }
 
 // ProofToBigInts transforms a zkp (that uses `*bn256.G1` and `*bn256.G2`) into
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+)
+
+// ProofJobStatus is the status of a proof job
+type ProofJobStatus string
+
+const (
+	// ProofJobPending the job waits for a worker
+	ProofJobPending ProofJobStatus = "pending"
+	// ProofJobRunning a worker is generating the proofs
+	ProofJobRunning ProofJobStatus = "running"
+	// ProofJobDone the proofs were generated
+	ProofJobDone ProofJobStatus = "done"
+	// ProofJobFailed the proofs could not be generated
+	ProofJobFailed ProofJobStatus = "failed"
+	// ProofJobCancelled the job was cancelled before it finished
+	ProofJobCancelled ProofJobStatus = "cancelled"
+)
+
+// ProofJobScope is the proof generated for a scope of the authorization request of a proof job
+type ProofJobScope struct {
+	ID        uint32     `json:"id"`
+	CircuitID string     `json:"circuitId"`
+	Proof     *FullProof `json:"proof"`
+}
+
+// ProofJob is the generation of the proofs of an authorization request, run in the background by a worker.
+// The queries are the ones of the request scopes, the result holds the proofs until the job expires.
+type ProofJob struct {
+	ID         uuid.UUID      `json:"id"`
+	Identifier string         `json:"identifier"`
+	Queries    pgtype.JSONB   `json:"queries"`
+	Status     ProofJobStatus `json:"status"`
+	Result     pgtype.JSONB   `json:"result"`
+	Error      *string        `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+	ExpiresAt  *time.Time     `json:"expires_at,omitempty"`
+}
+
+// IsFinished returns true if the job is not going to run anymore
+func (j *ProofJob) IsFinished() bool {
+	return j.Status == ProofJobDone || j.Status == ProofJobFailed || j.Status == ProofJobCancelled
+}
+
+// SetResult sets the proofs generated by the job
+func (j *ProofJob) SetResult(scopes []ProofJobScope) error {
+	if err := j.Result.Set(scopes); err != nil {
+		return fmt.Errorf("failed to set proof job result: %w", err)
+	}
+	return nil
+}
+
+// GetResult returns the proofs generated by the job, none if it is not done
+func (j *ProofJob) GetResult() ([]ProofJobScope, error) {
+	scopes := make([]ProofJobScope, 0)
+	if j.Result.Status != pgtype.Present {
+		return scopes, nil
+	}
+	if err := json.Unmarshal(j.Result.Bytes, &scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal proof job result: %w", err)
+	}
+	return scopes, nil
+}
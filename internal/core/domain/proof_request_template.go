@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+)
+
+// ProofRequestTemplate is a named proof request a verifier can send to holders without
+// defining its query on every request
+type ProofRequestTemplate struct {
+	ID                uuid.UUID    `json:"id"`
+	Name              string       `json:"name"`
+	CircuitID         string       `json:"circuit_id"`
+	Context           string       `json:"context"`
+	Type              string       `json:"type"`
+	AllowedIssuers    pgtype.JSONB `json:"allowed_issuers"`
+	CredentialSubject pgtype.JSONB `json:"credential_subject"`
+	Reason            string       `json:"reason"`
+	ModifiedAt        time.Time    `json:"modified_at,omitempty"`
+	CreatedAt         time.Time    `json:"created_at,omitempty"`
+}
+
+// ProofRequestOverrides are the template values a single request can replace
+type ProofRequestOverrides struct {
+	AllowedIssuers    []string
+	CredentialSubject map[string]interface{}
+	Reason            *string
+}
+
+// NewProofRequestTemplate returns a new proof request template
+func NewProofRequestTemplate(name, circuitID, context, typ string, allowedIssuers []string, credentialSubject map[string]interface{}, reason string) (*ProofRequestTemplate, error) {
+	template := &ProofRequestTemplate{
+		Name:      name,
+		CircuitID: circuitID,
+		Context:   context,
+		Type:      typ,
+		Reason:    reason,
+	}
+	if err := template.SetAllowedIssuers(allowedIssuers); err != nil {
+		return nil, err
+	}
+	if err := template.SetCredentialSubject(credentialSubject); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// SetAllowedIssuers sets the DIDs of the issuers whose credentials the template accepts, "*" for any
+func (t *ProofRequestTemplate) SetAllowedIssuers(allowedIssuers []string) error {
+	if err := t.AllowedIssuers.Set(allowedIssuers); err != nil {
+		return fmt.Errorf("failed to set allowed issuers: %w", err)
+	}
+	return nil
+}
+
+// SetCredentialSubject sets the predicates of the template, by credential subject field
+func (t *ProofRequestTemplate) SetCredentialSubject(credentialSubject map[string]interface{}) error {
+	if credentialSubject == nil {
+		t.CredentialSubject = pgtype.JSONB{Status: pgtype.Null}
+		return nil
+	}
+	if err := t.CredentialSubject.Set(credentialSubject); err != nil {
+		return fmt.Errorf("failed to set credential subject: %w", err)
+	}
+	return nil
+}
+
+// GetAllowedIssuers returns the DIDs of the issuers whose credentials the template accepts
+func (t *ProofRequestTemplate) GetAllowedIssuers() ([]string, error) {
+	allowedIssuers := make([]string, 0)
+	if t.AllowedIssuers.Status != pgtype.Present {
+		return allowedIssuers, nil
+	}
+	if err := json.Unmarshal(t.AllowedIssuers.Bytes, &allowedIssuers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal allowed issuers: %w", err)
+	}
+	return allowedIssuers, nil
+}
+
+// GetCredentialSubject returns the predicates of the template, by credential subject field
+func (t *ProofRequestTemplate) GetCredentialSubject() (map[string]interface{}, error) {
+	credentialSubject := make(map[string]interface{})
+	if t.CredentialSubject.Status != pgtype.Present {
+		return credentialSubject, nil
+	}
+	if err := json.Unmarshal(t.CredentialSubject.Bytes, &credentialSubject); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential subject: %w", err)
+	}
+	return credentialSubject, nil
+}
+
+// ProofRequest returns the proof request of the template with the given scope id and overrides applied
+func (t *ProofRequestTemplate) ProofRequest(id uint32, overrides ProofRequestOverrides) (protocol.ZeroKnowledgeProofRequest, error) {
+	allowedIssuers, err := t.GetAllowedIssuers()
+	if err != nil {
+		return protocol.ZeroKnowledgeProofRequest{}, err
+	}
+	if len(overrides.AllowedIssuers) > 0 {
+		allowedIssuers = overrides.AllowedIssuers
+	}
+
+	credentialSubject, err := t.GetCredentialSubject()
+	if err != nil {
+		return protocol.ZeroKnowledgeProofRequest{}, err
+	}
+	if overrides.CredentialSubject != nil {
+		credentialSubject = overrides.CredentialSubject
+	}
+
+	return protocol.ZeroKnowledgeProofRequest{
+		ID:        id,
+		CircuitID: t.CircuitID,
+		Query: map[string]interface{}{
+			"allowedIssuers":    allowedIssuers,
+			"credentialSubject": credentialSubject,
+			"context":           t.Context,
+			"type":              t.Type,
+		},
+	}, nil
+}
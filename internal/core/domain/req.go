@@ -1,23 +1,229 @@
 package domain
 
 import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/iden3/go-iden3-crypto/constants"
+	"github.com/jackc/pgtype"
 
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
 )
 
+const (
+	// RequestChallengeField is the field of the scope queries that carries the challenge of the request to the holder
+	RequestChallengeField = "challenge"
+	// CallbackSessionParam is the query parameter of the callback url that carries the id of the stored request
+	CallbackSessionParam = "sessionId"
+)
+
+// AuthRequestStatus represents the lifecycle status of a stored auth request
+type AuthRequestStatus string
+
+const (
+	// AuthRequestStatusCreated is the default status for a stored auth request
+	AuthRequestStatusCreated AuthRequestStatus = "created"
+	// AuthRequestStatusPending is the status of a request whose response is being verified
+	AuthRequestStatusPending AuthRequestStatus = "pending"
+	// AuthRequestStatusVerified is the status of a request with a valid response
+	AuthRequestStatusVerified AuthRequestStatus = "verified"
+	// AuthRequestStatusRejected is the status of a request with an invalid response
+	AuthRequestStatusRejected AuthRequestStatus = "rejected"
+	// AuthRequestStatusExpired is the status of a request that was not answered in time
+	AuthRequestStatusExpired AuthRequestStatus = "expired"
+)
+
+// authRequestTransitions holds, for every status, the statuses a request can move to
+var authRequestTransitions = map[AuthRequestStatus][]AuthRequestStatus{
+	AuthRequestStatusCreated: {AuthRequestStatusPending, AuthRequestStatusExpired},
+	AuthRequestStatusPending: {AuthRequestStatusVerified, AuthRequestStatusRejected, AuthRequestStatusExpired},
+}
+
+// PreviousStatuses returns the statuses from which a request can move to s
+func (s AuthRequestStatus) PreviousStatuses() []AuthRequestStatus {
+	previous := make([]AuthRequestStatus, 0)
+	for from, targets := range authRequestTransitions {
+		for _, to := range targets {
+			if to == s {
+				previous = append(previous, from)
+			}
+		}
+	}
+	return previous
+}
+
+// CanTransitionTo returns true if a request in status s can move to status to
+func (s AuthRequestStatus) CanTransitionTo(to AuthRequestStatus) bool {
+	for _, target := range authRequestTransitions[s] {
+		if target == to {
+			return true
+		}
+	}
+	return false
+}
+
 // AuthRequest struct
 type AuthRequest struct {
-	ID               uuid.UUID       `json:"-"`
-	Identifier       *string         `json:"identifier"`
+	ID          uuid.UUID         `json:"-"`
+	ThreadID    string            `json:"thread_id"`
+	Verifier    string            `json:"verifier"`
+	Identifier  *string           `json:"identifier"`
+	Request     pgtype.JSONB      `json:"request"`
+	Status      AuthRequestStatus `json:"status"`
+	Challenge   *string           `json:"challenge,omitempty"`
+	VerifiedDID *string           `json:"verified_did,omitempty"`
+	Disclosed   pgtype.JSONB      `json:"disclosed"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"`
+	ModifiedAt  time.Time         `json:"modified_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at,omitempty"`
 }
 
-// FromAuthRequester TODO
-func FromAuthRequester() (*AuthRequest, error) {
+// FromAuthRequester builds a new AuthRequest from the authorization request message sent to the holder
+// and the challenge the holder proofs must be bound to
+func FromAuthRequester(message protocol.AuthorizationRequestMessage, challenge *big.Int, expiresAt *time.Time) (*AuthRequest, error) {
 	res := AuthRequest{
-		
+		ThreadID:  message.ThreadID,
+		Verifier:  message.From,
+		Status:    AuthRequestStatusCreated,
+		ExpiresAt: expiresAt,
+	}
+
+	if challenge != nil {
+		value := challenge.String()
+		res.Challenge = &value
+	}
+
+	if message.To != "" {
+		holder := message.To
+		res.Identifier = &holder
+	}
+
+	if err := res.Request.Set(message); err != nil {
+		return nil, fmt.Errorf("failed to set auth request message: %w", err)
 	}
 
 	return &res, nil
 }
 
+// GetAuthorizationRequestMessage returns the authorization request message stored in the request
+func (a *AuthRequest) GetAuthorizationRequestMessage() (*protocol.AuthorizationRequestMessage, error) {
+	var message protocol.AuthorizationRequestMessage
+	if err := json.Unmarshal(a.Request.Bytes, &message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth request message: %w", err)
+	}
+	return &message, nil
+}
+
+// GetChallenge returns the challenge the holder proofs must be bound to, nil if the request has none
+func (a *AuthRequest) GetChallenge() (*big.Int, error) {
+	if a.Challenge == nil {
+		return nil, nil
+	}
+	challenge, ok := new(big.Int).SetString(*a.Challenge, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid auth request challenge <%s>", *a.Challenge)
+	}
+	return challenge, nil
+}
+
+// NewRequestChallenge returns a random challenge for an authorization request, an element of the snark field
+func NewRequestChallenge() (*big.Int, error) {
+	challenge, err := rand.Int(rand.Reader, constants.Q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate request challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// SetRequestChallenge sets the challenge in the query of every scope of the request, the holder proves with it
+func SetRequestChallenge(message *protocol.AuthorizationRequestMessage, challenge *big.Int) {
+	for i := range message.Body.Scope {
+		if message.Body.Scope[i].Query == nil {
+			message.Body.Scope[i].Query = make(map[string]interface{})
+		}
+		message.Body.Scope[i].Query[RequestChallengeField] = challenge.String()
+	}
+}
+
+// SetCallbackSession adds the id of the stored request to the callback url of the message, so that
+// the response the wallet sends to it can be matched with the request
+func SetCallbackSession(message *protocol.AuthorizationRequestMessage, sessionID uuid.UUID) error {
+	callbackURL, err := url.Parse(message.Body.CallbackURL)
+	if err != nil {
+		return fmt.Errorf("invalid callback url <%s>: %w", message.Body.CallbackURL, err)
+	}
+	query := callbackURL.Query()
+	query.Set(CallbackSessionParam, sessionID.String())
+	callbackURL.RawQuery = query.Encode()
+	message.Body.CallbackURL = callbackURL.String()
+	return nil
+}
+
+// CallbackSession returns the id of the stored request carried by the callback url of the message
+func CallbackSession(message protocol.AuthorizationRequestMessage) (uuid.UUID, error) {
+	callbackURL, err := url.Parse(message.Body.CallbackURL)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid callback url <%s>: %w", message.Body.CallbackURL, err)
+	}
+	sessionID, err := uuid.Parse(callbackURL.Query().Get(CallbackSessionParam))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("callback url <%s> has no session: %w", message.Body.CallbackURL, err)
+	}
+	return sessionID, nil
+}
+
+// ScopeChallenge returns the challenge carried by the query of the scope, nil if it has none
+func ScopeChallenge(scope protocol.ZeroKnowledgeProofRequest) (*big.Int, error) {
+	value, ok := scope.Query[RequestChallengeField]
+	if !ok {
+		return nil, nil
+	}
+	decimal, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("scope %d: challenge must be a decimal string", scope.ID)
+	}
+	challenge, ok := new(big.Int).SetString(decimal, 10)
+	if !ok {
+		return nil, fmt.Errorf("scope %d: invalid challenge <%s>", scope.ID, decimal)
+	}
+	return challenge, nil
+}
+
+// IsExpired returns true if the request has an expiration in the past
+func (a *AuthRequest) IsExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && a.ExpiresAt.Before(now)
+}
+
+// GetDisclosed returns the verifiable presentations disclosed by the holder, by scope id
+func (a *AuthRequest) GetDisclosed() (map[string]interface{}, error) {
+	disclosed := make(map[string]interface{})
+	if a.Disclosed.Status != pgtype.Present {
+		return disclosed, nil
+	}
+	if err := json.Unmarshal(a.Disclosed.Bytes, &disclosed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal disclosed values: %w", err)
+	}
+	return disclosed, nil
+}
+
+// DisclosedFromResponse returns the verifiable presentations of the response scopes, by scope id
+func DisclosedFromResponse(response protocol.AuthorizationResponseMessage) (pgtype.JSONB, error) {
+	presentations := make(map[string]json.RawMessage)
+	for _, scope := range response.Body.Scope {
+		if len(scope.VerifiablePresentation) == 0 || string(scope.VerifiablePresentation) == "null" {
+			continue
+		}
+		presentations[fmt.Sprint(scope.ID)] = scope.VerifiablePresentation
+	}
+
+	var disclosed pgtype.JSONB
+	if err := disclosed.Set(presentations); err != nil {
+		return disclosed, fmt.Errorf("failed to set disclosed values: %w", err)
+	}
+	return disclosed, nil
+}
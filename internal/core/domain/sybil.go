@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+)
+
+// StateCommitmentCredential is the schema type of the state commitment claims
+const StateCommitmentCredential = "StateCommitmentCredential"
+
+// StateCommitmentSchemaHash is the schema hash of the state commitment claims, hardcoded in the sybil resistance circuits.
+// An identity keeps one state commitment claim in its claims tree, with a random secret in the value slot A, and
+// the circuits derive its nullifier for a verifier CRS from that secret.
+// Hex: da5b2efc8386250550e458a33b7926c5
+var StateCommitmentSchemaHash = core.SchemaHash{218, 91, 46, 252, 131, 134, 37, 5, 80, 228, 88, 163, 59, 121, 38, 197}
+
+// NewStateCommitmentClaim returns the core claim committing the identity to the given secret
+func NewStateCommitmentClaim(secret *big.Int, revNonce uint64) (*core.Claim, error) {
+	slotA, err := core.NewElemBytesFromInt(secret)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewClaim(StateCommitmentSchemaHash,
+		core.WithValueData(slotA, core.ElemBytes{}),
+		core.WithRevocationNonce(revNonce))
+}
+
+// SybilNullifier is the registration of a person with a verifier. The sybil id of a sybil resistance proof is the same
+// for every identity and profile of the holder for a given CRS, so a verifier accepts it only once.
+type SybilNullifier struct {
+	ID            uuid.UUID `json:"id"`
+	Verifier      string    `json:"verifier"`
+	CRS           string    `json:"crs"`
+	SybilID       string    `json:"sybil_id"`
+	UserID        string    `json:"user_id"`
+	AuthRequestID uuid.UUID `json:"auth_request_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// ClaimExpirationService is the interface implemented by the service that looks for the expired claims
+type ClaimExpirationService interface {
+	Sweep(ctx context.Context) ([]*domain.Claim, error)
+}
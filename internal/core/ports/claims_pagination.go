@@ -0,0 +1,90 @@
+package ports
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iden3/go-schema-processor/verifiable"
+)
+
+// MaxClaimsPageSize is the maximum number of claims of a page
+const MaxClaimsPageSize = 1000
+
+// ClaimsSortField is a field the claims can be sorted by
+type ClaimsSortField string
+
+const (
+	ClaimsSortByCreatedAt  ClaimsSortField = "created_at" // ClaimsSortByCreatedAt sorts the claims by creation date
+	ClaimsSortByExpiration ClaimsSortField = "expiration" // ClaimsSortByExpiration sorts the claims by expiration, the claims without one first
+)
+
+// ClaimsCursor is the position of the last claim of a page, the next page starts right after it.
+// It is only valid for the same sorting it was returned for.
+type ClaimsCursor struct {
+	SortBy     ClaimsSortField `json:"s"`
+	Descending bool            `json:"d"`
+	Value      string          `json:"v"`
+	ID         uuid.UUID       `json:"id"`
+}
+
+// String returns the opaque representation of the cursor sent to the clients
+func (c ClaimsCursor) String() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseClaimsCursor parses the cursor returned to the clients
+func ParseClaimsCursor(cursor string) (*ClaimsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var c ClaimsCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Value == "" || c.ID == uuid.Nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &c, nil
+}
+
+// ClaimsPage is a page of the claims that match a filter
+type ClaimsPage struct {
+	Credentials []*verifiable.W3CCredential
+	Total       int
+	NextCursor  *ClaimsCursor
+}
+
+// SetPagination sets the sorting and the page of the filter. Without limit all the claims are returned.
+func (f *Filter) SetPagination(sortBy *string, descending *bool, cursor *string, limit *int) error {
+	if sortBy != nil && *sortBy != "" {
+		switch ClaimsSortField(*sortBy) {
+		case ClaimsSortByCreatedAt, ClaimsSortByExpiration:
+			f.SortBy = ClaimsSortField(*sortBy)
+		default:
+			return fmt.Errorf("claims cannot be sorted by %s", *sortBy)
+		}
+	}
+	if descending != nil {
+		f.Descending = *descending
+	}
+
+	if limit != nil {
+		if *limit < 1 || *limit > MaxClaimsPageSize {
+			return fmt.Errorf("limit must be between 1 and %d", MaxClaimsPageSize)
+		}
+		f.Limit = *limit
+	}
+
+	if cursor != nil && *cursor != "" {
+		c, err := ParseClaimsCursor(*cursor)
+		if err != nil {
+			return err
+		}
+		if c.SortBy != f.SortBy || c.Descending != f.Descending {
+			return fmt.Errorf("the cursor is for another sorting")
+		}
+		f.Cursor = c
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -20,8 +21,11 @@ type ClaimsRepository interface {
 	GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifier *core.DID, claimID uuid.UUID) (*domain.Claim, error)
 	FindOneClaimBySchemaHash(ctx context.Context, conn db.Querier, subject *core.DID, schemaHash string) (*domain.Claim, error)
 	GetAllByIssuerID(ctx context.Context, conn db.Querier, identifier *core.DID, filter *Filter) ([]*domain.Claim, error)
+	CountByIssuerID(ctx context.Context, conn db.Querier, identifier *core.DID, filter *Filter) (int, error)
 	GetAllByState(ctx context.Context, conn db.Querier, did *core.DID, state *merkletree.Hash) (claims []domain.Claim, err error)
 	UpdateState(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error)
 	GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier, identifier *core.DID, publishingState string, schemaHash string) ([]*domain.Claim, error)
 	UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error)
+	MarkExpired(ctx context.Context, conn db.Querier, now time.Time) ([]*domain.Claim, error)
+	UnmarkExpired(ctx context.Context, conn db.Querier, ids []uuid.UUID) error
 }
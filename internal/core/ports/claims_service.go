@@ -13,6 +13,7 @@ import (
 	"github.com/lastingasset/wallet-service/iden3comm/protocol"
 
 	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
 )
 
 // CreateClaimRequest struct
@@ -25,6 +26,24 @@ type CreateClaimRequest struct {
 	Version               uint32
 	SubjectPos            string
 	MerklizedRootPosition string
+	Updatable             bool
+}
+
+// BatchClaimResult is the result of one of the requests of a batch, the claim issued or the reason it was not
+type BatchClaimResult struct {
+	Claim *domain.Claim
+	Err   error
+}
+
+// BatchSaveFunc stores the records that go with a batch of claims in the transaction the claims are stored in
+type BatchSaveFunc func(ctx context.Context, tx db.Querier) error
+
+// UpdateClaimRequest struct
+type UpdateClaimRequest struct {
+	DID               *core.DID
+	ClaimID           uuid.UUID
+	CredentialSubject map[string]any
+	Expiration        *time.Time
 }
 
 // AgentRequest struct
@@ -42,14 +61,20 @@ type AgentRequest struct {
 type Filter struct {
 	Self       *bool
 	Revoked    *bool
+	Expired    *bool
 	SchemaHash string
 	SchemaType string
 	Subject    string
 	QueryField string
+	Search     string
+	SortBy     ClaimsSortField
+	Descending bool
+	Limit      int
+	Cursor     *ClaimsCursor
 }
 
 // NewClaimsFilter returns a valid claims filter
-func NewClaimsFilter(schemaHash, schemaType, subject, queryField *string, self, revoked *bool) (*Filter, error) {
+func NewClaimsFilter(schemaHash, schemaType, subject, queryField, search *string, self, revoked, expired *bool) (*Filter, error) {
 	var filter Filter
 
 	if self != nil && *self {
@@ -71,6 +96,10 @@ func NewClaimsFilter(schemaHash, schemaType, subject, queryField *string, self,
 		filter.Revoked = revoked
 	}
 
+	if expired != nil {
+		filter.Expired = expired
+	}
+
 	if subject != nil && *subject != "" {
 		filter.Subject = *subject
 	}
@@ -79,11 +108,18 @@ func NewClaimsFilter(schemaHash, schemaType, subject, queryField *string, self,
 		filter.QueryField = *queryField
 	}
 
+	if search != nil && *search != "" {
+		filter.Search = *search
+	}
+
+	filter.SortBy = ClaimsSortByCreatedAt
+	filter.Descending = true
+
 	return &filter, nil
 }
 
 // NewCreateClaimRequest returns a new claim object with the given parameters
-func NewCreateClaimRequest(did *core.DID, credentialSchema string, credentialSubject map[string]any, expiration *int64, typ string, cVersion *uint32, subjectPos *string, merklizedRootPosition *string) *CreateClaimRequest {
+func NewCreateClaimRequest(did *core.DID, credentialSchema string, credentialSubject map[string]any, expiration *int64, typ string, cVersion *uint32, subjectPos *string, merklizedRootPosition *string, updatable *bool) *CreateClaimRequest {
 	req := &CreateClaimRequest{
 		DID:               did,
 		Schema:            credentialSchema,
@@ -103,6 +139,23 @@ func NewCreateClaimRequest(did *core.DID, credentialSchema string, credentialSub
 	if merklizedRootPosition != nil {
 		req.MerklizedRootPosition = *merklizedRootPosition
 	}
+	if updatable != nil {
+		req.Updatable = *updatable
+	}
+	return req
+}
+
+// NewUpdateClaimRequest returns a new request to update or reissue the claim with the given credential subject
+func NewUpdateClaimRequest(did *core.DID, claimID uuid.UUID, credentialSubject map[string]any, expiration *int64) *UpdateClaimRequest {
+	req := &UpdateClaimRequest{
+		DID:               did,
+		ClaimID:           claimID,
+		CredentialSubject: credentialSubject,
+	}
+	if expiration != nil {
+		t := time.Unix(*expiration, 0)
+		req.Expiration = &t
+	}
 	return req
 }
 
@@ -135,7 +188,9 @@ func NewAgentRequest(basicMessage *comm.BasicMessage) (*AgentRequest, error) {
 		return nil, err
 	}
 
-	if basicMessage.Type != protocol.CredentialFetchRequestMessageType && basicMessage.Type != protocol.RevocationStatusRequestMessageType {
+	if basicMessage.Type != protocol.CredentialFetchRequestMessageType &&
+		basicMessage.Type != protocol.CredentialIssuanceRequestMessageType &&
+		basicMessage.Type != protocol.RevocationStatusRequestMessageType {
 		return nil, fmt.Errorf("invalid type")
 	}
 
@@ -157,12 +212,15 @@ func NewAgentRequest(basicMessage *comm.BasicMessage) (*AgentRequest, error) {
 // ClaimsService is the interface implemented by the claim service
 type ClaimsService interface {
 	CreateClaim(ctx context.Context, claimReq *CreateClaimRequest) (*domain.Claim, error)
+	CreateClaimsBatch(ctx context.Context, did *core.DID, reqs []*CreateClaimRequest, save BatchSaveFunc) ([]BatchClaimResult, error)
+	UpdateClaim(ctx context.Context, req *UpdateClaimRequest) (*domain.Claim, error)
 	Revoke(ctx context.Context, id string, nonce uint64, description string) error
-	GetAll(ctx context.Context, did *core.DID, filter *Filter) ([]*verifiable.W3CCredential, error)
+	GetAll(ctx context.Context, did *core.DID, filter *Filter) (*ClaimsPage, error)
 	GetRevocationStatus(ctx context.Context, id string, nonce uint64) (*verifiable.RevocationStatus, error)
 	GetByID(ctx context.Context, issID *core.DID, id uuid.UUID) (*domain.Claim, error)
 	Agent(ctx context.Context, req *AgentRequest) (*domain.Agent, error)
 	GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim, error)
 	GetAuthClaimForPublishing(ctx context.Context, did *core.DID, state string) (*domain.Claim, error)
+	GetStateCommitmentClaim(ctx context.Context, did *core.DID) (*domain.Claim, error)
 	UpdateClaimsMTPAndState(ctx context.Context, currentState *domain.IdentityState) error
 }
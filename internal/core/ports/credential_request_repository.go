@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// CredentialRequestRepository is the interface that defines the available methods
+type CredentialRequestRepository interface {
+	Save(ctx context.Context, conn db.Querier, req *domain.CredentialRequest) error
+	GetByID(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error)
+	GetByIDForUpdate(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error)
+	GetByThread(ctx context.Context, conn db.Querier, issuer *core.DID, holder *core.DID, threadID string) (*domain.CredentialRequest, error)
+	GetAll(ctx context.Context, conn db.Querier, issuer *core.DID, status *domain.CredentialRequestStatus) ([]*domain.CredentialRequest, error)
+	UpdateStatus(ctx context.Context, conn db.Querier, req *domain.CredentialRequest, from domain.CredentialRequestStatus) error
+}
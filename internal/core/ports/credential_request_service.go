@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// BatchSubmitResult is the result of one of the claims of a batch created through the api: the claim issued, the
+// request an issuance policy holds for approval, or the reason it was not issued
+type BatchSubmitResult struct {
+	Claim   *domain.Claim
+	Request *domain.CredentialRequest
+	Err     error
+}
+
+// CredentialRequestService is the interface implemented by the service that queues the credentials requested
+// by the holders, or held by the issuance policies, for the approval of the issuer
+type CredentialRequestService interface {
+	Agent(ctx context.Context, req *AgentRequest) (*domain.Agent, error)
+	Submit(ctx context.Context, req *CreateClaimRequest, actor string) (*domain.CredentialRequest, error)
+	SubmitBatch(ctx context.Context, did *core.DID, reqs []*CreateClaimRequest, actor string) ([]BatchSubmitResult, error)
+	GetAll(ctx context.Context, issuer *core.DID, status *domain.CredentialRequestStatus) ([]*domain.CredentialRequest, error)
+	GetByID(ctx context.Context, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error)
+	Approve(ctx context.Context, issuer *core.DID, id uuid.UUID, actor string) (*protocol.CredentialsOfferMessage, error)
+	Reject(ctx context.Context, issuer *core.DID, id uuid.UUID, actor string, reason string) (*domain.CredentialRequest, error)
+}
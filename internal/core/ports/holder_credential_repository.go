@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// HolderCredentialRepository is the interface that defines the available methods
+type HolderCredentialRepository interface {
+	Save(ctx context.Context, conn db.Querier, credential *domain.HolderCredential) (uuid.UUID, error)
+	GetByID(ctx context.Context, conn db.Querier, holder *core.DID, id uuid.UUID) (*domain.HolderCredential, error)
+	GetAll(ctx context.Context, conn db.Querier, holder *core.DID, filter *HolderCredentialFilter) ([]*domain.HolderCredential, error)
+	UpdateRevoked(ctx context.Context, conn db.Querier, credential *domain.HolderCredential) error
+	RevokeByIssuerNonce(ctx context.Context, conn db.Querier, issuer *core.DID, revNonce domain.RevNonceUint64) error
+	Delete(ctx context.Context, conn db.Querier, holder *core.DID, id uuid.UUID) error
+}
@@ -0,0 +1,75 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// HolderCredentialFilter struct
+type HolderCredentialFilter struct {
+	SchemaHash string
+	SchemaType string
+	Issuer     string
+	Source     domain.HolderCredentialSource
+	Revoked    *bool
+	QueryField string
+}
+
+// NewHolderCredentialFilter returns a valid holder credentials filter
+func NewHolderCredentialFilter(schemaHash, schemaType, issuer, source, queryField *string, revoked *bool) (*HolderCredentialFilter, error) {
+	var filter HolderCredentialFilter
+	if schemaHash != nil {
+		filter.SchemaHash = *schemaHash
+	}
+	if schemaType != nil {
+		filter.SchemaType = *schemaType
+	}
+	if issuer != nil {
+		if _, err := core.ParseDID(*issuer); err != nil {
+			return nil, fmt.Errorf("invalid issuer did: %w", err)
+		}
+		filter.Issuer = *issuer
+	}
+	if source != nil && *source != "" {
+		switch s := domain.HolderCredentialSource(*source); s {
+		case domain.HolderCredentialSourceOffer, domain.HolderCredentialSourceImport, domain.HolderCredentialSourceSelfIssued:
+			filter.Source = s
+		default:
+			return nil, fmt.Errorf("unknown credential source <%s>", *source)
+		}
+	}
+	if queryField != nil {
+		filter.QueryField = *queryField
+	}
+	filter.Revoked = revoked
+
+	return &filter, nil
+}
+
+// ImportCredentialRequest struct
+type ImportCredentialRequest struct {
+	DID        *core.DID
+	Credential verifiable.W3CCredential
+}
+
+// NewImportCredentialRequest returns a new import credential request for the given holder
+func NewImportCredentialRequest(did *core.DID, credential verifiable.W3CCredential) *ImportCredentialRequest {
+	return &ImportCredentialRequest{
+		DID:        did,
+		Credential: credential,
+	}
+}
+
+// HolderCredentialService is the interface implemented by the holder credential service
+type HolderCredentialService interface {
+	Import(ctx context.Context, req *ImportCredentialRequest) (*domain.HolderCredential, error)
+	GetAll(ctx context.Context, holder *core.DID, filter *HolderCredentialFilter) ([]*domain.HolderCredential, error)
+	GetByID(ctx context.Context, holder *core.DID, id uuid.UUID) (*domain.HolderCredential, error)
+	Delete(ctx context.Context, holder *core.DID, id uuid.UUID) error
+}
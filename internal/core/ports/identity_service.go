@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"math/big"
 
 	core "github.com/iden3/go-iden3-core"
 	"github.com/iden3/go-schema-processor/verifiable"
@@ -24,4 +25,7 @@ type IdentityService interface {
 	GetNonTransactedStates(ctx context.Context) ([]domain.IdentityState, error)
 	UpdateIdentityState(ctx context.Context, state *domain.IdentityState) error
 	GetTransactedStates(ctx context.Context) ([]domain.IdentityState, error)
+	CreateProfile(ctx context.Context, did *core.DID, nonce *domain.ProfileNonce, verifier *string) (*domain.Profile, error)
+	GetProfiles(ctx context.Context, did *core.DID) ([]domain.Profile, error)
+	ResolveProfile(ctx context.Context, did *core.DID) (*core.DID, *big.Int, error)
 }
@@ -14,5 +14,6 @@ type IdentityStateRepository interface {
 	Save(ctx context.Context, conn db.Querier, state domain.IdentityState) error
 	GetLatestStateByIdentifier(ctx context.Context, conn db.Querier, identifier *core.DID) (*domain.IdentityState, error)
 	GetStatesByStatus(ctx context.Context, conn db.Querier, status domain.IdentityStatus) ([]domain.IdentityState, error)
+	GetConfirmedStates(ctx context.Context, conn db.Querier, identifier *core.DID) ([]domain.IdentityState, error)
 	UpdateState(ctx context.Context, conn db.Querier, state *domain.IdentityState) (int64, error)
 }
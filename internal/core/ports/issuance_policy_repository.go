@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// IssuancePolicyRepository is the interface that defines the available methods
+type IssuancePolicyRepository interface {
+	Save(ctx context.Context, conn db.Querier, policy *domain.IssuancePolicy) error
+	GetAll(ctx context.Context, conn db.Querier, issuer *core.DID) ([]*domain.IssuancePolicy, error)
+	Delete(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) error
+}
+
+// IssuanceAuditRepository is the interface that defines the available methods
+type IssuanceAuditRepository interface {
+	Save(ctx context.Context, conn db.Querier, audit *domain.IssuanceAudit) error
+	GetAll(ctx context.Context, conn db.Querier, issuer *core.DID, requestID *uuid.UUID) ([]*domain.IssuanceAudit, error)
+}
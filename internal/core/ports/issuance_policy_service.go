@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// CreateIssuancePolicyRequest struct
+type CreateIssuancePolicyRequest struct {
+	DID              *core.DID
+	SchemaType       string
+	SubjectAllowlist []string
+	FieldConstraints map[string]interface{}
+	Action           domain.IssuancePolicyAction
+	Priority         int
+}
+
+// NewCreateIssuancePolicyRequest returns a new request to create an issuance policy of the given issuer
+func NewCreateIssuancePolicyRequest(did *core.DID, schemaType *string, subjectAllowlist *[]string, fieldConstraints *map[string]interface{}, action domain.IssuancePolicyAction, priority *int) *CreateIssuancePolicyRequest {
+	req := &CreateIssuancePolicyRequest{
+		DID:    did,
+		Action: action,
+	}
+	if schemaType != nil {
+		req.SchemaType = *schemaType
+	}
+	if subjectAllowlist != nil {
+		req.SubjectAllowlist = *subjectAllowlist
+	}
+	if fieldConstraints != nil {
+		req.FieldConstraints = *fieldConstraints
+	}
+	if priority != nil {
+		req.Priority = *priority
+	}
+	return req
+}
+
+// IssuancePolicyService is the interface implemented by the service that manages the issuance policies of the issuers
+type IssuancePolicyService interface {
+	Create(ctx context.Context, req *CreateIssuancePolicyRequest) (*domain.IssuancePolicy, error)
+	GetAll(ctx context.Context, issuer *core.DID) ([]*domain.IssuancePolicy, error)
+	Delete(ctx context.Context, issuer *core.DID, id uuid.UUID) error
+	Evaluate(ctx context.Context, issuer *core.DID, schemaType string, credentialSubject map[string]interface{}) (*domain.IssuancePolicy, error)
+	GetAudit(ctx context.Context, issuer *core.DID, requestID *uuid.UUID) ([]*domain.IssuanceAudit, error)
+}
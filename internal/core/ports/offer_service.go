@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// AcceptOfferRequest struct
+type AcceptOfferRequest struct {
+	DID   *core.DID
+	Offer protocol.CredentialsOfferMessage
+}
+
+// NewAcceptOfferRequest returns a new accept offer request for the given holder
+func NewAcceptOfferRequest(did *core.DID, offer protocol.CredentialsOfferMessage) *AcceptOfferRequest {
+	return &AcceptOfferRequest{
+		DID:   did,
+		Offer: offer,
+	}
+}
+
+// OfferService is the interface implemented by the credential offer service
+type OfferService interface {
+	Accept(ctx context.Context, req *AcceptOfferRequest) ([]*domain.HolderCredential, error)
+}
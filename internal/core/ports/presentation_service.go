@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// CreatePresentationRequest struct
+type CreatePresentationRequest struct {
+	DID         *core.DID
+	Credentials []uuid.UUID
+	Challenge   string
+	Domain      string
+}
+
+// NewCreatePresentationRequest returns a new request to present the given held credentials to a verifier
+func NewCreatePresentationRequest(did *core.DID, credentials []uuid.UUID, challenge string, domain string) *CreatePresentationRequest {
+	return &CreatePresentationRequest{
+		DID:         did,
+		Credentials: credentials,
+		Challenge:   challenge,
+		Domain:      domain,
+	}
+}
+
+// VerifyPresentationRequest struct
+type VerifyPresentationRequest struct {
+	Presentation domain.VerifiablePresentation
+	Challenge    string
+	Domain       string
+}
+
+// NewVerifyPresentationRequest returns a new request to verify a presentation against the challenge and domain of the verifier
+func NewVerifyPresentationRequest(presentation domain.VerifiablePresentation, challenge string, domain string) *VerifyPresentationRequest {
+	return &VerifyPresentationRequest{
+		Presentation: presentation,
+		Challenge:    challenge,
+		Domain:       domain,
+	}
+}
+
+// PresentationService is the interface implemented by the verifiable presentation service
+type PresentationService interface {
+	Create(ctx context.Context, req *CreatePresentationRequest) (*domain.VerifiablePresentation, error)
+	Verify(ctx context.Context, req *VerifyPresentationRequest) error
+}
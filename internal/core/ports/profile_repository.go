@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ProfileRepository is the interface that defines the available methods
+type ProfileRepository interface {
+	Save(ctx context.Context, conn db.Querier, profile *domain.Profile) (uuid.UUID, error)
+	GetByProfileDID(ctx context.Context, conn db.Querier, profileDID *core.DID) (*domain.Profile, error)
+	GetByVerifier(ctx context.Context, conn db.Querier, identifier *core.DID, verifier string) (*domain.Profile, error)
+	GetAllByIdentifier(ctx context.Context, conn db.Querier, identifier *core.DID) ([]domain.Profile, error)
+}
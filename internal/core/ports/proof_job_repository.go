@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ProofJobRepository is the interface that defines the available methods
+type ProofJobRepository interface {
+	Save(ctx context.Context, conn db.Querier, job *domain.ProofJob) error
+	GetByID(ctx context.Context, conn db.Querier, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error)
+	Next(ctx context.Context, conn db.Querier, staleBefore time.Time) (*domain.ProofJob, error)
+	Finish(ctx context.Context, conn db.Querier, job *domain.ProofJob) error
+	Release(ctx context.Context, conn db.Querier, job *domain.ProofJob) error
+	Cancel(ctx context.Context, conn db.Querier, identifier *core.DID, id uuid.UUID, expiresAt time.Time) (*domain.ProofJob, error)
+	DeleteExpired(ctx context.Context, conn db.Querier, now time.Time) (int64, error)
+}
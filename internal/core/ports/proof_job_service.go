@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// ProofJobService is the interface implemented by the service that generates the proofs of authorization
+// requests in the background
+type ProofJobService interface {
+	Create(ctx context.Context, identifier *core.DID, queries []Query) (*domain.ProofJob, error)
+	GetByID(ctx context.Context, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error)
+	Cancel(ctx context.Context, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ProofRequestTemplateRepository is the interface that defines the available methods
+type ProofRequestTemplateRepository interface {
+	Save(ctx context.Context, conn db.Querier, template *domain.ProofRequestTemplate) (uuid.UUID, error)
+	GetByID(ctx context.Context, conn db.Querier, id uuid.UUID) (*domain.ProofRequestTemplate, error)
+	GetAll(ctx context.Context, conn db.Querier) ([]domain.ProofRequestTemplate, error)
+	Delete(ctx context.Context, conn db.Querier, id uuid.UUID) error
+}
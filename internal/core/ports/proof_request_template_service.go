@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+)
+
+// ProofRequestTemplateRequest holds the values of a proof request template to create or update
+type ProofRequestTemplateRequest struct {
+	Name              string
+	CircuitID         string
+	Context           string
+	Type              string
+	AllowedIssuers    []string
+	CredentialSubject map[string]interface{}
+	Reason            string
+}
+
+// ProofRequestTemplateService is the interface implemented by the proof request template service
+type ProofRequestTemplateService interface {
+	Create(ctx context.Context, req *ProofRequestTemplateRequest) (*domain.ProofRequestTemplate, error)
+	Update(ctx context.Context, id uuid.UUID, req *ProofRequestTemplateRequest) (*domain.ProofRequestTemplate, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ProofRequestTemplate, error)
+	GetAll(ctx context.Context) ([]domain.ProofRequestTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
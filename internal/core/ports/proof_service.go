@@ -7,19 +7,22 @@ import (
 
 	core "github.com/iden3/go-iden3-core"
 
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 )
 
 // Query represents structure for query to atomic circuit
 type Query struct {
 	CircuitID                string
+	RequestID                uint32
 	Challenge                *big.Int
-	AllowedIssuers           string                 `json:"allowedIssuers"`
+	AllowedIssuers           []string               `json:"allowedIssuers"`
 	Req                      map[string]interface{} `json:"req"`
 	Context                  string                 `json:"context"`
 	Type                     string                 `json:"type"`
 	ClaimID                  string                 `json:"claimId"`
 	SkipClaimRevocationCheck bool                   `json:"skipClaimRevocationCheck"`
+	CRS                      *big.Int               `json:"crs"`
 }
 
 // SchemaType returns the schema type
@@ -27,9 +30,38 @@ func (q *Query) SchemaType() string {
 	return fmt.Sprintf("%s#%s", q.Context, q.Type)
 }
 
+// IsAllowedIssuer tells whether the query accepts credentials of the given issuer.
+// A query without allowed issuers or with "*" among them accepts any issuer.
+func (q *Query) IsAllowedIssuer(issuer string) bool {
+	if len(q.AllowedIssuers) == 0 {
+		return true
+	}
+	for _, allowed := range q.AllowedIssuers {
+		if allowed == "*" || allowed == issuer {
+			return true
+		}
+	}
+	return false
+}
+
+// Predicates returns one query for each field predicate of the query, sorted by field and operator.
+// A field without predicate, which asks to disclose its value, is a query of its own.
+// They are split as the verifier does, so the proofs are in the order it expects them.
+func (q *Query) Predicates() []Query {
+	conditions := pubsignals.SplitPredicates(q.Req)
+	predicates := make([]Query, len(conditions))
+	for i, condition := range conditions {
+		predicates[i] = *q
+		predicates[i].Req = condition
+	}
+	return predicates
+}
+
 // ProofService is the interface implemented by the ProofService service
 type ProofService interface {
 	PrepareInputs(ctx context.Context, identifier *core.DID, query Query) ([]byte, []*domain.Claim, error)
+	SelectCircuit(ctx context.Context, identifier *core.DID, query Query) (string, error)
 	GenerateAuthProof(ctx context.Context, identifier *core.DID, challenge *big.Int) (*domain.FullProof, error)
 	GenerateAgeProof(ctx context.Context, identifier *core.DID, query Query) (*domain.FullProof, error)
+	GenerateQueryProofs(ctx context.Context, identifier *core.DID, query Query) ([]*domain.FullProof, error)
 }
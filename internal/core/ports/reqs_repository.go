@@ -4,12 +4,18 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/db"
 )
 
-// AuthRequestsRepository is the interface that defines the available methods
+// ReqsRepository is the interface that defines the available methods
 type ReqsRepository interface {
 	Save(ctx context.Context, conn db.Querier, authRequest *domain.AuthRequest) (uuid.UUID, error)
+	GetByID(ctx context.Context, conn db.Querier, id uuid.UUID) (*domain.AuthRequest, error)
+	GetByThreadID(ctx context.Context, conn db.Querier, threadID string) (*domain.AuthRequest, error)
+	UpdateStatus(ctx context.Context, conn db.Querier, id uuid.UUID, status domain.AuthRequestStatus) error
+	UpdateVerification(ctx context.Context, conn db.Querier, id uuid.UUID, verifiedDID string, disclosed pgtype.JSONB) error
+	ListByVerifier(ctx context.Context, conn db.Querier, verifier string) ([]domain.AuthRequest, error)
 }
@@ -5,8 +5,11 @@ import (
 
 	"time"
 
+	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
 )
 
 // CreateAuthRequestRequest struct
@@ -31,6 +34,26 @@ type CreateQueryRequestRequest struct {
 	Version               uint32
 	SubjectPos            string
 	MerklizedRootPosition string
+	CircuitID             string
+	AllowedIssuers        []string
+	Scopes                []QueryRequestScope
+}
+
+// QueryRequestScope is a proof request of a query request besides the one of the credential of the request
+type QueryRequestScope struct {
+	CircuitID         string
+	AllowedIssuers    []string
+	Context           string
+	Type              string
+	CredentialSubject map[string]any
+}
+
+// CreateAuthRequestFromTemplateRequest struct
+type CreateAuthRequestFromTemplateRequest struct {
+	VerifierDID *core.DID
+	TemplateID  uuid.UUID
+	To          string
+	Overrides   domain.ProofRequestOverrides
 }
 
 type GenerateProofQuery struct {
@@ -145,4 +168,6 @@ type ReqsService interface {
 	CreateQueryRequest(ctx context.Context, authRequestReq *CreateQueryRequestRequest) (protocol.AuthorizationRequestMessage, error)
 	CreateAuthorizationRequestMessage(ctx context.Context, generateProofRequest *CreateQueryRequestRequest) (protocol.AuthorizationRequestMessage, error)
 	VerifyQueryRequestResponse(ctx context.Context, authorizationRequestMessage *protocol.AuthorizationRequestMessage, authorizationResponseMessage *protocol.AuthorizationResponseMessage) bool
+	Callback(ctx context.Context, verifierDID *core.DID, sessionID uuid.UUID, token string) (*domain.AuthRequest, error)
+	CreateAuthRequestFromTemplate(ctx context.Context, req *CreateAuthRequestFromTemplateRequest) (protocol.AuthorizationRequestMessage, error)
 }
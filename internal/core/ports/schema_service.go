@@ -14,6 +14,8 @@ import (
 // SchemaService is the interface implemented by the Schema service
 type SchemaService interface {
 	LoadSchema(ctx context.Context, url string) (jsonSuite.Schema, error)
+	LoadRawSchema(ctx context.Context, url string) ([]byte, error)
 	Process(ctx context.Context, schemaURL, credentialType string, credential verifiable.W3CCredential, opts *processor.CoreClaimOptions) (*core.Claim, error)
+	ProcessWithSchema(ctx context.Context, schema []byte, credentialType string, credential verifiable.W3CCredential, opts *processor.CoreClaimOptions) (*core.Claim, error)
 	FromClaimModelToW3CCredential(claim domain.Claim) (*verifiable.W3CCredential, error)
 }
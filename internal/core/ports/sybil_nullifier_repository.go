@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// SybilNullifierRepository is the interface that defines the available methods
+type SybilNullifierRepository interface {
+	Save(ctx context.Context, conn db.Querier, nullifier *domain.SybilNullifier) error
+	Get(ctx context.Context, conn db.Querier, verifier string, crs string, sybilID string) (*domain.SybilNullifier, error)
+}
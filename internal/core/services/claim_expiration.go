@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/pkg/pubsub"
+)
+
+type claimExpiration struct {
+	claimsRepo ports.ClaimsRepository
+	publisher  pubsub.Publisher
+	storage    *db.Storage
+}
+
+// NewClaimExpiration creates a new service that marks the expired claims and notifies their expiration
+func NewClaimExpiration(claimsRepo ports.ClaimsRepository, publisher pubsub.Publisher, storage *db.Storage) ports.ClaimExpirationService {
+	return &claimExpiration{
+		claimsRepo: claimsRepo,
+		publisher:  publisher,
+		storage:    storage,
+	}
+}
+
+// Sweep marks as expired the claims whose expiration has passed and publishes an EventClaimExpired event for each of them.
+// The events are published once the claims are marked. The claims whose event cannot be published are unmarked,
+// so the next sweep retries them, and only the claims whose event was published are returned.
+func (c *claimExpiration) Sweep(ctx context.Context) ([]*domain.Claim, error) {
+	expired, err := c.claimsRepo.MarkExpired(ctx, c.storage.Pgx, time.Now())
+	if err != nil {
+		log.Error(ctx, "marking expired claims", err)
+		return nil, err
+	}
+
+	for i, claim := range expired {
+		event := domain.ClaimExpiredEvent{
+			ClaimID:    claim.ID,
+			Issuer:     claim.Issuer,
+			Subject:    claim.OtherIdentifier,
+			SchemaType: claim.SchemaType,
+			RevNonce:   uint64(claim.RevNonce),
+			Expiration: time.Unix(claim.Expiration, 0).UTC(),
+		}
+		if err := c.publisher.Publish(ctx, domain.EventClaimExpired, event); err != nil {
+			err = fmt.Errorf("publishing the expiration of claim %s: %w", claim.ID, err)
+			log.Error(ctx, "sweeping expired claims", err)
+			c.unmark(ctx, expired[i:])
+			return expired[:i], err
+		}
+	}
+
+	return expired, nil
+}
+
+// unmark clears the expiration mark of the claims whose event was not published
+func (c *claimExpiration) unmark(ctx context.Context, claims []*domain.Claim) {
+	ids := make([]uuid.UUID, 0, len(claims))
+	for _, claim := range claims {
+		ids = append(ids, claim.ID)
+	}
+	if err := c.claimsRepo.UnmarkExpired(ctx, c.storage.Pgx, ids); err != nil {
+		log.Error(ctx, "unmarking expired claims, their expiration will not be notified", err, "claims", ids)
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+type claimsRepositoryMock struct {
+	ports.ClaimsRepository
+	claims  []*domain.Claim
+	expired map[uuid.UUID]bool
+}
+
+func (r *claimsRepositoryMock) MarkExpired(_ context.Context, _ db.Querier, now time.Time) ([]*domain.Claim, error) {
+	marked := make([]*domain.Claim, 0)
+	for _, claim := range r.claims {
+		if claim.Expiration > 0 && claim.Expiration <= now.Unix() && !r.expired[claim.ID] {
+			r.expired[claim.ID] = true
+			marked = append(marked, claim)
+		}
+	}
+	return marked, nil
+}
+
+func (r *claimsRepositoryMock) UnmarkExpired(_ context.Context, _ db.Querier, ids []uuid.UUID) error {
+	for _, id := range ids {
+		delete(r.expired, id)
+	}
+	return nil
+}
+
+type publisherMock struct {
+	failing   map[uuid.UUID]bool
+	published []domain.ClaimExpiredEvent
+}
+
+func (p *publisherMock) Publish(_ context.Context, topic string, payload any) error {
+	event, ok := payload.(domain.ClaimExpiredEvent)
+	if topic != domain.EventClaimExpired || !ok {
+		return errors.New("unexpected event")
+	}
+	if p.failing[event.ClaimID] {
+		return errors.New("publisher unavailable")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestClaimExpiration_Sweep(t *testing.T) {
+	ctx := context.Background()
+	expiredAt := time.Now().Add(-time.Hour).Unix()
+	first := &domain.Claim{ID: uuid.New(), Issuer: kycIssuer, Expiration: expiredAt}
+	second := &domain.Claim{ID: uuid.New(), Issuer: kycIssuer, Expiration: expiredAt}
+	notExpired := &domain.Claim{ID: uuid.New(), Issuer: kycIssuer, Expiration: time.Now().Add(time.Hour).Unix()}
+	neverExpires := &domain.Claim{ID: uuid.New(), Issuer: kycIssuer}
+
+	repo := &claimsRepositoryMock{
+		claims:  []*domain.Claim{first, second, notExpired, neverExpires},
+		expired: map[uuid.UUID]bool{},
+	}
+	publisher := &publisherMock{failing: map[uuid.UUID]bool{second.ID: true}}
+	service := NewClaimExpiration(repo, publisher, &db.Storage{})
+
+	t.Run("should keep marked only the claims whose expiration was published", func(t *testing.T) {
+		expired, err := service.Sweep(ctx)
+		require.Error(t, err)
+		assert.Equal(t, []*domain.Claim{first}, expired)
+		assert.Equal(t, map[uuid.UUID]bool{first.ID: true}, repo.expired)
+		require.Len(t, publisher.published, 1)
+		assert.Equal(t, first.ID, publisher.published[0].ClaimID)
+		assert.Equal(t, time.Unix(expiredAt, 0).UTC(), publisher.published[0].Expiration)
+	})
+
+	t.Run("should retry the claims whose expiration was not published", func(t *testing.T) {
+		delete(publisher.failing, second.ID)
+		expired, err := service.Sweep(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.Claim{second}, expired)
+		assert.Len(t, publisher.published, 2)
+	})
+
+	t.Run("should not publish the expiration of a claim twice", func(t *testing.T) {
+		expired, err := service.Sweep(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, expired)
+		assert.Len(t, publisher.published, 2)
+	})
+}
@@ -2,19 +2,26 @@ package services
 
 import (
 	"context"
+	cryptorand "crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/constants"
 	"github.com/iden3/go-merkletree-sql/v2"
+	jsonSuite "github.com/iden3/go-schema-processor/json"
 	"github.com/iden3/go-schema-processor/processor"
+	"github.com/iden3/go-schema-processor/utils"
 	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 	"github.com/lastingasset/wallet-service/iden3comm/packers"
 	"github.com/lastingasset/wallet-service/iden3comm/protocol"
 
@@ -28,13 +35,18 @@ import (
 )
 
 var (
-	ErrClaimNotFound = errors.New("claim not found")                // ErrClaimNotFound Cannot retrieve the given claim 	// ErrProcessSchema Cannot process schema
-	ErrJSONLdContext = errors.New("jsonLdContext must be a string") // ErrJSONLdContext Field jsonLdContext must be a string
-	ErrLoadingSchema = errors.New("cannot load schema")             // ErrLoadingSchema means the system cannot load the schema file
-	ErrMalformedURL  = errors.New("malformed url")                  // ErrMalformedURL The schema url is wrong
-	ErrProcessSchema = errors.New("cannot process schema")          // ErrProcessSchema Cannot process schema
+	ErrClaimNotFound      = errors.New("claim not found")                // ErrClaimNotFound Cannot retrieve the given claim 	// ErrProcessSchema Cannot process schema
+	ErrClaimUpdateInvalid = errors.New("the claim cannot be updated")    // ErrClaimUpdateInvalid The claim cannot be reissued with the request
+	ErrClaimsBatchInvalid = errors.New("invalid batch of claims")        // ErrClaimsBatchInvalid The batch is empty or too large
+	ErrJSONLdContext      = errors.New("jsonLdContext must be a string") // ErrJSONLdContext Field jsonLdContext must be a string
+	ErrLoadingSchema      = errors.New("cannot load schema")             // ErrLoadingSchema means the system cannot load the schema file
+	ErrMalformedURL       = errors.New("malformed url")                  // ErrMalformedURL The schema url is wrong
+	ErrProcessSchema      = errors.New("cannot process schema")          // ErrProcessSchema Cannot process schema
 )
 
+// MaxClaimsBatchSize is the maximum number of claims issued in a single batch
+const MaxClaimsBatchSize = 1000
+
 // ClaimCfg claim service configuration
 type ClaimCfg struct {
 	RHSEnabled bool // ReverseHash Enabled
@@ -49,11 +61,12 @@ type claim struct {
 	identitySrv             ports.IdentityService
 	mtService               ports.MtService
 	identityStateRepository ports.IdentityStateRepository
+	holderCredentialRepo    ports.HolderCredentialRepository
 	storage                 *db.Storage
 }
 
 // NewClaim creates a new claim service
-func NewClaim(repo ports.ClaimsRepository, schemaSrv ports.SchemaService, idenSrv ports.IdentityService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, storage *db.Storage, cfg ClaimCfg) ports.ClaimsService {
+func NewClaim(repo ports.ClaimsRepository, schemaSrv ports.SchemaService, idenSrv ports.IdentityService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, holderCredentialRepo ports.HolderCredentialRepository, storage *db.Storage, cfg ClaimCfg) ports.ClaimsService {
 	s := &claim{
 		cfg: ClaimCfg{
 			RHSEnabled: cfg.RHSEnabled,
@@ -65,6 +78,7 @@ func NewClaim(repo ports.ClaimsRepository, schemaSrv ports.SchemaService, idenSr
 		identitySrv:             idenSrv,
 		mtService:               mtService,
 		identityStateRepository: identityStateRepository,
+		holderCredentialRepo:    holderCredentialRepo,
 		storage:                 storage,
 	}
 	return s
@@ -86,9 +100,187 @@ func (c *claim) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest)
 		return nil, err
 	}
 
-	schema, err := c.schemaSrv.LoadSchema(ctx, req.Schema)
+	vcID, err := uuid.NewUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := c.newClaim(ctx, req, vcID, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimResp *domain.Claim
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		claimResp, err = c.save(ctx, tx, claim)
+		if err != nil {
+			log.Error(ctx, "Can not save the claim", err)
+			return err
+		}
+
+		if err := c.holdCredential(ctx, tx, *claimResp); err != nil {
+			log.Error(ctx, "Can not store the credential of the holder", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimResp, nil
+}
+
+// CreateClaimsBatch issues all the claims of the batch in a single transaction, so that they are published together
+// in the next state transition of the identity. Every request is validated, and its claim built, before storing any
+// of them. The result of each request has either its claim or the reason it was not issued. The save function, if any,
+// stores the records that go with the batch in the same transaction.
+func (c *claim) CreateClaimsBatch(ctx context.Context, did *core.DID, reqs []*ports.CreateClaimRequest, save ports.BatchSaveFunc) ([]ports.BatchClaimResult, error) {
+	if len(reqs) == 0 || len(reqs) > MaxClaimsBatchSize {
+		return nil, fmt.Errorf("%w: it must have between 1 and %d claims", ErrClaimsBatchInvalid, MaxClaimsBatchSize)
+	}
+
+	authClaim, err := c.GetAuthClaim(ctx, did)
 	if err != nil {
-		log.Error(ctx, "loading schema", err, "schema", req.Schema)
+		log.Error(ctx, "Can not retrieve the auth claim", err)
+		return nil, err
+	}
+
+	results := make([]ports.BatchClaimResult, len(reqs))
+	schemas := make(map[string]*issuanceSchema)
+	schemaErrs := make(map[string]error)
+	for i, req := range reqs {
+		req.DID = did
+		if err := c.guardCreateClaimRequest(req); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		schema, ok := schemas[req.Schema]
+		if !ok && schemaErrs[req.Schema] == nil {
+			schema, err = c.loadIssuanceSchema(ctx, req.Schema)
+			if err != nil {
+				schemaErrs[req.Schema] = err
+			} else {
+				schemas[req.Schema] = schema
+			}
+		}
+		if err := schemaErrs[req.Schema]; err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		nonce, err := rand.Int64()
+		if err != nil {
+			return nil, err
+		}
+		vcID, err := uuid.NewUUID()
+		if err != nil {
+			return nil, err
+		}
+		results[i].Claim, results[i].Err = c.issueClaim(ctx, req, schema, authClaim, vcID, nonce)
+	}
+
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for _, result := range results {
+			if result.Claim == nil {
+				continue
+			}
+			if _, err := c.icRepo.Save(ctx, tx, result.Claim); err != nil {
+				return err
+			}
+			if err := c.holdCredential(ctx, tx, *result.Claim); err != nil {
+				return err
+			}
+		}
+		if save != nil {
+			return save(ctx, tx)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, "Can not save the batch of claims", err)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// UpdateClaim reissues the claim with the new credential subject. An updatable claim is updated in place with
+// its version increased, any other claim is revoked and replaced by a new one that links to it.
+// Both the replacement and the revocation are added to the identity in the same state transition.
+func (c *claim) UpdateClaim(ctx context.Context, req *ports.UpdateClaimRequest) (*domain.Claim, error) {
+	previous, err := c.GetByID(ctx, req.DID, req.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+	if previous.Revoked {
+		return nil, fmt.Errorf("%w: the claim is revoked", ErrClaimUpdateInvalid)
+	}
+
+	createReq, err := updateClaimRequest(previous, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var claim *domain.Claim
+	if previous.Updatable {
+		createReq.Version = previous.Version + 1
+		claim, err = c.newClaim(ctx, createReq, previous.ID, uint64(previous.RevNonce))
+	} else {
+		var nonce uint64
+		var vcID uuid.UUID
+		if nonce, err = rand.Int64(); err != nil {
+			log.Error(ctx, "create a nonce", err)
+			return nil, err
+		}
+		if vcID, err = uuid.NewUUID(); err != nil {
+			return nil, err
+		}
+		claim, err = c.newClaim(ctx, createReq, vcID, nonce)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if !previous.Updatable {
+			claim.Replaces = &previous.ID
+			description := fmt.Sprintf("replaced by claim %s", claim.ID)
+			if err := c.revoke(ctx, tx, req.DID, uint64(previous.RevNonce), description); err != nil {
+				return err
+			}
+		}
+
+		if _, err := c.icRepo.Save(ctx, tx, claim); err != nil {
+			return err
+		}
+		return c.holdCredential(ctx, tx, *claim)
+	})
+	if err != nil {
+		log.Error(ctx, "Can not update the claim", err, "claim", req.ClaimID)
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// issuanceSchema is the schema of the claims to issue, loaded once for any number of claims
+type issuanceSchema struct {
+	raw           []byte
+	metadata      *jsonSuite.SchemaMetadata
+	jsonLdContext string
+}
+
+func (c *claim) loadIssuanceSchema(ctx context.Context, url string) (*issuanceSchema, error) {
+	raw, err := c.schemaSrv.LoadRawSchema(ctx, url)
+	if err != nil {
+		log.Error(ctx, "loading schema", err, "schema", url)
+		return nil, ErrLoadingSchema
+	}
+
+	var schema jsonSuite.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		log.Error(ctx, "loading schema", err, "schema", url)
 		return nil, ErrLoadingSchema
 	}
 
@@ -98,11 +290,29 @@ func (c *claim) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest)
 		return nil, ErrJSONLdContext
 	}
 
-	vcID, err := uuid.NewUUID()
+	return &issuanceSchema{raw: raw, metadata: schema.Metadata, jsonLdContext: jsonLdContext}, nil
+}
+
+// newClaim builds the claim of the request, signed by the issuer, with the given credential id and revocation nonce
+func (c *claim) newClaim(ctx context.Context, req *ports.CreateClaimRequest, vcID uuid.UUID, nonce uint64) (*domain.Claim, error) {
+	schema, err := c.loadIssuanceSchema(ctx, req.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	authClaim, err := c.GetAuthClaim(ctx, req.DID)
 	if err != nil {
+		log.Error(ctx, "Can not retrieve the auth claim", err)
 		return nil, err
 	}
 
+	return c.issueClaim(ctx, req, schema, authClaim, vcID, nonce)
+}
+
+// issueClaim builds the claim of the request with the schema already loaded and signs it with the auth claim of the issuer
+func (c *claim) issueClaim(ctx context.Context, req *ports.CreateClaimRequest, schema *issuanceSchema, authClaim *domain.Claim, vcID uuid.UUID, nonce uint64) (*domain.Claim, error) {
+	jsonLdContext := schema.jsonLdContext
+
 	vc, err := c.createVC(req, vcID, jsonLdContext, nonce)
 	if err != nil {
 		log.Error(ctx, "creating verifiable credential", err)
@@ -110,14 +320,14 @@ func (c *claim) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest)
 	}
 
 	credentialType := fmt.Sprintf("%s#%s", jsonLdContext, req.Type)
-	mtRootPostion := common.DefineMerklizedRootPosition(schema.Metadata, req.MerklizedRootPosition)
+	mtRootPostion := common.DefineMerklizedRootPosition(schema.metadata, req.MerklizedRootPosition)
 
-	coreClaim, err := c.schemaSrv.Process(ctx, req.Schema, credentialType, vc, &processor.CoreClaimOptions{
+	coreClaim, err := c.schemaSrv.ProcessWithSchema(ctx, schema.raw, credentialType, vc, &processor.CoreClaimOptions{
 		RevNonce:              nonce,
 		MerklizedRootPosition: mtRootPostion,
 		Version:               req.Version,
 		SubjectPosition:       req.SubjectPos,
-		Updatable:             false,
+		Updatable:             req.Updatable,
 	})
 	if err != nil {
 		log.Error(ctx, "Can not process the schema", err)
@@ -130,12 +340,6 @@ func (c *claim) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest)
 		return nil, err
 	}
 
-	authClaim, err := c.GetAuthClaim(ctx, req.DID)
-	if err != nil {
-		log.Error(ctx, "Can not retrieve the auth claim", err)
-		return nil, err
-	}
-
 	proof, err := c.identitySrv.SignClaimEntry(ctx, authClaim, coreClaim)
 	if err != nil {
 		log.Error(ctx, "Can not sign claim entry", err)
@@ -172,12 +376,7 @@ func (c *claim) CreateClaim(ctx context.Context, req *ports.CreateClaimRequest)
 		return nil, err
 	}
 
-	claimResp, err := c.save(ctx, claim)
-	if err != nil {
-		log.Error(ctx, "Can not save the claim", err)
-		return nil, err
-	}
-	return claimResp, err
+	return claim, nil
 }
 
 func (c *claim) Revoke(ctx context.Context, id string, nonce uint64, description string) error {
@@ -186,16 +385,20 @@ func (c *claim) Revoke(ctx context.Context, id string, nonce uint64, description
 		return fmt.Errorf("error parsing did: %w", err)
 	}
 
+	return c.revoke(ctx, c.storage.Pgx, did, nonce, description)
+}
+
+func (c *claim) revoke(ctx context.Context, conn db.Querier, did *core.DID, nonce uint64, description string) error {
 	rID := new(big.Int).SetUint64(nonce)
 	revocation := domain.Revocation{
-		Identifier:  id,
+		Identifier:  did.String(),
 		Nonce:       domain.RevNonceUint64(nonce),
 		Version:     0,
 		Status:      0,
 		Description: description,
 	}
 
-	identityTrees, err := c.mtService.GetIdentityMerkleTrees(ctx, c.storage.Pgx, did)
+	identityTrees, err := c.mtService.GetIdentityMerkleTrees(ctx, conn, did)
 	if err != nil {
 		return fmt.Errorf("error getting merkle trees: %w", err)
 	}
@@ -206,7 +409,7 @@ func (c *claim) Revoke(ctx context.Context, id string, nonce uint64, description
 	}
 
 	var claim *domain.Claim
-	claim, err = c.icRepo.GetByRevocationNonce(ctx, c.storage.Pgx, did, domain.RevNonceUint64(nonce))
+	claim, err = c.icRepo.GetByRevocationNonce(ctx, conn, did, domain.RevNonceUint64(nonce))
 
 	if err != nil {
 		if errors.Is(err, repositories.ErrClaimDoesNotExist) {
@@ -216,12 +419,17 @@ func (c *claim) Revoke(ctx context.Context, id string, nonce uint64, description
 	}
 
 	claim.Revoked = true
-	_, err = c.icRepo.Save(ctx, c.storage.Pgx, claim)
+	_, err = c.icRepo.Save(ctx, conn, claim)
 	if err != nil {
 		return fmt.Errorf("error saving the claim: %w", err)
 	}
 
-	return c.icRepo.RevokeNonce(ctx, c.storage.Pgx, &revocation)
+	err = c.holderCredentialRepo.RevokeByIssuerNonce(ctx, conn, did, domain.RevNonceUint64(nonce))
+	if err != nil {
+		return fmt.Errorf("error revoking the holder credentials: %w", err)
+	}
+
+	return c.icRepo.RevokeNonce(ctx, conn, &revocation)
 }
 
 func (c *claim) GetByID(ctx context.Context, issID *core.DID, id uuid.UUID) (*domain.Claim, error) {
@@ -259,13 +467,73 @@ func (c *claim) GetAuthClaim(ctx context.Context, did *core.DID) (*domain.Claim,
 	return c.icRepo.FindOneClaimBySchemaHash(ctx, c.storage.Pgx, did, string(authHash))
 }
 
-func (c *claim) GetAll(ctx context.Context, did *core.DID, filter *ports.Filter) ([]*verifiable.W3CCredential, error) {
-	claims, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, did, filter)
+// GetStateCommitmentClaim returns the state commitment claim of the identity, creating it with a new random secret
+// if the identity has not got one yet. A new claim enters the claims tree of the identity with its next state.
+func (c *claim) GetStateCommitmentClaim(ctx context.Context, did *core.DID) (*domain.Claim, error) {
+	schemaHash, err := domain.StateCommitmentSchemaHash.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	commitment, err := c.icRepo.FindOneClaimBySchemaHash(ctx, c.storage.Pgx, did, string(schemaHash))
+	if err == nil {
+		return commitment, nil
+	}
+	if !errors.Is(err, repositories.ErrClaimDoesNotExist) {
+		return nil, err
+	}
+
+	secret, err := cryptorand.Int(cryptorand.Reader, constants.Q)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := rand.Int64()
+	if err != nil {
+		return nil, err
+	}
+	coreClaim, err := domain.NewStateCommitmentClaim(secret, nonce)
 	if err != nil {
 		return nil, err
 	}
 
-	w3Credentials := make([]*verifiable.W3CCredential, 0)
+	commitment, err = domain.FromClaimer(coreClaim, "", domain.StateCommitmentCredential)
+	if err != nil {
+		return nil, err
+	}
+	identifier := did.String()
+	commitment.Identifier = &identifier
+	commitment.Issuer = identifier
+
+	commitment.ID, err = c.icRepo.Save(ctx, c.storage.Pgx, commitment)
+	if err != nil {
+		return nil, err
+	}
+	return commitment, nil
+}
+
+// GetAll returns the page of the claims of the identity that match the filter, and the total of matching claims
+func (c *claim) GetAll(ctx context.Context, did *core.DID, filter *ports.Filter) (*ports.ClaimsPage, error) {
+	total, err := c.icRepo.CountByIssuerID(ctx, c.storage.Pgx, did, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := *filter
+	if filter.Limit > 0 {
+		// one more claim tells whether there is a next page
+		pageFilter.Limit = filter.Limit + 1
+	}
+	claims, err := c.icRepo.GetAllByIssuerID(ctx, c.storage.Pgx, did, &pageFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ports.ClaimsPage{Total: total}
+	if filter.Limit > 0 && len(claims) > filter.Limit {
+		claims = claims[:filter.Limit]
+		page.NextCursor = claimsCursor(filter, claims[len(claims)-1])
+	}
+
+	page.Credentials = make([]*verifiable.W3CCredential, 0, len(claims))
 	for _, cred := range claims {
 		w3Cred, err := c.schemaSrv.FromClaimModelToW3CCredential(*cred)
 		if err != nil {
@@ -273,10 +541,22 @@ func (c *claim) GetAll(ctx context.Context, did *core.DID, filter *ports.Filter)
 			continue
 		}
 
-		w3Credentials = append(w3Credentials, w3Cred)
+		page.Credentials = append(page.Credentials, w3Cred)
 	}
 
-	return w3Credentials, nil
+	return page, nil
+}
+
+// claimsCursor returns the cursor of the page that starts right after the claim
+func claimsCursor(filter *ports.Filter, last *domain.Claim) *ports.ClaimsCursor {
+	cursor := &ports.ClaimsCursor{SortBy: filter.SortBy, Descending: filter.Descending, ID: last.ID}
+	switch filter.SortBy {
+	case ports.ClaimsSortByExpiration:
+		cursor.Value = strconv.FormatInt(last.Expiration, 10)
+	default:
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	}
+	return cursor
 }
 
 func (c *claim) getAgentCredential(ctx context.Context, basicMessage *ports.AgentRequest) (*domain.Agent, error) {
@@ -379,8 +659,8 @@ func (c *claim) createVC(claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jso
 	return vCredential, nil
 }
 
-func (c *claim) save(ctx context.Context, claim *domain.Claim) (*domain.Claim, error) {
-	id, err := c.icRepo.Save(ctx, c.storage.Pgx, claim)
+func (c *claim) save(ctx context.Context, conn db.Querier, claim *domain.Claim) (*domain.Claim, error) {
+	id, err := c.icRepo.Save(ctx, conn, claim)
 	if err != nil {
 		return nil, err
 	}
@@ -390,6 +670,45 @@ func (c *claim) save(ctx context.Context, claim *domain.Claim) (*domain.Claim, e
 	return claim, nil
 }
 
+// holdCredential stores the claim in the credential store of its subject when the subject is an identity of the service
+// or one of their profiles,
+// replacing the one stored before so that the holder gets the new proofs
+func (c *claim) holdCredential(ctx context.Context, conn db.Querier, claim domain.Claim) error {
+	if claim.OtherIdentifier == "" {
+		return nil
+	}
+	holder, err := core.ParseDID(claim.OtherIdentifier)
+	if err != nil {
+		return nil
+	}
+	if _, _, err := c.identitySrv.ResolveProfile(ctx, holder); err != nil {
+		if errors.Is(err, ErrIdentityNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if claim.SignatureProof.Status == pgtype.Undefined {
+		claim.SignatureProof.Status = pgtype.Null
+	}
+	if claim.MTPProof.Status == pgtype.Undefined {
+		claim.MTPProof.Status = pgtype.Null
+	}
+	credential, err := c.schemaSrv.FromClaimModelToW3CCredential(claim)
+	if err != nil {
+		return err
+	}
+
+	held, err := domain.NewHolderCredential(holder, *credential, domain.HolderCredentialSourceSelfIssued)
+	if err != nil {
+		return err
+	}
+	held.ID = claim.ID
+	held.Revoked = claim.Revoked
+	_, err = c.holderCredentialRepo.Save(ctx, conn, held)
+	return err
+}
+
 func (c *claim) guardCreateClaimRequest(req *ports.CreateClaimRequest) error {
 	if _, err := url.ParseRequestURI(req.Schema); err != nil {
 		return ErrMalformedURL
@@ -397,6 +716,61 @@ func (c *claim) guardCreateClaimRequest(req *ports.CreateClaimRequest) error {
 	return nil
 }
 
+// updateClaimRequest returns the request to issue the claim again with the credential subject of the update request.
+// The schema, type and claim positions are kept and the subject must be the same.
+func updateClaimRequest(previous *domain.Claim, req *ports.UpdateClaimRequest) (*ports.CreateClaimRequest, error) {
+	credentialSubject := make(map[string]any, len(req.CredentialSubject)+1)
+	for k, v := range req.CredentialSubject {
+		credentialSubject[k] = v
+	}
+	if previous.OtherIdentifier != "" {
+		subject, ok := credentialSubject["id"]
+		if !ok {
+			credentialSubject["id"] = previous.OtherIdentifier
+		} else if subject != previous.OtherIdentifier {
+			return nil, fmt.Errorf("%w: the credential subject id cannot change", ErrClaimUpdateInvalid)
+		}
+	}
+
+	_, typ, found := strings.Cut(previous.SchemaType, "#")
+	if !found {
+		return nil, fmt.Errorf("%w: unknown credential type <%s>", ErrClaimUpdateInvalid, previous.SchemaType)
+	}
+
+	coreClaim := previous.CoreClaim.Get()
+	subjectPos := utils.SubjectPositionIndex
+	if idPosition, err := coreClaim.GetIDPosition(); err == nil && idPosition == core.IDPositionValue {
+		subjectPos = utils.SubjectPositionValue
+	}
+	merklizedRootPosition := utils.MerklizedRootPositionNone
+	if position, err := coreClaim.GetMerklizedPosition(); err == nil {
+		switch position {
+		case core.MerklizedRootPositionIndex:
+			merklizedRootPosition = utils.MerklizedRootPositionIndex
+		case core.MerklizedRootPositionValue:
+			merklizedRootPosition = utils.MerklizedRootPositionValue
+		}
+	}
+
+	expiration := req.Expiration
+	if expiration == nil && previous.Expiration > 0 {
+		t := time.Unix(previous.Expiration, 0)
+		expiration = &t
+	}
+
+	return &ports.CreateClaimRequest{
+		DID:                   req.DID,
+		Schema:                previous.SchemaURL,
+		CredentialSubject:     credentialSubject,
+		Expiration:            expiration,
+		Type:                  typ,
+		Version:               previous.Version,
+		SubjectPos:            subjectPos,
+		MerklizedRootPosition: merklizedRootPosition,
+		Updatable:             previous.Updatable,
+	}, nil
+}
+
 func (c *claim) newVerifiableCredential(claimReq *ports.CreateClaimRequest, vcID uuid.UUID, jsonLdContext string, nonce uint64) (verifiable.W3CCredential, error) {
 	credentialCtx := []string{verifiable.JSONLDSchemaW3CCredential2018, verifiable.JSONLDSchemaIden3Credential, jsonLdContext}
 	credentialType := []string{verifiable.TypeW3CVerifiableCredential, claimReq.Type}
@@ -555,6 +929,10 @@ func (c *claim) UpdateClaimsMTPAndState(ctx context.Context, currentState *domai
 		if affected == 0 {
 			return fmt.Errorf("claim has not been updated %v", claims[i])
 		}
+
+		if err = c.holdCredential(ctx, c.storage.Pgx, claims[i]); err != nil {
+			return fmt.Errorf("can't update the credential of the holder: %w", err)
+		}
 	}
 	_, err = c.identityStateRepository.UpdateState(ctx, c.storage.Pgx, currentState)
 	if err != nil {
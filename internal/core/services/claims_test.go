@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+)
+
+func TestUpdateClaimRequest(t *testing.T) {
+	issuer, err := core.ParseDID(kycIssuer)
+	require.NoError(t, err)
+	subject, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+
+	previousClaim := func(t *testing.T, options ...core.Option) *domain.Claim {
+		coreClaim, err := core.NewClaim(core.SchemaHash{}, options...)
+		require.NoError(t, err)
+		return &domain.Claim{
+			SchemaURL:       "https://example.com/kyc.json",
+			SchemaType:      kycContext + "#KYCAgeCredential",
+			OtherIdentifier: subject.String(),
+			Expiration:      time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			Version:         2,
+			Updatable:       true,
+			CoreClaim:       domain.CoreClaim(*coreClaim),
+		}
+	}
+	update := func(credentialSubject map[string]any, expiration *time.Time) *ports.UpdateClaimRequest {
+		return &ports.UpdateClaimRequest{DID: issuer, ClaimID: uuid.New(), CredentialSubject: credentialSubject, Expiration: expiration}
+	}
+
+	t.Run("should keep the schema, type, positions and subject of the previous claim", func(t *testing.T) {
+		previous := previousClaim(t, core.WithValueID(subject.ID), core.WithFlagMerklized(core.MerklizedRootPositionIndex))
+		req, err := updateClaimRequest(previous, update(map[string]any{"birthday": 19960425}, nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, issuer, req.DID)
+		assert.Equal(t, previous.SchemaURL, req.Schema)
+		assert.Equal(t, "KYCAgeCredential", req.Type)
+		assert.Equal(t, previous.Version, req.Version)
+		assert.True(t, req.Updatable)
+		assert.Equal(t, utils.SubjectPositionValue, req.SubjectPos)
+		assert.Equal(t, utils.MerklizedRootPositionIndex, req.MerklizedRootPosition)
+		assert.Equal(t, map[string]any{"id": subject.String(), "birthday": 19960425}, req.CredentialSubject)
+		require.NotNil(t, req.Expiration)
+		assert.Equal(t, previous.Expiration, req.Expiration.Unix())
+	})
+
+	t.Run("should use the expiration of the update", func(t *testing.T) {
+		expiration := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+		req, err := updateClaimRequest(previousClaim(t, core.WithIndexID(subject.ID)), update(map[string]any{"id": subject.String()}, &expiration))
+		require.NoError(t, err)
+		assert.Equal(t, &expiration, req.Expiration)
+		assert.Equal(t, utils.SubjectPositionIndex, req.SubjectPos)
+		assert.Equal(t, utils.MerklizedRootPositionNone, req.MerklizedRootPosition)
+	})
+
+	t.Run("should not change the subject of the claim", func(t *testing.T) {
+		_, err := updateClaimRequest(previousClaim(t, core.WithIndexID(subject.ID)), update(map[string]any{"id": kycIssuer}, nil))
+		assert.ErrorIs(t, err, ErrClaimUpdateInvalid)
+	})
+
+	t.Run("should not update a claim of unknown type", func(t *testing.T) {
+		previous := previousClaim(t, core.WithIndexID(subject.ID))
+		previous.SchemaType = "KYCAgeCredential"
+		_, err := updateClaimRequest(previous, update(map[string]any{}, nil))
+		assert.ErrorIs(t, err, ErrClaimUpdateInvalid)
+	})
+}
@@ -0,0 +1,514 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/iden3comm/packers"
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/common"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+var (
+	ErrCredentialRequestInvalid  = errors.New("invalid credential request")         // ErrCredentialRequestInvalid The holder request cannot be queued for approval
+	ErrCredentialRequestNotFound = errors.New("credential request not found")       // ErrCredentialRequestNotFound Cannot retrieve the given credential request
+	ErrCredentialRequestDecided  = errors.New("credential request already decided") // ErrCredentialRequestDecided The request was already approved or rejected
+)
+
+type credentialRequests struct {
+	repo        ports.CredentialRequestRepository
+	auditRepo   ports.IssuanceAuditRepository
+	policies    ports.IssuancePolicyService
+	claimSrv    ports.ClaimsService
+	identitySrv ports.IdentityService
+	storage     *db.Storage
+	host        string
+}
+
+// NewCredentialRequests creates a new service for the credentials the holders request through the agent, and the
+// claims created through the api that the issuance policies hold for approval.
+// The holder requests wait for the approval of the issuer unless a policy decides otherwise, the approved
+// credentials are delivered with the offer and fetch messages. Every decision is recorded in the issuance audit.
+func NewCredentialRequests(repo ports.CredentialRequestRepository, auditRepo ports.IssuanceAuditRepository, policies ports.IssuancePolicyService, claimSrv ports.ClaimsService, identitySrv ports.IdentityService, storage *db.Storage, host string) ports.CredentialRequestService {
+	return &credentialRequests{
+		repo:        repo,
+		auditRepo:   auditRepo,
+		policies:    policies,
+		claimSrv:    claimSrv,
+		identitySrv: identitySrv,
+		storage:     storage,
+		host:        host,
+	}
+}
+
+// Submit applies the issuance policies of the issuer to a claim created through the api. It returns nil when
+// the claim can be issued right away, which is the case when no policy matches, the pending request when a policy
+// requires the approval of the issuer, or ErrIssuanceDenied when a policy denies it.
+func (c *credentialRequests) Submit(ctx context.Context, req *ports.CreateClaimRequest, actor string) (*domain.CredentialRequest, error) {
+	sub, err := c.evaluate(ctx, req, actor)
+	if err != nil || sub == nil {
+		return nil, err
+	}
+
+	if err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error { return c.store(ctx, tx, sub) }); err != nil {
+		log.Error(ctx, "saving credential request", err, "issuerDID", req.DID)
+		return nil, err
+	}
+	if sub.request == nil {
+		log.Info(ctx, "claim denied by issuance policy", "issuerDID", req.DID, "policy", sub.audit.PolicyID)
+		return nil, ErrIssuanceDenied
+	}
+	log.Info(ctx, "claim queued for approval", "id", sub.request.ID, "issuerDID", req.DID, "policy", sub.audit.PolicyID)
+	return sub.request, nil
+}
+
+// SubmitBatch applies the issuance policies to every claim of a batch created through the api and issues the claims
+// no policy holds or denies. The requests held for approval and the audit records are stored in the transaction of
+// the claims, so nothing is stored when the batch cannot be.
+func (c *credentialRequests) SubmitBatch(ctx context.Context, did *core.DID, reqs []*ports.CreateClaimRequest, actor string) ([]ports.BatchSubmitResult, error) {
+	if len(reqs) == 0 || len(reqs) > MaxClaimsBatchSize {
+		return nil, fmt.Errorf("%w: it must have between 1 and %d claims", ErrClaimsBatchInvalid, MaxClaimsBatchSize)
+	}
+
+	results := make([]ports.BatchSubmitResult, len(reqs))
+	subs := make([]*submission, 0, len(reqs))
+	toIssue := make([]*ports.CreateClaimRequest, 0, len(reqs))
+	positions := make([]int, 0, len(reqs))
+	for i, req := range reqs {
+		req.DID = did
+		sub, err := c.evaluate(ctx, req, actor)
+		if err != nil {
+			return nil, err
+		}
+		if sub == nil {
+			toIssue = append(toIssue, req)
+			positions = append(positions, i)
+			continue
+		}
+		subs = append(subs, sub)
+		if sub.request == nil {
+			results[i].Err = ErrIssuanceDenied
+		} else {
+			results[i].Request = sub.request
+		}
+	}
+
+	save := func(ctx context.Context, tx db.Querier) error {
+		for _, sub := range subs {
+			if err := c.store(ctx, tx, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if len(toIssue) == 0 {
+		if err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error { return save(ctx, tx) }); err != nil {
+			log.Error(ctx, "saving credential requests", err, "issuerDID", did)
+			return nil, err
+		}
+		return results, nil
+	}
+
+	issued, err := c.claimSrv.CreateClaimsBatch(ctx, did, toIssue, save)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range issued {
+		results[positions[i]].Claim, results[positions[i]].Err = result.Claim, result.Err
+	}
+	return results, nil
+}
+
+// submission is what the issuance policies decided on a claim created through the api, to be stored
+type submission struct {
+	request *domain.CredentialRequest // the request held for approval, nil when the claim is denied
+	audit   *domain.IssuanceAudit
+}
+
+// evaluate applies the issuance policies to the claim without storing anything. It returns nil when the claim
+// can be issued right away.
+func (c *credentialRequests) evaluate(ctx context.Context, req *ports.CreateClaimRequest, actor string) (*submission, error) {
+	policy, err := c.policies.Evaluate(ctx, req.DID, req.Type, req.CredentialSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	holder, _ := req.CredentialSubject["id"].(string)
+	switch policyAction(policy, domain.IssuancePolicyAutoApprove) {
+	case domain.IssuancePolicyDeny:
+		audit := newIssuanceAudit(req.DID.String(), holder, req.Type, domain.IssuanceAuditDenied, domain.IssuanceAuditActorPolicy)
+		audit.PolicyID = &policy.ID
+		return &submission{audit: audit}, nil
+	case domain.IssuancePolicyManual:
+	default:
+		return nil, nil
+	}
+
+	credentialReq := &domain.CredentialRequest{
+		ID:                    uuid.New(),
+		Issuer:                req.DID.String(),
+		Holder:                holder,
+		ThreadID:              uuid.NewString(),
+		SchemaURL:             req.Schema,
+		SchemaType:            req.Type,
+		Expiration:            req.Expiration,
+		Version:               req.Version,
+		SubjectPosition:       req.SubjectPos,
+		MerklizedRootPosition: req.MerklizedRootPosition,
+		Updatable:             req.Updatable,
+		Status:                domain.CredentialRequestPending,
+		CreatedAt:             time.Now(),
+	}
+	if err := credentialReq.CredentialSubject.Set(req.CredentialSubject); err != nil {
+		return nil, err
+	}
+	return &submission{request: credentialReq, audit: requestAudit(credentialReq, actor, policy)}, nil
+}
+
+// store saves the request held for approval, if any, and the audit record of the submission
+func (c *credentialRequests) store(ctx context.Context, conn db.Querier, sub *submission) error {
+	if sub.request != nil {
+		if err := c.repo.Save(ctx, conn, sub.request); err != nil {
+			return err
+		}
+	}
+	return c.auditRepo.Save(ctx, conn, sub.audit)
+}
+
+// Agent handles the issuance request of a holder. The first request of a thread is queued for approval,
+// the next ones in the same thread get the status of the request, or the credential offer once approved.
+func (c *credentialRequests) Agent(ctx context.Context, req *ports.AgentRequest) (*domain.Agent, error) {
+	exists, err := c.identitySrv.Exists(ctx, req.IssuerDID)
+	if err != nil {
+		log.Error(ctx, "loading issuer identity", err, "issuerDID", req.IssuerDID)
+		return nil, err
+	}
+	if !exists {
+		log.Warn(ctx, "issuer not found", "issuerDID", req.IssuerDID)
+		return nil, fmt.Errorf("cannot proceed with this identity, not found")
+	}
+
+	threadID := req.ThreadID
+	if threadID == "" {
+		threadID = req.ClaimID.String()
+	}
+
+	credentialReq, err := c.repo.GetByThread(ctx, c.storage.Pgx, req.IssuerDID, req.UserDID, threadID)
+	if errors.Is(err, repositories.ErrCredentialRequestDoesNotExist) {
+		credentialReq, err = c.queue(ctx, req, threadID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.agentResponse(credentialReq), nil
+}
+
+// queue validates the issuance request of the holder and stores it pending for approval
+func (c *credentialRequests) queue(ctx context.Context, req *ports.AgentRequest, threadID string) (*domain.CredentialRequest, error) {
+	body := &protocol.CredentialIssuanceRequestMessageBody{}
+	if err := json.Unmarshal(req.Body, body); err != nil {
+		return nil, fmt.Errorf("%w: invalid issuance request body: %v", ErrCredentialRequestInvalid, err)
+	}
+	if _, err := url.ParseRequestURI(body.Schema.URL); err != nil {
+		return nil, fmt.Errorf("%w: invalid schema url", ErrCredentialRequestInvalid)
+	}
+	if body.Schema.Type == "" {
+		return nil, fmt.Errorf("%w: the schema type is required", ErrCredentialRequestInvalid)
+	}
+
+	var subject map[string]interface{}
+	if err := json.Unmarshal(body.Data, &subject); err != nil || subject == nil {
+		return nil, fmt.Errorf("%w: the data must be a json object", ErrCredentialRequestInvalid)
+	}
+	if id, ok := subject["id"]; ok && id != req.UserDID.String() {
+		return nil, fmt.Errorf("%w: the credential subject is not the sender", ErrCredentialRequestInvalid)
+	}
+	subject["id"] = req.UserDID.String()
+
+	credentialReq := &domain.CredentialRequest{
+		ID:         uuid.New(),
+		Issuer:     req.IssuerDID.String(),
+		Holder:     req.UserDID.String(),
+		ThreadID:   threadID,
+		SchemaURL:  body.Schema.URL,
+		SchemaType: body.Schema.Type,
+		Status:     domain.CredentialRequestPending,
+		CreatedAt:  time.Now(),
+	}
+	if body.Expiration > 0 {
+		expiration := time.Unix(body.Expiration, 0)
+		credentialReq.Expiration = &expiration
+	}
+	if err := credentialReq.CredentialSubject.Set(subject); err != nil {
+		return nil, err
+	}
+
+	policy, err := c.policies.Evaluate(ctx, req.IssuerDID, body.Schema.Type, subject)
+	if err != nil {
+		return nil, err
+	}
+	action := policyAction(policy, domain.IssuancePolicyManual)
+	if action == domain.IssuancePolicyDeny {
+		credentialReq.Status = domain.CredentialRequestRejected
+		credentialReq.Reason = common.ToPointer(ErrIssuanceDenied.Error())
+	}
+
+	if err := c.save(ctx, credentialReq, req.UserDID.String(), policy); err != nil {
+		log.Error(ctx, "saving credential request", err, "issuerDID", req.IssuerDID, "thid", threadID)
+		return nil, err
+	}
+
+	switch action {
+	case domain.IssuancePolicyDeny:
+		log.Info(ctx, "credential request denied by issuance policy", "id", credentialReq.ID, "issuerDID", req.IssuerDID, "policy", policy.ID)
+	case domain.IssuancePolicyAutoApprove:
+		if _, err := c.approve(ctx, credentialReq, domain.IssuanceAuditActorPolicy, policy); err != nil {
+			log.Warn(ctx, "auto approving credential request, it stays pending", "err", err, "id", credentialReq.ID)
+		}
+	default:
+		log.Info(ctx, "credential request queued for approval", "id", credentialReq.ID, "issuerDID", req.IssuerDID, "holder", req.UserDID)
+	}
+	return credentialReq, nil
+}
+
+// save stores a new request and its audit record
+func (c *credentialRequests) save(ctx context.Context, req *domain.CredentialRequest, actor string, policy *domain.IssuancePolicy) error {
+	return c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return c.store(ctx, tx, &submission{request: req, audit: requestAudit(req, actor, policy)})
+	})
+}
+
+// requestAudit returns the audit record of a new request: requested by the actor when it is pending, denied by the
+// policy when it was rejected right away
+func requestAudit(req *domain.CredentialRequest, actor string, policy *domain.IssuancePolicy) *domain.IssuanceAudit {
+	audit := newIssuanceAudit(req.Issuer, req.Holder, req.SchemaType, domain.IssuanceAuditRequested, actor)
+	if req.Status == domain.CredentialRequestRejected {
+		audit.Action = domain.IssuanceAuditDenied
+		audit.Actor = domain.IssuanceAuditActorPolicy
+		audit.Reason = req.Reason
+	}
+	audit.RequestID = &req.ID
+	if policy != nil {
+		audit.PolicyID = &policy.ID
+	}
+	return audit
+}
+
+// agentResponse returns the credential offer of an approved request, or the status of the request otherwise
+func (c *credentialRequests) agentResponse(req *domain.CredentialRequest) *domain.Agent {
+	if req.Status == domain.CredentialRequestApproved && req.ClaimID != nil {
+		offer := c.offer(req)
+		return &domain.Agent{
+			ID:       offer.ID,
+			Typ:      offer.Typ,
+			Type:     offer.Type,
+			ThreadID: offer.ThreadID,
+			Body:     offer.Body,
+			From:     offer.From,
+			To:       offer.To,
+		}
+	}
+
+	body := protocol.CredentialIssuanceRequestStatusMessageBody{ID: req.ID.String(), Status: string(req.Status)}
+	if req.Reason != nil {
+		body.Reason = *req.Reason
+	}
+	return &domain.Agent{
+		ID:       uuid.NewString(),
+		Typ:      packers.MediaTypePlainMessage,
+		Type:     protocol.CredentialIssuanceRequestStatusMessageType,
+		ThreadID: req.ThreadID,
+		Body:     body,
+		From:     req.Issuer,
+		To:       req.Holder,
+	}
+}
+
+// offer returns the offer of the credential issued for the request, to be fetched from the agent
+func (c *credentialRequests) offer(req *domain.CredentialRequest) *protocol.CredentialsOfferMessage {
+	return &protocol.CredentialsOfferMessage{
+		ID:       uuid.NewString(),
+		Typ:      packers.MediaTypePlainMessage,
+		Type:     protocol.CredentialOfferMessageType,
+		ThreadID: req.ThreadID,
+		Body: protocol.CredentialsOfferMessageBody{
+			URL:         fmt.Sprintf("%s/v1/agent", strings.TrimSuffix(c.host, "/")),
+			Credentials: []protocol.CredentialOffer{{ID: req.ClaimID.String(), Description: req.SchemaType}},
+		},
+		From: req.Issuer,
+		To:   req.Holder,
+	}
+}
+
+func (c *credentialRequests) GetAll(ctx context.Context, issuer *core.DID, status *domain.CredentialRequestStatus) ([]*domain.CredentialRequest, error) {
+	return c.repo.GetAll(ctx, c.storage.Pgx, issuer, status)
+}
+
+func (c *credentialRequests) GetByID(ctx context.Context, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error) {
+	req, err := c.repo.GetByID(ctx, c.storage.Pgx, issuer, id)
+	if errors.Is(err, repositories.ErrCredentialRequestDoesNotExist) {
+		return nil, ErrCredentialRequestNotFound
+	}
+	return req, err
+}
+
+// Approve issues the requested credential and returns the offer the holder fetches it with.
+// The holder gets the same offer when sending the issuance request again in the same thread.
+func (c *credentialRequests) Approve(ctx context.Context, issuer *core.DID, id uuid.UUID, actor string) (*protocol.CredentialsOfferMessage, error) {
+	req, err := c.pending(ctx, issuer, id)
+	if err != nil {
+		return nil, err
+	}
+	return c.approve(ctx, req, actor, nil)
+}
+
+// approve moves the request from pending to approving before issuing its credential, so a request is only issued once
+// however many approvals run at the same time. The request goes back to pending when the credential cannot be issued.
+func (c *credentialRequests) approve(ctx context.Context, req *domain.CredentialRequest, actor string, policy *domain.IssuancePolicy) (*protocol.CredentialsOfferMessage, error) {
+	subject, err := req.GetCredentialSubject()
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := core.ParseDID(req.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.startApproval(ctx, issuer, req); err != nil {
+		return nil, err
+	}
+
+	claim, err := c.claimSrv.CreateClaim(ctx, &ports.CreateClaimRequest{
+		DID:                   issuer,
+		Schema:                req.SchemaURL,
+		CredentialSubject:     subject,
+		Expiration:            req.Expiration,
+		Type:                  req.SchemaType,
+		Version:               req.Version,
+		SubjectPos:            req.SubjectPosition,
+		MerklizedRootPosition: req.MerklizedRootPosition,
+		Updatable:             req.Updatable,
+	})
+	if err != nil {
+		log.Warn(ctx, "issuing requested credential", "err", err, "id", req.ID)
+		c.abortApproval(ctx, req)
+		return nil, err
+	}
+
+	req.Status = domain.CredentialRequestApproved
+	req.ClaimID = &claim.ID
+	audit := newIssuanceAudit(req.Issuer, req.Holder, req.SchemaType, domain.IssuanceAuditApproved, actor)
+	audit.ClaimID = &claim.ID
+	if policy != nil {
+		audit.PolicyID = &policy.ID
+	}
+	if err := c.decide(ctx, req, audit, domain.CredentialRequestApproving); err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "credential request approved", "id", req.ID, "issuerDID", req.Issuer, "actor", actor, "claimID", claim.ID)
+	return c.offer(req), nil
+}
+
+// Reject rejects the request, the holder gets the reason when sending the issuance request again
+func (c *credentialRequests) Reject(ctx context.Context, issuer *core.DID, id uuid.UUID, actor string, reason string) (*domain.CredentialRequest, error) {
+	req, err := c.pending(ctx, issuer, id)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Status = domain.CredentialRequestRejected
+	if reason != "" {
+		req.Reason = &reason
+	}
+	audit := newIssuanceAudit(req.Issuer, req.Holder, req.SchemaType, domain.IssuanceAuditRejected, actor)
+	audit.Reason = req.Reason
+	if err := c.decide(ctx, req, audit, domain.CredentialRequestPending); err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "credential request rejected", "id", req.ID, "issuerDID", req.Issuer, "actor", actor)
+	return req, nil
+}
+
+func (c *credentialRequests) pending(ctx context.Context, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error) {
+	req, err := c.GetByID(ctx, issuer, id)
+	if err != nil {
+		return nil, err
+	}
+	if !req.IsPending() {
+		return nil, fmt.Errorf("%w: the request is %s", ErrCredentialRequestDecided, req.Status)
+	}
+	return req, nil
+}
+
+// startApproval locks the request and moves it from pending to approving
+func (c *credentialRequests) startApproval(ctx context.Context, issuer *core.DID, req *domain.CredentialRequest) error {
+	return c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		locked, err := c.repo.GetByIDForUpdate(ctx, tx, issuer, req.ID)
+		if err != nil {
+			return err
+		}
+		if !locked.IsPending() {
+			return fmt.Errorf("%w: the request is %s", ErrCredentialRequestDecided, locked.Status)
+		}
+		req.Status = domain.CredentialRequestApproving
+		return c.repo.UpdateStatus(ctx, tx, req, domain.CredentialRequestPending)
+	})
+}
+
+// abortApproval moves back to pending a request whose credential could not be issued, so it can be approved again
+func (c *credentialRequests) abortApproval(ctx context.Context, req *domain.CredentialRequest) {
+	req.Status = domain.CredentialRequestPending
+	if err := c.repo.UpdateStatus(ctx, c.storage.Pgx, req, domain.CredentialRequestApproving); err != nil {
+		log.Error(ctx, "moving the credential request back to pending", err, "id", req.ID)
+	}
+}
+
+// decide records the decision and its audit, unless the request is no longer in the from status
+func (c *credentialRequests) decide(ctx context.Context, req *domain.CredentialRequest, audit *domain.IssuanceAudit, from domain.CredentialRequestStatus) error {
+	audit.RequestID = &req.ID
+	err := c.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := c.repo.UpdateStatus(ctx, tx, req, from); err != nil {
+			return err
+		}
+		return c.auditRepo.Save(ctx, tx, audit)
+	})
+	if errors.Is(err, repositories.ErrCredentialRequestDoesNotExist) {
+		return ErrCredentialRequestDecided
+	}
+	return err
+}
+
+// policyAction returns the action of the policy, or the given one when no policy matched
+func policyAction(policy *domain.IssuancePolicy, fallback domain.IssuancePolicyAction) domain.IssuancePolicyAction {
+	if policy == nil {
+		return fallback
+	}
+	return policy.Action
+}
+
+func newIssuanceAudit(issuer, holder, schemaType string, action domain.IssuanceAuditAction, actor string) *domain.IssuanceAudit {
+	return &domain.IssuanceAudit{
+		ID:         uuid.New(),
+		Issuer:     issuer,
+		SchemaType: schemaType,
+		Holder:     holder,
+		Action:     action,
+		Actor:      actor,
+		CreatedAt:  time.Now(),
+	}
+}
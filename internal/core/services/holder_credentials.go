@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/verifiable"
+
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+var (
+	ErrHolderCredentialNotFound = errors.New("credential not found")                      // ErrHolderCredentialNotFound Cannot retrieve the given credential of the holder
+	ErrHolderCredentialInvalid  = errors.New("the credential cannot be held by identity") // ErrHolderCredentialInvalid The credential is not valid or is not about the holder
+)
+
+type holderCredentials struct {
+	repo           ports.HolderCredentialRepository
+	identitySrv    ports.IdentityService
+	storage        *db.Storage
+	stateResolvers map[string]pubsignals.StateResolver
+}
+
+// NewHolderCredentials creates a new service for the credentials held by the identities, whoever issued them.
+// The issuer states the credentials are proved with are resolved with stateResolvers.
+func NewHolderCredentials(repo ports.HolderCredentialRepository, identitySrv ports.IdentityService, storage *db.Storage, stateResolvers map[string]pubsignals.StateResolver) ports.HolderCredentialService {
+	return &holderCredentials{
+		repo:           repo,
+		identitySrv:    identitySrv,
+		storage:        storage,
+		stateResolvers: stateResolvers,
+	}
+}
+
+// Import validates the credential and stores it as held by the identity
+func (h *holderCredentials) Import(ctx context.Context, req *ports.ImportCredentialRequest) (*domain.HolderCredential, error) {
+	if _, _, err := h.identitySrv.ResolveProfile(ctx, req.DID); err != nil {
+		return nil, fmt.Errorf("%w: identity not found", ErrHolderCredentialInvalid)
+	}
+
+	credential, err := newHolderCredential(ctx, h.stateResolvers, req.DID, &req.Credential, domain.HolderCredentialSourceImport)
+	if err != nil {
+		log.Warn(ctx, "validating imported credential", "err", err, "credential", req.Credential.ID)
+		return nil, err
+	}
+
+	id, err := h.repo.Save(ctx, h.storage.Pgx, credential)
+	if err != nil {
+		log.Error(ctx, "saving imported credential", err, "credential", req.Credential.ID)
+		return nil, err
+	}
+
+	return h.GetByID(ctx, req.DID, id)
+}
+
+// GetAll returns the credentials held by the identity that match the filter
+func (h *holderCredentials) GetAll(ctx context.Context, holder *core.DID, filter *ports.HolderCredentialFilter) ([]*domain.HolderCredential, error) {
+	return h.repo.GetAll(ctx, h.storage.Pgx, holder, filter)
+}
+
+func (h *holderCredentials) GetByID(ctx context.Context, holder *core.DID, id uuid.UUID) (*domain.HolderCredential, error) {
+	credential, err := h.repo.GetByID(ctx, h.storage.Pgx, holder, id)
+	if errors.Is(err, repositories.ErrHolderCredentialDoesNotExist) {
+		return nil, ErrHolderCredentialNotFound
+	}
+	return credential, err
+}
+
+func (h *holderCredentials) Delete(ctx context.Context, holder *core.DID, id uuid.UUID) error {
+	err := h.repo.Delete(ctx, h.storage.Pgx, holder, id)
+	if errors.Is(err, repositories.ErrHolderCredentialDoesNotExist) {
+		return ErrHolderCredentialNotFound
+	}
+	return err
+}
+
+// newHolderCredential validates the credential issued to holder and returns it ready to be stored.
+// The core claim must be proved by a valid issuer signature or by an issuer merkle tree proof, in a state
+// of the issuer known by the state resolvers.
+func newHolderCredential(ctx context.Context, stateResolvers map[string]pubsignals.StateResolver, holder *core.DID, credential *verifiable.W3CCredential, source domain.HolderCredentialSource) (*domain.HolderCredential, error) {
+	issuer, err := core.ParseDID(credential.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid issuer did", ErrHolderCredentialInvalid)
+	}
+	if credential.Expiration != nil && credential.Expiration.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: the credential has expired", ErrHolderCredentialInvalid)
+	}
+
+	held, err := domain.NewHolderCredential(holder, *credential, source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrHolderCredentialInvalid, err)
+	}
+
+	for _, proof := range credential.Proof {
+		if err := verifyIssuerProof(ctx, stateResolvers, issuer, proof); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrHolderCredentialInvalid, err)
+		}
+	}
+
+	held.ID = credentialID(credential.ID)
+	return held, nil
+}
+
+// verifyIssuerProof checks a signature or merkle tree proof of the issuer. A signature must be made with the key of an
+// auth claim in a state of the issuer, a merkle tree proof must prove the claim in a state of the issuer.
+// Other proofs are not checked.
+func verifyIssuerProof(ctx context.Context, stateResolvers map[string]pubsignals.StateResolver, issuer *core.DID, proof verifiable.CredentialProof) error {
+	switch issuerProof := proof.(type) {
+	case *verifiable.BJJSignatureProof2021:
+		coreClaim, err := issuerProof.GetCoreClaim()
+		if err != nil {
+			return err
+		}
+		var authClaim core.Claim
+		if err := authClaim.FromHex(issuerProof.IssuerData.AuthCoreClaim); err != nil {
+			return fmt.Errorf("invalid issuer auth claim: %w", err)
+		}
+		if err := verifyIssuerState(ctx, stateResolvers, issuer, issuerProof.IssuerData, issuerProof.IssuerData.MTP, &authClaim); err != nil {
+			return fmt.Errorf("issuer auth claim: %w", err)
+		}
+		return verifyClaimSignature(issuerProof, &authClaim, coreClaim)
+	case *verifiable.Iden3SparseMerkleProof:
+		coreClaim, err := issuerProof.GetCoreClaim()
+		if err != nil {
+			return err
+		}
+		return verifyIssuerState(ctx, stateResolvers, issuer, issuerProof.IssuerData, issuerProof.MTP, coreClaim)
+	default:
+		return nil
+	}
+}
+
+// verifyIssuerState checks that the claim is in the claims tree of the issuer data state, that the state is the hash
+// of its roots and that the state resolvers know it as a state of the issuer
+func verifyIssuerState(ctx context.Context, stateResolvers map[string]pubsignals.StateResolver, issuer *core.DID, issuerData verifiable.IssuerData, mtp *merkletree.Proof, claim *core.Claim) error {
+	if issuerData.ID != issuer.String() {
+		return errors.New("the proof is from another issuer")
+	}
+	if mtp == nil {
+		return errors.New("the proof has no merkle tree proof")
+	}
+	stateHash, claimsRoot, _, err := verifiedTreeState(issuerData.State)
+	if err != nil {
+		return err
+	}
+
+	hIndex, hValue, err := claim.HiHv()
+	if err != nil {
+		return err
+	}
+	if !mtp.Existence || !merkletree.VerifyProof(claimsRoot, mtp, hIndex, hValue) {
+		return errors.New("the claim is not in the issuer state")
+	}
+
+	resolver, err := pubsignals.GetStateResolver(stateResolvers, issuer)
+	if err != nil {
+		return err
+	}
+	if _, err := resolver.Resolve(ctx, issuer.ID.BigInt(), stateHash.BigInt()); err != nil {
+		return fmt.Errorf("resolving the issuer state: %w", err)
+	}
+	return nil
+}
+
+// verifiedTreeState returns the state, claims tree root and revocation tree root of the given state,
+// once checked that the state is the hash of its roots
+func verifiedTreeState(st verifiable.State) (stateHash, claimsRoot, revocationRoot *merkletree.Hash, err error) {
+	if st.Value == nil || st.ClaimsTreeRoot == nil || st.RevocationTreeRoot == nil || st.RootOfRoots == nil {
+		return nil, nil, nil, errors.New("the state is incomplete")
+	}
+	if stateHash, err = merkletree.NewHashFromHex(*st.Value); err != nil {
+		return nil, nil, nil, err
+	}
+	if claimsRoot, err = merkletree.NewHashFromHex(*st.ClaimsTreeRoot); err != nil {
+		return nil, nil, nil, err
+	}
+	if revocationRoot, err = merkletree.NewHashFromHex(*st.RevocationTreeRoot); err != nil {
+		return nil, nil, nil, err
+	}
+	rootOfRoots, err := merkletree.NewHashFromHex(*st.RootOfRoots)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	calculated, err := merkletree.HashElems(claimsRoot.BigInt(), revocationRoot.BigInt(), rootOfRoots.BigInt())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if calculated.BigInt().Cmp(stateHash.BigInt()) != 0 {
+		return nil, nil, nil, errors.New("the state does not match its roots")
+	}
+	return stateHash, claimsRoot, revocationRoot, nil
+}
+
+// verifyClaimSignature checks the signature of the claim with the key of the issuer auth claim
+func verifyClaimSignature(proof *verifiable.BJJSignatureProof2021, authClaim, claim *core.Claim) error {
+	slots := authClaim.RawSlotsAsInts()
+	publicKey := babyjub.PublicKey{X: slots[2], Y: slots[3]}
+
+	sigBytes, err := hex.DecodeString(proof.Signature)
+	if err != nil {
+		return err
+	}
+	var sigComp babyjub.SignatureComp
+	copy(sigComp[:], sigBytes)
+	signature, err := sigComp.Decompress()
+	if err != nil {
+		return err
+	}
+
+	hashIndex, hashValue, err := claim.HiHv()
+	if err != nil {
+		return err
+	}
+	message, err := poseidon.Hash([]*big.Int{hashIndex, hashValue})
+	if err != nil {
+		return err
+	}
+
+	if !publicKey.VerifyPoseidon(message, signature) {
+		return errors.New("invalid claim signature")
+	}
+	return nil
+}
+
+// credentialID returns the uuid at the end of the credential id, a new one if there is none
+func credentialID(id string) uuid.UUID {
+	last := id[strings.LastIndexAny(id, "/:")+1:]
+	if parsed, err := uuid.Parse(last); err == nil {
+		return parsed
+	}
+	return uuid.New()
+}
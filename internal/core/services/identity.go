@@ -25,22 +25,31 @@ import (
 	"github.com/lastingasset/wallet-service/internal/db"
 	"github.com/lastingasset/wallet-service/internal/kms"
 	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
 	"github.com/lastingasset/wallet-service/pkg/credentials/signature/circuit/signer"
 	"github.com/lastingasset/wallet-service/pkg/credentials/signature/suite"
 	"github.com/lastingasset/wallet-service/pkg/credentials/signature/suite/babyjubjub"
 	"github.com/lastingasset/wallet-service/pkg/primitive"
+	"github.com/lastingasset/wallet-service/pkg/rand"
 	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
 )
 
 // ErrWrongDIDMetada - represents an error in the identity metadata
 var ErrWrongDIDMetada = errors.New("wrong DID Metadata")
 
+var (
+	ErrIdentityNotFound = errors.New("identity not found")                                     // ErrIdentityNotFound the did is not an identity nor a profile of the service
+	ErrProfileInvalid   = errors.New("invalid profile")                                        // ErrProfileInvalid the profile cannot be derived from the identity
+	ErrProfileDuplicate = errors.New("the identity has already got a profile with that nonce") // ErrProfileDuplicate the nonce or the verifier already have a profile
+)
+
 type identity struct {
 	identityRepository      ports.IndentityRepository
 	imtRepository           ports.IdentityMerkleTreeRepository
 	identityStateRepository ports.IdentityStateRepository
 	claimsRepository        ports.ClaimsRepository
 	revocationRepository    ports.RevocationRepository
+	profileRepository       ports.ProfileRepository
 	storage                 *db.Storage
 	mtService               ports.MtService
 	kms                     kms.KMSType
@@ -50,13 +59,14 @@ type identity struct {
 }
 
 // NewIdentity creates a new identity
-func NewIdentity(kms kms.KMSType, identityRepository ports.IndentityRepository, imtRepository ports.IdentityMerkleTreeRepository, identityStateRepository ports.IdentityStateRepository, mtservice ports.MtService, claimsRepository ports.ClaimsRepository, revocationRepository ports.RevocationRepository, storage *db.Storage, rhsPublisher reverse_hash.RhsPublisher) ports.IdentityService {
+func NewIdentity(kms kms.KMSType, identityRepository ports.IndentityRepository, imtRepository ports.IdentityMerkleTreeRepository, identityStateRepository ports.IdentityStateRepository, mtservice ports.MtService, claimsRepository ports.ClaimsRepository, revocationRepository ports.RevocationRepository, profileRepository ports.ProfileRepository, storage *db.Storage, rhsPublisher reverse_hash.RhsPublisher) ports.IdentityService {
 	return &identity{
 		identityRepository:      identityRepository,
 		imtRepository:           imtRepository,
 		identityStateRepository: identityStateRepository,
 		claimsRepository:        claimsRepository,
 		revocationRepository:    revocationRepository,
+		profileRepository:       profileRepository,
 		storage:                 storage,
 		mtService:               mtservice,
 		kms:                     kms,
@@ -151,6 +161,77 @@ func (i *identity) Exists(ctx context.Context, identifier *core.DID) (bool, erro
 	return identity != nil, nil
 }
 
+// CreateProfile derives a new profile of the identity. A random nonce is used when none is given.
+// When the profile is for a verifier, the profile that the identity already has for it is returned.
+func (i *identity) CreateProfile(ctx context.Context, did *core.DID, nonce *domain.ProfileNonce, verifier *string) (*domain.Profile, error) {
+	if _, err := i.identityRepository.GetByID(ctx, i.storage.Pgx, did); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIdentityNotFound, did)
+	}
+
+	if verifier != nil {
+		if *verifier == "" {
+			return nil, fmt.Errorf("%w: empty verifier", ErrProfileInvalid)
+		}
+		profile, err := i.profileRepository.GetByVerifier(ctx, i.storage.Pgx, did, *verifier)
+		if err == nil {
+			return profile, nil
+		}
+		if !errors.Is(err, repositories.ErrProfileDoesNotExist) {
+			return nil, err
+		}
+	}
+
+	if nonce == nil {
+		random, err := rand.Int64()
+		if err != nil {
+			return nil, err
+		}
+		nonce = common.ToPointer(domain.ProfileNonce(random))
+	}
+
+	profile, err := domain.NewProfile(did, *nonce, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProfileInvalid, err)
+	}
+
+	profile.ID, err = i.profileRepository.Save(ctx, i.storage.Pgx, profile)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProfileDuplication) {
+			return nil, ErrProfileDuplicate
+		}
+		log.Error(ctx, "saving profile", err, "id", did)
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// GetProfiles returns all the profiles of the identity
+func (i *identity) GetProfiles(ctx context.Context, did *core.DID) ([]domain.Profile, error) {
+	return i.profileRepository.GetAllByIdentifier(ctx, i.storage.Pgx, did)
+}
+
+// ResolveProfile returns the genesis identity and the profile nonce of the did, that can be either
+// one of the identities of the service, with a zero nonce, or one of their profiles
+func (i *identity) ResolveProfile(ctx context.Context, did *core.DID) (*core.DID, *big.Int, error) {
+	profile, err := i.profileRepository.GetByProfileDID(ctx, i.storage.Pgx, did)
+	if err == nil {
+		identifier, err := core.ParseDID(profile.Identifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		return identifier, profile.Nonce.BigInt(), nil
+	}
+	if !errors.Is(err, repositories.ErrProfileDoesNotExist) {
+		return nil, nil, err
+	}
+
+	if exists, err := i.Exists(ctx, did); err != nil || !exists {
+		return nil, nil, fmt.Errorf("%w: %s", ErrIdentityNotFound, did)
+	}
+	return did, big.NewInt(0), nil
+}
+
 // getKeyIDFromAuthClaim finds BJJ KeyID of auth claim
 // in registered key providers
 func (i *identity) getKeyIDFromAuthClaim(ctx context.Context, authClaim *domain.Claim) (kms.KeyID, error) {
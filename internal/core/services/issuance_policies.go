@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+var (
+	ErrIssuancePolicyInvalid  = errors.New("invalid issuance policy")                    // ErrIssuancePolicyInvalid The policy cannot be created
+	ErrIssuancePolicyNotFound = errors.New("issuance policy not found")                  // ErrIssuancePolicyNotFound Cannot retrieve the given issuance policy
+	ErrIssuanceDenied         = errors.New("the issuance policy denies this credential") // ErrIssuanceDenied A policy of the issuer denies the credential
+)
+
+type issuancePolicies struct {
+	repo      ports.IssuancePolicyRepository
+	auditRepo ports.IssuanceAuditRepository
+	storage   *db.Storage
+}
+
+// NewIssuancePolicies creates a new service for the rules the issuers set about the credentials they issue.
+// The policies decide if a credential is issued right away, queued for approval or denied.
+func NewIssuancePolicies(repo ports.IssuancePolicyRepository, auditRepo ports.IssuanceAuditRepository, storage *db.Storage) ports.IssuancePolicyService {
+	return &issuancePolicies{
+		repo:      repo,
+		auditRepo: auditRepo,
+		storage:   storage,
+	}
+}
+
+func (p *issuancePolicies) Create(ctx context.Context, req *ports.CreateIssuancePolicyRequest) (*domain.IssuancePolicy, error) {
+	if !req.Action.IsValid() {
+		return nil, fmt.Errorf("%w: unknown action %s", ErrIssuancePolicyInvalid, req.Action)
+	}
+	for _, subject := range req.SubjectAllowlist {
+		if _, err := core.ParseDID(subject); err != nil {
+			return nil, fmt.Errorf("%w: invalid subject did %s", ErrIssuancePolicyInvalid, subject)
+		}
+	}
+
+	policy, err := domain.NewIssuancePolicy(req.DID.String(), req.SchemaType, req.SubjectAllowlist, req.FieldConstraints, req.Action, req.Priority)
+	if err != nil {
+		if errors.Is(err, domain.ErrIssuancePolicyConstraint) {
+			return nil, fmt.Errorf("%w: %v", ErrIssuancePolicyInvalid, err)
+		}
+		return nil, err
+	}
+
+	if err := p.repo.Save(ctx, p.storage.Pgx, policy); err != nil {
+		log.Error(ctx, "saving issuance policy", err, "issuerDID", req.DID)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (p *issuancePolicies) GetAll(ctx context.Context, issuer *core.DID) ([]*domain.IssuancePolicy, error) {
+	return p.repo.GetAll(ctx, p.storage.Pgx, issuer)
+}
+
+func (p *issuancePolicies) Delete(ctx context.Context, issuer *core.DID, id uuid.UUID) error {
+	err := p.repo.Delete(ctx, p.storage.Pgx, issuer, id)
+	if errors.Is(err, repositories.ErrIssuancePolicyDoesNotExist) {
+		return ErrIssuancePolicyNotFound
+	}
+	return err
+}
+
+// Evaluate returns the first policy of the issuer matching the credential, by priority, or nil if none matches
+func (p *issuancePolicies) Evaluate(ctx context.Context, issuer *core.DID, schemaType string, credentialSubject map[string]interface{}) (*domain.IssuancePolicy, error) {
+	policies, err := p.repo.GetAll(ctx, p.storage.Pgx, issuer)
+	if err != nil {
+		log.Error(ctx, "loading issuance policies", err, "issuerDID", issuer)
+		return nil, err
+	}
+	for _, policy := range policies {
+		matches, err := policy.Matches(schemaType, credentialSubject)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			return policy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *issuancePolicies) GetAudit(ctx context.Context, issuer *core.DID, requestID *uuid.UUID) ([]*domain.IssuanceAudit, error) {
+	return p.auditRepo.GetAll(ctx, p.storage.Pgx, issuer, requestID)
+}
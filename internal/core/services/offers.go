@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
+	"github.com/lastingasset/wallet-service/go-jwz"
+	"github.com/lastingasset/wallet-service/iden3comm"
+	"github.com/lastingasset/wallet-service/iden3comm/packers"
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	client "github.com/lastingasset/wallet-service/pkg/http"
+)
+
+var (
+	ErrOfferInvalid         = errors.New("invalid credential offer")                         // ErrOfferInvalid The offer cannot be accepted by the identity
+	ErrOfferAgentResponse   = errors.New("the issuer agent returned an invalid response")    // ErrOfferAgentResponse The agent response is not an issuance of the offered credential
+	ErrOfferCredentialProof = errors.New("the offered credential has no valid issuer proof") // ErrOfferCredentialProof The credential is not signed nor proved by its issuer
+)
+
+const agentResponseTimeout = 30 * time.Second
+
+type offers struct {
+	holderCredentialRepo ports.HolderCredentialRepository
+	identitySrv          ports.IdentityService
+	packageManager       *iden3comm.PackageManager
+	httpClient           *client.Client
+	storage              *db.Storage
+	stateResolvers       map[string]pubsignals.StateResolver
+}
+
+// NewOffers creates a new service that accepts, as a holder, the credentials offered by any issuer.
+// The issuer states the credentials are proved with are resolved with stateResolvers.
+func NewOffers(holderCredentialRepo ports.HolderCredentialRepository, identitySrv ports.IdentityService, packageManager *iden3comm.PackageManager, storage *db.Storage, stateResolvers map[string]pubsignals.StateResolver) ports.OfferService {
+	return &offers{
+		holderCredentialRepo: holderCredentialRepo,
+		identitySrv:          identitySrv,
+		packageManager:       packageManager,
+		httpClient:           client.NewClient(http.Client{Timeout: agentResponseTimeout}),
+		storage:              storage,
+		stateResolvers:       stateResolvers,
+	}
+}
+
+// Accept fetches every credential of the offer from the issuer agent, authenticating with a JWZ
+// fetch request of the holder, and stores them in the credential store of the identity.
+// Either all the credentials of the offer are stored or none of them.
+func (o *offers) Accept(ctx context.Context, req *ports.AcceptOfferRequest) ([]*domain.HolderCredential, error) {
+	if err := o.guardOffer(ctx, req); err != nil {
+		return nil, err
+	}
+
+	credentials := make([]*domain.HolderCredential, 0, len(req.Offer.Body.Credentials))
+	for _, offered := range req.Offer.Body.Credentials {
+		credential, err := o.fetch(ctx, req, offered)
+		if err != nil {
+			log.Warn(ctx, "fetching offered credential", "err", err, "credential", offered.ID, "issuer", req.Offer.From)
+			return nil, err
+		}
+
+		held, err := heldCredential(ctx, o.stateResolvers, req.DID, req.Offer.From, credential)
+		if err != nil {
+			log.Warn(ctx, "validating offered credential", "err", err, "credential", offered.ID, "issuer", req.Offer.From)
+			return nil, err
+		}
+		credentials = append(credentials, held)
+	}
+
+	err := o.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for _, held := range credentials {
+			id, err := o.holderCredentialRepo.Save(ctx, tx, held)
+			if err != nil {
+				log.Error(ctx, "saving offered credential", err, "credential", held.CredentialID)
+				return err
+			}
+			held.ID = id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (o *offers) guardOffer(ctx context.Context, req *ports.AcceptOfferRequest) error {
+	offer := req.Offer
+	if offer.Type != protocol.CredentialOfferMessageType {
+		return fmt.Errorf("%w: unexpected message type <%s>", ErrOfferInvalid, offer.Type)
+	}
+	if offer.To != "" && offer.To != req.DID.String() {
+		return fmt.Errorf("%w: the offer is for another identity", ErrOfferInvalid)
+	}
+	if _, err := core.ParseDID(offer.From); err != nil {
+		return fmt.Errorf("%w: invalid issuer did", ErrOfferInvalid)
+	}
+	if _, err := url.ParseRequestURI(offer.Body.URL); err != nil {
+		return fmt.Errorf("%w: invalid agent url", ErrOfferInvalid)
+	}
+	if len(offer.Body.Credentials) == 0 {
+		return fmt.Errorf("%w: no credentials offered", ErrOfferInvalid)
+	}
+
+	if _, _, err := o.identitySrv.ResolveProfile(ctx, req.DID); err != nil {
+		if errors.Is(err, ErrIdentityNotFound) {
+			return fmt.Errorf("%w: identity not found", ErrOfferInvalid)
+		}
+		return err
+	}
+	return nil
+}
+
+// fetch sends the credential fetch request of the holder to the issuer agent and returns the issued credential
+func (o *offers) fetch(ctx context.Context, req *ports.AcceptOfferRequest, offered protocol.CredentialOffer) (*verifiable.W3CCredential, error) {
+	threadID := req.Offer.ThreadID
+	if threadID == "" {
+		threadID = req.Offer.ID
+	}
+
+	fetchRequest, err := json.Marshal(protocol.CredentialFetchRequestMessage{
+		ID:       uuid.NewString(),
+		Typ:      packers.MediaTypeZKPMessage,
+		Type:     protocol.CredentialFetchRequestMessageType,
+		ThreadID: threadID,
+		Body:     protocol.CredentialFetchRequestMessageBody{ID: offered.ID},
+		From:     req.DID.String(),
+		To:       req.Offer.From,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := o.packageManager.Pack(packers.MediaTypeZKPMessage, fetchRequest, packers.ZKPPackerParams{
+		SenderID:         req.DID,
+		ProvingMethodAlg: jwz.AuthV2Groth16Alg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("packing credential fetch request: %w", err)
+	}
+
+	resp, err := o.httpClient.Post(ctx, req.Offer.Body.URL, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOfferAgentResponse, err)
+	}
+
+	basicMessage, _, err := o.packageManager.Unpack(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOfferAgentResponse, err)
+	}
+	if basicMessage.Type != protocol.CredentialIssuanceResponseMessageType ||
+		basicMessage.ThreadID != threadID ||
+		basicMessage.From != req.Offer.From ||
+		basicMessage.To != req.DID.String() {
+		return nil, fmt.Errorf("%w: the response does not answer the fetch request", ErrOfferAgentResponse)
+	}
+
+	var body protocol.IssuanceMessageBody
+	if err := json.Unmarshal(basicMessage.Body, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOfferAgentResponse, err)
+	}
+	return &body.Credential, nil
+}
+
+// heldCredential validates the credential fetched from the issuer of the offer and returns it as held by the holder
+func heldCredential(ctx context.Context, stateResolvers map[string]pubsignals.StateResolver, holder *core.DID, issuer string, credential *verifiable.W3CCredential) (*domain.HolderCredential, error) {
+	if credential.Issuer != issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer <%s>", ErrOfferCredentialProof, credential.Issuer)
+	}
+
+	held, err := newHolderCredential(ctx, stateResolvers, holder, credential, domain.HolderCredentialSourceOffer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOfferCredentialProof, err)
+	}
+	return held, nil
+}
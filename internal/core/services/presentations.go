@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
+	"github.com/lastingasset/wallet-service/internal/common"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/kms"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/pkg/primitive"
+)
+
+var (
+	ErrPresentationInvalid  = errors.New("invalid presentation request")           // ErrPresentationInvalid The presentation cannot be created with the request
+	ErrPresentationNotValid = errors.New("the presentation could not be verified") // ErrPresentationNotValid The presentation or its holder proof is not valid
+)
+
+// presentationStateTransitionDelay is how long the previous state of the holder is still accepted after a state transition
+const presentationStateTransitionDelay = 5 * time.Minute
+
+type presentations struct {
+	holderCredentialSrv ports.HolderCredentialService
+	claimSrv            ports.ClaimsService
+	identitySrv         ports.IdentityService
+	mtService           ports.MtService
+	keyProvider         *kms.KMS
+	storage             *db.Storage
+	stateResolvers      map[string]pubsignals.StateResolver
+}
+
+// NewPresentations creates a new service that presents the credentials held by the identities as W3C verifiable presentations
+// signed with their BJJ key, and verifies those presentations for the verifiers that do not use zero knowledge proofs
+func NewPresentations(holderCredentialSrv ports.HolderCredentialService, claimSrv ports.ClaimsService, identitySrv ports.IdentityService, mtService ports.MtService, keyProvider *kms.KMS, storage *db.Storage, stateResolvers map[string]pubsignals.StateResolver) ports.PresentationService {
+	return &presentations{
+		holderCredentialSrv: holderCredentialSrv,
+		claimSrv:            claimSrv,
+		identitySrv:         identitySrv,
+		mtService:           mtService,
+		keyProvider:         keyProvider,
+		storage:             storage,
+		stateResolvers:      stateResolvers,
+	}
+}
+
+// Create bundles the held credentials in a presentation signed by the holder for the challenge and domain of the verifier
+func (p *presentations) Create(ctx context.Context, req *ports.CreatePresentationRequest) (*domain.VerifiablePresentation, error) {
+	if req.Challenge == "" || req.Domain == "" {
+		return nil, fmt.Errorf("%w: the challenge and the domain are required", ErrPresentationInvalid)
+	}
+	if len(req.Credentials) == 0 {
+		return nil, fmt.Errorf("%w: no credentials to present", ErrPresentationInvalid)
+	}
+
+	identifier, _, err := p.identitySrv.ResolveProfile(ctx, req.DID)
+	if err != nil {
+		if errors.Is(err, ErrIdentityNotFound) {
+			return nil, fmt.Errorf("%w: identity not found", ErrPresentationInvalid)
+		}
+		return nil, err
+	}
+	if identifier.String() != req.DID.String() {
+		// the signature of the holder key would link the profile to its identity
+		return nil, fmt.Errorf("%w: profiles cannot sign presentations", ErrPresentationInvalid)
+	}
+
+	presentation := &domain.VerifiablePresentation{
+		Context:              []string{verifiable.JSONLDSchemaW3CCredential2018},
+		ID:                   fmt.Sprintf("urn:uuid:%s", uuid.NewString()),
+		Type:                 []string{domain.PresentationType},
+		Holder:               req.DID.String(),
+		VerifiableCredential: make([]verifiable.W3CCredential, 0, len(req.Credentials)),
+	}
+
+	now := time.Now()
+	for _, id := range req.Credentials {
+		held, err := p.holderCredentialSrv.GetByID(ctx, req.DID, id)
+		if err != nil {
+			if errors.Is(err, ErrHolderCredentialNotFound) {
+				return nil, fmt.Errorf("%w: credential %s not found", ErrPresentationInvalid, id)
+			}
+			return nil, err
+		}
+		if held.Revoked || held.IsExpired(now) {
+			return nil, fmt.Errorf("%w: credential %s is revoked or expired", ErrPresentationInvalid, id)
+		}
+		credential, err := held.GetCredential()
+		if err != nil {
+			return nil, err
+		}
+		presentation.VerifiableCredential = append(presentation.VerifiableCredential, *credential)
+	}
+
+	authClaim, err := p.claimSrv.GetAuthClaim(ctx, req.DID)
+	if err != nil {
+		log.Error(ctx, "getting auth claim of the holder", err, "did", req.DID)
+		return nil, err
+	}
+
+	presentation.Proof = &domain.PresentationProof{
+		Type:         verifiable.BJJSignatureProofType,
+		Created:      now.UTC(),
+		ProofPurpose: domain.PresentationProofPurpose,
+		Challenge:    req.Challenge,
+		Domain:       req.Domain,
+	}
+	if err := p.fillHolderData(ctx, req.DID, authClaim, presentation.Proof); err != nil {
+		log.Error(ctx, "proving the auth claim of the holder", err, "did", req.DID)
+		return nil, err
+	}
+
+	if err := p.sign(ctx, authClaim, presentation); err != nil {
+		log.Error(ctx, "signing presentation", err, "did", req.DID)
+		return nil, err
+	}
+
+	return presentation, nil
+}
+
+// fillHolderData adds to the proof the auth claim of the holder with its inclusion and non revocation proofs in the latest state
+func (p *presentations) fillHolderData(ctx context.Context, did *core.DID, authClaim *domain.Claim, proof *domain.PresentationProof) error {
+	return p.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		idState, err := p.identitySrv.GetLatestStateByID(ctx, did)
+		if err != nil {
+			return err
+		}
+		treeState := idState.TreeState()
+
+		identityTrees, err := p.mtService.GetIdentityMerkleTrees(ctx, tx, did)
+		if err != nil {
+			return err
+		}
+		claimsTree, err := identityTrees.ClaimsTree()
+		if err != nil {
+			return err
+		}
+
+		coreClaim := authClaim.CoreClaim.Get()
+		hIndex, err := coreClaim.HIndex()
+		if err != nil {
+			return err
+		}
+		incProof, _, err := claimsTree.GenerateProof(ctx, hIndex, treeState.ClaimsRoot)
+		if err != nil {
+			return err
+		}
+		nonRevProof, err := identityTrees.GenerateRevocationProof(ctx, new(big.Int).SetUint64(uint64(authClaim.RevNonce)), treeState.RevocationRoot)
+		if err != nil {
+			return err
+		}
+
+		authCoreClaim, err := coreClaim.Hex()
+		if err != nil {
+			return err
+		}
+		proof.HolderData = verifiable.IssuerData{
+			ID:            did.String(),
+			AuthCoreClaim: authCoreClaim,
+			MTP:           incProof,
+			State: verifiable.State{
+				Value:              common.ToPointer(treeState.State.Hex()),
+				ClaimsTreeRoot:     common.ToPointer(treeState.ClaimsRoot.Hex()),
+				RevocationTreeRoot: common.ToPointer(treeState.RevocationRoot.Hex()),
+				RootOfRoots:        common.ToPointer(treeState.RootOfRoots.Hex()),
+			},
+		}
+		proof.NonRevocationMTP = nonRevProof
+		return nil
+	})
+}
+
+// sign signs the presentation with the key of the auth claim through the key management service
+func (p *presentations) sign(ctx context.Context, authClaim *domain.Claim, presentation *domain.VerifiablePresentation) error {
+	keyID, err := p.identitySrv.GetKeyIDFromAuthClaim(ctx, authClaim)
+	if err != nil {
+		return err
+	}
+	signer, err := primitive.NewBJJSigner(p.keyProvider, keyID)
+	if err != nil {
+		return err
+	}
+
+	digest, err := presentationDigest(presentation)
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(ctx, kms.BJJDigest(digest))
+	if err != nil {
+		return err
+	}
+
+	presentation.Proof.Signature = hex.EncodeToString(signature)
+	return nil
+}
+
+// Verify checks the holder proof of the presentation against the challenge and domain of the verifier,
+// that the holder key is in a valid state of the holder, and that every credential is about the holder
+// and proved by its issuer in a state of the issuer known by the state resolvers
+func (p *presentations) Verify(ctx context.Context, req *ports.VerifyPresentationRequest) error {
+	presentation := req.Presentation
+	proof := presentation.Proof
+	if proof == nil {
+		return fmt.Errorf("%w: %v", ErrPresentationNotValid, domain.ErrPresentationNotSigned)
+	}
+	if proof.Type != verifiable.BJJSignatureProofType || proof.ProofPurpose != domain.PresentationProofPurpose {
+		return fmt.Errorf("%w: unsupported proof", ErrPresentationNotValid)
+	}
+	if proof.Challenge != req.Challenge || proof.Domain != req.Domain {
+		return fmt.Errorf("%w: the proof is for another challenge or domain", ErrPresentationNotValid)
+	}
+
+	holder, err := core.ParseDID(presentation.Holder)
+	if err != nil || proof.HolderData.ID != presentation.Holder {
+		return fmt.Errorf("%w: invalid holder", ErrPresentationNotValid)
+	}
+
+	var authClaim core.Claim
+	if err := authClaim.FromHex(proof.HolderData.AuthCoreClaim); err != nil {
+		return fmt.Errorf("%w: invalid holder auth claim", ErrPresentationNotValid)
+	}
+	if err := verifyPresentationSignature(&presentation, &authClaim); err != nil {
+		return fmt.Errorf("%w: %v", ErrPresentationNotValid, err)
+	}
+	if err := p.verifyHolderState(ctx, holder, &authClaim, proof); err != nil {
+		return fmt.Errorf("%w: %v", ErrPresentationNotValid, err)
+	}
+
+	if len(presentation.VerifiableCredential) == 0 {
+		return fmt.Errorf("%w: no credentials presented", ErrPresentationNotValid)
+	}
+	for i := range presentation.VerifiableCredential {
+		if _, err := newHolderCredential(ctx, p.stateResolvers, holder, &presentation.VerifiableCredential[i], domain.HolderCredentialSourceImport); err != nil {
+			return fmt.Errorf("%w: credential %s: %v", ErrPresentationNotValid, presentation.VerifiableCredential[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyHolderState checks that the auth claim is in the state of the holder and not revoked,
+// and that the state is the current state of the holder or was replaced a moment ago
+func (p *presentations) verifyHolderState(ctx context.Context, holder *core.DID, authClaim *core.Claim, proof *domain.PresentationProof) error {
+	if proof.HolderData.MTP == nil || proof.NonRevocationMTP == nil {
+		return errors.New("the holder data is incomplete")
+	}
+	stateHash, claimsRoot, revocationRoot, err := verifiedTreeState(proof.HolderData.State)
+	if err != nil {
+		return fmt.Errorf("holder state: %w", err)
+	}
+
+	hIndex, hValue, err := authClaim.HiHv()
+	if err != nil {
+		return err
+	}
+	if !proof.HolderData.MTP.Existence || !merkletree.VerifyProof(claimsRoot, proof.HolderData.MTP, hIndex, hValue) {
+		return errors.New("the holder auth claim is not in the holder state")
+	}
+
+	revNonce := new(big.Int).SetUint64(authClaim.GetRevocationNonce())
+	if proof.NonRevocationMTP.Existence || !merkletree.VerifyProof(revocationRoot, proof.NonRevocationMTP, revNonce, big.NewInt(0)) {
+		return errors.New("the holder auth claim is revoked")
+	}
+
+	resolver, err := pubsignals.GetStateResolver(p.stateResolvers, holder)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolver.Resolve(ctx, holder.ID.BigInt(), stateHash.BigInt())
+	if err != nil {
+		return fmt.Errorf("resolving the holder state: %w", err)
+	}
+	if !resolved.Latest && time.Since(time.Unix(resolved.TransitionTimestamp, 0)) > presentationStateTransitionDelay {
+		return errors.New("the holder state is not the current one")
+	}
+	return nil
+}
+
+// verifyPresentationSignature checks the holder signature of the presentation with the key of the auth claim
+func verifyPresentationSignature(presentation *domain.VerifiablePresentation, authClaim *core.Claim) error {
+	signature, err := hex.DecodeString(presentation.Proof.Signature)
+	if err != nil {
+		return errors.New("invalid signature encoding")
+	}
+	slots := authClaim.RawSlotsAsInts()
+	publicKey, err := babyjub.PublicKey{X: slots[2], Y: slots[3]}.MarshalText()
+	if err != nil {
+		return err
+	}
+
+	digest, err := presentationDigest(presentation)
+	if err != nil {
+		return err
+	}
+	verifier := &primitive.BJJVerifier{}
+	return verifier.Verify(publicKey, kms.BJJDigest(digest), signature)
+}
+
+// presentationDigest returns the field element the holder signs for the presentation
+func presentationDigest(presentation *domain.VerifiablePresentation) (*big.Int, error) {
+	input, err := presentation.SigningInput()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(input)
+	return poseidon.HashBytes(h[:])
+}
@@ -40,7 +40,11 @@ const (
 
 // ErrAllClaimsRevoked all claims are revoked.
 var (
-	ErrAllClaimsRevoked = errors.New("all claims are revoked")
+	ErrAllClaimsRevoked            = errors.New("all claims are revoked")
+	ErrClaimExpired                = errors.New("the claim has expired")                                 // ErrClaimExpired the claim selected by id has expired
+	ErrStateCommitmentNotPublished = errors.New("the state commitment claim is not published yet")       // ErrStateCommitmentNotPublished the identity must publish a new state before proving sybil resistance
+	ErrSybilCRSRequired            = errors.New("sybil resistance queries need the crs of the verifier") // ErrSybilCRSRequired the query of a sybil resistance proof has no crs
+	ErrChallengeRequired           = errors.New("on chain queries need the challenge of the request")    // ErrChallengeRequired the query of a proof that signs the challenge has none
 )
 
 // Proof service generates and validates ZK zk
@@ -49,7 +53,7 @@ type Proof struct {
 	revocationService ports.RevocationService
 	identityService   ports.IdentityService
 	mtService         ports.MtService
-	claimsRepository  ports.ClaimsRepository
+	holderCredentials ports.HolderCredentialRepository
 	zkService         ports.ZKGenerator
 	keyProvider       *kms.KMS
 	storage           *db.Storage
@@ -58,13 +62,13 @@ type Proof struct {
 }
 
 // NewProofService init proof service
-func NewProofService(claimService ports.ClaimsService, revocationService ports.RevocationService, identityService ports.IdentityService, mtService ports.MtService, claimsRepository ports.ClaimsRepository, zkService ports.ZKGenerator, keyProvider *kms.KMS, storage *db.Storage, stateContract *eth.State, ld loader.Factory) ports.ProofService {
+func NewProofService(claimService ports.ClaimsService, revocationService ports.RevocationService, identityService ports.IdentityService, mtService ports.MtService, holderCredentials ports.HolderCredentialRepository, zkService ports.ZKGenerator, keyProvider *kms.KMS, storage *db.Storage, stateContract *eth.State, ld loader.Factory) ports.ProofService {
 	return &Proof{
 		claimService:      claimService,
 		revocationService: revocationService,
 		identityService:   identityService,
 		mtService:         mtService,
-		claimsRepository:  claimsRepository,
+		holderCredentials: holderCredentials,
 		zkService:         zkService,
 		keyProvider:       keyProvider,
 		storage:           storage,
@@ -103,6 +107,27 @@ func (p *Proof) PrepareInputs(ctx context.Context, identifier *core.DID, query p
 			return nil, nil, err
 		}
 		claims = append(claims, claim)
+	case circuits.AtomicQuerySigV2OnChainCircuitID:
+		circuitInputs, claim, err = p.prepareAtomicQuerySigV2OnChainCircuit(ctx, identifier, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		claims = append(claims, claim)
+
+	case circuits.SybilMTPCircuitID:
+		circuitInputs, claim, err = p.prepareSybilMTPCircuit(ctx, identifier, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		claims = append(claims, claim)
+
+	case circuits.SybilSigCircuitID:
+		circuitInputs, claim, err = p.prepareSybilSigCircuit(ctx, identifier, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		claims = append(claims, claim)
+
 	case circuits.AuthV2CircuitID:
 		circuitInputs, err = p.prepareAuthV2Circuit(ctx, identifier, query.Challenge)
 		if err != nil {
@@ -124,17 +149,17 @@ func (p *Proof) PrepareInputs(ctx context.Context, identifier *core.DID, query p
 }
 
 func (p *Proof) prepareAtomicQuerySigV2Circuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
-	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	sigProof, err := claim.GetBJJSignatureProof2021()
+	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	sig, err := signer.BJJSignatureFromHexString(sigProof.Signature)
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -144,7 +169,7 @@ func (p *Proof) prepareAtomicQuerySigV2Circuit(ctx context.Context, did *core.DI
 		return nil, nil, err
 	}
 
-	issuerAuthNonRevProof, err := p.callNonRevProof(ctx, sigProof.IssuerData, issuerDID)
+	sig1, err := p.claimSignatureProof(ctx, claim, issuerDID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -154,32 +179,11 @@ func (p *Proof) prepareAtomicQuerySigV2Circuit(ctx context.Context, did *core.DI
 		return nil, nil, err
 	}
 
-	authClaim := &core.Claim{}
-	err = authClaim.FromHex(sigProof.IssuerData.AuthCoreClaim)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	sig1 := circuits.BJJSignatureProof{
-		Signature:       sig,
-		IssuerAuthClaim: authClaim,
-		IssuerAuthIncProof: circuits.MTProof{
-			Proof: sigProof.IssuerData.MTP,
-			TreeState: circuits.TreeState{
-				State:          common.StrMTHex(sigProof.IssuerData.State.Value),
-				ClaimsRoot:     common.StrMTHex(sigProof.IssuerData.State.ClaimsTreeRoot),
-				RevocationRoot: common.StrMTHex(sigProof.IssuerData.State.RevocationTreeRoot),
-				RootOfRoots:    common.StrMTHex(sigProof.IssuerData.State.RootOfRoots),
-			},
-		},
-		IssuerAuthNonRevProof: issuerAuthNonRevProof,
-	}
-
 	inputs := circuits.AtomicQuerySigV2Inputs{
-		RequestID:                big.NewInt(defaultAtomicCircuitsID),
-		ID:                       &did.ID,
-		ProfileNonce:             big.NewInt(0),
-		ClaimSubjectProfileNonce: big.NewInt(0),
+		RequestID:                atomicQueryRequestID(query),
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
 		Claim: circuits.ClaimWithSigProof{
 			IssuerID:       &issuerDID.ID,
 			Claim:          claim.CoreClaim.Get(),
@@ -195,11 +199,21 @@ func (p *Proof) prepareAtomicQuerySigV2Circuit(ctx context.Context, did *core.DI
 }
 
 func (p *Proof) prepareAtomicQueryMTPV2Circuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	claimInc, err := claim.GetCircuitIncProof()
 	if err != nil {
 		return nil, nil, err
@@ -213,10 +227,10 @@ func (p *Proof) prepareAtomicQueryMTPV2Circuit(ctx context.Context, did *core.DI
 	issuerDidLastPart := claim.Issuer[strings.LastIndex(claim.Issuer, ":")+1:]
 	issuerId, _ := core.IDFromString(issuerDidLastPart)
 	inputs := circuits.AtomicQueryMTPV2Inputs{
-		RequestID:                big.NewInt(defaultAtomicCircuitsID),
-		ID:                       &did.ID,
-		ProfileNonce:             big.NewInt(0),
-		ClaimSubjectProfileNonce: big.NewInt(0),
+		RequestID:                atomicQueryRequestID(query),
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
 		Claim: circuits.ClaimWithMTPProof{
 			IssuerID:    &issuerId,
 			Claim:       claim.CoreClaim.Get(),
@@ -232,11 +246,21 @@ func (p *Proof) prepareAtomicQueryMTPV2Circuit(ctx context.Context, did *core.DI
 }
 
 func (p *Proof) prepareAtomicQueryMTPV2OnChainCircuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// revocationNonce := claim.CoreClaim.Get().getGetRevocationNonce()
 
 	claimInc, err := claim.GetCircuitIncProof()
@@ -249,12 +273,12 @@ func (p *Proof) prepareAtomicQueryMTPV2OnChainCircuit(ctx context.Context, did *
 		return nil, nil, err
 	}
 
-	authClaim, err := p.claimService.GetAuthClaim(ctx, did)
+	authClaim, err := p.claimService.GetAuthClaim(ctx, genesis)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	authClaimData, err := p.fillAuthClaimData(ctx, did, authClaim)
+	authClaimData, err := p.fillAuthClaimData(ctx, genesis, authClaim)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -263,7 +287,7 @@ func (p *Proof) prepareAtomicQueryMTPV2OnChainCircuit(ctx context.Context, did *
 	if err != nil {
 		return nil, nil, err
 	}
-	globalTree, err := populateGlobalTree(ctx, did, p.stateContract)
+	globalTree, err := populateGlobalTree(ctx, genesis, p.stateContract)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -271,10 +295,10 @@ func (p *Proof) prepareAtomicQueryMTPV2OnChainCircuit(ctx context.Context, did *
 	issuerDidLastPart := claim.Issuer[strings.LastIndex(claim.Issuer, ":")+1:]
 	issuerId, _ := core.IDFromString(issuerDidLastPart)
 	inputs := circuits.AtomicQueryMTPV2OnChainInputs{
-		RequestID:                big.NewInt(defaultAtomicCircuitsID),
-		ID:                       &did.ID,
-		ProfileNonce:             big.NewInt(0),
-		ClaimSubjectProfileNonce: big.NewInt(0),
+		RequestID:                atomicQueryRequestID(query),
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
 		Claim: circuits.ClaimWithMTPProof{
 			IssuerID:    &issuerId,
 			Claim:       claim.CoreClaim.Get(),
@@ -297,31 +321,272 @@ func (p *Proof) prepareAtomicQueryMTPV2OnChainCircuit(ctx context.Context, did *
 	return inputs, claim, nil
 }
 
-func (p *Proof) getClaimDataForAtomicQueryCircuit(ctx context.Context, identifier *core.DID, query ports.Query) (claim *domain.Claim, revStatus *circuits.MTProof, err error) {
-	var claims []*domain.Claim
+func (p *Proof) prepareAtomicQuerySigV2OnChainCircuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if query.ClaimID != "" {
-		// if claimID exist. Search by claimID.
-		claimUUID, err := uuid.Parse(query.ClaimID)
-		if err != nil {
-			return nil, nil, err
-		}
-		var c *domain.Claim
-		//TODO:
-		did, err := core.ParseDID(query.AllowedIssuers)
-		c, err = p.claimService.GetByID(ctx, did, claimUUID)
+	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if err != nil {
-			return nil, nil, err
-		}
-		// we need to be sure that the hallmark selected by ID matches circuitQuery.
-		claims = append(claims, c)
-	} else {
-		// if claimID NOT exist in request select all claims and filter it.
-		claims, err = p.findClaimForQuery(ctx, identifier, query)
-		if err != nil {
-			return claim, nil, err
-		}
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuerDID, err := core.ParseDID(claim.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signatureProof, err := p.claimSignatureProof(ctx, claim, issuerDID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	circuitQuery, err := p.toCircuitsQuery(ctx, *claim, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authClaim, err := p.claimService.GetAuthClaim(ctx, genesis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authClaimData, err := p.fillAuthClaimData(ctx, genesis, authClaim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := p.signChallange(ctx, authClaim, query.Challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+	globalTree, err := populateGlobalTree(ctx, genesis, p.stateContract)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs := circuits.AtomicQuerySigV2OnChainInputs{
+		RequestID:                atomicQueryRequestID(query),
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
+		Claim: circuits.ClaimWithSigProof{
+			IssuerID:       &issuerDID.ID,
+			Claim:          claim.CoreClaim.Get(),
+			NonRevProof:    *claimNonRevProof,
+			SignatureProof: signatureProof,
+		},
+		Query:                    circuitQuery,
+		CurrentTimeStamp:         time.Now().Unix(),
+		SkipClaimRevocationCheck: query.SkipClaimRevocationCheck,
+		AuthClaim:                authClaim.CoreClaim.Get(),
+		GISTProof:                globalTree,
+		Challenge:                query.Challenge,
+		Signature:                signature,
+
+		AuthClaimIncMtp:    authClaimData.IncProof.Proof,
+		AuthClaimNonRevMtp: authClaimData.NonRevProof.Proof,
+		TreeState:          authClaimData.IncProof.TreeState,
+	}
+
+	return inputs, claim, nil
+}
+
+// claimSignatureProof returns the BJJ signature proof of the claim, with the proofs of the auth claim of the issuer
+func (p *Proof) claimSignatureProof(ctx context.Context, claim *domain.Claim, issuerDID *core.DID) (circuits.BJJSignatureProof, error) {
+	sigProof, err := claim.GetBJJSignatureProof2021()
+	if err != nil {
+		return circuits.BJJSignatureProof{}, err
+	}
+
+	sig, err := signer.BJJSignatureFromHexString(sigProof.Signature)
+	if err != nil {
+		return circuits.BJJSignatureProof{}, err
+	}
+
+	issuerAuthNonRevProof, err := p.callNonRevProof(ctx, sigProof.IssuerData, issuerDID)
+	if err != nil {
+		return circuits.BJJSignatureProof{}, err
+	}
+
+	authClaim := &core.Claim{}
+	err = authClaim.FromHex(sigProof.IssuerData.AuthCoreClaim)
+	if err != nil {
+		return circuits.BJJSignatureProof{}, err
+	}
+
+	return circuits.BJJSignatureProof{
+		Signature:       sig,
+		IssuerAuthClaim: authClaim,
+		IssuerAuthIncProof: circuits.MTProof{
+			Proof: sigProof.IssuerData.MTP,
+			TreeState: circuits.TreeState{
+				State:          common.StrMTHex(sigProof.IssuerData.State.Value),
+				ClaimsRoot:     common.StrMTHex(sigProof.IssuerData.State.ClaimsTreeRoot),
+				RevocationRoot: common.StrMTHex(sigProof.IssuerData.State.RevocationTreeRoot),
+				RootOfRoots:    common.StrMTHex(sigProof.IssuerData.State.RootOfRoots),
+			},
+		},
+		IssuerAuthNonRevProof: issuerAuthNonRevProof,
+	}, nil
+}
+
+func (p *Proof) prepareSybilMTPCircuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
+	if query.CRS == nil {
+		return nil, nil, ErrSybilCRSRequired
+	}
+
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claimInc, err := claim.GetCircuitIncProof()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitment, globalTree, err := p.stateCommitmentData(ctx, genesis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuerDID, err := core.ParseDID(claim.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs := circuits.SybilAtomicMTPInputs{
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
+		IssuerClaim: circuits.ClaimWithMTPProof{
+			IssuerID:    &issuerDID.ID,
+			Claim:       claim.CoreClaim.Get(),
+			NonRevProof: *claimNonRevProof,
+			IncProof:    claimInc,
+		},
+		StateCommitmentClaim: commitment,
+		GISTProof:            globalTree,
+		CRS:                  query.CRS,
+		RequestID:            atomicQueryRequestID(query),
+		Timestamp:            time.Now().Unix(),
+	}
+
+	return inputs, claim, nil
+}
+
+func (p *Proof) prepareSybilSigCircuit(ctx context.Context, did *core.DID, query ports.Query) (circuits.InputsMarshaller, *domain.Claim, error) {
+	if query.CRS == nil {
+		return nil, nil, ErrSybilCRSRequired
+	}
+
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claim, claimNonRevProof, err := p.getClaimDataForAtomicQueryCircuit(ctx, did, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subjectProfileNonce, err := p.claimSubjectProfileNonce(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuerDID, err := core.ParseDID(claim.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signatureProof, err := p.claimSignatureProof(ctx, claim, issuerDID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitment, globalTree, err := p.stateCommitmentData(ctx, genesis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs := circuits.SybilAtomicSigInputs{
+		ID:                       &genesis.ID,
+		ProfileNonce:             profileNonce,
+		ClaimSubjectProfileNonce: subjectProfileNonce,
+		IssuerClaim: circuits.ClaimWithSigProof{
+			IssuerID:       &issuerDID.ID,
+			Claim:          claim.CoreClaim.Get(),
+			NonRevProof:    *claimNonRevProof,
+			SignatureProof: signatureProof,
+		},
+		StateCommitmentClaim: commitment,
+		GISTProof:            globalTree,
+		CRS:                  query.CRS,
+		RequestID:            atomicQueryRequestID(query),
+		Timestamp:            time.Now().Unix(),
+	}
+
+	return inputs, claim, nil
+}
+
+// stateCommitmentData returns the state commitment claim of the identity with its proof in the latest state
+// of the identity, and the proof of the identity in the global identities tree
+func (p *Proof) stateCommitmentData(ctx context.Context, genesis *core.DID) (circuits.ClaimWithMTPProof, circuits.GISTProof, error) {
+	commitment, err := p.claimService.GetStateCommitmentClaim(ctx, genesis)
+	if err != nil {
+		return circuits.ClaimWithMTPProof{}, circuits.GISTProof{}, err
+	}
+	if commitment.IdentityState == nil {
+		return circuits.ClaimWithMTPProof{}, circuits.GISTProof{}, ErrStateCommitmentNotPublished
+	}
+
+	commitmentData, err := p.fillAuthClaimData(ctx, genesis, commitment)
+	if err != nil {
+		return circuits.ClaimWithMTPProof{}, circuits.GISTProof{}, err
+	}
+
+	globalTree, err := populateGlobalTree(ctx, genesis, p.stateContract)
+	if err != nil {
+		return circuits.ClaimWithMTPProof{}, circuits.GISTProof{}, err
+	}
+
+	return commitmentData, globalTree, nil
+}
+
+// atomicQueryRequestID returns the request id of the query or the default one if the query has not got any
+func atomicQueryRequestID(query ports.Query) *big.Int {
+	if query.RequestID == 0 {
+		return big.NewInt(defaultAtomicCircuitsID)
+	}
+	return new(big.Int).SetUint64(uint64(query.RequestID))
+}
+
+func (p *Proof) getClaimDataForAtomicQueryCircuit(ctx context.Context, identifier *core.DID, query ports.Query) (claim *domain.Claim, revStatus *circuits.MTProof, err error) {
+	claims, err := p.findClaimsForAtomicQuery(ctx, identifier, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(claims) == 0 {
+		return nil, nil, ErrClaimNotFound
 	}
 
 	var claimRs circuits.MTProof
@@ -344,23 +609,152 @@ func (p *Proof) getClaimDataForAtomicQueryCircuit(ctx context.Context, identifie
 	return claim, &claimRs, nil
 }
 
+func (p *Proof) findClaimsForAtomicQuery(ctx context.Context, identifier *core.DID, query ports.Query) ([]*domain.Claim, error) {
+	if query.ClaimID == "" {
+		// if claimID NOT exist in request select all claims and filter it.
+		return p.findClaimForQuery(ctx, identifier, query)
+	}
+
+	// if claimID exist. Search by claimID.
+	claimUUID, err := uuid.Parse(query.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+	dids, err := p.holderDIDs(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	var held *domain.HolderCredential
+	for _, did := range dids {
+		held, err = p.holderCredentials.GetByID(ctx, p.storage.Pgx, did, claimUUID)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, repositories.ErrHolderCredentialDoesNotExist) {
+			return nil, err
+		}
+	}
+	if held == nil {
+		return nil, ErrClaimNotFound
+	}
+	if !query.IsAllowedIssuer(held.Issuer) {
+		return nil, fmt.Errorf("the claim %s was not issued by any of %v", query.ClaimID, query.AllowedIssuers)
+	}
+	if held.IsExpired(time.Now()) {
+		return nil, ErrClaimExpired
+	}
+	c, err := held.Claim()
+	if err != nil {
+		return nil, err
+	}
+	// we need to be sure that the hallmark selected by ID matches circuitQuery.
+	return []*domain.Claim{c}, nil
+}
+
+// SelectCircuit returns the circuit the query must be proved with. When the query does not set any,
+// the MTP circuit is chosen if a matching claim has been published and the Sig circuit if it only has a signature proof.
+func (p *Proof) SelectCircuit(ctx context.Context, identifier *core.DID, query ports.Query) (string, error) {
+	if query.CircuitID != "" {
+		return query.CircuitID, nil
+	}
+
+	claims, err := p.findClaimsForAtomicQuery(ctx, identifier, query)
+	if err != nil {
+		return "", err
+	}
+
+	hasSignature := false
+	now := time.Now()
+	for _, claim := range claims {
+		if claim.Revoked || claim.IsExpired(now) {
+			continue
+		}
+		if claim.HasMTPProof() {
+			return string(circuits.AtomicQueryMTPV2CircuitID), nil
+		}
+		hasSignature = hasSignature || claim.HasSignatureProof()
+	}
+
+	if hasSignature {
+		return string(circuits.AtomicQuerySigV2CircuitID), nil
+	}
+	return "", fmt.Errorf("claim with credential type %s has neither signature nor merkle tree proofs", query.SchemaType())
+}
+
 func (p *Proof) findClaimForQuery(ctx context.Context, identifier *core.DID, query ports.Query) ([]*domain.Claim, error) {
 	field := ""
 	var err error
 
 	// TODO "query_value":    value,
 	// TODO "query_operator": operator,
-	filter := &ports.Filter{QueryField: field, SchemaType: query.SchemaType()}
+	filter := &ports.HolderCredentialFilter{QueryField: field, SchemaType: query.SchemaType()}
 	if !query.SkipClaimRevocationCheck {
 		filter.Revoked = common.ToPointer(false)
 	}
 
-	claim, err := p.claimsRepository.GetAllByIssuerID(ctx, p.storage.Pgx, identifier, filter)
-	if errors.Is(err, repositories.ErrClaimDoesNotExist) {
-		return nil, fmt.Errorf("claim with credential type %v was not found", query)
+	dids, err := p.holderDIDs(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	held := make([]*domain.HolderCredential, 0)
+	for _, did := range dids {
+		credentials, err := p.holderCredentials.GetAll(ctx, p.storage.Pgx, did, filter)
+		if err != nil {
+			return nil, err
+		}
+		held = append(held, credentials...)
 	}
 
-	return claim, err
+	now := time.Now()
+	claims := make([]*domain.Claim, 0, len(held))
+	for _, credential := range held {
+		if credential.IsExpired(now) || !query.IsAllowedIssuer(credential.Issuer) {
+			continue
+		}
+		claim, err := credential.Claim()
+		if err != nil {
+			log.Warn(ctx, "could not read the held credential", "err", err, "id", credential.ID)
+			continue
+		}
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// holderDIDs returns the identity of the did and all its profiles, the identity can prove the credentials held by any of them
+func (p *Proof) holderDIDs(ctx context.Context, did *core.DID) ([]*core.DID, error) {
+	genesis, _, err := p.identityService.ResolveProfile(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := p.identityService.GetProfiles(ctx, genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	dids := []*core.DID{genesis}
+	for _, profile := range profiles {
+		profileDID, err := core.ParseDID(profile.ProfileDID)
+		if err != nil {
+			return nil, err
+		}
+		dids = append(dids, profileDID)
+	}
+	return dids, nil
+}
+
+// claimSubjectProfileNonce returns the nonce of the profile the claim was issued to, zero if it was issued to the identity
+func (p *Proof) claimSubjectProfileNonce(ctx context.Context, claim *domain.Claim) (*big.Int, error) {
+	if claim.Identifier == nil {
+		return big.NewInt(0), nil
+	}
+	holder, err := core.ParseDID(*claim.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	_, nonce, err := p.identityService.ResolveProfile(ctx, holder)
+	return nonce, err
 }
 
 func (p *Proof) checkRevocationStatus(ctx context.Context, claim *domain.Claim) (*verifiable.RevocationStatus, error) {
@@ -414,7 +808,7 @@ func (p *Proof) checkRevocationStatus(ctx context.Context, claim *domain.Claim)
 		// update revocation status
 		err = p.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
 			claim.Revoked = true
-			_, err = p.claimsRepository.Save(ctx, p.storage.Pgx, claim)
+			err = p.holderCredentials.UpdateRevoked(ctx, tx, &domain.HolderCredential{ID: claim.ID, Revoked: true})
 			if err != nil {
 				return fmt.Errorf("can't save claim %v", err)
 			}
@@ -431,7 +825,11 @@ func (p *Proof) checkRevocationStatus(ctx context.Context, claim *domain.Claim)
 }
 
 func (p *Proof) findNonRevokedClaim(ctx context.Context, claims []*domain.Claim) (*domain.Claim, circuits.MTProof, error) {
+	now := time.Now()
 	for _, claim := range claims {
+		if claim.IsExpired(now) {
+			continue
+		}
 		rsClaim, err := p.checkRevocationStatus(ctx, claim)
 		if err != nil {
 			return nil, circuits.MTProof{}, err
@@ -464,7 +862,7 @@ func (p *Proof) toCircuitsQuery(ctx context.Context, claim domain.Claim, query p
 		if err != nil {
 			return circuits.Query{}, err
 		}
-		return p.prepareNonMerklizedQuery(ctx, credential.CredentialSchema.ID, query)
+		return p.prepareNonMerklizedQuery(ctx, credential.CredentialSchema.ID, coreClaim, query)
 	}
 
 	return p.prepareMerklizedQuery(ctx, claim, query)
@@ -526,10 +924,14 @@ func (p *Proof) prepareMerklizedQuery(ctx context.Context, claim domain.Claim, q
 		MTP:   jsonP,
 	}
 
+	if isSelectiveDisclosure(query.Req) {
+		circuitQuery.Values = []*big.Int{value}
+	}
+
 	return circuitQuery, nil
 }
 
-func (p *Proof) prepareNonMerklizedQuery(ctx context.Context, jsonSchemaURL string, query ports.Query) (circuits.Query, error) {
+func (p *Proof) prepareNonMerklizedQuery(ctx context.Context, jsonSchemaURL string, coreClaim *core.Claim, query ports.Query) (circuits.Query, error) {
 	parser := jsonSuite.Parser{}
 	pr := processor.InitProcessorOptions(&processor.Processor{},
 		processor.WithParser(parser),
@@ -554,6 +956,14 @@ func (p *Proof) prepareNonMerklizedQuery(ctx context.Context, jsonSchemaURL stri
 		return circuits.Query{}, err
 	}
 
+	if isSelectiveDisclosure(query.Req) {
+		slots := coreClaim.RawSlotsAsInts()
+		if circuitQuery.SlotIndex < 0 || circuitQuery.SlotIndex >= len(slots) {
+			return circuits.Query{}, fmt.Errorf("invalid slot index %d for field %s", circuitQuery.SlotIndex, field)
+		}
+		circuitQuery.Values = []*big.Int{slots[circuitQuery.SlotIndex]}
+	}
+
 	return circuitQuery, nil
 }
 
@@ -597,12 +1007,17 @@ func (p *Proof) callNonRevProof(ctx context.Context, issuerData verifiable.Issue
 }
 
 func (p *Proof) prepareAuthV2Circuit(ctx context.Context, identifier *core.DID, challenge *big.Int) (circuits.AuthV2Inputs, error) {
-	authClaim, err := p.claimService.GetAuthClaim(ctx, identifier)
+	genesis, profileNonce, err := p.identityService.ResolveProfile(ctx, identifier)
+	if err != nil {
+		return circuits.AuthV2Inputs{}, err
+	}
+
+	authClaim, err := p.claimService.GetAuthClaim(ctx, genesis)
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
 
-	authClaimData, err := p.fillAuthClaimData(ctx, identifier, authClaim)
+	authClaimData, err := p.fillAuthClaimData(ctx, genesis, authClaim)
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
@@ -610,15 +1025,19 @@ func (p *Proof) prepareAuthV2Circuit(ctx context.Context, identifier *core.DID,
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
-	globalTree, err := populateGlobalTree(ctx, identifier, p.stateContract)
+	globalTree, err := populateGlobalTree(ctx, genesis, p.stateContract)
 	if err != nil {
 		return circuits.AuthV2Inputs{}, err
 	}
-	circuitInputs := prepareAuthV2CircuitInputs(identifier, authClaimData, challenge, signature, globalTree)
+	circuitInputs := prepareAuthV2CircuitInputs(genesis, profileNonce, authClaimData, challenge, signature, globalTree)
 	return circuitInputs, nil
 }
 
 func (p *Proof) signChallange(ctx context.Context, authClaim *domain.Claim, challenge *big.Int) (*babyjub.Signature, error) {
+	if challenge == nil {
+		return nil, ErrChallengeRequired
+	}
+
 	signingKeyID, err := p.identityService.GetKeyIDFromAuthClaim(ctx, authClaim)
 	if err != nil {
 		return nil, err
@@ -694,10 +1113,10 @@ func (p *Proof) fillAuthClaimData(ctx context.Context, identifier *core.DID, aut
 	return authClaimData, nil
 }
 
-func prepareAuthV2CircuitInputs(did *core.DID, authClaim circuits.ClaimWithMTPProof, challenge *big.Int, signature *babyjub.Signature, globalMTP circuits.GISTProof) circuits.AuthV2Inputs {
+func prepareAuthV2CircuitInputs(did *core.DID, profileNonce *big.Int, authClaim circuits.ClaimWithMTPProof, challenge *big.Int, signature *babyjub.Signature, globalMTP circuits.GISTProof) circuits.AuthV2Inputs {
 	return circuits.AuthV2Inputs{
 		GenesisID:          &did.ID,
-		ProfileNonce:       big.NewInt(0),
+		ProfileNonce:       profileNonce,
 		AuthClaim:          authClaim.Claim,
 		AuthClaimIncMtp:    authClaim.IncProof.Proof,
 		AuthClaimNonRevMtp: authClaim.NonRevProof.Proof,
@@ -798,6 +1217,14 @@ func parseQueryWithoutSlot(req map[string]interface{}) (circuits.Query, string,
 			return circuits.Query{}, "", errors.New("multiple predicates are currently not supported")
 		}
 
+		// a field without predicate asks to disclose its value, proven with $eq
+		if len(condition) == 0 {
+			return circuits.Query{
+				Operator: circuits.EQ,
+				Values:   []*big.Int{},
+			}, field, nil
+		}
+
 		for op, v := range condition {
 
 			intOp, ok := circuits.QueryOperators[op]
@@ -823,6 +1250,57 @@ func parseQueryWithoutSlot(req map[string]interface{}) (circuits.Query, string,
 	}, "", nil
 }
 
+// isSelectiveDisclosure returns true if the query asks for a single field without predicate
+func isSelectiveDisclosure(req map[string]interface{}) bool {
+	if len(req) != 1 {
+		return false
+	}
+	for _, body := range req {
+		condition, ok := body.(map[string]interface{})
+		return ok && len(condition) == 0
+	}
+	return false
+}
+
+// disclosedPresentation builds the JSON-LD verifiable presentation holding the value of
+// the field the query asks to disclose, taken from the claim credential subject.
+func disclosedPresentation(claim *domain.Claim, query ports.Query) (json.RawMessage, error) {
+	vc, err := claim.GetVerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	var field string
+	for f := range query.Req {
+		field = f
+	}
+
+	path := strings.Split(field, ".")
+	var value interface{} = vc.CredentialSubject
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in the credential", field)
+		}
+		if value, ok = object[key]; !ok {
+			return nil, fmt.Errorf("field %s not found in the credential", field)
+		}
+	}
+
+	for i := len(path) - 1; i > 0; i-- {
+		value = map[string]interface{}{path[i]: value}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"@context": []string{verifiable.JSONLDSchemaW3CCredential2018, query.Context},
+		"@type":    "VerifiablePresentation",
+		"verifiableCredential": map[string]interface{}{
+			"@type": query.Type,
+			path[0]: value,
+		},
+	})
+}
+
 func (p *Proof) GenerateAuthProof(ctx context.Context, identifier *core.DID, challenge *big.Int) (*domain.FullProof, error) {
 
 	circuitInputs, err := p.prepareAuthV2Circuit(ctx, identifier, challenge)
@@ -845,21 +1323,71 @@ func (p *Proof) GenerateAuthProof(ctx context.Context, identifier *core.DID, cha
 }
 
 func (p *Proof) GenerateAgeProof(ctx context.Context, identifier *core.DID, query ports.Query) (*domain.FullProof, error) {
+	circuitID, err := p.SelectCircuit(ctx, identifier, query)
+	if err != nil {
+		return nil, err
+	}
+	query.CircuitID = circuitID
 
 	circuitInputs, claim, err := p.PrepareInputs(ctx, identifier, query)
+	if err != nil {
+		return nil, err
+	}
 
 	if claim == nil {
-		return nil, err
+		return nil, errors.New("no claim matches the query")
 	}
 
+	fullProof, err := p.zkService.Generate(ctx, circuitInputs, query.CircuitID)
 	if err != nil {
 		return nil, err
 	}
-	// TODO OnChain
-	fullProof, err := p.zkService.Generate(ctx, circuitInputs, string(circuits.AtomicQueryMTPV2CircuitID))
-	if err != nil {
-		return nil, err
+
+	if isSelectiveDisclosure(query.Req) {
+		fullProof.VerifiablePresentation, err = disclosedPresentation(claim[0], query)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return fullProof, nil
 }
+
+// GenerateQueryProofs generates a proof for each field predicate of the query. When the query has several,
+// all of them are proved with the same circuit and credential, the first one the query selects, although the
+// proofs do not tell a verifier which credential they were generated with. It stops before the next proof once
+// the context is done.
+func (p *Proof) GenerateQueryProofs(ctx context.Context, identifier *core.DID, query ports.Query) ([]*domain.FullProof, error) {
+	predicates := query.Predicates()
+	if len(predicates) > 1 {
+		circuitID, err := p.SelectCircuit(ctx, identifier, query)
+		if err != nil {
+			return nil, err
+		}
+		claimID := query.ClaimID
+		if claimID == "" {
+			claim, _, err := p.getClaimDataForAtomicQueryCircuit(ctx, identifier, query)
+			if err != nil {
+				return nil, err
+			}
+			claimID = claim.ID.String()
+		}
+		for i := range predicates {
+			predicates[i].CircuitID = circuitID
+			predicates[i].ClaimID = claimID
+		}
+	}
+
+	fullProofs := make([]*domain.FullProof, 0, len(predicates))
+	for _, predicate := range predicates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fullProof, err := p.GenerateAgeProof(ctx, identifier, predicate)
+		if err != nil {
+			return nil, err
+		}
+		fullProofs = append(fullProofs, fullProof)
+	}
+	return fullProofs, nil
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgtype"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+var (
+	ErrProofJobNotFound = errors.New("proof job not found")        // ErrProofJobNotFound Cannot retrieve the given proof job
+	ErrProofJobFinished = errors.New("proof job already finished") // ErrProofJobFinished The job is done, failed or cancelled
+)
+
+const (
+	defaultProofJobWorkers      = 1
+	defaultProofJobPollInterval = 2 * time.Second
+	defaultProofJobResultTTL    = 24 * time.Hour
+	defaultProofJobLeaseTimeout = 10 * time.Minute
+	proofJobSweepInterval       = time.Minute
+)
+
+// ProofJobCfg configures the workers of the proof jobs. Zero values take the defaults.
+type ProofJobCfg struct {
+	Workers      int
+	PollInterval time.Duration
+	ResultTTL    time.Duration
+	// LeaseTimeout is the time after which a running job is considered abandoned, by a worker that stopped
+	// without releasing it, and is taken by another worker
+	LeaseTimeout time.Duration
+}
+
+type proofJobs struct {
+	repo         ports.ProofJobRepository
+	proofService ports.ProofService
+	storage      *db.Storage
+	cfg          ProofJobCfg
+	// running holds the cancel functions of the jobs the workers of this process are running, by job id
+	running sync.Map
+}
+
+// NewProofJobs creates a new service that generates the proofs of authorization requests in the background.
+// The jobs are stored, so any instance of the service can run them, and generated by a bounded number of workers.
+func NewProofJobs(repo ports.ProofJobRepository, proofService ports.ProofService, storage *db.Storage, cfg ProofJobCfg) ports.ProofJobService {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultProofJobWorkers
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultProofJobPollInterval
+	}
+	if cfg.ResultTTL <= 0 {
+		cfg.ResultTTL = defaultProofJobResultTTL
+	}
+	if cfg.LeaseTimeout <= 0 {
+		cfg.LeaseTimeout = defaultProofJobLeaseTimeout
+	}
+	return &proofJobs{
+		repo:         repo,
+		proofService: proofService,
+		storage:      storage,
+		cfg:          cfg,
+	}
+}
+
+// Create queues a job that generates the proofs of the given queries, one for each scope of an authorization request
+func (p *proofJobs) Create(ctx context.Context, identifier *core.DID, queries []ports.Query) (*domain.ProofJob, error) {
+	job := &domain.ProofJob{
+		ID:         uuid.New(),
+		Identifier: identifier.String(),
+		Status:     domain.ProofJobPending,
+		Result:     pgtype.JSONB{Status: pgtype.Null},
+		CreatedAt:  time.Now(),
+	}
+	if err := job.Queries.Set(queries); err != nil {
+		return nil, fmt.Errorf("failed to set proof job queries: %w", err)
+	}
+
+	if err := p.repo.Save(ctx, p.storage.Pgx, job); err != nil {
+		log.Error(ctx, "saving proof job", err, "identifier", identifier)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetByID returns the job of the identity with the given id
+func (p *proofJobs) GetByID(ctx context.Context, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error) {
+	job, err := p.repo.GetByID(ctx, p.storage.Pgx, identifier, id)
+	if errors.Is(err, repositories.ErrProofJobDoesNotExist) {
+		return nil, ErrProofJobNotFound
+	}
+	return job, err
+}
+
+// Cancel cancels a pending or running job. The proofs of a running job are discarded when they are generated.
+// If a worker of this process is running it, the job stops before its next proof, the one being generated is
+// not interrupted.
+func (p *proofJobs) Cancel(ctx context.Context, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error) {
+	job, err := p.repo.Cancel(ctx, p.storage.Pgx, identifier, id, time.Now().Add(p.cfg.ResultTTL))
+	if errors.Is(err, repositories.ErrProofJobDoesNotExist) {
+		if _, err := p.GetByID(ctx, identifier, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrProofJobFinished
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel, ok := p.running.Load(id); ok {
+		cancel.(context.CancelFunc)()
+	}
+	return job, nil
+}
+
+// Run runs the workers of the jobs and deletes the expired ones until the context is done
+func (p *proofJobs) Run(ctx context.Context) {
+	log.Info(ctx, "proof job workers started", "workers", p.cfg.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(proofJobSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := p.repo.DeleteExpired(ctx, p.storage.Pgx, time.Now())
+			if err != nil {
+				log.Error(ctx, "deleting expired proof jobs", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Debug(ctx, "expired proof jobs deleted", "count", deleted)
+			}
+		case <-ctx.Done():
+			wg.Wait()
+			log.Info(ctx, "proof job workers stopped")
+			return
+		}
+	}
+}
+
+// work runs the pending jobs one after the other, waiting the poll interval when there are none
+func (p *proofJobs) work(ctx context.Context) {
+	for {
+		job, err := p.repo.Next(ctx, p.storage.Pgx, time.Now().Add(-p.cfg.LeaseTimeout))
+		if err == nil {
+			p.process(ctx, job)
+			continue
+		}
+		if !errors.Is(err, repositories.ErrProofJobDoesNotExist) && ctx.Err() == nil {
+			log.Error(ctx, "getting the next proof job", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.cfg.PollInterval):
+		}
+	}
+}
+
+// process generates the proofs of the job and records the outcome
+func (p *proofJobs) process(ctx context.Context, job *domain.ProofJob) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	p.running.Store(job.ID, cancel)
+	defer func() {
+		p.running.Delete(job.ID)
+		cancel()
+	}()
+
+	scopes, err := p.generate(jobCtx, job)
+	if ctx.Err() != nil {
+		// the workers are stopping, leave the job for the next run
+		if err := p.repo.Release(context.Background(), p.storage.Pgx, job); err != nil {
+			log.Error(ctx, "releasing proof job", err, "id", job.ID)
+		}
+		return
+	}
+	if jobCtx.Err() != nil {
+		log.Info(ctx, "proof job cancelled", "id", job.ID)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(p.cfg.ResultTTL)
+	job.FinishedAt = &now
+	job.ExpiresAt = &expiresAt
+	job.Status = domain.ProofJobDone
+	job.Result = pgtype.JSONB{Status: pgtype.Null}
+	if err == nil {
+		err = job.SetResult(scopes)
+	}
+	if err != nil {
+		log.Warn(ctx, "proof job failed", "id", job.ID, "err", err)
+		message := err.Error()
+		job.Status = domain.ProofJobFailed
+		job.Error = &message
+		job.Result = pgtype.JSONB{Status: pgtype.Null}
+	}
+
+	err = p.repo.Finish(ctx, p.storage.Pgx, job)
+	if errors.Is(err, repositories.ErrProofJobDoesNotExist) {
+		log.Info(ctx, "proof job cancelled, proofs discarded", "id", job.ID)
+		return
+	}
+	if err != nil {
+		log.Error(ctx, "recording proof job", err, "id", job.ID)
+	}
+}
+
+// generate generates the proofs of every query of the job, one for each predicate of the queries with several of them
+func (p *proofJobs) generate(ctx context.Context, job *domain.ProofJob) ([]domain.ProofJobScope, error) {
+	identifier, err := core.ParseDID(job.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []ports.Query
+	if err := json.Unmarshal(job.Queries.Bytes, &queries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal proof job queries: %w", err)
+	}
+
+	scopes := make([]domain.ProofJobScope, 0, len(queries))
+	for _, query := range queries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		proofs, err := p.proofService.GenerateQueryProofs(ctx, identifier, query)
+		if err != nil {
+			return nil, fmt.Errorf("scope %d: %w", query.RequestID, err)
+		}
+		for _, proof := range proofs {
+			scopes = append(scopes, domain.ProofJobScope{ID: query.RequestID, CircuitID: query.CircuitID, Proof: proof})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+type proofJobRepositoryMock struct {
+	ports.ProofJobRepository
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*domain.ProofJob
+}
+
+func (r *proofJobRepositoryMock) Save(_ context.Context, _ db.Querier, job *domain.ProofJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *job
+	r.jobs[job.ID] = &stored
+	return nil
+}
+
+func (r *proofJobRepositoryMock) GetByID(_ context.Context, _ db.Querier, _ *core.DID, id uuid.UUID) (*domain.ProofJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, repositories.ErrProofJobDoesNotExist
+	}
+	stored := *job
+	return &stored, nil
+}
+
+func (r *proofJobRepositoryMock) Next(_ context.Context, _ db.Querier, _ time.Time) (*domain.ProofJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.Status == domain.ProofJobPending {
+			now := time.Now()
+			job.Status = domain.ProofJobRunning
+			job.StartedAt = &now
+			running := *job
+			return &running, nil
+		}
+	}
+	return nil, repositories.ErrProofJobDoesNotExist
+}
+
+func (r *proofJobRepositoryMock) Finish(_ context.Context, _ db.Querier, job *domain.ProofJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.jobs[job.ID].Status != domain.ProofJobRunning {
+		return repositories.ErrProofJobDoesNotExist
+	}
+	stored := *job
+	r.jobs[job.ID] = &stored
+	return nil
+}
+
+func (r *proofJobRepositoryMock) Release(_ context.Context, _ db.Querier, job *domain.ProofJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored := r.jobs[job.ID]; stored.Status == domain.ProofJobRunning {
+		stored.Status = domain.ProofJobPending
+		stored.StartedAt = nil
+	}
+	return nil
+}
+
+func (r *proofJobRepositoryMock) Cancel(_ context.Context, _ db.Querier, _ *core.DID, id uuid.UUID, expiresAt time.Time) (*domain.ProofJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || job.IsFinished() {
+		return nil, repositories.ErrProofJobDoesNotExist
+	}
+	job.Status = domain.ProofJobCancelled
+	job.ExpiresAt = &expiresAt
+	cancelled := *job
+	return &cancelled, nil
+}
+
+// proofServiceMock generates a proof for each query, waiting for the release channel when there is one,
+// as a proof that takes a while and is not interrupted
+type proofServiceMock struct {
+	ports.ProofService
+	started   chan struct{}
+	release   chan struct{}
+	err       error
+	mu        sync.Mutex
+	generated int
+}
+
+func (p *proofServiceMock) GenerateQueryProofs(_ context.Context, _ *core.DID, query ports.Query) ([]*domain.FullProof, error) {
+	if p.started != nil {
+		p.started <- struct{}{}
+	}
+	if p.release != nil {
+		<-p.release
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.generated++
+	return []*domain.FullProof{{PubSignals: []string{query.CircuitID}}}, nil
+}
+
+func TestProofJobs_Process(t *testing.T) {
+	ctx := context.Background()
+	identifier, err := core.ParseDID(kycIssuer)
+	require.NoError(t, err)
+	queries := []ports.Query{
+		{RequestID: 1, CircuitID: "credentialAtomicQueryMTPV2"},
+		{RequestID: 2, CircuitID: "credentialAtomicQuerySigV2"},
+	}
+
+	// start creates a job with the queries and has a worker of the service take it
+	start := func(t *testing.T, proofService *proofServiceMock) (*proofJobs, *proofJobRepositoryMock, *domain.ProofJob) {
+		repo := &proofJobRepositoryMock{jobs: map[uuid.UUID]*domain.ProofJob{}}
+		service, ok := NewProofJobs(repo, proofService, &db.Storage{}, ProofJobCfg{}).(*proofJobs)
+		require.True(t, ok)
+		_, err := service.Create(ctx, identifier, queries)
+		require.NoError(t, err)
+		job, err := repo.Next(ctx, nil, time.Now())
+		require.NoError(t, err)
+		return service, repo, job
+	}
+	stored := func(t *testing.T, service *proofJobs, id uuid.UUID) *domain.ProofJob {
+		job, err := service.GetByID(ctx, identifier, id)
+		require.NoError(t, err)
+		return job
+	}
+
+	t.Run("should record the proofs of every query", func(t *testing.T) {
+		service, _, job := start(t, &proofServiceMock{})
+		service.process(ctx, job)
+
+		done := stored(t, service, job.ID)
+		assert.Equal(t, domain.ProofJobDone, done.Status)
+		require.NotNil(t, done.ExpiresAt)
+		result, err := done.GetResult()
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, uint32(1), result[0].ID)
+		assert.Equal(t, []string{"credentialAtomicQueryMTPV2"}, result[0].Proof.PubSignals)
+		assert.Equal(t, uint32(2), result[1].ID)
+	})
+
+	t.Run("should record the error of a query that cannot be proved", func(t *testing.T) {
+		service, _, job := start(t, &proofServiceMock{err: errors.New("no claim matches the query")})
+		service.process(ctx, job)
+
+		failed := stored(t, service, job.ID)
+		assert.Equal(t, domain.ProofJobFailed, failed.Status)
+		require.NotNil(t, failed.Error)
+		assert.Equal(t, "scope 1: no claim matches the query", *failed.Error)
+	})
+
+	t.Run("should stop a cancelled job before its next proof", func(t *testing.T) {
+		proofService := &proofServiceMock{started: make(chan struct{}), release: make(chan struct{})}
+		service, _, job := start(t, proofService)
+		processed := make(chan struct{})
+		go func() {
+			service.process(ctx, job)
+			close(processed)
+		}()
+
+		<-proofService.started
+		cancelled, err := service.Cancel(ctx, identifier, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProofJobCancelled, cancelled.Status)
+		close(proofService.release)
+		<-processed
+
+		assert.Equal(t, 1, proofService.generated)
+		assert.Equal(t, domain.ProofJobCancelled, stored(t, service, job.ID).Status)
+
+		_, err = service.Cancel(ctx, identifier, job.ID)
+		assert.ErrorIs(t, err, ErrProofJobFinished)
+	})
+
+	t.Run("should release the job of a worker that stops", func(t *testing.T) {
+		proofService := &proofServiceMock{started: make(chan struct{}), release: make(chan struct{})}
+		service, repo, job := start(t, proofService)
+		workerCtx, stop := context.WithCancel(ctx)
+		processed := make(chan struct{})
+		go func() {
+			service.process(workerCtx, job)
+			close(processed)
+		}()
+
+		<-proofService.started
+		stop()
+		close(proofService.release)
+		<-processed
+
+		released := stored(t, service, job.ID)
+		assert.Equal(t, domain.ProofJobPending, released.Status)
+		assert.Nil(t, released.StartedAt)
+
+		again, err := repo.Next(ctx, nil, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, again.ID)
+	})
+
+	t.Run("should not cancel an unknown job", func(t *testing.T) {
+		service, _, _ := start(t, &proofServiceMock{})
+		_, err := service.Cancel(ctx, identifier, uuid.New())
+		assert.ErrorIs(t, err, ErrProofJobNotFound)
+	})
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/lastingasset/wallet-service/go-circuits"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+var (
+	ErrProofRequestTemplateNotFound  = errors.New("proof request template not found")                       // ErrProofRequestTemplateNotFound Cannot retrieve the given template
+	ErrProofRequestTemplateDuplicate = errors.New("a proof request template with that name already exists") // ErrProofRequestTemplateDuplicate The template name is taken
+	ErrProofRequestTemplateInvalid   = errors.New("invalid proof request template")                         // ErrProofRequestTemplateInvalid The template cannot be used to build a proof request
+)
+
+// templateCircuits are the circuits a proof request template can ask a proof of
+var templateCircuits = map[circuits.CircuitID]bool{
+	circuits.AtomicQueryMTPV2CircuitID:        true,
+	circuits.AtomicQueryMTPV2OnChainCircuitID: true,
+	circuits.AtomicQuerySigV2CircuitID:        true,
+	circuits.AtomicQuerySigV2OnChainCircuitID: true,
+}
+
+type proofRequestTemplates struct {
+	repo    ports.ProofRequestTemplateRepository
+	storage *db.Storage
+}
+
+// NewProofRequestTemplates creates a new proof request template service
+func NewProofRequestTemplates(repo ports.ProofRequestTemplateRepository, storage *db.Storage) ports.ProofRequestTemplateService {
+	return &proofRequestTemplates{
+		repo:    repo,
+		storage: storage,
+	}
+}
+
+// Create validates and stores a new template
+func (p *proofRequestTemplates) Create(ctx context.Context, req *ports.ProofRequestTemplateRequest) (*domain.ProofRequestTemplate, error) {
+	template, err := newProofRequestTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := p.repo.Save(ctx, p.storage.Pgx, template)
+	if err != nil {
+		return nil, p.repositoryError(ctx, err)
+	}
+
+	return p.GetByID(ctx, id)
+}
+
+// Update replaces all the values of an existing template
+func (p *proofRequestTemplates) Update(ctx context.Context, id uuid.UUID, req *ports.ProofRequestTemplateRequest) (*domain.ProofRequestTemplate, error) {
+	if _, err := p.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	template, err := newProofRequestTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+
+	if _, err := p.repo.Save(ctx, p.storage.Pgx, template); err != nil {
+		return nil, p.repositoryError(ctx, err)
+	}
+
+	return p.GetByID(ctx, id)
+}
+
+func (p *proofRequestTemplates) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProofRequestTemplate, error) {
+	template, err := p.repo.GetByID(ctx, p.storage.Pgx, id)
+	if err != nil {
+		return nil, p.repositoryError(ctx, err)
+	}
+	return template, nil
+}
+
+func (p *proofRequestTemplates) GetAll(ctx context.Context) ([]domain.ProofRequestTemplate, error) {
+	return p.repo.GetAll(ctx, p.storage.Pgx)
+}
+
+func (p *proofRequestTemplates) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := p.repo.Delete(ctx, p.storage.Pgx, id); err != nil {
+		return p.repositoryError(ctx, err)
+	}
+	return nil
+}
+
+func (p *proofRequestTemplates) repositoryError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrProofRequestTemplateDoesNotExist):
+		return ErrProofRequestTemplateNotFound
+	case errors.Is(err, repositories.ErrProofRequestTemplateDuplication):
+		return ErrProofRequestTemplateDuplicate
+	}
+	log.Error(ctx, "proof request template repository", err)
+	return err
+}
+
+// newProofRequestTemplate validates the request and builds the template. Templates with no
+// allowed issuers accept credentials from any issuer.
+func newProofRequestTemplate(req *ports.ProofRequestTemplateRequest) (*domain.ProofRequestTemplate, error) {
+	if req.Name == "" || req.Type == "" {
+		return nil, ErrProofRequestTemplateInvalid
+	}
+	if !templateCircuits[circuits.CircuitID(req.CircuitID)] {
+		return nil, ErrProofRequestTemplateInvalid
+	}
+	if _, err := url.ParseRequestURI(req.Context); err != nil {
+		return nil, ErrMalformedURL
+	}
+	if !hasSinglePredicate(req.CredentialSubject) {
+		return nil, ErrProofRequestTemplateInvalid
+	}
+
+	allowedIssuers := req.AllowedIssuers
+	if len(allowedIssuers) == 0 {
+		allowedIssuers = []string{"*"}
+	}
+
+	return domain.NewProofRequestTemplate(req.Name, req.CircuitID, req.Context, req.Type, allowedIssuers, req.CredentialSubject, req.Reason)
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	jsonSuite "github.com/iden3/go-schema-processor/json"
+	"github.com/iden3/go-schema-processor/utils"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/go-circuits"
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+)
+
+const (
+	kycContext = "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld"
+	kycIssuer  = "did:iden3:polygon:mumbai:wzS76vqXD6XhkU1LtUXbM1MMZEbjgt2GS8tU1koD3"
+)
+
+// kycAgeSchema is the json schema of the non merklized KYCAgeCredential, with the birthday in the value slot A
+const kycAgeSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "$metadata": {
+    "uris": {
+      "jsonLdContext": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
+      "jsonSchema": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+    },
+    "serialization": {
+      "valueDataSlotA": "birthday"
+    }
+  }
+}`
+
+type schemaLoaderMock []byte
+
+func (s schemaLoaderMock) Load(_ context.Context, _ string) ([]byte, string, error) {
+	return s, "json", nil
+}
+
+// ldContextTransport serves the JSON-LD contexts the presentations are merklized with from the testdata folder
+type ldContextTransport map[string]string
+
+func (c ldContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	file, ok := c[req.URL.String()]
+	if !ok {
+		return nil, fmt.Errorf("unexpected request to %s", req.URL)
+	}
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/ld+json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func withLDContexts(t *testing.T) {
+	t.Helper()
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = ldContextTransport{
+		verifiable.JSONLDSchemaW3CCredential2018: "testdata/credentials-v1.json-ld",
+		kycContext:                               "testdata/kyc-v3.json-ld",
+	}
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+}
+
+func TestDisclosedPresentation_NonMerklized(t *testing.T) {
+	withLDContexts(t)
+
+	claim := &domain.Claim{}
+	require.NoError(t, claim.Data.Set(verifiable.W3CCredential{
+		Context: []string{verifiable.JSONLDSchemaW3CCredential2018, kycContext},
+		Type:    []string{verifiable.TypeW3CVerifiableCredential, "KYCAgeCredential"},
+		CredentialSubject: map[string]interface{}{
+			"id":           "did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ",
+			"type":         "KYCAgeCredential",
+			"birthday":     19960424,
+			"documentType": 2,
+		},
+	}))
+
+	vp, err := disclosedPresentation(claim, ports.Query{
+		Context: kycContext,
+		Type:    "KYCAgeCredential",
+		Req:     map[string]interface{}{"birthday": map[string]interface{}{}},
+	})
+	require.NoError(t, err)
+
+	issuerDID, err := core.ParseDID(kycIssuer)
+	require.NoError(t, err)
+	slotIndex, err := jsonSuite.Parser{}.GetFieldSlotIndex("birthday", []byte(kycAgeSchema))
+	require.NoError(t, err)
+
+	query := pubsignals.Query{
+		AllowedIssuers:    []string{kycIssuer},
+		Context:           kycContext,
+		Type:              "KYCAgeCredential",
+		CredentialSubject: map[string]interface{}{"birthday": map[string]interface{}{}},
+	}
+	// the slot of a non merklized claim holds the integer itself, which is what the EQ proof outputs
+	pubSig := func(value int64) *pubsignals.CircuitOutputs {
+		return &pubsignals.CircuitOutputs{
+			IssuerID:            &issuerDID.ID,
+			ClaimSchema:         utils.CreateSchemaHash([]byte(kycContext + "#KYCAgeCredential")),
+			SlotIndex:           slotIndex,
+			Operator:            circuits.EQ,
+			Value:               []*big.Int{big.NewInt(value), big.NewInt(0), big.NewInt(0)},
+			Timestamp:           time.Now().Unix(),
+			IsRevocationChecked: 1,
+		}
+	}
+
+	t.Run("should accept the disclosed value", func(t *testing.T) {
+		assert.NoError(t, query.Check(context.Background(), schemaLoaderMock(kycAgeSchema), pubSig(19960424), vp))
+	})
+
+	t.Run("should reject a proof of another value", func(t *testing.T) {
+		assert.EqualError(t, query.Check(context.Background(), schemaLoaderMock(kycAgeSchema), pubSig(19960425), vp), "different value between proof and disclosure value")
+	})
+}
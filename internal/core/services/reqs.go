@@ -2,24 +2,49 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/lastingasset/wallet-service/go-circuits"
 	auth "github.com/lastingasset/wallet-service/go-iden3-auth"
 	"github.com/lastingasset/wallet-service/go-iden3-auth/loaders"
 	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
-	"github.com/lastingasset/wallet-service/go-iden3-auth/state"
 	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/config"
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/core/ports"
 	"github.com/lastingasset/wallet-service/internal/db"
 	"github.com/lastingasset/wallet-service/internal/log"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+	pkgloaders "github.com/lastingasset/wallet-service/pkg/loaders"
 )
 
 var (
-	ErrAuthRequestNotFound = errors.New("authRequest not found") // ErrAuthRequestNotFound Cannot retrieve the given authRequest 	// ErrProcessSchema Cannot process schema
+	ErrAuthRequestNotFound   = errors.New("authRequest not found")          // ErrAuthRequestNotFound Cannot retrieve the given authRequest
+	ErrAuthRequestNotPending = errors.New("authRequest cannot be answered") // ErrAuthRequestNotPending The authRequest was already answered or has expired
+	ErrAuthResponseNotValid  = errors.New("authResponse is not valid")      // ErrAuthResponseNotValid The response does not satisfy the authRequest
+	ErrSybilRegistered       = errors.New("holder already registered")      // ErrSybilRegistered The sybil resistance proof nullifier was already registered with the verifier
+	ErrVerifierNotFound      = errors.New("verifier not found")             // ErrVerifierNotFound The DID is not the DID of any verifier profile
+	ErrQueryRequestInvalid   = errors.New("invalid query request")          // ErrQueryRequestInvalid A scope of the query request cannot be built
+)
+
+const (
+	// authScopeID is the id of the scope of the requests built from a template or for an auth proof,
+	// and of the first scope of query requests
+	authScopeID uint32 = 1
+	// authRequestReason is the reason of the requests for an auth proof
+	authRequestReason = "authentication"
+	// queryRequestReason is the reason of the query requests
+	queryRequestReason = "credential query"
 )
 
 // AuthRequestCfg authRequest service configuration
@@ -27,6 +52,7 @@ type AuthRequestCfg struct {
 	RHSEnabled bool // ReverseHash Enabled
 	RHSUrl     string
 	Host       string
+	Verifier   config.Verifier
 }
 
 type authRequest struct {
@@ -36,11 +62,13 @@ type authRequest struct {
 	identitySrv             ports.IdentityService
 	mtService               ports.MtService
 	identityStateRepository ports.IdentityStateRepository
+	templateRepository      ports.ProofRequestTemplateRepository
+	nullifierRepository     ports.SybilNullifierRepository
 	storage                 *db.Storage
 }
 
 // NewAuthRequest creates a new authRequest service
-func NewAuthRequest(repo ports.ReqsRepository, schemaSrv ports.SchemaService, idenSrv ports.IdentityService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, storage *db.Storage, cfg AuthRequestCfg) ports.ReqsService {
+func NewAuthRequest(repo ports.ReqsRepository, schemaSrv ports.SchemaService, idenSrv ports.IdentityService, mtService ports.MtService, identityStateRepository ports.IdentityStateRepository, templateRepository ports.ProofRequestTemplateRepository, nullifierRepository ports.SybilNullifierRepository, storage *db.Storage, cfg AuthRequestCfg) ports.ReqsService {
 	// r := &protocol.AuthorizationRequestMessage {}
 
 	s := &authRequest{
@@ -48,12 +76,15 @@ func NewAuthRequest(repo ports.ReqsRepository, schemaSrv ports.SchemaService, id
 			RHSEnabled: cfg.RHSEnabled,
 			RHSUrl:     cfg.RHSUrl,
 			Host:       cfg.Host,
+			Verifier:   cfg.Verifier,
 		},
 		icRepo:                  repo,
 		schemaSrv:               schemaSrv,
 		identitySrv:             idenSrv,
 		mtService:               mtService,
 		identityStateRepository: identityStateRepository,
+		templateRepository:      templateRepository,
+		nullifierRepository:     nullifierRepository,
 		storage:                 storage,
 	}
 	return s
@@ -66,20 +97,20 @@ func NewAuthRequest(repo ports.ReqsRepository, schemaSrv ports.SchemaService, id
 
 // TODO: remove or update CreateAuthRequestRequest
 func (a *authRequest) CreateAuthRequest(ctx context.Context, req *ports.CreateAuthRequestRequest) (protocol.AuthorizationRequestMessage, error) {
-	err := a.guardCreateAuthRequestRequest(req)
-	if err != nil {
+	guardErr := a.guardCreateAuthRequestRequest(req)
+	if guardErr != nil {
 		log.Warn(ctx, "validating create authRequest request", "req", req)
 	}
 
-	const CallBackUrl = "http:localhost:8001/call-back"
-	const VerifierIdentity = "did:polygonid:polygon:mumbai:2qHWXLmy3YR1Hh6pmzS5GVUUzWtNQi9xcAy2oKVm73"
+	profile, err := a.verifierProfile(req.DID.String())
+	if err != nil {
+		return protocol.AuthorizationRequestMessage{}, err
+	}
 
-	request := auth.CreateAuthorizationRequestWithMessage("10", "message", VerifierIdentity, CallBackUrl)
-	request.ID = "6789"
-	request.ThreadID = "7f38a193-0918-4a48-9fac-36adfdb8b542"
+	request := auth.CreateAuthorizationRequestWithMessage(authRequestReason, "", profile.DID, a.callbackURL(profile))
 
 	var mtpProofRequest protocol.ZeroKnowledgeProofRequest
-	mtpProofRequest.ID = 10
+	mtpProofRequest.ID = authScopeID
 	mtpProofRequest.CircuitID = string(circuits.AuthV2CircuitID)
 	mtpProofRequest.Query = map[string]interface{}{
 		"allowedIssuers": []string{"*"},
@@ -93,129 +124,324 @@ func (a *authRequest) CreateAuthRequest(ctx context.Context, req *ports.CreateAu
 	}
 
 	request.Body.Scope = append(request.Body.Scope, mtpProofRequest)
+	if guardErr != nil {
+		return request, guardErr
+	}
+
+	if err := a.saveRequestMessage(ctx, &request); err != nil {
+		return request, err
+	}
 
-	return request, err
+	return request, nil
 }
 
-func (a *authRequest) CreateAuthorizationRequestMessage(ctx context.Context, req *ports.CreateQueryRequestRequest) (protocol.AuthorizationRequestMessage, error) {
-	err := a.guardCreateQueryRequestRequest(req)
+// CreateAuthRequestFromTemplate creates and stores an authRequest whose only scope is the proof request
+// of the given template, with the per request overrides applied
+func (a *authRequest) CreateAuthRequestFromTemplate(ctx context.Context, req *ports.CreateAuthRequestFromTemplateRequest) (protocol.AuthorizationRequestMessage, error) {
+	profile, err := a.verifierProfile(req.VerifierDID.String())
 	if err != nil {
-		log.Warn(ctx, "validating create queryRequest request", "req", req)
+		return protocol.AuthorizationRequestMessage{}, err
 	}
 
-	const CallBackUrl = "http:localhost:8001/call-back"
-	const VerifierIdentity = "did:polygonid:polygon:mumbai:2qJT3RnL8ZwU7mgQeVjgw6qNpyYTV3Z7CgtxueBdsA"
+	template, err := a.templateRepository.GetByID(ctx, a.storage.Pgx, req.TemplateID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProofRequestTemplateDoesNotExist) {
+			return protocol.AuthorizationRequestMessage{}, ErrProofRequestTemplateNotFound
+		}
+		return protocol.AuthorizationRequestMessage{}, err
+	}
 
-	request := auth.CreateAuthorizationRequestWithMessage("12345", "message", VerifierIdentity, CallBackUrl)
-	request.To = req.DID.String()
-	request.ID = "6789"
-	request.ThreadID = "7f38a193-0918-4a48-9fac-36adfdb8b542"
+	reason := template.Reason
+	if req.Overrides.Reason != nil {
+		reason = *req.Overrides.Reason
+	}
 
-	var mtpProofRequest protocol.ZeroKnowledgeProofRequest
-	mtpProofRequest.ID = 10
-	// TODO OnChain
-	mtpProofRequest.CircuitID = string(circuits.AtomicQueryMTPV2CircuitID)
-	mtpProofRequest.Query = map[string]interface{}{
-		"allowedIssuers": []string{"did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS"},
-		"credentialSubject": map[string]interface{}{
-			"birthday": map[string]interface{}{
-				"$lt": float64(20221010),
-			},
-		},
-		"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
-		"type":    "KYCAgeCredential",
+	request := auth.CreateAuthorizationRequestWithMessage(reason, "", profile.DID, a.callbackURL(profile))
+	request.To = req.To
+
+	proofRequest, err := template.ProofRequest(authScopeID, req.Overrides)
+	if err != nil {
+		return protocol.AuthorizationRequestMessage{}, err
 	}
-	request.Body.Scope = append(request.Body.Scope, mtpProofRequest)
-	return request, err
+	if credentialSubject, _ := proofRequest.Query["credentialSubject"].(map[string]interface{}); !hasSinglePredicate(credentialSubject) {
+		return protocol.AuthorizationRequestMessage{}, fmt.Errorf("%w: the credential subject has several predicates", ErrQueryRequestInvalid)
+	}
+	request.Body.Scope = append(request.Body.Scope, proofRequest)
+
+	if err := a.saveRequestMessage(ctx, &request); err != nil {
+		return request, err
+	}
+
+	return request, nil
+}
+
+func (a *authRequest) CreateAuthorizationRequestMessage(ctx context.Context, req *ports.CreateQueryRequestRequest) (protocol.AuthorizationRequestMessage, error) {
+	return a.createQueryRequest(ctx, req, circuits.AtomicQueryMTPV2CircuitID)
 }
 
 func (a *authRequest) CreateQueryRequest(ctx context.Context, req *ports.CreateQueryRequestRequest) (protocol.AuthorizationRequestMessage, error) {
-	err := a.guardCreateQueryRequestRequest(req)
-	if err != nil {
+	return a.createQueryRequest(ctx, req, circuits.AtomicQueryMTPV2OnChainCircuitID)
+}
+
+// createQueryRequest creates and stores a request from the verifier profile of the DID of the given one to that DID,
+// with the scopes built from it. The credential of the request is proved with the given circuit when the request does not set any.
+func (a *authRequest) createQueryRequest(ctx context.Context, req *ports.CreateQueryRequestRequest, circuitID circuits.CircuitID) (protocol.AuthorizationRequestMessage, error) {
+	guardErr := a.guardCreateQueryRequestRequest(req)
+	if guardErr != nil {
 		log.Warn(ctx, "validating create queryRequest request", "req", req)
 	}
 
-	const CallBackUrl = "http:localhost:8001/call-back"
-	const VerifierIdentity = "did:polygonid:polygon:mumbai:2qJT3RnL8ZwU7mgQeVjgw6qNpyYTV3Z7CgtxueBdsA"
+	profile, err := a.verifierProfile(req.DID.String())
+	if err != nil {
+		return protocol.AuthorizationRequestMessage{}, err
+	}
 
-	request := auth.CreateAuthorizationRequestWithMessage("12345", "message", VerifierIdentity, CallBackUrl)
+	request := auth.CreateAuthorizationRequestWithMessage(queryRequestReason, "", profile.DID, a.callbackURL(profile))
 	request.To = req.DID.String()
-	request.ID = "6789"
-	request.ThreadID = "7f38a193-0918-4a48-9fac-36adfdb8b542"
+	if guardErr != nil {
+		return request, guardErr
+	}
 
-	var mtpProofRequest protocol.ZeroKnowledgeProofRequest
-	mtpProofRequest.ID = 10
-	mtpProofRequest.CircuitID = string(circuits.AtomicQueryMTPV2OnChainCircuitID)
-	mtpProofRequest.Query = map[string]interface{}{
-		"allowedIssuers": []string{"did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS"},
-		"credentialSubject": map[string]interface{}{
-			"birthday": map[string]interface{}{
-				"$lt": float64(20221010),
-			},
-		},
-		"context": "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld",
-		"type":    "KYCAgeCredential",
+	if req.CircuitID != "" {
+		circuitID = circuits.CircuitID(req.CircuitID)
 	}
-	request.Body.Scope = append(request.Body.Scope, mtpProofRequest)
-	return request, err
+	scopes, err := a.queryScopes(ctx, req, circuitID)
+	if err != nil {
+		return request, err
+	}
+	request.Body.Scope = append(request.Body.Scope, scopes...)
+
+	if err := a.saveRequestMessage(ctx, &request); err != nil {
+		return request, err
+	}
+
+	return request, nil
 }
 
 func (a *authRequest) VerifyAuthRequestResponse(ctx context.Context, authorizationRequestMessage *protocol.AuthorizationRequestMessage, authorizationResponseMessage *protocol.AuthorizationResponseMessage) bool {
-	// TODO OnChain
-	keyDIR := "/home/zakwan/wallet-service/pkg/credentials/circuits/credentialAtomicQueryMTPV2"
-	// circuitsLoaderService := pkgloader.NewCircuits("/home/zakwan/wallet-service/pkg/credentials/circuits")
+	return a.verify(ctx, authorizationRequestMessage, authorizationResponseMessage)
+}
 
-	// authV2Set, err := circuitsLoaderService.Load(circuits.AuthV2CircuitID)
+func (a *authRequest) VerifyQueryRequestResponse(ctx context.Context, authorizationRequestMessage *protocol.AuthorizationRequestMessage, authorizationResponseMessage *protocol.AuthorizationResponseMessage) bool {
+	return a.verify(ctx, authorizationRequestMessage, authorizationResponseMessage)
+}
+
+// verify checks the response against the request using the verifier profile of the request sender.
+// The response must answer an open request stored by this service, which is consumed by the verification.
+func (a *authRequest) verify(ctx context.Context, authorizationRequestMessage *protocol.AuthorizationRequestMessage, authorizationResponseMessage *protocol.AuthorizationResponseMessage) bool {
+	authRequest, err := a.icRepo.GetByThreadID(ctx, a.storage.Pgx, authorizationRequestMessage.ThreadID)
+	if err != nil {
+		log.Warn(ctx, "auth response does not answer a stored request", "err", err, "thid", authorizationRequestMessage.ThreadID)
+		return false
+	}
 
-	var verificationKeyloader = &loaders.FSKeyLoader{Dir: keyDIR}
+	stored, err := authRequest.GetAuthorizationRequestMessage()
+	if err != nil {
+		log.Error(ctx, "loading stored auth request", err, "thid", authorizationRequestMessage.ThreadID)
+		return false
+	}
+	if stored.ID != authorizationRequestMessage.ID || authRequest.Verifier != authorizationRequestMessage.From {
+		log.Warn(ctx, "auth request does not match the stored one", "id", authorizationRequestMessage.ID, "thid", authorizationRequestMessage.ThreadID)
+		return false
+	}
 
-	// TODO
-	URL := "https://polygon-mumbai.g.alchemy.com/v2/jNN6BxHCdHmxeTFcHHz-6DG7VTqX1tPY"
-	contractAddress := "0x134B1BE34911E39A8397ec6289782989729807a4"
+	// from here on only the stored request is trusted, the scopes of the given one may have been changed
+	verifier, err := a.newVerifier(stored.From)
+	if err != nil {
+		log.Error(ctx, "building verifier", err, "from", stored.From)
+		return false
+	}
 
-	resolver := state.ETHResolver{
-		RPCUrl:          URL,
-		ContractAddress: common.HexToAddress(contractAddress),
+	if err := a.open(ctx, authRequest); err != nil {
+		log.Warn(ctx, "auth request cannot be answered", "err", err, "thid", stored.ThreadID)
+		return false
 	}
 
-	resolvers := map[string]pubsignals.StateResolver{
-		"polygon:mumbai": resolver,
+	verifyErr := verifier.VerifyAuthResponse(ctx, *authorizationResponseMessage, *stored)
+	if verifyErr == nil {
+		verifyErr = checkChallenge(authRequest, authorizationResponseMessage)
+	}
+	var nullifiers []*domain.SybilNullifier
+	if verifyErr == nil {
+		nullifiers, verifyErr = sybilNullifiers(authRequest, authorizationResponseMessage)
+	}
+	if verifyErr != nil {
+		log.Warn(ctx, "auth response verification failed", "err", verifyErr, "thid", stored.ThreadID)
 	}
-	verifier := auth.NewVerifier(verificationKeyloader, loaders.DefaultSchemaLoader{IpfsURL: "ipfs.io"}, resolvers)
 
-	err := verifier.VerifyAuthResponse(ctx, *authorizationResponseMessage, *authorizationRequestMessage)
-	if err != nil {
+	if err := a.close(ctx, authRequest, authorizationResponseMessage, nullifiers, verifyErr); err != nil {
+		if errors.Is(err, ErrSybilRegistered) {
+			log.Warn(ctx, "auth response verification failed", "err", err, "thid", stored.ThreadID)
+			return false
+		}
+		log.Error(ctx, "recording auth request verification", err, "thid", stored.ThreadID)
 		return false
 	}
-	return true
+
+	return verifyErr == nil
 }
 
-func (a *authRequest) VerifyQueryRequestResponse(ctx context.Context, authorizationRequestMessage *protocol.AuthorizationRequestMessage, authorizationResponseMessage *protocol.AuthorizationResponseMessage) bool {
-	keyDIR := "/home/zakwan/wallet-service/pkg/credentials/circuits/authV2"
-	// circuitsLoaderService := pkgloader.NewCircuits("/home/zakwan/wallet-service/pkg/credentials/circuits")
+// verifierProfile returns the profile whose DID is the given one, ErrVerifierNotFound if there is none
+func (a *authRequest) verifierProfile(verifierDID string) (config.VerifierProfile, error) {
+	profile, err := a.cfg.Verifier.ProfileByDID(verifierDID)
+	if errors.Is(err, config.ErrVerifierProfileNotFound) {
+		return profile, fmt.Errorf("%w: %s", ErrVerifierNotFound, verifierDID)
+	}
+	return profile, err
+}
+
+// callbackURL returns the url where the wallets send their responses to the verifier of the profile,
+// the callback endpoint of this service for the verifier when the profile does not set one
+func (a *authRequest) callbackURL(profile config.VerifierProfile) string {
+	if profile.CallbackURL != "" {
+		return profile.CallbackURL
+	}
+	return fmt.Sprintf("%s/v1/%s/callback", strings.TrimSuffix(a.cfg.Host, "/"), profile.DID)
+}
 
-	// authV2Set, err := circuitsLoaderService.Load(circuits.AuthV2CircuitID)
+// newVerifier builds an auth verifier from the profile whose DID is the given one
+func (a *authRequest) newVerifier(verifierDID string) (*auth.Verifier, error) {
+	profile, err := a.verifierProfile(verifierDID)
+	if err != nil {
+		return nil, err
+	}
 
-	var verificationKeyloader = &loaders.FSKeyLoader{Dir: keyDIR}
+	resolvers := NewStateResolvers(profile.Chains, a.identityStateRepository, a.storage)
 
-	URL := "https://polygon-mumbai.g.alchemy.com/v2/jNN6BxHCdHmxeTFcHHz-6DG7VTqX1tPY"
-	contractAddress := "0x134B1BE34911E39A8397ec6289782989729807a4"
+	verificationKeyLoader := pkgloaders.VerificationKeyLoader{Circuits: pkgloaders.NewCircuits(profile.CircuitsPath)}
+	return auth.NewVerifier(verificationKeyLoader, loaders.DefaultSchemaLoader{IpfsURL: "ipfs.io"}, resolvers), nil
+}
 
-	resolver := state.ETHResolver{
-		RPCUrl:          URL,
-		ContractAddress: common.HexToAddress(contractAddress),
+// Callback verifies the JWZ token sent by a wallet against the stored request of the session
+// and records the holder DID and the values it disclosed.
+func (a *authRequest) Callback(ctx context.Context, verifierDID *core.DID, sessionID uuid.UUID, token string) (*domain.AuthRequest, error) {
+	authRequest, err := a.icRepo.GetByID(ctx, a.storage.Pgx, sessionID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAuthRequestDoesNotExist) {
+			return nil, ErrAuthRequestNotFound
+		}
+		return nil, err
 	}
 
-	resolvers := map[string]pubsignals.StateResolver{
-		"polygon:mumbai": resolver,
+	if authRequest.Verifier != verifierDID.String() {
+		return nil, ErrAuthRequestNotFound
 	}
-	verifier := auth.NewVerifier(verificationKeyloader, loaders.DefaultSchemaLoader{IpfsURL: "ipfs.io"}, resolvers)
 
-	err := verifier.VerifyAuthResponse(ctx, *authorizationResponseMessage, *authorizationRequestMessage)
+	request, err := authRequest.GetAuthorizationRequestMessage()
 	if err != nil {
-		return false
+		return nil, err
+	}
+
+	verifier, err := a.newVerifier(request.From)
+	if err != nil {
+		log.Error(ctx, "building verifier", err, "from", request.From)
+		return nil, err
+	}
+
+	if err := a.open(ctx, authRequest); err != nil {
+		return nil, err
+	}
+
+	response, verifyErr := verifier.FullVerify(ctx, token, *request)
+	if verifyErr == nil && response.ThreadID != authRequest.ThreadID {
+		verifyErr = fmt.Errorf("response thread id <%s> does not match the request", response.ThreadID)
+	}
+	if verifyErr == nil {
+		verifyErr = checkChallenge(authRequest, response)
+	}
+	var nullifiers []*domain.SybilNullifier
+	if verifyErr == nil {
+		nullifiers, verifyErr = sybilNullifiers(authRequest, response)
+	}
+	if verifyErr != nil {
+		log.Warn(ctx, "callback verification failed", "err", verifyErr, "session", sessionID)
+	}
+
+	if err := a.close(ctx, authRequest, response, nullifiers, verifyErr); err != nil {
+		if errors.Is(err, ErrSybilRegistered) {
+			log.Warn(ctx, "callback verification failed", "err", err, "session", sessionID)
+			return nil, fmt.Errorf("%w: %v", ErrAuthResponseNotValid, err)
+		}
+		log.Error(ctx, "recording callback verification", err, "session", sessionID)
+		return nil, err
+	}
+	if verifyErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthResponseNotValid, verifyErr)
+	}
+
+	return a.icRepo.GetByID(ctx, a.storage.Pgx, authRequest.ID)
+}
+
+// queryScopes returns the proof requests of the query request, with consecutive ids from authScopeID: the one of
+// the credential of the request, with the context of its schema, followed by the other scopes of the request.
+// Scopes without allowed issuers accept credentials of any issuer.
+func (a *authRequest) queryScopes(ctx context.Context, req *ports.CreateQueryRequestRequest, circuitID circuits.CircuitID) ([]protocol.ZeroKnowledgeProofRequest, error) {
+	jsonLdContext, err := a.schemaContext(ctx, req.Schema)
+	if err != nil {
+		return nil, err
 	}
-	return true
+
+	queries := append([]ports.QueryRequestScope{{
+		CircuitID:         string(circuitID),
+		AllowedIssuers:    req.AllowedIssuers,
+		Context:           jsonLdContext,
+		Type:              req.Type,
+		CredentialSubject: req.CredentialSubject,
+	}}, req.Scopes...)
+
+	scopes := make([]protocol.ZeroKnowledgeProofRequest, 0, len(queries))
+	for i, query := range queries {
+		id := authScopeID + uint32(i)
+		if query.CircuitID == "" || query.Context == "" || query.Type == "" {
+			return nil, fmt.Errorf("%w: scope %d needs a circuit, a context and a type", ErrQueryRequestInvalid, id)
+		}
+		allowedIssuers := query.AllowedIssuers
+		if len(allowedIssuers) == 0 {
+			allowedIssuers = []string{"*"}
+		}
+		credentialSubject := query.CredentialSubject
+		if credentialSubject == nil {
+			credentialSubject = map[string]any{}
+		}
+		if !hasSinglePredicate(credentialSubject) {
+			return nil, fmt.Errorf("%w: scope %d has several predicates", ErrQueryRequestInvalid, id)
+		}
+		scopes = append(scopes, protocol.ZeroKnowledgeProofRequest{
+			ID:        id,
+			CircuitID: query.CircuitID,
+			Query: map[string]interface{}{
+				"allowedIssuers":    allowedIssuers,
+				"credentialSubject": credentialSubject,
+				"context":           query.Context,
+				"type":              query.Type,
+			},
+		})
+	}
+	return scopes, nil
+}
+
+// hasSinglePredicate tells whether the verifier can check the proof of the credential subject query. The query circuits
+// do not output the credential a proof was generated with, so the proofs of several predicates could be of different
+// credentials of the holder.
+func hasSinglePredicate(credentialSubject map[string]interface{}) bool {
+	return len(pubsignals.SplitPredicates(credentialSubject)) <= 1
+}
+
+// schemaContext returns the json-ld context of the credential type of the json schema with the given url
+func (a *authRequest) schemaContext(ctx context.Context, schemaURL string) (string, error) {
+	schema, err := a.schemaSrv.LoadSchema(ctx, schemaURL)
+	if err != nil {
+		log.Error(ctx, "loading schema", err, "schema", schemaURL)
+		return "", ErrLoadingSchema
+	}
+	if schema.Metadata == nil {
+		return "", ErrJSONLdContext
+	}
+	jsonLdContext, ok := schema.Metadata.Uris["jsonLdContext"].(string)
+	if !ok {
+		return "", ErrJSONLdContext
+	}
+	return jsonLdContext, nil
 }
 
 func (c *authRequest) guardCreateAuthRequestRequest(req *ports.CreateAuthRequestRequest) error {
@@ -236,6 +462,192 @@ func (c *authRequest) guardAuthorizationRequestMessage(authorizationRequestMessa
 	return nil
 }
 
+// saveRequestMessage binds the request to a new random challenge, carried to the holder in its scopes,
+// and stores it so that the challenge of the response proofs can be checked against the stored one.
+// The id of the stored request is added to the callback url as the session the wallet answers.
+func (a *authRequest) saveRequestMessage(ctx context.Context, message *protocol.AuthorizationRequestMessage) error {
+	sessionID := uuid.New()
+	if err := domain.SetCallbackSession(message, sessionID); err != nil {
+		log.Error(ctx, "setting auth request session", err)
+		return err
+	}
+
+	var expiresAt *time.Time
+	if a.cfg.Verifier.RequestExpiration > 0 {
+		expiration := time.Now().Add(a.cfg.Verifier.RequestExpiration)
+		expiresAt = &expiration
+	}
+
+	challenge, err := domain.NewRequestChallenge()
+	if err != nil {
+		log.Error(ctx, "generating auth request challenge", err)
+		return err
+	}
+	domain.SetRequestChallenge(message, challenge)
+
+	authRequest, err := domain.FromAuthRequester(*message, challenge, expiresAt)
+	if err != nil {
+		log.Error(ctx, "building auth request", err)
+		return err
+	}
+	authRequest.ID = sessionID
+
+	if _, err := a.save(ctx, authRequest); err != nil {
+		log.Error(ctx, "saving auth request", err, "thid", message.ThreadID)
+		return err
+	}
+
+	return nil
+}
+
+// open moves the stored request to pending so that it can be answered only once.
+// Expired requests are marked as such and, like already answered ones, return ErrAuthRequestNotPending.
+func (a *authRequest) open(ctx context.Context, authRequest *domain.AuthRequest) error {
+	if authRequest.IsExpired(time.Now()) {
+		if err := a.icRepo.UpdateStatus(ctx, a.storage.Pgx, authRequest.ID, domain.AuthRequestStatusExpired); err != nil && !errors.Is(err, repositories.ErrAuthRequestInvalidStatus) {
+			return err
+		}
+		return ErrAuthRequestNotPending
+	}
+
+	if err := a.icRepo.UpdateStatus(ctx, a.storage.Pgx, authRequest.ID, domain.AuthRequestStatusPending); err != nil {
+		if errors.Is(err, repositories.ErrAuthRequestInvalidStatus) {
+			return ErrAuthRequestNotPending
+		}
+		return err
+	}
+
+	return nil
+}
+
+// close moves the pending request to rejected if verifyErr is not nil, or records the holder DID
+// and the disclosed values, registers the sybil ids of the response and moves it to verified otherwise.
+// The request is rejected, and ErrSybilRegistered returned, when any of the sybil ids was already registered.
+func (a *authRequest) close(ctx context.Context, authRequest *domain.AuthRequest, response *protocol.AuthorizationResponseMessage, nullifiers []*domain.SybilNullifier, verifyErr error) error {
+	if verifyErr != nil {
+		return a.icRepo.UpdateStatus(ctx, a.storage.Pgx, authRequest.ID, domain.AuthRequestStatusRejected)
+	}
+
+	err := a.storage.Pgx.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return a.verified(ctx, tx, authRequest, response, nullifiers)
+	})
+	if errors.Is(err, ErrSybilRegistered) {
+		if err := a.icRepo.UpdateStatus(ctx, a.storage.Pgx, authRequest.ID, domain.AuthRequestStatusRejected); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// verified records the verification of the request and registers the sybil ids of the response with its verifier
+func (a *authRequest) verified(ctx context.Context, conn db.Querier, authRequest *domain.AuthRequest, response *protocol.AuthorizationResponseMessage, nullifiers []*domain.SybilNullifier) error {
+	disclosed, err := domain.DisclosedFromResponse(*response)
+	if err != nil {
+		return err
+	}
+	if err := a.icRepo.UpdateVerification(ctx, conn, authRequest.ID, response.From, disclosed); err != nil {
+		return err
+	}
+	if err := a.icRepo.UpdateStatus(ctx, conn, authRequest.ID, domain.AuthRequestStatusVerified); err != nil {
+		return err
+	}
+	for _, nullifier := range nullifiers {
+		err := a.nullifierRepository.Save(ctx, conn, nullifier)
+		if errors.Is(err, repositories.ErrSybilNullifierDuplicated) {
+			return ErrSybilRegistered
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChallenge checks that the proofs of the circuits that take a challenge were generated
+// for the challenge of the stored request
+func checkChallenge(authRequest *domain.AuthRequest, response *protocol.AuthorizationResponseMessage) error {
+	challenge, err := authRequest.GetChallenge()
+	if err != nil {
+		return err
+	}
+	if challenge == nil {
+		return errors.New("the request has no challenge")
+	}
+
+	for _, scope := range response.Body.Scope {
+		pubSignals, err := json.Marshal(scope.PubSignals)
+		if err != nil {
+			return err
+		}
+
+		var proofChallenge *big.Int
+		switch circuits.CircuitID(scope.CircuitID) {
+		case circuits.AuthV2CircuitID:
+			var signals circuits.AuthV2PubSignals
+			err = signals.PubSignalsUnmarshal(pubSignals)
+			proofChallenge = signals.Challenge
+		case circuits.AtomicQueryMTPV2OnChainCircuitID:
+			var signals circuits.AtomicQueryMTPV2OnChainPubSignals
+			err = signals.PubSignalsUnmarshal(pubSignals)
+			proofChallenge = signals.Challenge
+		case circuits.AtomicQuerySigV2OnChainCircuitID:
+			var signals circuits.AtomicQuerySigV2OnChainPubSignals
+			err = signals.PubSignalsUnmarshal(pubSignals)
+			proofChallenge = signals.Challenge
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("scope %d: %w", scope.ID, err)
+		}
+		if proofChallenge == nil || proofChallenge.Cmp(challenge) != 0 {
+			return fmt.Errorf("scope %d: proof challenge does not match the request", scope.ID)
+		}
+	}
+
+	return nil
+}
+
+// sybilNullifiers returns the sybil ids of the sybil resistance proofs of the response, to be registered with the
+// verifier of the request. The same person gets the same sybil id for a crs whatever identity or profile it proves
+// with, so the response is not valid if any of them was already registered.
+func sybilNullifiers(authRequest *domain.AuthRequest, response *protocol.AuthorizationResponseMessage) ([]*domain.SybilNullifier, error) {
+	nullifiers := make([]*domain.SybilNullifier, 0)
+	for _, scope := range response.Body.Scope {
+		pubSignals, err := json.Marshal(scope.PubSignals)
+		if err != nil {
+			return nil, err
+		}
+
+		var sybilID, crs *big.Int
+		switch circuits.CircuitID(scope.CircuitID) {
+		case circuits.SybilMTPCircuitID:
+			var signals circuits.SybilAtomicMTPPubSignals
+			err = signals.PubSignalsUnmarshal(pubSignals)
+			sybilID, crs = signals.SybilID, signals.CRS
+		case circuits.SybilSigCircuitID:
+			var signals circuits.SybilAtomicSigPubSignals
+			err = signals.PubSignalsUnmarshal(pubSignals)
+			sybilID, crs = signals.SybilID, signals.CRS
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("scope %d: %w", scope.ID, err)
+		}
+		nullifiers = append(nullifiers, &domain.SybilNullifier{
+			ID:            uuid.New(),
+			Verifier:      authRequest.Verifier,
+			CRS:           crs.String(),
+			SybilID:       sybilID.String(),
+			UserID:        response.From,
+			AuthRequestID: authRequest.ID,
+			CreatedAt:     time.Now(),
+		})
+	}
+	return nullifiers, nil
+}
+
 func (a *authRequest) save(ctx context.Context, authRequest *domain.AuthRequest) (*domain.AuthRequest, error) {
 	id, err := a.icRepo.Save(ctx, a.storage.Pgx, authRequest)
 	if err != nil {
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-rapidsnark/types"
+	jsonSuite "github.com/iden3/go-schema-processor/json"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/go-circuits"
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/config"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+type reqsRepositoryMock struct {
+	ports.ReqsRepository
+	requests map[uuid.UUID]*domain.AuthRequest
+}
+
+func (r *reqsRepositoryMock) Save(_ context.Context, _ db.Querier, authRequest *domain.AuthRequest) (uuid.UUID, error) {
+	r.requests[authRequest.ID] = authRequest
+	return authRequest.ID, nil
+}
+
+func (r *reqsRepositoryMock) GetByID(_ context.Context, _ db.Querier, id uuid.UUID) (*domain.AuthRequest, error) {
+	return r.requests[id], nil
+}
+
+func (r *reqsRepositoryMock) UpdateStatus(_ context.Context, _ db.Querier, id uuid.UUID, status domain.AuthRequestStatus) error {
+	r.requests[id].Status = status
+	return nil
+}
+
+func (r *reqsRepositoryMock) UpdateVerification(_ context.Context, _ db.Querier, id uuid.UUID, verifiedDID string, _ pgtype.JSONB) error {
+	r.requests[id].VerifiedDID = &verifiedDID
+	return nil
+}
+
+// schemaServiceMock loads json schemas whose json-ld context is the url of the schema with the jsonld extension
+type schemaServiceMock struct {
+	ports.SchemaService
+}
+
+func (s *schemaServiceMock) LoadSchema(_ context.Context, url string) (jsonSuite.Schema, error) {
+	return jsonSuite.Schema{Metadata: &jsonSuite.SchemaMetadata{Uris: map[string]interface{}{"jsonLdContext": url + "ld"}}}, nil
+}
+
+type sybilNullifierRepositoryMock struct {
+	ports.SybilNullifierRepository
+	nullifiers map[string]*domain.SybilNullifier
+}
+
+func (r *sybilNullifierRepositoryMock) Save(_ context.Context, _ db.Querier, nullifier *domain.SybilNullifier) error {
+	key := nullifier.Verifier + ":" + nullifier.CRS + ":" + nullifier.SybilID
+	if _, ok := r.nullifiers[key]; ok {
+		return repositories.ErrSybilNullifierDuplicated
+	}
+	r.nullifiers[key] = nullifier
+	return nil
+}
+
+func TestAuthRequest_UnknownVerifier(t *testing.T) {
+	ctx := context.Background()
+	unknown, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+
+	sessionID := uuid.New()
+	stored, err := domain.FromAuthRequester(protocol.AuthorizationRequestMessage{
+		ID:       uuid.NewString(),
+		ThreadID: uuid.NewString(),
+		From:     unknown.String(),
+		Type:     protocol.AuthorizationRequestMessageType,
+	}, nil, nil)
+	require.NoError(t, err)
+	stored.ID = sessionID
+
+	repo := &reqsRepositoryMock{requests: map[uuid.UUID]*domain.AuthRequest{sessionID: stored}}
+	service := NewAuthRequest(repo, nil, nil, nil, nil, nil, nil, &db.Storage{}, AuthRequestCfg{
+		Verifier: config.Verifier{
+			DefaultProfile: "default",
+			Profiles: map[string]config.VerifierProfile{
+				"default": {DID: kycIssuer},
+			},
+		},
+	})
+
+	t.Run("should not create a request for a verifier without profile", func(t *testing.T) {
+		_, err := service.CreateAuthRequestFromTemplate(ctx, &ports.CreateAuthRequestFromTemplateRequest{
+			VerifierDID: unknown,
+			TemplateID:  uuid.New(),
+		})
+		assert.ErrorIs(t, err, ErrVerifierNotFound)
+	})
+
+	t.Run("should not create an auth request for a DID without profile", func(t *testing.T) {
+		_, err := service.CreateAuthRequest(ctx, &ports.CreateAuthRequestRequest{DID: unknown, Schema: "https://schemas.com/KYCAgeCredential-v3.json"})
+		assert.ErrorIs(t, err, ErrVerifierNotFound)
+	})
+
+	t.Run("should not create a query request for a DID without profile", func(t *testing.T) {
+		_, err := service.CreateQueryRequest(ctx, &ports.CreateQueryRequestRequest{DID: unknown, Schema: "https://schemas.com/KYCAgeCredential-v3.json"})
+		assert.ErrorIs(t, err, ErrVerifierNotFound)
+	})
+
+	t.Run("should not answer the callback of a verifier without profile", func(t *testing.T) {
+		_, err := service.Callback(ctx, unknown, sessionID, "token")
+		assert.ErrorIs(t, err, ErrVerifierNotFound)
+	})
+}
+
+func TestAuthRequest_SybilRegistered(t *testing.T) {
+	ctx := context.Background()
+	verifier, err := core.ParseDID(kycIssuer)
+	require.NoError(t, err)
+	holder, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+	profile, err := core.ParseDID("did:iden3:polygon:mumbai:wuQT8NtFq736wsJahUuZpbA8otTzjKGyKj4i4yWtU")
+	require.NoError(t, err)
+
+	repo := &reqsRepositoryMock{requests: map[uuid.UUID]*domain.AuthRequest{}}
+	nullifierRepo := &sybilNullifierRepositoryMock{nullifiers: map[string]*domain.SybilNullifier{}}
+	service := &authRequest{icRepo: repo, nullifierRepository: nullifierRepo}
+
+	newRequest := func() *domain.AuthRequest {
+		req := &domain.AuthRequest{ID: uuid.New(), Verifier: verifier.String(), Status: domain.AuthRequestStatusPending}
+		repo.requests[req.ID] = req
+		return req
+	}
+	// response returns the response of the user with a sybil resistance proof of the given sybil id and crs
+	response := func(t *testing.T, user *core.DID, sybilID, crs string) *protocol.AuthorizationResponseMessage {
+		// userID, sybilID, issuer states, schema, gist root, crs, requestID, issuerID and timestamp
+		pubSignals := []string{user.ID.BigInt().String(), sybilID, "0", "0", "0", "0", crs, "1", verifier.ID.BigInt().String(), "1683100000"}
+		return &protocol.AuthorizationResponseMessage{
+			From: user.String(),
+			Body: protocol.AuthorizationMessageResponseBody{
+				Scope: []protocol.ZeroKnowledgeProofResponse{{ID: 1, CircuitID: string(circuits.SybilMTPCircuitID), ZKProof: types.ZKProof{PubSignals: pubSignals}}},
+			},
+		}
+	}
+	register := func(t *testing.T, req *domain.AuthRequest, resp *protocol.AuthorizationResponseMessage) error {
+		nullifiers, err := sybilNullifiers(req, resp)
+		require.NoError(t, err)
+		require.Len(t, nullifiers, 1)
+		return service.verified(ctx, nil, req, resp, nullifiers)
+	}
+
+	first := newRequest()
+	require.NoError(t, register(t, first, response(t, holder, "1234", "5678")))
+	assert.Equal(t, domain.AuthRequestStatusVerified, first.Status)
+
+	t.Run("should reject a second response with the same sybil id, whatever the identity", func(t *testing.T) {
+		err := register(t, newRequest(), response(t, profile, "1234", "5678"))
+		assert.ErrorIs(t, err, ErrSybilRegistered)
+	})
+
+	t.Run("should accept the same sybil id for another crs", func(t *testing.T) {
+		assert.NoError(t, register(t, newRequest(), response(t, holder, "1234", "8765")))
+	})
+}
+
+func TestAuthRequest_CreateQueryRequest(t *testing.T) {
+	ctx := context.Background()
+	holder, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+	issuer := "did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS"
+
+	repo := &reqsRepositoryMock{requests: map[uuid.UUID]*domain.AuthRequest{}}
+	service := NewAuthRequest(repo, &schemaServiceMock{}, nil, nil, nil, nil, nil, &db.Storage{}, AuthRequestCfg{
+		Verifier: config.Verifier{
+			DefaultProfile: "default",
+			Profiles: map[string]config.VerifierProfile{
+				"default": {DID: kycIssuer, CallbackURL: "https://verifier.com/callback"},
+				"holder":  {DID: holder.String(), CallbackURL: "https://holder.com/callback"},
+			},
+		},
+	})
+
+	t.Run("should build a scope for the credential and each of the other scopes", func(t *testing.T) {
+		request, err := service.CreateQueryRequest(ctx, &ports.CreateQueryRequestRequest{
+			DID:               holder,
+			Schema:            "https://schemas.com/KYCAgeCredential-v3.json",
+			Type:              "KYCAgeCredential",
+			CredentialSubject: map[string]any{"birthday": map[string]any{"$lt": 20050101}},
+			AllowedIssuers:    []string{issuer},
+			Scopes: []ports.QueryRequestScope{{
+				CircuitID:         string(circuits.AtomicQuerySigV2CircuitID),
+				Context:           kycContext,
+				Type:              "KYCCountryOfResidenceCredential",
+				CredentialSubject: map[string]any{"countryCode": map[string]any{"$nin": []int{840}}},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, holder.String(), request.From, "the request is sent from the profile of its DID")
+		assert.Equal(t, holder.String(), request.To)
+		assert.True(t, strings.HasPrefix(request.Body.CallbackURL, "https://holder.com/callback?"), request.Body.CallbackURL)
+		require.Len(t, request.Body.Scope, 2)
+
+		credential := request.Body.Scope[0]
+		assert.Equal(t, authScopeID, credential.ID)
+		assert.Equal(t, string(circuits.AtomicQueryMTPV2OnChainCircuitID), credential.CircuitID)
+		assert.Equal(t, []string{issuer}, credential.Query["allowedIssuers"])
+		assert.Equal(t, "https://schemas.com/KYCAgeCredential-v3.jsonld", credential.Query["context"])
+		assert.Equal(t, "KYCAgeCredential", credential.Query["type"])
+
+		residence := request.Body.Scope[1]
+		assert.Equal(t, authScopeID+1, residence.ID)
+		assert.Equal(t, string(circuits.AtomicQuerySigV2CircuitID), residence.CircuitID)
+		assert.Equal(t, []string{"*"}, residence.Query["allowedIssuers"])
+		assert.Equal(t, kycContext, residence.Query["context"])
+
+		sessionID, err := domain.CallbackSession(request)
+		require.NoError(t, err)
+		stored, err := repo.requests[sessionID].GetAuthorizationRequestMessage()
+		require.NoError(t, err)
+		require.Len(t, stored.Body.Scope, 2)
+		assert.NotEqual(t, stored.Body.Scope[0].ID, stored.Body.Scope[1].ID)
+	})
+
+	t.Run("should prove the credential with the circuit of the request", func(t *testing.T) {
+		request, err := service.CreateAuthorizationRequestMessage(ctx, &ports.CreateQueryRequestRequest{
+			DID:       holder,
+			Schema:    "https://schemas.com/KYCAgeCredential-v3.json",
+			Type:      "KYCAgeCredential",
+			CircuitID: string(circuits.AtomicQuerySigV2OnChainCircuitID),
+		})
+		require.NoError(t, err)
+		require.Len(t, request.Body.Scope, 1)
+		assert.Equal(t, string(circuits.AtomicQuerySigV2OnChainCircuitID), request.Body.Scope[0].CircuitID)
+	})
+
+	t.Run("should not build a scope without type", func(t *testing.T) {
+		_, err := service.CreateQueryRequest(ctx, &ports.CreateQueryRequestRequest{
+			DID:    holder,
+			Schema: "https://schemas.com/KYCAgeCredential-v3.json",
+			Type:   "KYCAgeCredential",
+			Scopes: []ports.QueryRequestScope{{CircuitID: string(circuits.AtomicQueryMTPV2CircuitID), Context: kycContext}},
+		})
+		assert.ErrorIs(t, err, ErrQueryRequestInvalid)
+	})
+
+	t.Run("should not build a scope with several predicates", func(t *testing.T) {
+		_, err := service.CreateQueryRequest(ctx, &ports.CreateQueryRequestRequest{
+			DID:    holder,
+			Schema: "https://schemas.com/KYCAgeCredential-v3.json",
+			Type:   "KYCAgeCredential",
+			CredentialSubject: map[string]any{
+				"birthday":     map[string]any{"$lt": 20050101},
+				"documentType": map[string]any{"$eq": 2},
+			},
+		})
+		assert.ErrorIs(t, err, ErrQueryRequestInvalid)
+	})
+}
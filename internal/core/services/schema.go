@@ -29,7 +29,7 @@ func NewSchema(lf loader.Factory) ports.SchemaService {
 
 // LoadSchema loads schema from url
 func (s *schema) LoadSchema(ctx context.Context, url string) (jsonSuite.Schema, error) {
-	schemaBytes, _, err := s.load(ctx, url)
+	schemaBytes, err := s.LoadRawSchema(ctx, url)
 	if err != nil {
 		return jsonSuite.Schema{}, err
 	}
@@ -40,21 +40,26 @@ func (s *schema) LoadSchema(ctx context.Context, url string) (jsonSuite.Schema,
 	return schema, err
 }
 
+// LoadRawSchema loads the json schema document from url
+func (s *schema) LoadRawSchema(ctx context.Context, url string) ([]byte, error) {
+	schemaBytes, _, err := s.load(ctx, url)
+	return schemaBytes, err
+}
+
 // Process data and schema and create Index and Value slots
 func (s *schema) Process(ctx context.Context, schemaURL, credentialType string, credential verifiable.W3CCredential, options *processor.CoreClaimOptions) (*core.Claim, error) {
-	var parser processor.Parser
-	var validator processor.Validator
-	pr := &processor.Processor{}
-
-	validator = jsonSuite.Validator{}
-	parser = jsonSuite.Parser{}
-
-	pr = processor.InitProcessorOptions(pr, processor.WithValidator(validator), processor.WithParser(parser), processor.WithSchemaLoader(s.loaderFactory(schemaURL)))
-
-	schema, _, err := pr.Load(ctx)
+	schemaBytes, err := s.LoadRawSchema(ctx, schemaURL)
 	if err != nil {
 		return nil, err
 	}
+	return s.ProcessWithSchema(ctx, schemaBytes, credentialType, credential, options)
+}
+
+// ProcessWithSchema validates the credential data against the json schema document already loaded and creates
+// Index and Value slots
+func (s *schema) ProcessWithSchema(ctx context.Context, schema []byte, credentialType string, credential verifiable.W3CCredential, options *processor.CoreClaimOptions) (*core.Claim, error) {
+	pr := &processor.Processor{}
+	pr = processor.InitProcessorOptions(pr, processor.WithValidator(jsonSuite.Validator{}), processor.WithParser(jsonSuite.Parser{}))
 
 	jsonCredential, err := json.Marshal(credential)
 	if err != nil {
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+
+	"github.com/lastingasset/wallet-service/go-iden3-auth/pubsignals"
+	"github.com/lastingasset/wallet-service/go-iden3-auth/state"
+	"github.com/lastingasset/wallet-service/internal/config"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+var (
+	ErrStateNotConfirmed   = errors.New("state is not a confirmed state of the identity")                        // ErrStateNotConfirmed the identity is managed by the service but the state was never confirmed
+	ErrGlobalRootNotLocal  = errors.New("global state root cannot be resolved locally")                          // ErrGlobalRootNotLocal there is no chain resolver to resolve the global root
+	ErrIdentityStateRemote = errors.New("identity is not managed by this service and the chain has no resolver") // ErrIdentityStateRemote there is no chain resolver to resolve the identity state
+)
+
+type localStateResolver struct {
+	identityStateRepository ports.IdentityStateRepository
+	storage                 *db.Storage
+	fallback                pubsignals.StateResolver
+}
+
+// NewLocalStateResolver returns a state resolver that answers for the identities managed by this service
+// straight from their confirmed states. Other identities and global roots are resolved by fallback, which may be nil.
+func NewLocalStateResolver(identityStateRepository ports.IdentityStateRepository, storage *db.Storage, fallback pubsignals.StateResolver) pubsignals.StateResolver {
+	return &localStateResolver{
+		identityStateRepository: identityStateRepository,
+		storage:                 storage,
+		fallback:                fallback,
+	}
+}
+
+// NewStateResolvers returns the state resolver registry of the given chains, keyed by their "blockchain:network"
+// or "blockchain" prefix, plus a local only resolver for any other chain.
+// Every resolver answers for the identities of this service without calling the chain.
+func NewStateResolvers(chains map[string]config.VerifierChain, identityStateRepository ports.IdentityStateRepository, storage *db.Storage) map[string]pubsignals.StateResolver {
+	resolvers := make(map[string]pubsignals.StateResolver, len(chains)+1)
+	for prefix, chain := range chains {
+		resolvers[prefix] = NewLocalStateResolver(identityStateRepository, storage, state.NewETHResolver(chain.RPCUrl, chain.ContractAddress))
+	}
+	if _, ok := resolvers[pubsignals.AnyChain]; !ok {
+		resolvers[pubsignals.AnyChain] = NewLocalStateResolver(identityStateRepository, storage, nil)
+	}
+	return resolvers
+}
+
+// Resolve returns the resolved state of the identity with the given id
+func (r *localStateResolver) Resolve(ctx context.Context, id, st *big.Int) (*state.ResolvedState, error) {
+	coreID, err := core.IDFromInt(id)
+	if err != nil {
+		return nil, err
+	}
+	did, err := core.ParseDIDFromID(coreID)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := r.identityStateRepository.GetConfirmedStates(ctx, r.storage.Pgx, did)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		if r.fallback == nil {
+			return nil, ErrIdentityStateRemote
+		}
+		return r.fallback.Resolve(ctx, id, st)
+	}
+
+	isGenesis, err := state.CheckGenesisStateID(id, st)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := merkletree.NewHashFromBigInt(st)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range states {
+		if states[i].State == nil || *states[i].State != hash.Hex() {
+			continue
+		}
+		if i == len(states)-1 {
+			return &state.ResolvedState{State: st.String(), Latest: true, Genesis: isGenesis}, nil
+		}
+		return &state.ResolvedState{
+			State:               st.String(),
+			Genesis:             isGenesis,
+			TransitionTimestamp: transitionTimestamp(states[i+1]),
+		}, nil
+	}
+
+	return nil, ErrStateNotConfirmed
+}
+
+// ResolveGlobalRoot resolves the global root with the fallback resolver, the service does not keep the global tree
+func (r *localStateResolver) ResolveGlobalRoot(ctx context.Context, st *big.Int) (*state.ResolvedState, error) {
+	if r.fallback == nil {
+		return nil, ErrGlobalRootNotLocal
+	}
+	return r.fallback.ResolveGlobalRoot(ctx, st)
+}
+
+// transitionTimestamp returns when the given state replaced the previous one
+func transitionTimestamp(replacedBy domain.IdentityState) int64 {
+	if replacedBy.BlockTimestamp != nil {
+		return int64(*replacedBy.BlockTimestamp)
+	}
+	return replacedBy.ModifiedAt.Unix()
+}
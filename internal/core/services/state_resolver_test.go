@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-merkletree-sql/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/go-iden3-auth/state"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+type identityStateRepositoryMock struct {
+	ports.IdentityStateRepository
+	confirmed map[string][]domain.IdentityState
+}
+
+func (r *identityStateRepositoryMock) GetConfirmedStates(_ context.Context, _ db.Querier, identifier *core.DID) ([]domain.IdentityState, error) {
+	return r.confirmed[identifier.String()], nil
+}
+
+type stateResolverMock struct {
+	resolved *state.ResolvedState
+}
+
+func (r *stateResolverMock) Resolve(_ context.Context, _, _ *big.Int) (*state.ResolvedState, error) {
+	return r.resolved, nil
+}
+
+func (r *stateResolverMock) ResolveGlobalRoot(_ context.Context, _ *big.Int) (*state.ResolvedState, error) {
+	return r.resolved, nil
+}
+
+func TestLocalStateResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	did, err := core.ParseDID(kycIssuer)
+	require.NoError(t, err)
+	remote, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+
+	stateHex := func(st int64) *string {
+		hash, err := merkletree.NewHashFromBigInt(big.NewInt(st))
+		require.NoError(t, err)
+		hex := hash.Hex()
+		return &hex
+	}
+	blockTimestamp := func(at time.Time) *int {
+		timestamp := int(at.Unix())
+		return &timestamp
+	}
+
+	recently := time.Now().Add(-time.Minute)
+	longAgo := time.Now().Add(-24 * time.Hour)
+	repo := &identityStateRepositoryMock{confirmed: map[string][]domain.IdentityState{
+		did.String(): {
+			{State: stateHex(1)},
+			{State: stateHex(2), BlockTimestamp: blockTimestamp(longAgo)},
+			{State: stateHex(3), BlockTimestamp: blockTimestamp(recently)},
+		},
+	}}
+	resolver := NewLocalStateResolver(repo, &db.Storage{}, nil)
+
+	t.Run("should resolve the latest state", func(t *testing.T) {
+		resolved, err := resolver.Resolve(ctx, did.ID.BigInt(), big.NewInt(3))
+		require.NoError(t, err)
+		assert.True(t, resolved.Latest)
+		assert.Equal(t, "3", resolved.State)
+		assert.Zero(t, resolved.TransitionTimestamp)
+	})
+
+	t.Run("should resolve a state replaced within the delay with the time it was replaced", func(t *testing.T) {
+		resolved, err := resolver.Resolve(ctx, did.ID.BigInt(), big.NewInt(2))
+		require.NoError(t, err)
+		assert.False(t, resolved.Latest)
+		assert.Equal(t, recently.Unix(), resolved.TransitionTimestamp)
+		assert.Less(t, time.Since(time.Unix(resolved.TransitionTimestamp, 0)), time.Hour)
+	})
+
+	t.Run("should resolve a state replaced long ago with the time it was replaced", func(t *testing.T) {
+		resolved, err := resolver.Resolve(ctx, did.ID.BigInt(), big.NewInt(1))
+		require.NoError(t, err)
+		assert.False(t, resolved.Latest)
+		assert.Equal(t, longAgo.Unix(), resolved.TransitionTimestamp)
+	})
+
+	t.Run("should not resolve an unknown state of a local identity", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, did.ID.BigInt(), big.NewInt(4))
+		assert.ErrorIs(t, err, ErrStateNotConfirmed)
+	})
+
+	t.Run("should not resolve a remote identity without fallback", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, remote.ID.BigInt(), big.NewInt(1))
+		assert.ErrorIs(t, err, ErrIdentityStateRemote)
+	})
+
+	t.Run("should resolve a remote identity with the fallback", func(t *testing.T) {
+		fallback := &stateResolverMock{resolved: &state.ResolvedState{State: "1", Latest: true}}
+		resolved, err := NewLocalStateResolver(repo, &db.Storage{}, fallback).Resolve(ctx, remote.ID.BigInt(), big.NewInt(1))
+		require.NoError(t, err)
+		assert.Equal(t, fallback.resolved, resolved)
+	})
+
+	t.Run("should not resolve the global root without fallback", func(t *testing.T) {
+		_, err := resolver.ResolveGlobalRoot(ctx, big.NewInt(1))
+		assert.ErrorIs(t, err, ErrGlobalRootNotLocal)
+	})
+}
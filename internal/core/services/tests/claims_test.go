@@ -0,0 +1,302 @@
+package services_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/common"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/core/services"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/loader"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
+)
+
+func Test_updateClaim(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+	claimsService := services.NewClaim(claimsRepo, services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex)), identityService, mtService, identityStateRepo, repositories.NewHolderCredentials(), storage, services.ClaimCfg{Host: "https://host.com"})
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	subject := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	createClaim := func(t *testing.T, updatable bool) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           subject,
+			"birthday":     19960424,
+			"documentType": 2,
+		}
+		return ports.NewCreateClaimRequest(did, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, common.ToPointer(updatable))
+	}
+	newSubject := map[string]any{
+		"birthday":     19960425,
+		"documentType": 2,
+	}
+
+	t.Run("should bump the version of an updatable claim", func(t *testing.T) {
+		previous, err := claimsService.CreateClaim(ctx, createClaim(t, true))
+		require.NoError(t, err)
+
+		updated, err := claimsService.UpdateClaim(ctx, ports.NewUpdateClaimRequest(did, previous.ID, newSubject, nil))
+		require.NoError(t, err)
+		assert.Equal(t, previous.ID, updated.ID)
+		assert.Equal(t, previous.RevNonce, updated.RevNonce)
+		assert.Equal(t, previous.Version+1, updated.Version)
+		assert.Nil(t, updated.Replaces)
+		assert.Equal(t, subject, updated.OtherIdentifier)
+
+		stored, err := claimsService.GetByID(ctx, did, previous.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Revoked)
+		assert.Equal(t, updated.Version, stored.Version)
+	})
+
+	t.Run("should revoke a non updatable claim and issue its replacement", func(t *testing.T) {
+		previous, err := claimsService.CreateClaim(ctx, createClaim(t, false))
+		require.NoError(t, err)
+
+		replacement, err := claimsService.UpdateClaim(ctx, ports.NewUpdateClaimRequest(did, previous.ID, newSubject, nil))
+		require.NoError(t, err)
+		assert.NotEqual(t, previous.ID, replacement.ID)
+		assert.NotEqual(t, previous.RevNonce, replacement.RevNonce)
+		require.NotNil(t, replacement.Replaces)
+		assert.Equal(t, previous.ID, *replacement.Replaces)
+		assert.Equal(t, subject, replacement.OtherIdentifier)
+
+		revoked, err := claimsService.GetByID(ctx, did, previous.ID)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+
+		stored, err := claimsService.GetByID(ctx, did, replacement.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Revoked)
+		require.NotNil(t, stored.Replaces)
+		assert.Equal(t, previous.ID, *stored.Replaces)
+
+		t.Run("should not update the replaced claim again", func(t *testing.T) {
+			_, err := claimsService.UpdateClaim(ctx, ports.NewUpdateClaimRequest(did, previous.ID, newSubject, nil))
+			assert.ErrorIs(t, err, services.ErrClaimUpdateInvalid)
+		})
+	})
+
+	t.Run("should not update the subject of a claim", func(t *testing.T) {
+		previous, err := claimsService.CreateClaim(ctx, createClaim(t, false))
+		require.NoError(t, err)
+
+		otherSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qD6cqGpLX2dibdFuKfrPxGiybi3wKa8RbR4onw49H",
+			"birthday":     19960425,
+			"documentType": 2,
+		}
+		_, err = claimsService.UpdateClaim(ctx, ports.NewUpdateClaimRequest(did, previous.ID, otherSubject, nil))
+		assert.ErrorIs(t, err, services.ErrClaimUpdateInvalid)
+
+		stored, err := claimsService.GetByID(ctx, did, previous.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Revoked)
+	})
+}
+
+// claimsRepositoryFailingSave stores the claims until the failAt-th one, which cannot be saved
+type claimsRepositoryFailingSave struct {
+	ports.ClaimsRepository
+	failAt int
+	saved  int
+}
+
+func (r *claimsRepositoryFailingSave) Save(ctx context.Context, conn db.Querier, claim *domain.Claim) (uuid.UUID, error) {
+	r.saved++
+	if r.saved == r.failAt {
+		return uuid.Nil, errors.New("cannot save the claim")
+	}
+	return r.ClaimsRepository.Save(ctx, conn, claim)
+}
+
+// missingSchemaLoader is the loader of a schema that cannot be found
+type missingSchemaLoader struct{}
+
+func (missingSchemaLoader) Load(_ context.Context) ([]byte, string, error) {
+	return nil, "", errors.New("schema not found")
+}
+
+func Test_createClaimsBatch(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	missingSchema := "https://schemas.example.com/missing.json"
+	loads := make(map[string]int)
+	loaderFactory := func(url string) loader.Loader {
+		loads[url]++
+		if url == missingSchema {
+			return missingSchemaLoader{}
+		}
+		return loader.CachedFactory(loader.HTTPFactory, cachex)(url)
+	}
+	newClaimsService := func(claimsRepo ports.ClaimsRepository) ports.ClaimsService {
+		return services.NewClaim(claimsRepo, services.NewSchema(loaderFactory), identityService, mtService, identityStateRepo, repositories.NewHolderCredentials(), storage, services.ClaimCfg{Host: "https://host.com"})
+	}
+	claimsService := newClaimsService(claimsRepo)
+
+	newIdentity := func(t *testing.T) *core.DID {
+		identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+		require.NoError(t, err)
+		did, err := core.ParseDID(identity.Identifier)
+		require.NoError(t, err)
+		return did
+	}
+	claimRequest := func(schema string, birthday int) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ",
+			"birthday":     birthday,
+			"documentType": 2,
+		}
+		return ports.NewCreateClaimRequest(nil, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil)
+	}
+	issuedClaims := func(t *testing.T, did *core.DID) int {
+		claims, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, &ports.Filter{})
+		require.NoError(t, err)
+		return len(claims)
+	}
+
+	t.Run("should not issue an empty batch", func(t *testing.T) {
+		_, err := claimsService.CreateClaimsBatch(ctx, newIdentity(t), nil, nil)
+		assert.ErrorIs(t, err, services.ErrClaimsBatchInvalid)
+	})
+
+	t.Run("should not issue a batch larger than the limit", func(t *testing.T) {
+		reqs := make([]*ports.CreateClaimRequest, services.MaxClaimsBatchSize+1)
+		for i := range reqs {
+			reqs[i] = claimRequest(schema, 19960424)
+		}
+		_, err := claimsService.CreateClaimsBatch(ctx, newIdentity(t), reqs, nil)
+		assert.ErrorIs(t, err, services.ErrClaimsBatchInvalid)
+	})
+
+	t.Run("should issue the valid claims and report the error of the others", func(t *testing.T) {
+		did := newIdentity(t)
+		before := issuedClaims(t, did)
+		for url := range loads {
+			delete(loads, url)
+		}
+
+		results, err := claimsService.CreateClaimsBatch(ctx, did, []*ports.CreateClaimRequest{
+			claimRequest(schema, 19960424),
+			claimRequest("not a url", 19960424),
+			claimRequest(missingSchema, 19960424),
+			claimRequest(schema, 19960425),
+			claimRequest(missingSchema, 19960425),
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+
+		for _, i := range []int{0, 3} {
+			require.NoError(t, results[i].Err)
+			require.NotNil(t, results[i].Claim)
+			stored, err := claimsService.GetByID(ctx, did, results[i].Claim.ID)
+			require.NoError(t, err)
+			assert.Nil(t, stored.IdentityState)
+		}
+		assert.ErrorIs(t, results[1].Err, services.ErrMalformedURL)
+		assert.ErrorIs(t, results[2].Err, services.ErrLoadingSchema)
+		assert.ErrorIs(t, results[4].Err, services.ErrLoadingSchema)
+		for _, i := range []int{1, 2, 4} {
+			assert.Nil(t, results[i].Claim)
+		}
+		assert.Equal(t, before+2, issuedClaims(t, did))
+
+		// each schema is loaded once for the whole batch, even the one that cannot be loaded
+		assert.Equal(t, map[string]int{schema: 1, missingSchema: 1}, loads)
+	})
+
+	t.Run("should not store any claim of the batch when one cannot be stored", func(t *testing.T) {
+		did := newIdentity(t)
+		before := issuedClaims(t, did)
+
+		failingService := newClaimsService(&claimsRepositoryFailingSave{ClaimsRepository: claimsRepo, failAt: 2})
+		_, err := failingService.CreateClaimsBatch(ctx, did, []*ports.CreateClaimRequest{
+			claimRequest(schema, 19960424),
+			claimRequest(schema, 19960425),
+		}, nil)
+		require.Error(t, err)
+		assert.Equal(t, before, issuedClaims(t, did))
+	})
+}
+
+func Test_createClaim(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+	newClaimsService := func(holderCredentialRepo ports.HolderCredentialRepository) ports.ClaimsService {
+		return services.NewClaim(claimsRepo, services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex)), identityService, mtService, identityStateRepo, holderCredentialRepo, storage, services.ClaimCfg{Host: "https://host.com"})
+	}
+
+	newIdentity := func(t *testing.T) *core.DID {
+		identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+		require.NoError(t, err)
+		did, err := core.ParseDID(identity.Identifier)
+		require.NoError(t, err)
+		return did
+	}
+	issuer, holder := newIdentity(t), newIdentity(t)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	claimRequest := func(birthday int) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{
+			"id":           holder.String(),
+			"birthday":     birthday,
+			"documentType": 2,
+		}
+		return ports.NewCreateClaimRequest(issuer, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil)
+	}
+	issuedClaims := func(t *testing.T) int {
+		claims, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, issuer, &ports.Filter{})
+		require.NoError(t, err)
+		return len(claims)
+	}
+
+	t.Run("should store the credential of a holder of the service with the claim", func(t *testing.T) {
+		claim, err := newClaimsService(holderCredentialRepo).CreateClaim(ctx, claimRequest(19960424))
+		require.NoError(t, err)
+
+		held, err := holderCredentialRepo.GetAll(ctx, storage.Pgx, holder, &ports.HolderCredentialFilter{})
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(held))
+		for _, credential := range held {
+			ids = append(ids, credential.ID)
+		}
+		assert.Contains(t, ids, claim.ID)
+	})
+
+	t.Run("should not store the claim when the credential of the holder cannot be stored", func(t *testing.T) {
+		before := issuedClaims(t)
+		failingService := newClaimsService(&holderCredentialRepositoryFailingSave{HolderCredentialRepository: holderCredentialRepo, failAt: 1})
+		_, err := failingService.CreateClaim(ctx, claimRequest(19960425))
+		require.Error(t, err)
+		assert.Equal(t, before, issuedClaims(t))
+	})
+}
@@ -0,0 +1,303 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/core/services"
+	"github.com/lastingasset/wallet-service/internal/loader"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
+)
+
+func Test_credentialRequests(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+
+	missingSchema := "https://schemas.example.com/missing.json"
+	loaderFactory := func(url string) loader.Loader {
+		if url == missingSchema {
+			return missingSchemaLoader{}
+		}
+		return loader.CachedFactory(loader.HTTPFactory, cachex)(url)
+	}
+	claimsService := services.NewClaim(claimsRepo, services.NewSchema(loaderFactory), identityService, mtService, identityStateRepo, repositories.NewHolderCredentials(), storage, services.ClaimCfg{Host: "https://host.com"})
+	auditRepo := repositories.NewIssuanceAudit()
+	policyService := services.NewIssuancePolicies(repositories.NewIssuancePolicies(), auditRepo, storage)
+	credentialRequestService := services.NewCredentialRequests(repositories.NewCredentialRequests(), auditRepo, policyService, claimsService, identityService, storage, "https://host.com")
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	issuer, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+	holder, err := core.ParseDID("did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ")
+	require.NoError(t, err)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	agentRequest := func(t *testing.T, threadID string, schemaURL string) *ports.AgentRequest {
+		body, err := json.Marshal(protocol.CredentialIssuanceRequestMessageBody{
+			Schema: protocol.Schema{URL: schemaURL, Type: "KYCAgeCredential"},
+			Data:   json.RawMessage(`{"birthday": 19960424, "documentType": 2}`),
+		})
+		require.NoError(t, err)
+		return &ports.AgentRequest{
+			Body:      body,
+			ThreadID:  threadID,
+			IssuerDID: issuer,
+			UserDID:   holder,
+			Type:      protocol.CredentialIssuanceRequestMessageType,
+		}
+	}
+	// requested sends a new issuance request and returns the pending request it was queued as
+	requested := func(t *testing.T, schemaURL string) *domain.CredentialRequest {
+		threadID := uuid.NewString()
+		resp, err := credentialRequestService.Agent(ctx, agentRequest(t, threadID, schemaURL))
+		require.NoError(t, err)
+		require.Equal(t, protocol.CredentialIssuanceRequestStatusMessageType, resp.Type)
+		body, ok := resp.Body.(protocol.CredentialIssuanceRequestStatusMessageBody)
+		require.True(t, ok)
+		assert.Equal(t, string(domain.CredentialRequestPending), body.Status)
+
+		id, err := uuid.Parse(body.ID)
+		require.NoError(t, err)
+		req, err := credentialRequestService.GetByID(ctx, issuer, id)
+		require.NoError(t, err)
+		assert.Equal(t, threadID, req.ThreadID)
+		return req
+	}
+
+	t.Run("should issue the approved request and offer it in the thread", func(t *testing.T) {
+		req := requested(t, schema)
+
+		offer, err := credentialRequestService.Approve(ctx, issuer, req.ID, "admin")
+		require.NoError(t, err)
+		require.Len(t, offer.Body.Credentials, 1)
+		assert.Equal(t, req.ThreadID, offer.ThreadID)
+		assert.Equal(t, holder.String(), offer.To)
+
+		approved, err := credentialRequestService.GetByID(ctx, issuer, req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CredentialRequestApproved, approved.Status)
+		require.NotNil(t, approved.ClaimID)
+		assert.Equal(t, approved.ClaimID.String(), offer.Body.Credentials[0].ID)
+		_, err = claimsService.GetByID(ctx, issuer, *approved.ClaimID)
+		require.NoError(t, err)
+
+		resp, err := credentialRequestService.Agent(ctx, agentRequest(t, req.ThreadID, schema))
+		require.NoError(t, err)
+		assert.Equal(t, protocol.CredentialOfferMessageType, resp.Type)
+
+		t.Run("should not decide the approved request again", func(t *testing.T) {
+			_, err := credentialRequestService.Approve(ctx, issuer, req.ID, "admin")
+			assert.ErrorIs(t, err, services.ErrCredentialRequestDecided)
+			_, err = credentialRequestService.Reject(ctx, issuer, req.ID, "admin", "")
+			assert.ErrorIs(t, err, services.ErrCredentialRequestDecided)
+		})
+	})
+
+	t.Run("should issue a single claim when the request is approved concurrently", func(t *testing.T) {
+		req := requested(t, schema)
+		before, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, issuer, &ports.Filter{})
+		require.NoError(t, err)
+
+		const approvals = 5
+		errs := make([]error, approvals)
+		var wg sync.WaitGroup
+		for i := 0; i < approvals; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = credentialRequestService.Approve(ctx, issuer, req.ID, "admin")
+			}(i)
+		}
+		wg.Wait()
+
+		approved := 0
+		for _, err := range errs {
+			if err == nil {
+				approved++
+				continue
+			}
+			assert.ErrorIs(t, err, services.ErrCredentialRequestDecided)
+		}
+		assert.Equal(t, 1, approved)
+
+		after, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, issuer, &ports.Filter{})
+		require.NoError(t, err)
+		assert.Len(t, after, len(before)+1)
+	})
+
+	t.Run("should keep the request pending when its claim cannot be issued", func(t *testing.T) {
+		req := requested(t, missingSchema)
+
+		_, err := credentialRequestService.Approve(ctx, issuer, req.ID, "admin")
+		assert.ErrorIs(t, err, services.ErrLoadingSchema)
+
+		pending, err := credentialRequestService.GetByID(ctx, issuer, req.ID)
+		require.NoError(t, err)
+		assert.True(t, pending.IsPending())
+		assert.Nil(t, pending.ClaimID)
+	})
+
+	t.Run("should tell the holder the reason of the rejection", func(t *testing.T) {
+		req := requested(t, schema)
+
+		rejected, err := credentialRequestService.Reject(ctx, issuer, req.ID, "admin", "the data could not be checked")
+		require.NoError(t, err)
+		assert.Equal(t, domain.CredentialRequestRejected, rejected.Status)
+
+		resp, err := credentialRequestService.Agent(ctx, agentRequest(t, req.ThreadID, schema))
+		require.NoError(t, err)
+		body, ok := resp.Body.(protocol.CredentialIssuanceRequestStatusMessageBody)
+		require.True(t, ok)
+		assert.Equal(t, string(domain.CredentialRequestRejected), body.Status)
+		assert.Equal(t, "the data could not be checked", body.Reason)
+
+		_, err = credentialRequestService.Approve(ctx, issuer, req.ID, "admin")
+		assert.ErrorIs(t, err, services.ErrCredentialRequestDecided)
+	})
+}
+
+func Test_submitClaims(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+	auditRepo := repositories.NewIssuanceAudit()
+	policyService := services.NewIssuancePolicies(repositories.NewIssuancePolicies(), auditRepo, storage)
+	newCredentialRequestService := func(claimsRepo ports.ClaimsRepository) ports.CredentialRequestService {
+		claimsService := services.NewClaim(claimsRepo, services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex)), identityService, mtService, identityStateRepo, repositories.NewHolderCredentials(), storage, services.ClaimCfg{Host: "https://host.com"})
+		return services.NewCredentialRequests(repositories.NewCredentialRequests(), auditRepo, policyService, claimsService, identityService, storage, "https://host.com")
+	}
+	credentialRequestService := newCredentialRequestService(claimsRepo)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	holder := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	// newIssuer creates an issuer with a policy on the KYCAgeCredential claims born before 2000
+	newIssuer := func(t *testing.T, action domain.IssuancePolicyAction) *core.DID {
+		identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+		require.NoError(t, err)
+		issuer, err := core.ParseDID(identity.Identifier)
+		require.NoError(t, err)
+		schemaType := "KYCAgeCredential"
+		constraints := map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20000101}}
+		_, err = policyService.Create(ctx, ports.NewCreateIssuancePolicyRequest(issuer, &schemaType, nil, &constraints, action, nil))
+		require.NoError(t, err)
+		return issuer
+	}
+	claimRequest := func(issuer *core.DID, birthday int) *ports.CreateClaimRequest {
+		credentialSubject := map[string]any{"id": holder, "birthday": birthday, "documentType": 2}
+		return ports.NewCreateClaimRequest(issuer, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil)
+	}
+	stored := func(t *testing.T, issuer *core.DID) (requests []*domain.CredentialRequest, audit []*domain.IssuanceAudit) {
+		requests, err := credentialRequestService.GetAll(ctx, issuer, nil)
+		require.NoError(t, err)
+		audit, err = policyService.GetAudit(ctx, issuer, nil)
+		require.NoError(t, err)
+		return requests, audit
+	}
+
+	t.Run("should issue right away the claims no policy holds", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyManual)
+		held, err := credentialRequestService.Submit(ctx, claimRequest(issuer, 20010101), "admin")
+		require.NoError(t, err)
+		assert.Nil(t, held)
+
+		requests, audit := stored(t, issuer)
+		assert.Empty(t, requests)
+		assert.Empty(t, audit)
+	})
+
+	t.Run("should issue right away the claims an auto approve policy matches", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyAutoApprove)
+		held, err := credentialRequestService.Submit(ctx, claimRequest(issuer, 19960424), "admin")
+		require.NoError(t, err)
+		assert.Nil(t, held)
+
+		requests, _ := stored(t, issuer)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("should hold for approval the claims a manual policy matches", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyManual)
+		held, err := credentialRequestService.Submit(ctx, claimRequest(issuer, 19960424), "admin")
+		require.NoError(t, err)
+		require.NotNil(t, held)
+		assert.True(t, held.IsPending())
+		assert.Equal(t, holder, held.Holder)
+
+		requests, audit := stored(t, issuer)
+		require.Len(t, requests, 1)
+		assert.Equal(t, held.ID, requests[0].ID)
+		require.Len(t, audit, 1)
+		assert.Equal(t, domain.IssuanceAuditRequested, audit[0].Action)
+		assert.Equal(t, "admin", audit[0].Actor)
+		require.NotNil(t, audit[0].RequestID)
+		assert.Equal(t, held.ID, *audit[0].RequestID)
+	})
+
+	t.Run("should deny the claims a deny policy matches", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyDeny)
+		_, err := credentialRequestService.Submit(ctx, claimRequest(issuer, 19960424), "admin")
+		assert.ErrorIs(t, err, services.ErrIssuanceDenied)
+
+		requests, audit := stored(t, issuer)
+		assert.Empty(t, requests)
+		require.Len(t, audit, 1)
+		assert.Equal(t, domain.IssuanceAuditDenied, audit[0].Action)
+		assert.Equal(t, domain.IssuanceAuditActorPolicy, audit[0].Actor)
+		assert.NotNil(t, audit[0].PolicyID)
+	})
+
+	t.Run("should issue, hold and deny the claims of a batch", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyManual)
+		results, err := credentialRequestService.SubmitBatch(ctx, issuer, []*ports.CreateClaimRequest{
+			claimRequest(nil, 20010101),
+			claimRequest(nil, 19960424),
+		}, "admin")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		require.NotNil(t, results[0].Claim)
+		assert.Nil(t, results[0].Request)
+		require.NoError(t, results[1].Err)
+		assert.Nil(t, results[1].Claim)
+		require.NotNil(t, results[1].Request)
+
+		requests, audit := stored(t, issuer)
+		require.Len(t, requests, 1)
+		assert.Equal(t, results[1].Request.ID, requests[0].ID)
+		assert.Len(t, audit, 1)
+	})
+
+	t.Run("should not store the held requests of a batch that cannot be stored", func(t *testing.T) {
+		issuer := newIssuer(t, domain.IssuancePolicyManual)
+		failingService := newCredentialRequestService(&claimsRepositoryFailingSave{ClaimsRepository: claimsRepo, failAt: 2})
+		_, err := failingService.SubmitBatch(ctx, issuer, []*ports.CreateClaimRequest{
+			claimRequest(nil, 19960424),
+			claimRequest(nil, 20010101),
+			claimRequest(nil, 20020101),
+		}, "admin")
+		require.Error(t, err)
+
+		requests, audit := stored(t, issuer)
+		assert.Empty(t, requests)
+		assert.Empty(t, audit)
+	})
+}
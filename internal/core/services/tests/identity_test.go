@@ -27,10 +27,12 @@ func Test_identity_UpdateState(t *testing.T) {
 	claimsRepo := repositories.NewClaims()
 	mtRepo := repositories.NewIdentityMerkleTreeRepository()
 	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
 	revocationRepository := repositories.NewRevocation()
+	profileRepo := repositories.NewProfiles()
 	mtService := services.NewIdentityMerkleTrees(mtRepo)
 	rhsp := reverse_hash.NewRhsPublisher(nil, false)
-	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, storage, rhsp)
+	identityService := services.NewIdentity(keyStore, identityRepo, mtRepo, identityStateRepo, mtService, claimsRepo, revocationRepository, profileRepo, storage, rhsp)
 	schemaService := services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex))
 
 	claimsConf := services.ClaimCfg{
@@ -43,6 +45,7 @@ func Test_identity_UpdateState(t *testing.T) {
 		identityService,
 		mtService,
 		identityStateRepo,
+		holderCredentialRepo,
 		storage,
 		claimsConf,
 	)
@@ -69,7 +72,7 @@ func Test_identity_UpdateState(t *testing.T) {
 	expiration := int64(12345)
 
 	merklizedRootPosition := "index"
-	_, err = claimsService.CreateClaim(context.Background(), ports.NewCreateClaimRequest(did, schema, credentialSubject, &expiration, typeC, nil, nil, &merklizedRootPosition))
+	_, err = claimsService.CreateClaim(context.Background(), ports.NewCreateClaimRequest(did, schema, credentialSubject, &expiration, typeC, nil, nil, &merklizedRootPosition, nil))
 	assert.NoError(t, err)
 
 	type testConfig struct {
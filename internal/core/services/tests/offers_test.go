@@ -0,0 +1,223 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/iden3/go-iden3-crypto/babyjub"
+	"github.com/iden3/go-iden3-crypto/poseidon"
+	"github.com/iden3/go-schema-processor/verifiable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/iden3comm"
+	"github.com/lastingasset/wallet-service/iden3comm/packers"
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/core/services"
+	"github.com/lastingasset/wallet-service/internal/db"
+	"github.com/lastingasset/wallet-service/internal/loader"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
+)
+
+// holderCredentialRepositoryFailingSave stores the credentials until the failAt-th one, which cannot be saved
+type holderCredentialRepositoryFailingSave struct {
+	ports.HolderCredentialRepository
+	failAt int
+	saved  int
+}
+
+func (r *holderCredentialRepositoryFailingSave) Save(ctx context.Context, conn db.Querier, credential *domain.HolderCredential) (uuid.UUID, error) {
+	r.saved++
+	if r.saved == r.failAt {
+		return uuid.Nil, errors.New("cannot save the credential")
+	}
+	return r.HolderCredentialRepository.Save(ctx, conn, credential)
+}
+
+// zkpPackerMock sends the fetch requests as plain messages, so no proof is generated
+type zkpPackerMock struct{}
+
+func (p *zkpPackerMock) Pack(payload []byte, _ iden3comm.PackerParams) ([]byte, error) {
+	return payload, nil
+}
+
+func (p *zkpPackerMock) Unpack(envelope []byte) (*iden3comm.BasicMessage, error) {
+	var msg iden3comm.BasicMessage
+	err := json.Unmarshal(envelope, &msg)
+	return &msg, err
+}
+
+func (p *zkpPackerMock) MediaType() iden3comm.MediaType {
+	return packers.MediaTypeZKPMessage
+}
+
+func Test_offers(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+	schemaService := services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex))
+	claimsService := services.NewClaim(claimsRepo, schemaService, identityService, mtService, identityStateRepo, holderCredentialRepo, storage, services.ClaimCfg{Host: "https://host.com"})
+
+	packageManager := iden3comm.NewPackageManager()
+	require.NoError(t, packageManager.RegisterPackers(&packers.PlainMessagePacker{}, &zkpPackerMock{}))
+	offerService := services.NewOffers(holderCredentialRepo, identityService, packageManager, storage, services.NewStateResolvers(nil, identityStateRepo, storage))
+
+	issuerIdentity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	issuer, err := core.ParseDID(issuerIdentity.Identifier)
+	require.NoError(t, err)
+	holderIdentity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	holder, err := core.ParseDID(holderIdentity.Identifier)
+	require.NoError(t, err)
+
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	credentialSubject := map[string]any{
+		"id":           holder.String(),
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	claim, err := claimsService.CreateClaim(ctx, ports.NewCreateClaimRequest(issuer, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil))
+	require.NoError(t, err)
+
+	// issued returns a copy of the issued credential with a single signature proof
+	issued := func(t *testing.T) (*verifiable.W3CCredential, *verifiable.BJJSignatureProof2021) {
+		credential, err := schemaService.FromClaimModelToW3CCredential(*claim)
+		require.NoError(t, err)
+		require.Len(t, credential.Proof, 1)
+		signatureProof, ok := credential.Proof[0].(*verifiable.BJJSignatureProof2021)
+		require.True(t, ok)
+		return credential, signatureProof
+	}
+
+	// offer returns an offer of the given credentials, of an agent that answers each fetch request with the next of them
+	offer := func(t *testing.T, credentials ...*verifiable.W3CCredential) protocol.CredentialsOfferMessage {
+		threadID := uuid.NewString()
+		var mu sync.Mutex
+		fetched := 0
+		agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			credential := credentials[fetched%len(credentials)]
+			fetched++
+			mu.Unlock()
+			resp, err := json.Marshal(protocol.CredentialIssuanceMessage{
+				ID:       uuid.NewString(),
+				Typ:      packers.MediaTypePlainMessage,
+				Type:     protocol.CredentialIssuanceResponseMessageType,
+				ThreadID: threadID,
+				Body:     protocol.IssuanceMessageBody{Credential: *credential},
+				From:     issuer.String(),
+				To:       holder.String(),
+			})
+			require.NoError(t, err)
+			_, _ = w.Write(resp)
+		}))
+		t.Cleanup(agent.Close)
+
+		offered := make([]protocol.CredentialOffer, 0, len(credentials))
+		for _, credential := range credentials {
+			offered = append(offered, protocol.CredentialOffer{ID: credential.ID})
+		}
+		return protocol.CredentialsOfferMessage{
+			ID:       uuid.NewString(),
+			Type:     protocol.CredentialOfferMessageType,
+			ThreadID: threadID,
+			Body: protocol.CredentialsOfferMessageBody{
+				URL:         agent.URL,
+				Credentials: offered,
+			},
+			From: issuer.String(),
+			To:   holder.String(),
+		}
+	}
+
+	t.Run("should accept a credential signed with an auth claim of the issuer state", func(t *testing.T) {
+		credential, _ := issued(t)
+		held, err := offerService.Accept(ctx, ports.NewAcceptOfferRequest(holder, offer(t, credential)))
+		require.NoError(t, err)
+		require.Len(t, held, 1)
+		assert.Equal(t, holder.String(), held[0].Holder)
+		assert.Equal(t, issuer.String(), held[0].Issuer)
+	})
+
+	t.Run("should store none of the credentials of an offer if any of them cannot be stored", func(t *testing.T) {
+		credential, _ := issued(t)
+		other, err := claimsService.CreateClaim(ctx, ports.NewCreateClaimRequest(issuer, schema, map[string]any{
+			"id":           holder.String(),
+			"birthday":     19970101,
+			"documentType": 3,
+		}, nil, "KYCAgeCredential", nil, nil, nil, nil))
+		require.NoError(t, err)
+		otherCredential, err := schemaService.FromClaimModelToW3CCredential(*other)
+		require.NoError(t, err)
+
+		failingRepo := &holderCredentialRepositoryFailingSave{HolderCredentialRepository: holderCredentialRepo, failAt: 2}
+		failingService := services.NewOffers(failingRepo, identityService, packageManager, storage, services.NewStateResolvers(nil, identityStateRepo, storage))
+		_, err = failingService.Accept(ctx, ports.NewAcceptOfferRequest(holder, offer(t, otherCredential, credential)))
+		require.Error(t, err)
+
+		held, err := holderCredentialRepo.GetAll(ctx, storage.Pgx, holder, &ports.HolderCredentialFilter{})
+		require.NoError(t, err)
+		for _, h := range held {
+			assert.NotEqual(t, otherCredential.ID, h.CredentialID, "the credential saved before the failure must not be stored")
+		}
+	})
+
+	t.Run("should not accept a credential signed with an auth claim out of the issuer state", func(t *testing.T) {
+		credential, signatureProof := issued(t)
+
+		forgedKey := babyjub.NewRandPrivKey()
+		forgedAuthClaim, err := core.NewClaim(core.AuthSchemaHash, core.WithIndexDataInts(forgedKey.Public().X, forgedKey.Public().Y))
+		require.NoError(t, err)
+		signatureProof.IssuerData.AuthCoreClaim, err = forgedAuthClaim.Hex()
+		require.NoError(t, err)
+
+		var coreClaim core.Claim
+		require.NoError(t, coreClaim.FromHex(signatureProof.CoreClaim))
+		hashIndex, hashValue, err := coreClaim.HiHv()
+		require.NoError(t, err)
+		message, err := poseidon.Hash([]*big.Int{hashIndex, hashValue})
+		require.NoError(t, err)
+		signature := forgedKey.SignPoseidon(message).Compress()
+		signatureProof.Signature = hex.EncodeToString(signature[:])
+
+		_, err = offerService.Accept(ctx, ports.NewAcceptOfferRequest(holder, offer(t, credential)))
+		assert.ErrorIs(t, err, services.ErrOfferCredentialProof)
+	})
+
+	t.Run("should not accept a credential with proofs of different core claims", func(t *testing.T) {
+		credential, signatureProof := issued(t)
+
+		// same index, so same HIndex, with another revocation nonce in the value
+		var otherClaim core.Claim
+		require.NoError(t, otherClaim.FromHex(signatureProof.CoreClaim))
+		otherClaim.SetRevocationNonce(otherClaim.GetRevocationNonce() + 1)
+		otherCoreClaim, err := otherClaim.Hex()
+		require.NoError(t, err)
+		credential.Proof = append(credential.Proof, &verifiable.Iden3SparseMerkleProof{
+			Type:       verifiable.Iden3SparseMerkleProofType,
+			IssuerData: signatureProof.IssuerData,
+			CoreClaim:  otherCoreClaim,
+			MTP:        signatureProof.IssuerData.MTP,
+		})
+
+		_, err = offerService.Accept(ctx, ports.NewAcceptOfferRequest(holder, offer(t, credential)))
+		assert.ErrorIs(t, err, services.ErrOfferCredentialProof)
+	})
+}
@@ -0,0 +1,99 @@
+package services_tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/core/services"
+	"github.com/lastingasset/wallet-service/internal/loader"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+	"github.com/lastingasset/wallet-service/pkg/reverse_hash"
+)
+
+func Test_presentations(t *testing.T) {
+	ctx := context.Background()
+	claimsRepo := repositories.NewClaims()
+	mtRepo := repositories.NewIdentityMerkleTreeRepository()
+	identityStateRepo := repositories.NewIdentityState()
+	holderCredentialRepo := repositories.NewHolderCredentials()
+	mtService := services.NewIdentityMerkleTrees(mtRepo)
+	identityService := services.NewIdentity(keyStore, repositories.NewIdentity(), mtRepo, identityStateRepo, mtService, claimsRepo, repositories.NewRevocation(), repositories.NewProfiles(), storage, reverse_hash.NewRhsPublisher(nil, false))
+	claimsService := services.NewClaim(claimsRepo, services.NewSchema(loader.CachedFactory(loader.HTTPFactory, cachex)), identityService, mtService, identityStateRepo, holderCredentialRepo, storage, services.ClaimCfg{Host: "https://host.com"})
+	stateResolvers := services.NewStateResolvers(nil, identityStateRepo, storage)
+	holderCredentialService := services.NewHolderCredentials(holderCredentialRepo, identityService, storage, stateResolvers)
+	presentationService := services.NewPresentations(holderCredentialService, claimsService, identityService, mtService, keyStore, storage, stateResolvers)
+
+	identity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	did, err := core.ParseDID(identity.Identifier)
+	require.NoError(t, err)
+	issuerIdentity, err := identityService.Create(ctx, method, blockchain, network, "http://localhost:3001")
+	require.NoError(t, err)
+	issuer, err := core.ParseDID(issuerIdentity.Identifier)
+	require.NoError(t, err)
+
+	// the claim issued to a managed identity is held by it with the same id
+	schema := "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json"
+	credentialSubject := map[string]any{
+		"id":           did.String(),
+		"birthday":     19960424,
+		"documentType": 2,
+	}
+	claim, err := claimsService.CreateClaim(ctx, ports.NewCreateClaimRequest(issuer, schema, credentialSubject, nil, "KYCAgeCredential", nil, nil, nil, nil))
+	require.NoError(t, err)
+
+	// presented returns the presentation of the held claim as received by the verifier
+	presented := func(t *testing.T, challenge string) domain.VerifiablePresentation {
+		presentation, err := presentationService.Create(ctx, ports.NewCreatePresentationRequest(did, []uuid.UUID{claim.ID}, challenge, "verifier.example.com"))
+		require.NoError(t, err)
+		raw, err := json.Marshal(presentation)
+		require.NoError(t, err)
+		var received domain.VerifiablePresentation
+		require.NoError(t, json.Unmarshal(raw, &received))
+		return received
+	}
+
+	t.Run("should not present credentials the identity does not hold", func(t *testing.T) {
+		_, err := presentationService.Create(ctx, ports.NewCreatePresentationRequest(did, []uuid.UUID{uuid.New()}, "challenge", "verifier.example.com"))
+		assert.ErrorIs(t, err, services.ErrPresentationInvalid)
+	})
+
+	t.Run("should not present without challenge", func(t *testing.T) {
+		_, err := presentationService.Create(ctx, ports.NewCreatePresentationRequest(did, []uuid.UUID{uuid.New()}, "", "verifier.example.com"))
+		assert.ErrorIs(t, err, services.ErrPresentationInvalid)
+	})
+
+	t.Run("should verify a presentation of a held credential", func(t *testing.T) {
+		presentation := presented(t, "challenge")
+		require.Len(t, presentation.VerifiableCredential, 1)
+		assert.Equal(t, issuer.String(), presentation.VerifiableCredential[0].Issuer)
+		err := presentationService.Verify(ctx, ports.NewVerifyPresentationRequest(presentation, "challenge", "verifier.example.com"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not verify a presentation for another challenge", func(t *testing.T) {
+		presentation := presented(t, "challenge")
+		err := presentationService.Verify(ctx, ports.NewVerifyPresentationRequest(presentation, "another challenge", "verifier.example.com"))
+		assert.ErrorIs(t, err, services.ErrPresentationNotValid)
+	})
+
+	t.Run("should not verify a presentation for another domain", func(t *testing.T) {
+		presentation := presented(t, "challenge")
+		err := presentationService.Verify(ctx, ports.NewVerifyPresentationRequest(presentation, "challenge", "another.example.com"))
+		assert.ErrorIs(t, err, services.ErrPresentationNotValid)
+	})
+
+	t.Run("should not verify an unsigned presentation", func(t *testing.T) {
+		presentation := domain.VerifiablePresentation{Holder: did.String()}
+		err := presentationService.Verify(ctx, ports.NewVerifyPresentationRequest(presentation, "challenge", "verifier.example.com"))
+		assert.ErrorIs(t, err, services.ErrPresentationNotValid)
+	})
+}
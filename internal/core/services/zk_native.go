@@ -4,42 +4,121 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/iden3/go-rapidsnark/prover"
+	"github.com/iden3/go-rapidsnark/types"
 	"github.com/iden3/go-rapidsnark/witness"
 	"github.com/lastingasset/wallet-service/go-circuits"
 
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/log"
-	"github.com/lastingasset/wallet-service/pkg/loaders"
 )
 
+// CircuitArtifactsLoader loads the files the native prover needs to generate the proofs of a circuit
+type CircuitArtifactsLoader interface {
+	LoadWasm(circuitID circuits.CircuitID) ([]byte, error)
+	LoadProvingKey(circuitID circuits.CircuitID) ([]byte, error)
+}
+
 // NativeProverConfig represents native prover config
 type NativeProverConfig struct {
-	CircuitsLoader *loaders.Circuits
+	CircuitsLoader CircuitArtifactsLoader
+	// MaxConcurrentProofs bounds the proofs generated at the same time. 0 means the number of CPUs
+	MaxConcurrentProofs int
+	// PreloadCircuits are the circuits whose artifacts are loaded when the service is created, the others
+	// are loaded on their first proof
+	PreloadCircuits []circuits.CircuitID
+	// StatsLogInterval is how often the timings of the proofs are logged. 0 means they are not
+	StatsLogInterval time.Duration
+}
+
+// CircuitProofStats are the timings of the proofs generated with a circuit since the service was created
+type CircuitProofStats struct {
+	Proofs     int64         // Proofs generated
+	Failures   int64         // Proofs that could not be generated
+	Wait       time.Duration // Total time waited for a proving slot
+	Witness    time.Duration // Total time calculating witnesses
+	Proving    time.Duration // Total time proving
+	MaxLatency time.Duration // Longest wait, witness and proving time of a proof
 }
 
 // NativeProverService service responsible for native zk generation
 type NativeProverService struct {
-	config *NativeProverConfig
+	config    *NativeProverConfig
+	semaphore chan struct{}
+
+	circuitsMu sync.Mutex
+	circuits   map[circuits.CircuitID]*nativeCircuit
+
+	statsMu sync.Mutex
+	stats   map[circuits.CircuitID]CircuitProofStats
+
+	// newCalculator and prove calculate the witnesses and prove them, the tests replace them
+	newCalculator func(wasm []byte) (witnessCalculator, error)
+	prove         func(provingKey []byte, witness []byte) (*types.ZKProof, error)
 }
 
-// NewNativeProverService new prover service that works with zero knowledge proofs
-func NewNativeProverService(config *NativeProverConfig) *NativeProverService {
-	return &NativeProverService{config: config}
+// witnessCalculator calculates the witnesses of the inputs of a circuit
+type witnessCalculator interface {
+	CalculateWTNSBin(inputs map[string]interface{}, sanityCheck bool) ([]byte, error)
+	Close()
 }
 
-// Generate calls prover-server for proof generation
-func (s *NativeProverService) Generate(ctx context.Context, inputs json.RawMessage, circuitName string) (*domain.FullProof, error) {
-	wasm, err := s.config.CircuitsLoader.LoadWasm(circuits.CircuitID(circuitName))
+// newCircom2Calculator returns a new witness calculator of the wasm of a circuit
+func newCircom2Calculator(wasm []byte) (witnessCalculator, error) {
+	calc, err := witness.NewCircom2WitnessCalculator(wasm, true)
 	if err != nil {
 		return nil, err
 	}
+	return calc, nil
+}
 
-	calc, err := witness.NewCircom2WitnessCalculator(wasm, true)
+// nativeCircuit holds the artifacts of a circuit, loaded once, and its idle witness calculators
+type nativeCircuit struct {
+	once        sync.Once
+	err         error
+	wasm        []byte
+	provingKey  []byte
+	calculators chan witnessCalculator
+}
+
+// NewNativeProverService new prover service that works with zero knowledge proofs
+func NewNativeProverService(ctx context.Context, config *NativeProverConfig) *NativeProverService {
+	maxConcurrentProofs := config.MaxConcurrentProofs
+	if maxConcurrentProofs <= 0 {
+		maxConcurrentProofs = runtime.NumCPU()
+	}
+
+	s := &NativeProverService{
+		config:        config,
+		semaphore:     make(chan struct{}, maxConcurrentProofs),
+		circuits:      make(map[circuits.CircuitID]*nativeCircuit),
+		stats:         make(map[circuits.CircuitID]CircuitProofStats),
+		newCalculator: newCircom2Calculator,
+		prove:         prover.Groth16Prover,
+	}
+	for _, circuitID := range config.PreloadCircuits {
+		if _, err := s.circuit(circuitID); err != nil {
+			log.Warn(ctx, "can't preload circuit", "circuit", circuitID, "err", err)
+		}
+	}
+	if config.StatsLogInterval > 0 {
+		go s.logStats(ctx, config.StatsLogInterval)
+	}
+	log.Info(ctx, "native prover started", "maxConcurrentProofs", maxConcurrentProofs, "preloadedCircuits", len(config.PreloadCircuits))
+	return s
+}
+
+// Generate generates the proof with the native prover. At most MaxConcurrentProofs proofs are generated
+// at the same time, the others wait for a slot until the context is done.
+func (s *NativeProverService) Generate(ctx context.Context, inputs json.RawMessage, circuitName string) (*domain.FullProof, error) {
+	circuitID := circuits.CircuitID(circuitName)
+	circuit, err := s.circuit(circuitID)
 	if err != nil {
-		log.Error(ctx, "can't create witness calculator", err)
-		return nil, fmt.Errorf("can't create witness calculator: %w", err)
+		return nil, err
 	}
 
 	parsedInputs, err := witness.ParseInputs(inputs)
@@ -47,21 +126,44 @@ func (s *NativeProverService) Generate(ctx context.Context, inputs json.RawMessa
 		return nil, err
 	}
 
+	start := time.Now()
+	select {
+	case s.semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-s.semaphore }()
+	wait := time.Since(start)
+
+	calc, err := circuit.calculator(s.newCalculator)
+	if err != nil {
+		s.record(circuitID, wait, 0, 0, err)
+		log.Error(ctx, "can't create witness calculator", err)
+		return nil, fmt.Errorf("can't create witness calculator: %w", err)
+	}
+
+	witnessStart := time.Now()
 	wtnsBytes, err := calc.CalculateWTNSBin(parsedInputs, true)
+	witnessTime := time.Since(witnessStart)
 	if err != nil {
+		// the calculator may be left in a broken state, it is not reused
+		calc.Close()
+		s.record(circuitID, wait, witnessTime, 0, err)
 		log.Error(ctx, "can't generate witnesses", err)
 		return nil, fmt.Errorf("can't generate witnesses: %w", err)
 	}
+	circuit.release(calc)
 
-	provingKey, err := s.config.CircuitsLoader.LoadProvingKey(circuits.CircuitID(circuitName))
-	if err != nil {
-		return nil, err
-	}
-	p, err := prover.Groth16Prover(provingKey, wtnsBytes)
+	provingStart := time.Now()
+	p, err := s.prove(circuit.provingKey, wtnsBytes)
+	provingTime := time.Since(provingStart)
+	s.record(circuitID, wait, witnessTime, provingTime, err)
 	if err != nil {
 		log.Error(ctx, "can't generate proof", err)
 		return nil, fmt.Errorf("can't generate proof: %w", err)
 	}
+	log.Debug(ctx, "proof generated", "circuit", circuitName, "wait", wait, "witness", witnessTime, "proving", provingTime)
+
 	// TODO: get rid of models.Proof structure
 	return &domain.FullProof{
 		Proof: &domain.ZKProof{
@@ -73,3 +175,99 @@ func (s *NativeProverService) Generate(ctx context.Context, inputs json.RawMessa
 		PubSignals: p.PubSignals,
 	}, nil
 }
+
+// Stats returns the timings of the proofs generated with each circuit
+func (s *NativeProverService) Stats() map[circuits.CircuitID]CircuitProofStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	stats := make(map[circuits.CircuitID]CircuitProofStats, len(s.stats))
+	for circuitID, circuitStats := range s.stats {
+		stats[circuitID] = circuitStats
+	}
+	return stats
+}
+
+// logStats logs the timings of the proofs of each circuit every interval until the context is done
+func (s *NativeProverService) logStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for circuitID, stats := range s.Stats() {
+				log.Info(ctx, "native prover stats", "circuit", circuitID, "proofs", stats.Proofs, "failures", stats.Failures,
+					"wait", stats.Wait, "witness", stats.Witness, "proving", stats.Proving, "maxLatency", stats.MaxLatency)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// circuit returns the circuit with its artifacts, loading them on the first call
+func (s *NativeProverService) circuit(circuitID circuits.CircuitID) (*nativeCircuit, error) {
+	s.circuitsMu.Lock()
+	circuit, ok := s.circuits[circuitID]
+	if !ok {
+		circuit = &nativeCircuit{calculators: make(chan witnessCalculator, cap(s.semaphore))}
+		s.circuits[circuitID] = circuit
+	}
+	s.circuitsMu.Unlock()
+
+	circuit.once.Do(func() {
+		circuit.wasm, circuit.err = s.config.CircuitsLoader.LoadWasm(circuitID)
+		if circuit.err != nil {
+			return
+		}
+		circuit.provingKey, circuit.err = s.config.CircuitsLoader.LoadProvingKey(circuitID)
+	})
+	if circuit.err != nil {
+		// a failed load is retried on the next call, the files may be fixed meanwhile
+		s.circuitsMu.Lock()
+		if s.circuits[circuitID] == circuit {
+			delete(s.circuits, circuitID)
+		}
+		s.circuitsMu.Unlock()
+		return nil, circuit.err
+	}
+	return circuit, nil
+}
+
+func (s *NativeProverService) record(circuitID circuits.CircuitID, wait, witnessTime, provingTime time.Duration, err error) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	stats := s.stats[circuitID]
+	if err != nil {
+		stats.Failures++
+	} else {
+		stats.Proofs++
+	}
+	stats.Wait += wait
+	stats.Witness += witnessTime
+	stats.Proving += provingTime
+	if latency := wait + witnessTime + provingTime; latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+	s.stats[circuitID] = stats
+}
+
+// calculator returns an idle witness calculator of the circuit, or a new one if all of them are in use
+func (c *nativeCircuit) calculator(newCalculator func(wasm []byte) (witnessCalculator, error)) (witnessCalculator, error) {
+	select {
+	case calc := <-c.calculators:
+		return calc, nil
+	default:
+		return newCalculator(c.wasm)
+	}
+}
+
+// release keeps the witness calculator for the next proof, it is closed if there are enough idle ones
+func (c *nativeCircuit) release(calc witnessCalculator) {
+	select {
+	case c.calculators <- calc:
+	default:
+		calc.Close()
+	}
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iden3/go-rapidsnark/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/go-circuits"
+)
+
+// circuitLoaderMock loads the artifacts of any circuit, failing the first failures loads
+type circuitLoaderMock struct {
+	mu       sync.Mutex
+	failures int
+	loads    int
+}
+
+func (l *circuitLoaderMock) LoadWasm(circuitID circuits.CircuitID) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.loads++
+	if l.loads <= l.failures {
+		return nil, errors.New("circuit files not found")
+	}
+	return []byte("wasm of " + circuitID), nil
+}
+
+func (l *circuitLoaderMock) LoadProvingKey(circuitID circuits.CircuitID) ([]byte, error) {
+	return []byte("proving key of " + circuitID), nil
+}
+
+type witnessCalculatorMock struct {
+	fail   bool
+	closed bool
+}
+
+func (c *witnessCalculatorMock) CalculateWTNSBin(_ map[string]interface{}, _ bool) ([]byte, error) {
+	if c.fail {
+		return nil, errors.New("assert failed")
+	}
+	return []byte("witness"), nil
+}
+
+func (c *witnessCalculatorMock) Close() {
+	c.closed = true
+}
+
+// nativeProverMock replaces the witness calculators and the prover of the service. The proofs wait for the
+// release channel, when there is one, and the highest number of proofs generated at the same time is kept.
+type nativeProverMock struct {
+	mu          sync.Mutex
+	calculators []*witnessCalculatorMock
+	failNext    bool
+	proving     int
+	maxProving  int
+	release     chan struct{}
+}
+
+func (m *nativeProverMock) newCalculator(_ []byte) (witnessCalculator, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	calc := &witnessCalculatorMock{fail: m.failNext}
+	m.failNext = false
+	m.calculators = append(m.calculators, calc)
+	return calc, nil
+}
+
+func (m *nativeProverMock) prove(_ []byte, _ []byte) (*types.ZKProof, error) {
+	m.mu.Lock()
+	m.proving++
+	if m.proving > m.maxProving {
+		m.maxProving = m.proving
+	}
+	m.mu.Unlock()
+
+	if m.release != nil {
+		<-m.release
+	}
+
+	m.mu.Lock()
+	m.proving--
+	m.mu.Unlock()
+	return &types.ZKProof{Proof: &types.ProofData{Protocol: "groth16"}, PubSignals: []string{"1"}}, nil
+}
+
+// inProof returns the number of proofs being generated
+func (m *nativeProverMock) inProof() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.proving
+}
+
+func TestNativeProverService(t *testing.T) {
+	ctx := context.Background()
+	inputs := json.RawMessage(`{"userGenesisID": "1"}`)
+	circuit := string(circuits.AtomicQueryMTPV2CircuitID)
+
+	newService := func(loader *circuitLoaderMock, maxConcurrentProofs int, preload ...circuits.CircuitID) (*NativeProverService, *nativeProverMock) {
+		mock := &nativeProverMock{}
+		s := NewNativeProverService(ctx, &NativeProverConfig{
+			CircuitsLoader:      loader,
+			MaxConcurrentProofs: maxConcurrentProofs,
+			PreloadCircuits:     preload,
+		})
+		s.newCalculator = mock.newCalculator
+		s.prove = mock.prove
+		return s, mock
+	}
+
+	t.Run("should load a circuit whose preload failed on its first proof", func(t *testing.T) {
+		loader := &circuitLoaderMock{failures: 1}
+		s, _ := newService(loader, 1, circuits.AtomicQueryMTPV2CircuitID)
+		assert.Equal(t, 1, loader.loads)
+
+		proof, err := s.Generate(ctx, inputs, circuit)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, proof.PubSignals)
+		assert.Equal(t, 2, loader.loads)
+
+		_, err = s.Generate(ctx, inputs, circuit)
+		require.NoError(t, err)
+		assert.Equal(t, 2, loader.loads)
+	})
+
+	t.Run("should retry a circuit that could not be loaded", func(t *testing.T) {
+		loader := &circuitLoaderMock{failures: 1}
+		s, _ := newService(loader, 1)
+
+		_, err := s.Generate(ctx, inputs, circuit)
+		require.Error(t, err)
+		_, err = s.Generate(ctx, inputs, circuit)
+		require.NoError(t, err)
+		assert.Equal(t, 2, loader.loads)
+	})
+
+	t.Run("should reuse the witness calculator of the circuit", func(t *testing.T) {
+		s, mock := newService(&circuitLoaderMock{}, 2)
+		for i := 0; i < 3; i++ {
+			_, err := s.Generate(ctx, inputs, circuit)
+			require.NoError(t, err)
+		}
+		require.Len(t, mock.calculators, 1)
+		assert.False(t, mock.calculators[0].closed)
+
+		stats := s.Stats()[circuits.AtomicQueryMTPV2CircuitID]
+		assert.Equal(t, int64(3), stats.Proofs)
+		assert.Equal(t, int64(0), stats.Failures)
+	})
+
+	t.Run("should not reuse a witness calculator that failed", func(t *testing.T) {
+		s, mock := newService(&circuitLoaderMock{}, 1)
+		mock.failNext = true
+		_, err := s.Generate(ctx, inputs, circuit)
+		require.Error(t, err)
+		_, err = s.Generate(ctx, inputs, circuit)
+		require.NoError(t, err)
+
+		require.Len(t, mock.calculators, 2)
+		assert.True(t, mock.calculators[0].closed)
+		assert.Equal(t, int64(1), s.Stats()[circuits.AtomicQueryMTPV2CircuitID].Failures)
+	})
+
+	t.Run("should generate at most the max concurrent proofs at the same time", func(t *testing.T) {
+		const maxConcurrentProofs, proofs = 2, 6
+		s, mock := newService(&circuitLoaderMock{}, maxConcurrentProofs)
+		mock.release = make(chan struct{})
+
+		errs := make(chan error, proofs)
+		for i := 0; i < proofs; i++ {
+			go func() {
+				_, err := s.Generate(ctx, inputs, circuit)
+				errs <- err
+			}()
+		}
+		require.Eventually(t, func() bool { return mock.inProof() == maxConcurrentProofs }, time.Second, time.Millisecond)
+		for i := 0; i < proofs; i++ {
+			mock.release <- struct{}{}
+		}
+		for i := 0; i < proofs; i++ {
+			require.NoError(t, <-errs)
+		}
+
+		assert.Equal(t, maxConcurrentProofs, mock.maxProving)
+		assert.LessOrEqual(t, len(mock.calculators), maxConcurrentProofs)
+		assert.Equal(t, int64(proofs), s.Stats()[circuits.AtomicQueryMTPV2CircuitID].Proofs)
+	})
+
+	t.Run("should stop waiting for a slot when the context is done", func(t *testing.T) {
+		s, mock := newService(&circuitLoaderMock{}, 1)
+		mock.release = make(chan struct{})
+		generated := make(chan error, 1)
+		go func() {
+			_, err := s.Generate(ctx, inputs, circuit)
+			generated <- err
+		}()
+		require.Eventually(t, func() bool { return mock.inProof() == 1 }, time.Second, time.Millisecond)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := s.Generate(waitCtx, inputs, circuit)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		mock.release <- struct{}{}
+		require.NoError(t, <-generated)
+	})
+}
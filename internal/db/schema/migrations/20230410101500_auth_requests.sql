@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE auth_request_status AS ENUM ('created', 'pending', 'verified', 'rejected', 'expired');
+
+CREATE TABLE auth_requests (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    thread_id text NOT NULL,
+    verifier text NOT NULL,
+    identifier text NULL,
+    request jsonb NOT NULL,
+    "status" auth_request_status NOT NULL DEFAULT 'created'::auth_request_status,
+    expires_at timestamptz NULL,
+    modified_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT auth_requests_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX auth_requests_thread_id ON auth_requests USING btree (thread_id);
+CREATE INDEX auth_requests_verifier_created_at ON auth_requests USING btree (verifier, created_at);
+
+CREATE TRIGGER update_auth_requests_modifiedtime
+    BEFORE UPDATE ON auth_requests FOR EACH ROW EXECUTE PROCEDURE update_modified_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_auth_requests_modifiedtime ON auth_requests;
+DROP TABLE IF EXISTS auth_requests;
+DROP TYPE IF EXISTS auth_request_status;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE auth_requests ADD COLUMN verified_did text NULL;
+ALTER TABLE auth_requests ADD COLUMN disclosed jsonb NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE auth_requests DROP COLUMN verified_did;
+ALTER TABLE auth_requests DROP COLUMN disclosed;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE auth_requests ADD COLUMN challenge text NULL;
+
+-- requests created before this migration share their thread id and cannot be bound to a challenge
+UPDATE auth_requests SET status = 'expired' WHERE status IN ('created', 'pending');
+
+CREATE UNIQUE INDEX auth_requests_open_thread_id ON auth_requests USING btree (thread_id) WHERE status IN ('created', 'pending');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS auth_requests_open_thread_id;
+ALTER TABLE auth_requests DROP COLUMN IF EXISTS challenge;
+-- +goose StatementEnd
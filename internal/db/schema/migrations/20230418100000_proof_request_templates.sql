@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE proof_request_templates (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    name text NOT NULL,
+    circuit_id text NOT NULL,
+    context text NOT NULL,
+    type text NOT NULL,
+    allowed_issuers jsonb NOT NULL,
+    credential_subject jsonb NULL,
+    reason text NOT NULL DEFAULT '',
+    modified_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT proof_request_templates_pkey PRIMARY KEY (id),
+    CONSTRAINT proof_request_templates_name_key UNIQUE (name)
+);
+
+CREATE TRIGGER update_proof_request_templates_modifiedtime
+    BEFORE UPDATE ON proof_request_templates FOR EACH ROW EXECUTE PROCEDURE update_modified_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_proof_request_templates_modifiedtime ON proof_request_templates;
+DROP TABLE IF EXISTS proof_request_templates;
+-- +goose StatementEnd
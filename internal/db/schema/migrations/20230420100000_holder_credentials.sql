@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE holder_credential_source AS ENUM ('offer', 'import', 'self-issued');
+
+CREATE TABLE holder_credentials (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    holder text NOT NULL,
+    issuer text NOT NULL,
+    credential_id text NOT NULL,
+    credential jsonb NOT NULL,
+    schema_url text NOT NULL,
+    schema_type text NOT NULL,
+    schema_hash text NOT NULL,
+    rev_nonce numeric NOT NULL,
+    proof_types text[] NOT NULL DEFAULT '{}',
+    source holder_credential_source NOT NULL,
+    revoked bool NOT NULL DEFAULT false,
+    expiration timestamptz NULL,
+    received_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT holder_credentials_pkey PRIMARY KEY (id),
+    CONSTRAINT holder_credentials_holder_credential_id_key UNIQUE (holder, credential_id)
+);
+
+CREATE INDEX holder_credentials_holder_schema_type ON holder_credentials USING btree (holder, schema_type);
+CREATE INDEX holder_credentials_issuer_rev_nonce ON holder_credentials USING btree (issuer, rev_nonce);
+
+CREATE TRIGGER update_holder_credentials_modifiedtime
+    BEFORE UPDATE ON holder_credentials FOR EACH ROW EXECUTE PROCEDURE update_modified_at_column();
+
+-- the credentials held by the managed identities were looked up in the claims table,
+-- those issued by other identities were stored there with the holder as identifier.
+-- They are self-issued when the issuer is also managed by this service.
+INSERT INTO holder_credentials (id, holder, issuer, credential_id, credential, schema_url, schema_type, schema_hash,
+                                rev_nonce, proof_types, source, revoked, expiration)
+SELECT claims.id,
+       claims.other_identifier,
+       claims.issuer,
+       claims.data ->> 'id',
+       claims.data || jsonb_build_object('proof', (SELECT jsonb_agg(proof)
+                                                   FROM unnest(ARRAY [claims.signature_proof, claims.mtp_proof]) AS proof
+                                                   WHERE proof IS NOT NULL)),
+       claims.schema_url,
+       claims.schema_type,
+       claims.schema_hash,
+       claims.rev_nonce,
+       array_remove(ARRAY [
+           CASE WHEN claims.signature_proof IS NOT NULL THEN claims.signature_proof ->> 'type' END,
+           CASE WHEN claims.mtp_proof IS NOT NULL THEN claims.mtp_proof ->> 'type' END], NULL),
+       CASE WHEN EXISTS (SELECT 1 FROM identities AS issuers WHERE issuers.identifier = claims.issuer)
+                THEN 'self-issued'
+            ELSE 'offer' END::holder_credential_source,
+       coalesce(claims.revoked, false),
+       CASE WHEN claims.expiration > 0 THEN to_timestamp(claims.expiration) END
+FROM claims
+         JOIN identities ON identities.identifier = claims.other_identifier
+WHERE claims.data IS NOT NULL
+  AND (claims.signature_proof IS NOT NULL OR claims.mtp_proof IS NOT NULL)
+ON CONFLICT DO NOTHING;
+
+-- the rows of the credentials issued by other identities are kept aside, so the claims table is issuer only
+-- and the migration can be rolled back
+CREATE TABLE held_claims_backup AS SELECT * FROM claims WHERE identifier <> issuer;
+DELETE FROM claims WHERE identifier <> issuer;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO claims SELECT * FROM held_claims_backup ON CONFLICT DO NOTHING;
+DROP TABLE IF EXISTS held_claims_backup;
+DROP TRIGGER IF EXISTS update_holder_credentials_modifiedtime ON holder_credentials;
+DROP TABLE IF EXISTS holder_credentials;
+DROP TYPE IF EXISTS holder_credential_source;
+-- +goose StatementEnd
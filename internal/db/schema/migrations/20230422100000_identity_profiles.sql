@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE identity_profiles (
+    id uuid NOT NULL DEFAULT gen_random_uuid(),
+    identifier text NOT NULL,
+    nonce numeric NOT NULL,
+    profile_did text NOT NULL,
+    verifier text NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT identity_profiles_pkey PRIMARY KEY (id),
+    CONSTRAINT identity_profiles_identifier_fkey FOREIGN KEY (identifier) REFERENCES identities (identifier),
+    CONSTRAINT identity_profiles_profile_did_key UNIQUE (profile_did),
+    CONSTRAINT identity_profiles_identifier_nonce_key UNIQUE (identifier, nonce)
+);
+
+-- a single pairwise profile per verifier
+CREATE UNIQUE INDEX identity_profiles_identifier_verifier_key ON identity_profiles (identifier, verifier) WHERE verifier IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS identity_profiles;
+-- +goose StatementEnd
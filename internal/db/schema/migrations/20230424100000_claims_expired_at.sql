@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims ADD COLUMN expired_at timestamptz NULL;
+
+-- the expiry sweeper looks for the claims with an expiration that have not been marked yet
+CREATE INDEX claims_expiration_idx ON claims (expiration) WHERE expiration > 0 AND expired_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claims_expiration_idx;
+ALTER TABLE claims DROP COLUMN IF EXISTS expired_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the claim a reissued claim replaces, the replaced claim is revoked in the same state transition
+ALTER TABLE claims ADD COLUMN replaces uuid NULL;
+CREATE INDEX claims_replaces_idx ON claims (replaces) WHERE replaces IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claims_replaces_idx;
+ALTER TABLE claims DROP COLUMN IF EXISTS replaces;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE claims ADD COLUMN created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- the claims issued before have their issuance date as creation date
+UPDATE claims SET created_at = (data ->> 'issuanceDate')::timestamptz WHERE data ->> 'issuanceDate' IS NOT NULL;
+
+-- full text search over the credential subject of the claims, the expression must match the one of the claims queries
+CREATE INDEX claims_credential_subject_search_idx ON claims
+    USING gin (jsonb_to_tsvector('simple', data -> 'credentialSubject', '["string", "numeric"]'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS claims_credential_subject_search_idx;
+ALTER TABLE claims DROP COLUMN IF EXISTS created_at;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE credential_request_status AS ENUM ('pending', 'approving', 'approved', 'rejected');
+
+CREATE TABLE credential_requests (
+    id uuid NOT NULL,
+    issuer text NOT NULL,
+    holder text NOT NULL,
+    thread_id text NOT NULL,
+    schema_url text NOT NULL,
+    schema_type text NOT NULL,
+    credential_subject jsonb NOT NULL,
+    expiration timestamptz NULL,
+    status credential_request_status NOT NULL DEFAULT 'pending',
+    reason text NULL,
+    claim_id uuid NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at timestamptz NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT credential_requests_pkey PRIMARY KEY (id),
+    CONSTRAINT credential_requests_issuer_holder_thread_id_key UNIQUE (issuer, holder, thread_id),
+    CONSTRAINT credential_requests_claim_id_fkey FOREIGN KEY (claim_id, issuer) REFERENCES claims (id, identifier)
+);
+
+CREATE INDEX credential_requests_issuer_status ON credential_requests USING btree (issuer, status, created_at);
+
+CREATE TRIGGER update_credential_requests_modifiedtime
+    BEFORE UPDATE ON credential_requests FOR EACH ROW EXECUTE PROCEDURE update_modified_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_credential_requests_modifiedtime ON credential_requests;
+DROP TABLE IF EXISTS credential_requests;
+DROP TYPE IF EXISTS credential_request_status;
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE issuance_policy_action AS ENUM ('auto-approve', 'manual', 'deny');
+
+CREATE TABLE issuance_policies (
+    id uuid NOT NULL,
+    issuer text NOT NULL,
+    schema_type text NOT NULL DEFAULT '',
+    subject_allowlist jsonb NULL,
+    field_constraints jsonb NULL,
+    action issuance_policy_action NOT NULL,
+    priority integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT issuance_policies_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX issuance_policies_issuer ON issuance_policies USING btree (issuer, priority DESC, created_at);
+
+ALTER TABLE credential_requests
+    ADD COLUMN version bigint NOT NULL DEFAULT 0,
+    ADD COLUMN subject_position text NOT NULL DEFAULT '',
+    ADD COLUMN merklized_root_position text NOT NULL DEFAULT '',
+    ADD COLUMN updatable boolean NOT NULL DEFAULT false;
+
+CREATE TYPE issuance_audit_action AS ENUM ('requested', 'approved', 'rejected', 'denied');
+
+CREATE TABLE issuance_audit (
+    id uuid NOT NULL,
+    issuer text NOT NULL,
+    request_id uuid NULL,
+    schema_type text NOT NULL,
+    holder text NOT NULL,
+    action issuance_audit_action NOT NULL,
+    actor text NOT NULL,
+    policy_id uuid NULL,
+    reason text NULL,
+    claim_id uuid NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT issuance_audit_pkey PRIMARY KEY (id),
+    CONSTRAINT issuance_audit_request_id_fkey FOREIGN KEY (request_id) REFERENCES credential_requests (id) ON DELETE SET NULL,
+    CONSTRAINT issuance_audit_policy_id_fkey FOREIGN KEY (policy_id) REFERENCES issuance_policies (id) ON DELETE SET NULL,
+    CONSTRAINT issuance_audit_claim_id_fkey FOREIGN KEY (claim_id, issuer) REFERENCES claims (id, identifier)
+);
+
+CREATE INDEX issuance_audit_issuer ON issuance_audit USING btree (issuer, created_at);
+CREATE INDEX issuance_audit_request_id ON issuance_audit USING btree (request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS issuance_audit;
+DROP TYPE IF EXISTS issuance_audit_action;
+ALTER TABLE credential_requests
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS subject_position,
+    DROP COLUMN IF EXISTS merklized_root_position,
+    DROP COLUMN IF EXISTS updatable;
+DROP TABLE IF EXISTS issuance_policies;
+DROP TYPE IF EXISTS issuance_policy_action;
+-- +goose StatementEnd
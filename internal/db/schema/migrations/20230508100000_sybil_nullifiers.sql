@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE sybil_nullifiers (
+    id uuid NOT NULL,
+    verifier text NOT NULL,
+    crs text NOT NULL,
+    sybil_id text NOT NULL,
+    user_id text NOT NULL,
+    auth_request_id uuid NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT sybil_nullifiers_pkey PRIMARY KEY (id),
+    CONSTRAINT sybil_nullifiers_verifier_crs_sybil_id_key UNIQUE (verifier, crs, sybil_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sybil_nullifiers;
+-- +goose StatementEnd
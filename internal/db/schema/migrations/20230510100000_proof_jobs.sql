@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE proof_job_status AS ENUM ('pending', 'running', 'done', 'failed', 'cancelled');
+
+CREATE TABLE proof_jobs (
+    id uuid NOT NULL,
+    identifier text NOT NULL,
+    queries jsonb NOT NULL,
+    status proof_job_status NOT NULL DEFAULT 'pending',
+    result jsonb NULL,
+    error text NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamptz NULL,
+    finished_at timestamptz NULL,
+    expires_at timestamptz NULL,
+    CONSTRAINT proof_jobs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX proof_jobs_pending ON proof_jobs USING btree (created_at) WHERE status = 'pending';
+CREATE INDEX proof_jobs_running ON proof_jobs USING btree (started_at) WHERE status = 'running';
+CREATE INDEX proof_jobs_expires_at ON proof_jobs USING btree (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS proof_jobs;
+DROP TYPE IF EXISTS proof_job_status;
+-- +goose StatementEnd
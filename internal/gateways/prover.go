@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/lastingasset/wallet-service/go-circuits"
 	"github.com/lastingasset/wallet-service/internal/config"
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/core/ports"
@@ -38,9 +39,15 @@ func NewProver(ctx context.Context, config *config.Configuration, circuitLoaderS
 	log.Info(ctx, "native prover enabled", "enabled", config.NativeProofGenerationEnabled)
 	if config.NativeProofGenerationEnabled {
 		proverConfig := &services.NativeProverConfig{
-			CircuitsLoader: circuitLoaderService,
+			CircuitsLoader:      circuitLoaderService,
+			MaxConcurrentProofs: config.Prover.MaxConcurrentProofs,
+			PreloadCircuits:     make([]circuits.CircuitID, 0, len(config.Prover.PreloadCircuits)),
+			StatsLogInterval:    config.Prover.StatsLogInterval,
 		}
-		return services.NewNativeProverService(proverConfig)
+		for _, circuitID := range config.Prover.PreloadCircuits {
+			proverConfig.PreloadCircuits = append(proverConfig.PreloadCircuits, circuits.CircuitID(circuitID))
+		}
+		return services.NewNativeProverService(ctx, proverConfig)
 	}
 
 	proverConfig := &ProverConfig{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
@@ -69,8 +70,9 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
           			credential_status,
 					revoked,
                     core_claim,
-                    index_hash)
-		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+                    index_hash,
+                    replaces)
+		VALUES ($1,  $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id`
 
 		err = conn.QueryRow(ctx, s,
@@ -91,7 +93,8 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.CredentialStatus,
 			claim.Revoked,
 			claim.CoreClaim,
-			claim.HIndex).Scan(&id)
+			claim.HIndex,
+			claim.Replaces).Scan(&id)
 	} else {
 		s := `INSERT INTO claims (
 					id,
@@ -112,10 +115,11 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
                     credential_status,
                     revoked,
                     core_claim,
-                    index_hash
+                    index_hash,
+                    replaces
 		)
 		VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
 		ON CONFLICT ON CONSTRAINT claims_pkey 
 		DO UPDATE SET 
@@ -123,7 +127,9 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			other_identifier, schema_hash, schema_url, schema_type, issuer, credential_status, revoked, core_claim)
 			= (EXCLUDED.expiration, EXCLUDED.updatable, EXCLUDED.version, EXCLUDED.rev_nonce, EXCLUDED.signature_proof,
 		EXCLUDED.mtp_proof, EXCLUDED.data, EXCLUDED.identity_state, EXCLUDED.other_identifier, EXCLUDED.schema_hash, 
-		EXCLUDED.schema_url, EXCLUDED.schema_type, EXCLUDED.issuer, EXCLUDED.credential_status, EXCLUDED.revoked, EXCLUDED.core_claim)
+		EXCLUDED.schema_url, EXCLUDED.schema_type, EXCLUDED.issuer, EXCLUDED.credential_status, EXCLUDED.revoked, EXCLUDED.core_claim),
+			-- the index hash changes when an updatable claim gets a new version, the claims read from the db have not got it
+			index_hash = COALESCE(NULLIF(EXCLUDED.index_hash, ''), claims.index_hash)
 			RETURNING id`
 		err = conn.QueryRow(ctx, s,
 			claim.ID,
@@ -144,7 +150,8 @@ func (c *claims) Save(ctx context.Context, conn db.Querier, claim *domain.Claim)
 			claim.CredentialStatus,
 			claim.Revoked,
 			claim.CoreClaim,
-			claim.HIndex).Scan(&id)
+			claim.HIndex,
+			claim.Replaces).Scan(&id)
 	}
 
 	if err == nil {
@@ -316,7 +323,9 @@ func (c *claims) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifi
        				claims.identifier,
         			identity_state,
        				credential_status,
-       				core_claim
+       				revoked,
+       				core_claim,
+       				replaces
         FROM claims
         WHERE claims.identifier = $1 AND claims.id = $2`, identifier.String(), claimID).Scan(
 		&claim.ID,
@@ -335,7 +344,9 @@ func (c *claims) GetByIdAndIssuer(ctx context.Context, conn db.Querier, identifi
 		&claim.Identifier,
 		&claim.IdentityState,
 		&claim.CredentialStatus,
-		&claim.CoreClaim)
+		&claim.Revoked,
+		&claim.CoreClaim,
+		&claim.Replaces)
 
 	if err != nil && err == pgx.ErrNoRows {
 		return nil, ErrClaimDoesNotExist
@@ -363,12 +374,14 @@ func (c *claims) GetAllByIssuerID(ctx context.Context, conn db.Querier, identifi
 				   identity_state,
 				   identity_states.status,
 				   credential_status,
-				   core_claim
+				   core_claim,
+				   claims.created_at
 			FROM claims
 			LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
 			`
 
 	filters := buildGetAllQueryAndFilters(identifier, filter, &query)
+	filters = buildPageQuery(filter, filters, &query)
 
 	rows, err := conn.Query(ctx, query, filters...)
 	if err != nil {
@@ -504,7 +517,8 @@ func processClaims(rows pgx.Rows) ([]*domain.Claim, error) {
 			&claim.IdentityState,
 			&claim.Status,
 			&claim.CredentialStatus,
-			&claim.CoreClaim)
+			&claim.CoreClaim,
+			&claim.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -543,13 +557,114 @@ func buildGetAllQueryAndFilters(identifier *core.DID, filter *ports.Filter, quer
 
 	}
 
+	if filter.Expired != nil {
+		filters = append(filters, time.Now().Unix())
+		if *filter.Expired {
+			*query = fmt.Sprintf("%s and expiration > 0 and expiration <= $%d", *query, len(filters))
+		} else {
+			*query = fmt.Sprintf("%s and (expiration IS NULL or expiration = 0 or expiration > $%d)", *query, len(filters))
+		}
+	}
+
 	if filter.QueryField != "" {
 		*query = fmt.Sprintf("%s and data -> 'credentialSubject' ->>'%s' = '%s'", *query, filter.QueryField, filter.QueryField)
 	}
 
+	if filter.Search != "" {
+		filters = append(filters, filter.Search)
+		*query = fmt.Sprintf("%s and jsonb_to_tsvector('simple', data -> 'credentialSubject', '[\"string\", \"numeric\"]') @@ plainto_tsquery('simple', $%d)", *query, len(filters))
+	}
+
 	return filters
 }
 
+// buildPageQuery adds the sorting, the cursor and the limit of the filter to the query
+func buildPageQuery(filter *ports.Filter, filters []interface{}, query *string) []interface{} {
+	var column, cast string
+	switch filter.SortBy {
+	case ports.ClaimsSortByCreatedAt:
+		column, cast = "claims.created_at", "timestamptz"
+	case ports.ClaimsSortByExpiration:
+		column, cast = "COALESCE(claims.expiration, 0)", "int8"
+	default:
+		return filters
+	}
+
+	order, comparison := "ASC", ">"
+	if filter.Descending {
+		order, comparison = "DESC", "<"
+	}
+
+	if filter.Cursor != nil {
+		filters = append(filters, filter.Cursor.Value, filter.Cursor.ID)
+		*query = fmt.Sprintf("%s and (%s, claims.id) %s ($%d::%s, $%d)", *query, column, comparison, len(filters)-1, cast, len(filters))
+	}
+
+	*query = fmt.Sprintf("%s ORDER BY %s %s, claims.id %s", *query, column, order, order)
+
+	if filter.Limit > 0 {
+		filters = append(filters, filter.Limit)
+		*query = fmt.Sprintf("%s LIMIT $%d", *query, len(filters))
+	}
+	return filters
+}
+
+// CountByIssuerID returns the number of claims of the issuer that match the filter, whatever the page
+func (c *claims) CountByIssuerID(ctx context.Context, conn db.Querier, identifier *core.DID, filter *ports.Filter) (int, error) {
+	query := `SELECT count(*) FROM claims`
+	filters := buildGetAllQueryAndFilters(identifier, filter, &query)
+
+	var count int
+	if err := conn.QueryRow(ctx, query, filters...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting the claims: %w", err)
+	}
+	return count, nil
+}
+
+// MarkExpired marks as expired the claims issued by the service whose expiration is not after now and
+// returns them. The claims already marked are not returned again.
+func (c *claims) MarkExpired(ctx context.Context, conn db.Querier, now time.Time) ([]*domain.Claim, error) {
+	rows, err := conn.Query(ctx,
+		`UPDATE claims SET expired_at = $1
+		WHERE expiration > 0 AND expiration <= $2 AND expired_at IS NULL
+		RETURNING id, issuer, identifier, other_identifier, schema_type, rev_nonce, expiration`,
+		now, now.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("error marking the expired claims: %w", err)
+	}
+	defer rows.Close()
+
+	expired := make([]*domain.Claim, 0)
+	for rows.Next() {
+		var claim domain.Claim
+		err := rows.Scan(&claim.ID,
+			&claim.Issuer,
+			&claim.Identifier,
+			&claim.OtherIdentifier,
+			&claim.SchemaType,
+			&claim.RevNonce,
+			&claim.Expiration)
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, &claim)
+	}
+
+	return expired, rows.Err()
+}
+
+// UnmarkExpired clears the expiration mark of the given claims, so they are marked again by the next MarkExpired
+func (c *claims) UnmarkExpired(ctx context.Context, conn db.Querier, ids []uuid.UUID) error {
+	claimIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		claimIDs = append(claimIDs, id.String())
+	}
+	if _, err := conn.Exec(ctx, `UPDATE claims SET expired_at = NULL WHERE id = ANY($1::uuid[])`, claimIDs); err != nil {
+		return fmt.Errorf("error unmarking the expired claims: %w", err)
+	}
+	return nil
+}
+
 func (c *claims) UpdateClaimMTP(ctx context.Context, conn db.Querier, claim *domain.Claim) (int64, error) {
 	query := "UPDATE claims SET mtp_proof = $1 WHERE id = $2 AND identifier = $3"
 	res, err := conn.Exec(ctx, query, claim.MTPProof, claim.ID, claim.Identifier)
@@ -579,7 +694,8 @@ func (c *claims) GetAuthClaimsForPublishing(ctx context.Context, conn db.Querier
 		identity_state,     
 		identity_states.status,
        	credential_status,
-       	core_claim
+       	core_claim,
+       	claims.created_at
 	FROM claims
 	LEFT JOIN identity_states  ON claims.identity_state = identity_states.state
 	LEFT JOIN revocation  ON claims.rev_nonce = revocation.nonce AND claims.issuer = revocation.identifier
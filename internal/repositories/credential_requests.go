@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ErrCredentialRequestDoesNotExist credential request does not exist
+var ErrCredentialRequestDoesNotExist = errors.New("credential request does not exist")
+
+const credentialRequestsColumns = `id, issuer, holder, thread_id, schema_url, schema_type, credential_subject, expiration,
+	version, subject_position, merklized_root_position, updatable, status, reason, claim_id, created_at, modified_at`
+
+type credentialRequests struct{}
+
+// NewCredentialRequests returns a new credential request repository
+func NewCredentialRequests() ports.CredentialRequestRepository {
+	return &credentialRequests{}
+}
+
+func (r *credentialRequests) Save(ctx context.Context, conn db.Querier, req *domain.CredentialRequest) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO credential_requests (id, issuer, holder, thread_id, schema_url, schema_type, credential_subject, expiration,
+			version, subject_position, merklized_root_position, updatable, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		req.ID,
+		req.Issuer,
+		req.Holder,
+		req.ThreadID,
+		req.SchemaURL,
+		req.SchemaType,
+		req.CredentialSubject,
+		req.Expiration,
+		req.Version,
+		req.SubjectPosition,
+		req.MerklizedRootPosition,
+		req.Updatable,
+		req.Status)
+	if err != nil {
+		return fmt.Errorf("error saving the credential request: %w", err)
+	}
+	return nil
+}
+
+func (r *credentialRequests) GetByID(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error) {
+	return scanCredentialRequest(conn.QueryRow(ctx,
+		`SELECT `+credentialRequestsColumns+` FROM credential_requests WHERE issuer = $1 AND id = $2`, issuer.String(), id))
+}
+
+// GetByThread returns the request the holder sent to the issuer in the given thread
+func (r *credentialRequests) GetByThread(ctx context.Context, conn db.Querier, issuer *core.DID, holder *core.DID, threadID string) (*domain.CredentialRequest, error) {
+	return scanCredentialRequest(conn.QueryRow(ctx,
+		`SELECT `+credentialRequestsColumns+` FROM credential_requests WHERE issuer = $1 AND holder = $2 AND thread_id = $3`,
+		issuer.String(), holder.String(), threadID))
+}
+
+// GetAll returns the requests sent to the issuer, the oldest first, with the given status if any
+func (r *credentialRequests) GetAll(ctx context.Context, conn db.Querier, issuer *core.DID, status *domain.CredentialRequestStatus) ([]*domain.CredentialRequest, error) {
+	query := `SELECT ` + credentialRequestsColumns + ` FROM credential_requests WHERE issuer = $1`
+	args := []interface{}{issuer.String()}
+	if status != nil {
+		args = append(args, *status)
+		query = fmt.Sprintf("%s AND status = $%d", query, len(args))
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]*domain.CredentialRequest, 0)
+	for rows.Next() {
+		req, err := scanCredentialRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// GetByIDForUpdate returns the request locked until the end of the transaction of conn
+func (r *credentialRequests) GetByIDForUpdate(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) (*domain.CredentialRequest, error) {
+	return scanCredentialRequest(conn.QueryRow(ctx,
+		`SELECT `+credentialRequestsColumns+` FROM credential_requests WHERE issuer = $1 AND id = $2 FOR UPDATE`, issuer.String(), id))
+}
+
+// UpdateStatus records the progress of the decision of the issuer on a request that is still in the from status
+func (r *credentialRequests) UpdateStatus(ctx context.Context, conn db.Querier, req *domain.CredentialRequest, from domain.CredentialRequestStatus) error {
+	tag, err := conn.Exec(ctx,
+		`UPDATE credential_requests SET status = $1, reason = $2, claim_id = $3 WHERE id = $4 AND status = $5`,
+		req.Status, req.Reason, req.ClaimID, req.ID, from)
+	if err != nil {
+		return fmt.Errorf("error updating the credential request: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCredentialRequestDoesNotExist
+	}
+	return nil
+}
+
+func scanCredentialRequest(row pgx.Row) (*domain.CredentialRequest, error) {
+	var req domain.CredentialRequest
+	err := row.Scan(&req.ID,
+		&req.Issuer,
+		&req.Holder,
+		&req.ThreadID,
+		&req.SchemaURL,
+		&req.SchemaType,
+		&req.CredentialSubject,
+		&req.Expiration,
+		&req.Version,
+		&req.SubjectPosition,
+		&req.MerklizedRootPosition,
+		&req.Updatable,
+		&req.Status,
+		&req.Reason,
+		&req.ClaimID,
+		&req.CreatedAt,
+		&req.ModifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCredentialRequestDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the credential request: %w", err)
+	}
+	return &req, nil
+}
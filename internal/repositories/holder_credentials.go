@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ErrHolderCredentialDoesNotExist holder credential does not exist
+var ErrHolderCredentialDoesNotExist = errors.New("holder credential does not exist")
+
+const holderCredentialsColumns = `id, holder, issuer, credential_id, credential, schema_url, schema_type, schema_hash, rev_nonce,
+	proof_types, source, revoked, expiration, received_at, modified_at`
+
+type holderCredentials struct{}
+
+// NewHolderCredentials returns a new holder credential repository
+func NewHolderCredentials() ports.HolderCredentialRepository {
+	return &holderCredentials{}
+}
+
+// Save stores the credential of the holder. A credential with the same id already held by the holder is replaced.
+func (r *holderCredentials) Save(ctx context.Context, conn db.Querier, credential *domain.HolderCredential) (uuid.UUID, error) {
+	id := credential.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	err := conn.QueryRow(ctx,
+		`INSERT INTO holder_credentials (id, holder, issuer, credential_id, credential, schema_url, schema_type, schema_hash,
+				rev_nonce, proof_types, source, revoked, expiration)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT ON CONSTRAINT holder_credentials_holder_credential_id_key
+		DO UPDATE SET credential = EXCLUDED.credential, schema_url = EXCLUDED.schema_url, schema_type = EXCLUDED.schema_type,
+				schema_hash = EXCLUDED.schema_hash, rev_nonce = EXCLUDED.rev_nonce, proof_types = EXCLUDED.proof_types,
+				revoked = EXCLUDED.revoked, expiration = EXCLUDED.expiration
+		RETURNING id`,
+		id,
+		credential.Holder,
+		credential.Issuer,
+		credential.CredentialID,
+		credential.Credential,
+		credential.SchemaURL,
+		credential.SchemaType,
+		credential.SchemaHash,
+		credential.RevNonce,
+		credential.ProofTypes,
+		credential.Source,
+		credential.Revoked,
+		credential.Expiration).Scan(&id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error saving the holder credential: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *holderCredentials) GetByID(ctx context.Context, conn db.Querier, holder *core.DID, id uuid.UUID) (*domain.HolderCredential, error) {
+	return scanHolderCredential(conn.QueryRow(ctx,
+		`SELECT `+holderCredentialsColumns+` FROM holder_credentials WHERE holder = $1 AND id = $2`, holder.String(), id))
+}
+
+// GetAll returns the credentials of the holder that match the filter, the most recently received first
+func (r *holderCredentials) GetAll(ctx context.Context, conn db.Querier, holder *core.DID, filter *ports.HolderCredentialFilter) ([]*domain.HolderCredential, error) {
+	query := `SELECT ` + holderCredentialsColumns + ` FROM holder_credentials`
+	args := buildHolderCredentialsFilters(holder, filter, &query)
+	query += ` ORDER BY received_at DESC`
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	credentials := make([]*domain.HolderCredential, 0)
+	for rows.Next() {
+		credential, err := scanHolderCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (r *holderCredentials) UpdateRevoked(ctx context.Context, conn db.Querier, credential *domain.HolderCredential) error {
+	tag, err := conn.Exec(ctx, `UPDATE holder_credentials SET revoked = $1 WHERE id = $2`, credential.Revoked, credential.ID)
+	if err != nil {
+		return fmt.Errorf("error updating the holder credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHolderCredentialDoesNotExist
+	}
+	return nil
+}
+
+// RevokeByIssuerNonce marks as revoked every held credential the issuer issued with the revocation nonce
+func (r *holderCredentials) RevokeByIssuerNonce(ctx context.Context, conn db.Querier, issuer *core.DID, revNonce domain.RevNonceUint64) error {
+	_, err := conn.Exec(ctx, `UPDATE holder_credentials SET revoked = true WHERE issuer = $1 AND rev_nonce = $2`, issuer.String(), revNonce)
+	if err != nil {
+		return fmt.Errorf("error revoking the holder credentials: %w", err)
+	}
+	return nil
+}
+
+func (r *holderCredentials) Delete(ctx context.Context, conn db.Querier, holder *core.DID, id uuid.UUID) error {
+	tag, err := conn.Exec(ctx, `DELETE FROM holder_credentials WHERE holder = $1 AND id = $2`, holder.String(), id)
+	if err != nil {
+		return fmt.Errorf("error deleting the holder credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHolderCredentialDoesNotExist
+	}
+	return nil
+}
+
+func buildHolderCredentialsFilters(holder *core.DID, filter *ports.HolderCredentialFilter, query *string) []interface{} {
+	args := []interface{}{holder.String()}
+	*query = fmt.Sprintf("%s WHERE holder = $%d", *query, len(args))
+	if filter == nil {
+		return args
+	}
+
+	if filter.SchemaHash != "" {
+		args = append(args, fmt.Sprintf("%s%%", filter.SchemaHash))
+		*query = fmt.Sprintf("%s AND schema_hash LIKE $%d", *query, len(args))
+	}
+
+	if filter.SchemaType != "" {
+		args = append(args, fmt.Sprintf("%%%s%%", filter.SchemaType))
+		*query = fmt.Sprintf("%s AND schema_type LIKE $%d", *query, len(args))
+	}
+
+	if filter.Issuer != "" {
+		args = append(args, filter.Issuer)
+		*query = fmt.Sprintf("%s AND issuer = $%d", *query, len(args))
+	}
+
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		*query = fmt.Sprintf("%s AND source = $%d", *query, len(args))
+	}
+
+	if filter.Revoked != nil {
+		args = append(args, *filter.Revoked)
+		*query = fmt.Sprintf("%s AND revoked = $%d", *query, len(args))
+	}
+
+	if filter.QueryField != "" {
+		args = append(args, filter.QueryField)
+		*query = fmt.Sprintf("%s AND credential -> 'credentialSubject' ? $%d", *query, len(args))
+	}
+
+	return args
+}
+
+func scanHolderCredential(row pgx.Row) (*domain.HolderCredential, error) {
+	var credential domain.HolderCredential
+	err := row.Scan(&credential.ID,
+		&credential.Holder,
+		&credential.Issuer,
+		&credential.CredentialID,
+		&credential.Credential,
+		&credential.SchemaURL,
+		&credential.SchemaType,
+		&credential.SchemaHash,
+		&credential.RevNonce,
+		&credential.ProofTypes,
+		&credential.Source,
+		&credential.Revoked,
+		&credential.Expiration,
+		&credential.ReceivedAt,
+		&credential.ModifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHolderCredentialDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the holder credential: %w", err)
+	}
+	return &credential, nil
+}
@@ -114,6 +114,44 @@ func (isr *identityState) GetStatesByStatus(ctx context.Context, conn db.Querier
 	return states, nil
 }
 
+// GetConfirmedStates returns the confirmed states of the identity, from the genesis state to the latest one
+func (isr *identityState) GetConfirmedStates(ctx context.Context, conn db.Querier, identifier *core.DID) ([]domain.IdentityState, error) {
+	rows, err := conn.Query(ctx, `SELECT state_id, identifier, state, root_of_roots, claims_tree_root, revocation_tree_root, block_timestamp, block_number, 
+       tx_id, previous_state, status, modified_at, created_at 
+	FROM identity_states WHERE identifier = $1 AND status = 'confirmed' ORDER BY state_id ASC`, identifier.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := []domain.IdentityState{}
+	for rows.Next() {
+		var state domain.IdentityState
+		if err := rows.Scan(&state.StateID,
+			&state.Identifier,
+			&state.State,
+			&state.RootOfRoots,
+			&state.ClaimsTreeRoot,
+			&state.RevocationTreeRoot,
+			&state.BlockTimestamp,
+			&state.BlockNumber,
+			&state.TxID,
+			&state.PreviousState,
+			&state.Status,
+			&state.ModifiedAt,
+			&state.CreatedAt); err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
+
 func (isr *identityState) UpdateState(ctx context.Context, conn db.Querier, state *domain.IdentityState) (int64, error) {
 	tag, err := conn.Exec(ctx, `UPDATE identity_states 
 		SET block_timestamp=$1, block_number=$2, tx_id=$3, status=$4 WHERE state = $5 `,
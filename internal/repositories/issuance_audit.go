@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+const issuanceAuditColumns = `id, issuer, request_id, schema_type, holder, action, actor, policy_id, reason, claim_id, created_at`
+
+type issuanceAudit struct{}
+
+// NewIssuanceAudit returns a new issuance audit repository
+func NewIssuanceAudit() ports.IssuanceAuditRepository {
+	return &issuanceAudit{}
+}
+
+func (r *issuanceAudit) Save(ctx context.Context, conn db.Querier, audit *domain.IssuanceAudit) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO issuance_audit (id, issuer, request_id, schema_type, holder, action, actor, policy_id, reason, claim_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		audit.ID,
+		audit.Issuer,
+		audit.RequestID,
+		audit.SchemaType,
+		audit.Holder,
+		audit.Action,
+		audit.Actor,
+		audit.PolicyID,
+		audit.Reason,
+		audit.ClaimID,
+		audit.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error saving the issuance audit: %w", err)
+	}
+	return nil
+}
+
+// GetAll returns the audit records of the issuer, the oldest first, only the ones of the given request if any
+func (r *issuanceAudit) GetAll(ctx context.Context, conn db.Querier, issuer *core.DID, requestID *uuid.UUID) ([]*domain.IssuanceAudit, error) {
+	query := `SELECT ` + issuanceAuditColumns + ` FROM issuance_audit WHERE issuer = $1`
+	args := []interface{}{issuer.String()}
+	if requestID != nil {
+		args = append(args, *requestID)
+		query = fmt.Sprintf("%s AND request_id = $%d", query, len(args))
+	}
+	query += ` ORDER BY created_at, id`
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*domain.IssuanceAudit, 0)
+	for rows.Next() {
+		audit, err := scanIssuanceAudit(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, audit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func scanIssuanceAudit(row pgx.Row) (*domain.IssuanceAudit, error) {
+	var audit domain.IssuanceAudit
+	err := row.Scan(&audit.ID,
+		&audit.Issuer,
+		&audit.RequestID,
+		&audit.SchemaType,
+		&audit.Holder,
+		&audit.Action,
+		&audit.Actor,
+		&audit.PolicyID,
+		&audit.Reason,
+		&audit.ClaimID,
+		&audit.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning the issuance audit: %w", err)
+	}
+	return &audit, nil
+}
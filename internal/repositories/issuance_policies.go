@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ErrIssuancePolicyDoesNotExist issuance policy does not exist
+var ErrIssuancePolicyDoesNotExist = errors.New("issuance policy does not exist")
+
+const issuancePoliciesColumns = `id, issuer, schema_type, subject_allowlist, field_constraints, action, priority, created_at`
+
+type issuancePolicies struct{}
+
+// NewIssuancePolicies returns a new issuance policy repository
+func NewIssuancePolicies() ports.IssuancePolicyRepository {
+	return &issuancePolicies{}
+}
+
+func (r *issuancePolicies) Save(ctx context.Context, conn db.Querier, policy *domain.IssuancePolicy) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO issuance_policies (id, issuer, schema_type, subject_allowlist, field_constraints, action, priority, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		policy.ID,
+		policy.Issuer,
+		policy.SchemaType,
+		policy.SubjectAllowlist,
+		policy.FieldConstraints,
+		policy.Action,
+		policy.Priority,
+		policy.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error saving the issuance policy: %w", err)
+	}
+	return nil
+}
+
+// GetAll returns the policies of the issuer in evaluation order, the highest priority first
+func (r *issuancePolicies) GetAll(ctx context.Context, conn db.Querier, issuer *core.DID) ([]*domain.IssuancePolicy, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT `+issuancePoliciesColumns+` FROM issuance_policies WHERE issuer = $1 ORDER BY priority DESC, created_at, id`,
+		issuer.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := make([]*domain.IssuancePolicy, 0)
+	for rows.Next() {
+		policy, err := scanIssuancePolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (r *issuancePolicies) Delete(ctx context.Context, conn db.Querier, issuer *core.DID, id uuid.UUID) error {
+	tag, err := conn.Exec(ctx, `DELETE FROM issuance_policies WHERE issuer = $1 AND id = $2`, issuer.String(), id)
+	if err != nil {
+		return fmt.Errorf("error deleting the issuance policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIssuancePolicyDoesNotExist
+	}
+	return nil
+}
+
+func scanIssuancePolicy(row pgx.Row) (*domain.IssuancePolicy, error) {
+	var policy domain.IssuancePolicy
+	err := row.Scan(&policy.ID,
+		&policy.Issuer,
+		&policy.SchemaType,
+		&policy.SubjectAllowlist,
+		&policy.FieldConstraints,
+		&policy.Action,
+		&policy.Priority,
+		&policy.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIssuancePolicyDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the issuance policy: %w", err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+var (
+	// ErrProfileDuplication the identity has already got a profile with the same nonce or for the same verifier
+	ErrProfileDuplication = errors.New("profile duplication error")
+	// ErrProfileDoesNotExist profile does not exist
+	ErrProfileDoesNotExist = errors.New("profile does not exist")
+)
+
+const profilesColumns = `id, identifier, nonce, profile_did, verifier, created_at`
+
+type profiles struct{}
+
+// NewProfiles returns a new identity profile repository
+func NewProfiles() ports.ProfileRepository {
+	return &profiles{}
+}
+
+func (r *profiles) Save(ctx context.Context, conn db.Querier, profile *domain.Profile) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := conn.QueryRow(ctx,
+		`INSERT INTO identity_profiles (identifier, nonce, profile_did, verifier) VALUES ($1, $2, $3, $4) RETURNING id`,
+		profile.Identifier,
+		profile.Nonce,
+		profile.ProfileDID,
+		profile.Verifier).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+			return uuid.Nil, ErrProfileDuplication
+		}
+		return uuid.Nil, fmt.Errorf("error saving the profile: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *profiles) GetByProfileDID(ctx context.Context, conn db.Querier, profileDID *core.DID) (*domain.Profile, error) {
+	return scanProfile(conn.QueryRow(ctx,
+		`SELECT `+profilesColumns+` FROM identity_profiles WHERE profile_did = $1`, profileDID.String()))
+}
+
+func (r *profiles) GetByVerifier(ctx context.Context, conn db.Querier, identifier *core.DID, verifier string) (*domain.Profile, error) {
+	return scanProfile(conn.QueryRow(ctx,
+		`SELECT `+profilesColumns+` FROM identity_profiles WHERE identifier = $1 AND verifier = $2`, identifier.String(), verifier))
+}
+
+// GetAllByIdentifier returns the profiles of the identity in the order they were created
+func (r *profiles) GetAllByIdentifier(ctx context.Context, conn db.Querier, identifier *core.DID) ([]domain.Profile, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT `+profilesColumns+` FROM identity_profiles WHERE identifier = $1 ORDER BY created_at`, identifier.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := make([]domain.Profile, 0)
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+func scanProfile(row pgx.Row) (*domain.Profile, error) {
+	var profile domain.Profile
+	err := row.Scan(&profile.ID,
+		&profile.Identifier,
+		&profile.Nonce,
+		&profile.ProfileDID,
+		&profile.Verifier,
+		&profile.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProfileDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the profile: %w", err)
+	}
+	return &profile, nil
+}
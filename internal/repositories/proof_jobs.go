@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+// ErrProofJobDoesNotExist proof job does not exist
+var ErrProofJobDoesNotExist = errors.New("proof job does not exist")
+
+const proofJobsColumns = `id, identifier, queries, status, result, error, created_at, started_at, finished_at, expires_at`
+
+type proofJobs struct{}
+
+// NewProofJobs returns a new proof job repository
+func NewProofJobs() ports.ProofJobRepository {
+	return &proofJobs{}
+}
+
+func (r *proofJobs) Save(ctx context.Context, conn db.Querier, job *domain.ProofJob) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO proof_jobs (id, identifier, queries, status, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		job.ID,
+		job.Identifier,
+		job.Queries,
+		job.Status,
+		job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error saving the proof job: %w", err)
+	}
+	return nil
+}
+
+func (r *proofJobs) GetByID(ctx context.Context, conn db.Querier, identifier *core.DID, id uuid.UUID) (*domain.ProofJob, error) {
+	return scanProofJob(conn.QueryRow(ctx,
+		`SELECT `+proofJobsColumns+` FROM proof_jobs WHERE identifier = $1 AND id = $2`, identifier.String(), id))
+}
+
+// Next marks as running the oldest pending job, or running job whose lease started before staleBefore, and returns it.
+// Concurrent callers never get the same job.
+func (r *proofJobs) Next(ctx context.Context, conn db.Querier, staleBefore time.Time) (*domain.ProofJob, error) {
+	return scanProofJob(conn.QueryRow(ctx,
+		`UPDATE proof_jobs SET status = 'running', started_at = $1
+		WHERE id = (SELECT id FROM proof_jobs WHERE status = 'pending' OR (status = 'running' AND started_at < $2)
+			ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING `+proofJobsColumns, time.Now(), staleBefore))
+}
+
+// Finish records the outcome of a running job. It returns ErrProofJobDoesNotExist if the job is not running anymore,
+// or another worker has taken it since.
+func (r *proofJobs) Finish(ctx context.Context, conn db.Querier, job *domain.ProofJob) error {
+	tag, err := conn.Exec(ctx,
+		`UPDATE proof_jobs SET status = $1, result = $2, error = $3, finished_at = $4, expires_at = $5
+		WHERE id = $6 AND status = 'running' AND started_at = $7`,
+		job.Status, job.Result, job.Error, job.FinishedAt, job.ExpiresAt, job.ID, job.StartedAt)
+	if err != nil {
+		return fmt.Errorf("error updating the proof job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProofJobDoesNotExist
+	}
+	return nil
+}
+
+// Release sets a running job back to pending, for another worker to run it, unless another worker has taken it since
+func (r *proofJobs) Release(ctx context.Context, conn db.Querier, job *domain.ProofJob) error {
+	_, err := conn.Exec(ctx,
+		`UPDATE proof_jobs SET status = 'pending', started_at = NULL WHERE id = $1 AND status = 'running' AND started_at = $2`,
+		job.ID, job.StartedAt)
+	if err != nil {
+		return fmt.Errorf("error releasing the proof job: %w", err)
+	}
+	return nil
+}
+
+// Cancel cancels a pending or running job and returns it. It returns ErrProofJobDoesNotExist if there is no such job
+// or it has already finished.
+func (r *proofJobs) Cancel(ctx context.Context, conn db.Querier, identifier *core.DID, id uuid.UUID, expiresAt time.Time) (*domain.ProofJob, error) {
+	return scanProofJob(conn.QueryRow(ctx,
+		`UPDATE proof_jobs SET status = 'cancelled', finished_at = $1, expires_at = $2
+		WHERE identifier = $3 AND id = $4 AND status IN ('pending', 'running')
+		RETURNING `+proofJobsColumns, time.Now(), expiresAt, identifier.String(), id))
+}
+
+// DeleteExpired deletes the finished jobs whose results have expired and returns how many
+func (r *proofJobs) DeleteExpired(ctx context.Context, conn db.Querier, now time.Time) (int64, error) {
+	tag, err := conn.Exec(ctx, `DELETE FROM proof_jobs WHERE expires_at < $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting the expired proof jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanProofJob(row pgx.Row) (*domain.ProofJob, error) {
+	var job domain.ProofJob
+	err := row.Scan(&job.ID,
+		&job.Identifier,
+		&job.Queries,
+		&job.Status,
+		&job.Result,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProofJobDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the proof job: %w", err)
+	}
+	return &job, nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+var (
+	// ErrProofRequestTemplateDuplication there is already a template with the same name
+	ErrProofRequestTemplateDuplication = errors.New("proof request template duplication error")
+	// ErrProofRequestTemplateDoesNotExist proof request template does not exist
+	ErrProofRequestTemplateDoesNotExist = errors.New("proof request template does not exist")
+)
+
+const proofRequestTemplatesColumns = `id, name, circuit_id, context, type, allowed_issuers, credential_subject, reason, modified_at, created_at`
+
+type proofRequestTemplates struct{}
+
+// NewProofRequestTemplates returns a new proof request template repository
+func NewProofRequestTemplates() ports.ProofRequestTemplateRepository {
+	return &proofRequestTemplates{}
+}
+
+// Save creates the template, or updates it if it already has an id
+func (r *proofRequestTemplates) Save(ctx context.Context, conn db.Querier, template *domain.ProofRequestTemplate) (uuid.UUID, error) {
+	var err error
+	id := template.ID
+
+	if template.CredentialSubject.Status == pgtype.Undefined {
+		template.CredentialSubject.Status = pgtype.Null
+	}
+
+	if id == uuid.Nil {
+		err = conn.QueryRow(ctx,
+			`INSERT INTO proof_request_templates (name, circuit_id, context, type, allowed_issuers, credential_subject, reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+			template.Name,
+			template.CircuitID,
+			template.Context,
+			template.Type,
+			template.AllowedIssuers,
+			template.CredentialSubject,
+			template.Reason).Scan(&id)
+	} else {
+		_, err = conn.Exec(ctx,
+			`INSERT INTO proof_request_templates (id, name, circuit_id, context, type, allowed_issuers, credential_subject, reason)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT ON CONSTRAINT proof_request_templates_pkey
+			DO UPDATE SET name = $2, circuit_id = $3, context = $4, type = $5, allowed_issuers = $6, credential_subject = $7, reason = $8`,
+			id,
+			template.Name,
+			template.CircuitID,
+			template.Context,
+			template.Type,
+			template.AllowedIssuers,
+			template.CredentialSubject,
+			template.Reason)
+	}
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+			return uuid.Nil, ErrProofRequestTemplateDuplication
+		}
+		return uuid.Nil, fmt.Errorf("error saving the proof request template: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *proofRequestTemplates) GetByID(ctx context.Context, conn db.Querier, id uuid.UUID) (*domain.ProofRequestTemplate, error) {
+	return scanProofRequestTemplate(conn.QueryRow(ctx,
+		`SELECT `+proofRequestTemplatesColumns+` FROM proof_request_templates WHERE id = $1`, id))
+}
+
+// GetAll returns all the templates sorted by name
+func (r *proofRequestTemplates) GetAll(ctx context.Context, conn db.Querier) ([]domain.ProofRequestTemplate, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT `+proofRequestTemplatesColumns+` FROM proof_request_templates ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]domain.ProofRequestTemplate, 0)
+	for rows.Next() {
+		template, err := scanProofRequestTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *proofRequestTemplates) Delete(ctx context.Context, conn db.Querier, id uuid.UUID) error {
+	tag, err := conn.Exec(ctx, `DELETE FROM proof_request_templates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting the proof request template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProofRequestTemplateDoesNotExist
+	}
+	return nil
+}
+
+func scanProofRequestTemplate(row pgx.Row) (*domain.ProofRequestTemplate, error) {
+	var template domain.ProofRequestTemplate
+	err := row.Scan(&template.ID,
+		&template.Name,
+		&template.CircuitID,
+		&template.Context,
+		&template.Type,
+		&template.AllowedIssuers,
+		&template.CredentialSubject,
+		&template.Reason,
+		&template.ModifiedAt,
+		&template.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProofRequestTemplateDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the proof request template: %w", err)
+	}
+	return &template, nil
+}
@@ -6,7 +6,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/labstack/gommon/log"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
 
 	"github.com/lastingasset/wallet-service/internal/core/domain"
 	"github.com/lastingasset/wallet-service/internal/core/ports"
@@ -18,8 +19,12 @@ var (
 	ErrAuthRequestDuplication = errors.New("authRequest duplication error")
 	// ErrAuthRequestDoesNotExist authRequest does not exist
 	ErrAuthRequestDoesNotExist = errors.New("authRequest does not exist")
+	// ErrAuthRequestInvalidStatus authRequest can not move to the given status
+	ErrAuthRequestInvalidStatus = errors.New("authRequest status transition not allowed")
 )
 
+const authRequestsColumns = `id, thread_id, verifier, identifier, request, status, challenge, verified_did, disclosed, expires_at, modified_at, created_at`
+
 type authRequests struct{}
 
 // NewAuthRequests returns a new authRequest repository
@@ -30,9 +35,156 @@ func NewAuthRequests() ports.ReqsRepository {
 func (c *authRequests) Save(ctx context.Context, conn db.Querier, authRequest *domain.AuthRequest) (uuid.UUID, error) {
 	var err error
 	id := authRequest.ID
-	log.Info(ctx, "Saving the auth request ............. ")
-	if err == nil {
-		return id, nil
+
+	if authRequest.Request.Status == pgtype.Undefined {
+		authRequest.Request.Status = pgtype.Null
+	}
+	if authRequest.Status == "" {
+		authRequest.Status = domain.AuthRequestStatusCreated
+	}
+
+	if id == uuid.Nil {
+		err = conn.QueryRow(ctx,
+			`INSERT INTO auth_requests (thread_id, verifier, identifier, request, status, challenge, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+			authRequest.ThreadID,
+			authRequest.Verifier,
+			authRequest.Identifier,
+			authRequest.Request,
+			authRequest.Status,
+			authRequest.Challenge,
+			authRequest.ExpiresAt).Scan(&id)
+	} else {
+		_, err = conn.Exec(ctx,
+			`INSERT INTO auth_requests (id, thread_id, verifier, identifier, request, status, challenge, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT ON CONSTRAINT auth_requests_pkey
+			DO UPDATE SET thread_id = $2, verifier = $3, identifier = $4, request = $5, status = $6, challenge = $7, expires_at = $8`,
+			id,
+			authRequest.ThreadID,
+			authRequest.Verifier,
+			authRequest.Identifier,
+			authRequest.Request,
+			authRequest.Status,
+			authRequest.Challenge,
+			authRequest.ExpiresAt)
+	}
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("error saving the authRequest: %w", err)
+	}
+
+	return id, nil
+}
+
+func (c *authRequests) GetByID(ctx context.Context, conn db.Querier, id uuid.UUID) (*domain.AuthRequest, error) {
+	authRequest, err := scanAuthRequest(conn.QueryRow(ctx,
+		`SELECT `+authRequestsColumns+` FROM auth_requests WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return authRequest, nil
+}
+
+// GetByThreadID returns the latest auth request sent within the given thread
+func (c *authRequests) GetByThreadID(ctx context.Context, conn db.Querier, threadID string) (*domain.AuthRequest, error) {
+	authRequest, err := scanAuthRequest(conn.QueryRow(ctx,
+		`SELECT `+authRequestsColumns+` FROM auth_requests WHERE thread_id = $1 ORDER BY created_at DESC LIMIT 1`, threadID))
+	if err != nil {
+		return nil, err
+	}
+	return authRequest, nil
+}
+
+// UpdateStatus moves the auth request to the given status.
+// It returns ErrAuthRequestInvalidStatus if the current status does not allow the transition.
+func (c *authRequests) UpdateStatus(ctx context.Context, conn db.Querier, id uuid.UUID, status domain.AuthRequestStatus) error {
+	tag, err := conn.Exec(ctx,
+		`UPDATE auth_requests SET status = $2 WHERE id = $1 AND status = ANY($3::auth_request_status[])`,
+		id, status, authRequestStatusesToStrings(status.PreviousStatuses()))
+	if err != nil {
+		return fmt.Errorf("error updating the authRequest status: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := c.GetByID(ctx, conn, id); err != nil {
+			return err
+		}
+		return ErrAuthRequestInvalidStatus
+	}
+
+	return nil
+}
+
+// UpdateVerification records the DID that answered the request and the values it disclosed
+func (c *authRequests) UpdateVerification(ctx context.Context, conn db.Querier, id uuid.UUID, verifiedDID string, disclosed pgtype.JSONB) error {
+	if disclosed.Status == pgtype.Undefined {
+		disclosed.Status = pgtype.Null
+	}
+
+	tag, err := conn.Exec(ctx,
+		`UPDATE auth_requests SET verified_did = $2, disclosed = $3 WHERE id = $1`, id, verifiedDID, disclosed)
+	if err != nil {
+		return fmt.Errorf("error updating the authRequest verification: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAuthRequestDoesNotExist
+	}
+	return nil
+}
+
+// ListByVerifier returns all the auth requests sent by the given verifier, newest first
+func (c *authRequests) ListByVerifier(ctx context.Context, conn db.Querier, verifier string) ([]domain.AuthRequest, error) {
+	rows, err := conn.Query(ctx,
+		`SELECT `+authRequestsColumns+` FROM auth_requests WHERE verifier = $1 ORDER BY created_at DESC`, verifier)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authRequests := make([]domain.AuthRequest, 0)
+	for rows.Next() {
+		authRequest, err := scanAuthRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		authRequests = append(authRequests, *authRequest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return authRequests, nil
+}
+
+func scanAuthRequest(row pgx.Row) (*domain.AuthRequest, error) {
+	var authRequest domain.AuthRequest
+	err := row.Scan(&authRequest.ID,
+		&authRequest.ThreadID,
+		&authRequest.Verifier,
+		&authRequest.Identifier,
+		&authRequest.Request,
+		&authRequest.Status,
+		&authRequest.Challenge,
+		&authRequest.VerifiedDID,
+		&authRequest.Disclosed,
+		&authRequest.ExpiresAt,
+		&authRequest.ModifiedAt,
+		&authRequest.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuthRequestDoesNotExist
+		}
+		return nil, fmt.Errorf("error scanning the authRequest: %w", err)
+	}
+	return &authRequest, nil
+}
+
+func authRequestStatusesToStrings(statuses []domain.AuthRequestStatus) []string {
+	res := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		res = append(res, string(status))
 	}
-	return uuid.Nil, fmt.Errorf("error saving the authRequest: %w", err)
+	return res
 }
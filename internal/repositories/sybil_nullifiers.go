@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/db"
+)
+
+var (
+	// ErrSybilNullifierDuplicated the person has already registered with the verifier for the CRS
+	ErrSybilNullifierDuplicated = errors.New("sybil nullifier already registered")
+	// ErrSybilNullifierDoesNotExist sybil nullifier does not exist
+	ErrSybilNullifierDoesNotExist = errors.New("sybil nullifier does not exist")
+)
+
+type sybilNullifiers struct{}
+
+// NewSybilNullifiers returns a new sybil nullifier repository
+func NewSybilNullifiers() ports.SybilNullifierRepository {
+	return &sybilNullifiers{}
+}
+
+func (r *sybilNullifiers) Save(ctx context.Context, conn db.Querier, nullifier *domain.SybilNullifier) error {
+	_, err := conn.Exec(ctx,
+		`INSERT INTO sybil_nullifiers (id, verifier, crs, sybil_id, user_id, auth_request_id, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		nullifier.ID,
+		nullifier.Verifier,
+		nullifier.CRS,
+		nullifier.SybilID,
+		nullifier.UserID,
+		nullifier.AuthRequestID,
+		nullifier.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == duplicateViolationErrorCode {
+			return ErrSybilNullifierDuplicated
+		}
+		return fmt.Errorf("error saving the sybil nullifier: %w", err)
+	}
+	return nil
+}
+
+func (r *sybilNullifiers) Get(ctx context.Context, conn db.Querier, verifier string, crs string, sybilID string) (*domain.SybilNullifier, error) {
+	var nullifier domain.SybilNullifier
+	err := conn.QueryRow(ctx,
+		`SELECT id, verifier, crs, sybil_id, user_id, auth_request_id, created_at
+		FROM sybil_nullifiers WHERE verifier = $1 AND crs = $2 AND sybil_id = $3`, verifier, crs, sybilID).Scan(
+		&nullifier.ID,
+		&nullifier.Verifier,
+		&nullifier.CRS,
+		&nullifier.SybilID,
+		&nullifier.UserID,
+		&nullifier.AuthRequestID,
+		&nullifier.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSybilNullifierDoesNotExist
+		}
+		return nil, fmt.Errorf("error getting the sybil nullifier: %w", err)
+	}
+	return &nullifier, nil
+}
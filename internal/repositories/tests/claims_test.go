@@ -3,12 +3,16 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	core "github.com/iden3/go-iden3-core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/lastingasset/wallet-service/internal/common"
 	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
 	"github.com/lastingasset/wallet-service/internal/db/tests"
 	"github.com/lastingasset/wallet-service/internal/repositories"
 )
@@ -139,3 +143,103 @@ func TestRevokeNonce(t *testing.T) {
 		}))
 	})
 }
+
+func TestMarkExpired(t *testing.T) {
+	ctx := context.Background()
+	fixture := tests.NewFixture(storage)
+	idStr := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	claim := fixture.NewClaim(t, idStr)
+	claim.Expiration = time.Now().Add(-time.Hour).Unix()
+	claimID := fixture.CreateClaim(t, claim)
+
+	claimsRepo := repositories.NewClaims()
+	t.Run("should filter the expired claims", func(t *testing.T) {
+		expired, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, &ports.Filter{Expired: common.ToPointer(true)})
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, claimID, expired[0].ID)
+
+		notExpired, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, &ports.Filter{Expired: common.ToPointer(false)})
+		require.NoError(t, err)
+		assert.Empty(t, notExpired)
+	})
+
+	t.Run("should mark the expired claims once", func(t *testing.T) {
+		expired, err := claimsRepo.MarkExpired(ctx, storage.Pgx, time.Now())
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(expired))
+		for _, c := range expired {
+			ids = append(ids, c.ID)
+		}
+		assert.Contains(t, ids, claimID)
+
+		again, err := claimsRepo.MarkExpired(ctx, storage.Pgx, time.Now())
+		require.NoError(t, err)
+		for _, c := range again {
+			assert.NotEqual(t, claimID, c.ID)
+		}
+	})
+
+	t.Run("should mark the unmarked claims again", func(t *testing.T) {
+		require.NoError(t, claimsRepo.UnmarkExpired(ctx, storage.Pgx, []uuid.UUID{claimID}))
+
+		expired, err := claimsRepo.MarkExpired(ctx, storage.Pgx, time.Now())
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, 0, len(expired))
+		for _, c := range expired {
+			ids = append(ids, c.ID)
+		}
+		assert.Contains(t, ids, claimID)
+	})
+}
+
+func TestGetAllByIssuerIDPages(t *testing.T) {
+	ctx := context.Background()
+	fixture := tests.NewFixture(storage)
+	idStr := "did:polygonid:polygon:mumbai:2qE1BZ7gcmEoP2KppvFPCZqyzyb5tK9T6Gec5HFANQ"
+	subject := "did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp"
+	did, err := core.ParseDID(subject)
+	require.NoError(t, err)
+
+	claimIDs := make([]uuid.UUID, 0, 3)
+	for i := 0; i < 3; i++ {
+		claim := fixture.NewClaim(t, idStr)
+		claim.OtherIdentifier = subject
+		claim.HIndex = uuid.NewString()
+		claimIDs = append(claimIDs, fixture.CreateClaim(t, claim))
+	}
+
+	claimsRepo := repositories.NewClaims()
+	t.Run("should count and page the claims", func(t *testing.T) {
+		filter, err := ports.NewClaimsFilter(nil, nil, nil, nil, common.ToPointer("19960424"), nil, nil, nil)
+		require.NoError(t, err)
+		total, err := claimsRepo.CountByIssuerID(ctx, storage.Pgx, did, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+
+		filter.Limit = 2
+		first, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, filter)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		last := first[len(first)-1]
+		filter.Cursor = &ports.ClaimsCursor{Value: last.CreatedAt.Format(time.RFC3339Nano), ID: last.ID}
+		second, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, filter)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+
+		assert.ElementsMatch(t, claimIDs, []uuid.UUID{first[0].ID, first[1].ID, second[0].ID})
+	})
+
+	t.Run("should not find the claims without the searched value", func(t *testing.T) {
+		filter, err := ports.NewClaimsFilter(nil, nil, nil, nil, common.ToPointer("19700101"), nil, nil, nil)
+		require.NoError(t, err)
+		none, err := claimsRepo.GetAllByIssuerID(ctx, storage.Pgx, did, filter)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/common"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestCredentialRequests(t *testing.T) {
+	ctx := context.Background()
+	credentialRequestsRepo := repositories.NewCredentialRequests()
+	issuer, err := core.ParseDID("did:iden3:polygon:mumbai:x3HstHLj2rTp6HHXk2WczYP7w3rpCsRbwCMeaQ2H2")
+	require.NoError(t, err)
+	holder, err := core.ParseDID("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ")
+	require.NoError(t, err)
+
+	req := &domain.CredentialRequest{
+		ID:         uuid.New(),
+		Issuer:     issuer.String(),
+		Holder:     holder.String(),
+		ThreadID:   uuid.NewString(),
+		SchemaURL:  "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		SchemaType: "KYCAgeCredential",
+		Status:     domain.CredentialRequestPending,
+	}
+	require.NoError(t, req.CredentialSubject.Set(map[string]interface{}{"id": holder.String(), "birthday": 19960424}))
+	require.NoError(t, credentialRequestsRepo.Save(ctx, storage.Pgx, req))
+
+	t.Run("should get the request of the thread", func(t *testing.T) {
+		byThread, err := credentialRequestsRepo.GetByThread(ctx, storage.Pgx, issuer, holder, req.ThreadID)
+		require.NoError(t, err)
+		assert.Equal(t, req.ID, byThread.ID)
+		assert.True(t, byThread.IsPending())
+
+		_, err = credentialRequestsRepo.GetByThread(ctx, storage.Pgx, issuer, holder, uuid.NewString())
+		assert.ErrorIs(t, err, repositories.ErrCredentialRequestDoesNotExist)
+	})
+
+	t.Run("should reject the pending request once", func(t *testing.T) {
+		req.Status = domain.CredentialRequestRejected
+		req.Reason = common.ToPointer("the data could not be checked")
+		require.NoError(t, credentialRequestsRepo.UpdateStatus(ctx, storage.Pgx, req, domain.CredentialRequestPending))
+		assert.ErrorIs(t, credentialRequestsRepo.UpdateStatus(ctx, storage.Pgx, req, domain.CredentialRequestPending), repositories.ErrCredentialRequestDoesNotExist)
+
+		locked, err := credentialRequestsRepo.GetByIDForUpdate(ctx, storage.Pgx, issuer, req.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CredentialRequestRejected, locked.Status)
+
+		rejected := domain.CredentialRequestRejected
+		all, err := credentialRequestsRepo.GetAll(ctx, storage.Pgx, issuer, &rejected)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, "the data could not be checked", *all[0].Reason)
+
+		pending := domain.CredentialRequestPending
+		none, err := credentialRequestsRepo.GetAll(ctx, storage.Pgx, issuer, &pending)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/core/ports"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestHolderCredentials(t *testing.T) {
+	ctx := context.Background()
+	holderCredentialsRepo := repositories.NewHolderCredentials()
+	holder, err := core.ParseDID("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ")
+	require.NoError(t, err)
+	issuer, err := core.ParseDID("did:iden3:polygon:mumbai:x3HstHLj2rTp6HHXk2WczYP7w3rpCsRbwCMeaQ2H2")
+	require.NoError(t, err)
+
+	credential := &domain.HolderCredential{
+		Holder:       holder.String(),
+		Issuer:       issuer.String(),
+		CredentialID: "urn:uuid:" + uuid.NewString(),
+		SchemaURL:    "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+		SchemaType:   "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld#KYCAgeCredential",
+		SchemaHash:   "ca938857241db9451ea329256b9c06e5",
+		RevNonce:     domain.RevNonceUint64(1234),
+		ProofTypes:   []string{"BJJSignature2021"},
+		Source:       domain.HolderCredentialSourceOffer,
+	}
+	require.NoError(t, credential.Credential.Set(map[string]interface{}{
+		"id":                credential.CredentialID,
+		"credentialSubject": map[string]interface{}{"id": holder.String(), "birthday": 19960424},
+	}))
+
+	id, err := holderCredentialsRepo.Save(ctx, storage.Pgx, credential)
+	require.NoError(t, err)
+
+	t.Run("should get the credential by id", func(t *testing.T) {
+		byID, err := holderCredentialsRepo.GetByID(ctx, storage.Pgx, holder, id)
+		require.NoError(t, err)
+		assert.Equal(t, credential.CredentialID, byID.CredentialID)
+		assert.Equal(t, domain.HolderCredentialSourceOffer, byID.Source)
+		assert.Equal(t, []string{"BJJSignature2021"}, byID.ProofTypes)
+	})
+
+	t.Run("should replace the credential with the same credential id", func(t *testing.T) {
+		credential.ProofTypes = []string{"BJJSignature2021", "Iden3SparseMerkleTreeProof"}
+		replacedID, err := holderCredentialsRepo.Save(ctx, storage.Pgx, credential)
+		require.NoError(t, err)
+		assert.Equal(t, id, replacedID)
+	})
+
+	t.Run("should search the credentials of the holder", func(t *testing.T) {
+		all, err := holderCredentialsRepo.GetAll(ctx, storage.Pgx, holder, &ports.HolderCredentialFilter{
+			Issuer:     issuer.String(),
+			SchemaType: "KYCAgeCredential",
+			QueryField: "birthday",
+		})
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Len(t, all[0].ProofTypes, 2)
+
+		none, err := holderCredentialsRepo.GetAll(ctx, storage.Pgx, holder, &ports.HolderCredentialFilter{Source: domain.HolderCredentialSourceImport})
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("should revoke the credentials by issuer and nonce", func(t *testing.T) {
+		require.NoError(t, holderCredentialsRepo.RevokeByIssuerNonce(ctx, storage.Pgx, issuer, credential.RevNonce))
+		revoked, err := holderCredentialsRepo.GetByID(ctx, storage.Pgx, holder, id)
+		require.NoError(t, err)
+		assert.True(t, revoked.Revoked)
+	})
+
+	t.Run("should delete the credential", func(t *testing.T) {
+		require.NoError(t, holderCredentialsRepo.Delete(ctx, storage.Pgx, holder, id))
+		_, err := holderCredentialsRepo.GetByID(ctx, storage.Pgx, holder, id)
+		assert.ErrorIs(t, err, repositories.ErrHolderCredentialDoesNotExist)
+	})
+}
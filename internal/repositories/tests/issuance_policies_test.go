@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestIssuancePolicies(t *testing.T) {
+	ctx := context.Background()
+	policiesRepo := repositories.NewIssuancePolicies()
+	auditRepo := repositories.NewIssuanceAudit()
+	credentialRequestsRepo := repositories.NewCredentialRequests()
+	issuer, err := core.ParseDID("did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp")
+	require.NoError(t, err)
+	holder := "did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ"
+
+	manual, err := domain.NewIssuancePolicy(issuer.String(), "KYCAgeCredential", nil, map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}}, domain.IssuancePolicyManual, 0)
+	require.NoError(t, err)
+	require.NoError(t, policiesRepo.Save(ctx, storage.Pgx, manual))
+	allowed, err := domain.NewIssuancePolicy(issuer.String(), "", []string{holder}, nil, domain.IssuancePolicyAutoApprove, 10)
+	require.NoError(t, err)
+	require.NoError(t, policiesRepo.Save(ctx, storage.Pgx, allowed))
+
+	t.Run("should get the policies by priority", func(t *testing.T) {
+		policies, err := policiesRepo.GetAll(ctx, storage.Pgx, issuer)
+		require.NoError(t, err)
+		require.Len(t, policies, 2)
+		assert.Equal(t, allowed.ID, policies[0].ID)
+		assert.Equal(t, manual.ID, policies[1].ID)
+
+		subject := map[string]interface{}{"id": "did:iden3:polygon:mumbai:wzS76vqXD6XhkU1LtUXbM1MMZEbjgt2GS8tU1koD3", "birthday": float64(19960424)}
+		matches, err := policies[0].Matches("KYCAgeCredential", subject)
+		require.NoError(t, err)
+		assert.False(t, matches)
+		matches, err = policies[1].Matches("KYCAgeCredential", subject)
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+
+	t.Run("should record the audit of a request", func(t *testing.T) {
+		req := &domain.CredentialRequest{
+			ID:         uuid.New(),
+			Issuer:     issuer.String(),
+			Holder:     holder,
+			ThreadID:   uuid.NewString(),
+			SchemaURL:  "https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json/KYCAgeCredential-v3.json",
+			SchemaType: "KYCAgeCredential",
+			Status:     domain.CredentialRequestPending,
+		}
+		require.NoError(t, req.CredentialSubject.Set(map[string]interface{}{"id": holder, "birthday": 19960424}))
+		require.NoError(t, credentialRequestsRepo.Save(ctx, storage.Pgx, req))
+
+		for i, action := range []domain.IssuanceAuditAction{domain.IssuanceAuditRequested, domain.IssuanceAuditRejected} {
+			require.NoError(t, auditRepo.Save(ctx, storage.Pgx, &domain.IssuanceAudit{
+				ID:         uuid.New(),
+				Issuer:     issuer.String(),
+				RequestID:  &req.ID,
+				SchemaType: req.SchemaType,
+				Holder:     holder,
+				Action:     action,
+				Actor:      "admin",
+				PolicyID:   &manual.ID,
+				CreatedAt:  time.Now().Add(time.Duration(i) * time.Second),
+			}))
+		}
+
+		records, err := auditRepo.GetAll(ctx, storage.Pgx, issuer, &req.ID)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, domain.IssuanceAuditRequested, records[0].Action)
+		assert.Equal(t, domain.IssuanceAuditRejected, records[1].Action)
+		assert.Equal(t, "admin", records[1].Actor)
+	})
+
+	t.Run("should delete a policy of the issuer", func(t *testing.T) {
+		require.NoError(t, policiesRepo.Delete(ctx, storage.Pgx, issuer, manual.ID))
+		assert.ErrorIs(t, policiesRepo.Delete(ctx, storage.Pgx, issuer, manual.ID), repositories.ErrIssuancePolicyDoesNotExist)
+	})
+}
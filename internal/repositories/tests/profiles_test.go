@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/iden3/go-iden3-core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/common"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/db/tests"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestProfiles(t *testing.T) {
+	ctx := context.Background()
+	profilesRepo := repositories.NewProfiles()
+	idStr := "did:iden3:polygon:mumbai:wzS76vqXD6XhkU1LtUXbM1MMZEbjgt2GS8tU1koD3"
+	fixture := tests.NewFixture(storage)
+	fixture.CreateIdentity(t, &domain.Identity{Identifier: idStr})
+	did, err := core.ParseDID(idStr)
+	require.NoError(t, err)
+
+	profile, err := domain.NewProfile(did, domain.ProfileNonce(4321), common.ToPointer("https://verifier.example.com"))
+	require.NoError(t, err)
+	id, err := profilesRepo.Save(ctx, storage.Pgx, profile)
+	require.NoError(t, err)
+
+	t.Run("should get the profile by its did", func(t *testing.T) {
+		profileDID, err := core.ParseDID(profile.ProfileDID)
+		require.NoError(t, err)
+		byDID, err := profilesRepo.GetByProfileDID(ctx, storage.Pgx, profileDID)
+		require.NoError(t, err)
+		assert.Equal(t, id, byDID.ID)
+		assert.Equal(t, idStr, byDID.Identifier)
+		assert.Equal(t, domain.ProfileNonce(4321), byDID.Nonce)
+	})
+
+	t.Run("should get the profile of the verifier", func(t *testing.T) {
+		byVerifier, err := profilesRepo.GetByVerifier(ctx, storage.Pgx, did, "https://verifier.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, profile.ProfileDID, byVerifier.ProfileDID)
+	})
+
+	t.Run("should not save two profiles with the same nonce", func(t *testing.T) {
+		duplicated, err := domain.NewProfile(did, domain.ProfileNonce(4321), nil)
+		require.NoError(t, err)
+		_, err = profilesRepo.Save(ctx, storage.Pgx, duplicated)
+		assert.ErrorIs(t, err, repositories.ErrProfileDuplication)
+	})
+
+	t.Run("should get all the profiles of the identity", func(t *testing.T) {
+		all, err := profilesRepo.GetAllByIdentifier(ctx, storage.Pgx, did)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	core "github.com/iden3/go-iden3-core"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestProofJobs(t *testing.T) {
+	ctx := context.Background()
+	jobsRepo := repositories.NewProofJobs()
+	identifier, err := core.ParseDID("did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ")
+	require.NoError(t, err)
+	// the jobs running for less than an hour keep their lease
+	staleBefore := time.Now().Add(-time.Hour)
+
+	newJob := func() *domain.ProofJob {
+		job := &domain.ProofJob{
+			ID:         uuid.New(),
+			Identifier: identifier.String(),
+			Status:     domain.ProofJobPending,
+			Result:     pgtype.JSONB{Status: pgtype.Null},
+			CreatedAt:  time.Now(),
+		}
+		require.NoError(t, job.Queries.Set([]map[string]interface{}{{"RequestID": 1}}))
+		require.NoError(t, jobsRepo.Save(ctx, storage.Pgx, job))
+		return job
+	}
+
+	t.Run("should run a pending job and record its proofs", func(t *testing.T) {
+		job := newJob()
+
+		running, err := jobsRepo.Next(ctx, storage.Pgx, staleBefore)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, running.ID)
+		assert.Equal(t, domain.ProofJobRunning, running.Status)
+		require.NotNil(t, running.StartedAt)
+
+		_, err = jobsRepo.Next(ctx, storage.Pgx, staleBefore)
+		assert.ErrorIs(t, err, repositories.ErrProofJobDoesNotExist)
+
+		now := time.Now()
+		running.Status = domain.ProofJobDone
+		running.FinishedAt = &now
+		running.ExpiresAt = &now
+		require.NoError(t, running.SetResult([]domain.ProofJobScope{{ID: 1, CircuitID: "credentialAtomicQueryMTPV2", Proof: &domain.FullProof{PubSignals: []string{"1"}}}}))
+		require.NoError(t, jobsRepo.Finish(ctx, storage.Pgx, running))
+
+		done, err := jobsRepo.GetByID(ctx, storage.Pgx, identifier, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProofJobDone, done.Status)
+		result, err := done.GetResult()
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, []string{"1"}, result[0].Proof.PubSignals)
+
+		_, err = jobsRepo.Cancel(ctx, storage.Pgx, identifier, job.ID, time.Now())
+		assert.ErrorIs(t, err, repositories.ErrProofJobDoesNotExist)
+	})
+
+	t.Run("should not record the proofs of a cancelled job", func(t *testing.T) {
+		job := newJob()
+		running, err := jobsRepo.Next(ctx, storage.Pgx, staleBefore)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, running.ID)
+
+		cancelled, err := jobsRepo.Cancel(ctx, storage.Pgx, identifier, job.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProofJobCancelled, cancelled.Status)
+
+		running.Status = domain.ProofJobDone
+		assert.ErrorIs(t, jobsRepo.Finish(ctx, storage.Pgx, running), repositories.ErrProofJobDoesNotExist)
+	})
+
+	t.Run("should give another worker a running job whose lease expired", func(t *testing.T) {
+		job := newJob()
+		running, err := jobsRepo.Next(ctx, storage.Pgx, staleBefore)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, running.ID)
+
+		_, err = jobsRepo.Next(ctx, storage.Pgx, staleBefore)
+		assert.ErrorIs(t, err, repositories.ErrProofJobDoesNotExist)
+
+		retaken, err := jobsRepo.Next(ctx, storage.Pgx, time.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, retaken.ID)
+		assert.Equal(t, domain.ProofJobRunning, retaken.Status)
+		assert.True(t, retaken.StartedAt.After(*running.StartedAt))
+
+		// the worker that lost the lease can neither record nor release the job
+		now := time.Now()
+		running.Status = domain.ProofJobDone
+		running.FinishedAt = &now
+		running.ExpiresAt = &now
+		assert.ErrorIs(t, jobsRepo.Finish(ctx, storage.Pgx, running), repositories.ErrProofJobDoesNotExist)
+		require.NoError(t, jobsRepo.Release(ctx, storage.Pgx, running))
+
+		stored, err := jobsRepo.GetByID(ctx, storage.Pgx, identifier, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ProofJobRunning, stored.Status)
+
+		retaken.Status = domain.ProofJobDone
+		retaken.FinishedAt = &now
+		retaken.ExpiresAt = &now
+		require.NoError(t, jobsRepo.Finish(ctx, storage.Pgx, retaken))
+	})
+
+	t.Run("should delete the expired jobs", func(t *testing.T) {
+		deleted, err := jobsRepo.DeleteExpired(ctx, storage.Pgx, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(3))
+	})
+}
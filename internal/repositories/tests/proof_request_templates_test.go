@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestProofRequestTemplates(t *testing.T) {
+	ctx := context.Background()
+	templatesRepo := repositories.NewProofRequestTemplates()
+	name := "age-" + uuid.NewString()
+
+	template, err := domain.NewProofRequestTemplate(name, "credentialAtomicQuerySigV2",
+		"https://raw.githubusercontent.com/iden3/claim-schema-vocab/main/schemas/json-ld/kyc-v3.json-ld", "KYCAgeCredential",
+		[]string{"*"}, map[string]interface{}{"birthday": map[string]interface{}{"$lt": 20050101}}, "age verification")
+	require.NoError(t, err)
+
+	id, err := templatesRepo.Save(ctx, storage.Pgx, template)
+	require.NoError(t, err)
+
+	t.Run("should get the template by id", func(t *testing.T) {
+		byID, err := templatesRepo.GetByID(ctx, storage.Pgx, id)
+		require.NoError(t, err)
+		assert.Equal(t, name, byID.Name)
+		allowedIssuers, err := byID.GetAllowedIssuers()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"*"}, allowedIssuers)
+		credentialSubject, err := byID.GetCredentialSubject()
+		require.NoError(t, err)
+		assert.Contains(t, credentialSubject, "birthday")
+	})
+
+	t.Run("should not save two templates with the same name", func(t *testing.T) {
+		duplicated, err := domain.NewProofRequestTemplate(name, template.CircuitID, template.Context, template.Type, []string{"*"}, nil, "")
+		require.NoError(t, err)
+		_, err = templatesRepo.Save(ctx, storage.Pgx, duplicated)
+		assert.ErrorIs(t, err, repositories.ErrProofRequestTemplateDuplication)
+	})
+
+	t.Run("should update the template", func(t *testing.T) {
+		template.ID = id
+		template.Reason = "updated"
+		require.NoError(t, template.SetCredentialSubject(nil))
+		_, err := templatesRepo.Save(ctx, storage.Pgx, template)
+		require.NoError(t, err)
+
+		updated, err := templatesRepo.GetByID(ctx, storage.Pgx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", updated.Reason)
+		credentialSubject, err := updated.GetCredentialSubject()
+		require.NoError(t, err)
+		assert.Empty(t, credentialSubject)
+	})
+
+	t.Run("should list and delete the template", func(t *testing.T) {
+		templates, err := templatesRepo.GetAll(ctx, storage.Pgx)
+		require.NoError(t, err)
+		assert.True(t, len(templates) >= 1)
+
+		require.NoError(t, templatesRepo.Delete(ctx, storage.Pgx, id))
+		_, err = templatesRepo.GetByID(ctx, storage.Pgx, id)
+		assert.ErrorIs(t, err, repositories.ErrProofRequestTemplateDoesNotExist)
+		assert.ErrorIs(t, templatesRepo.Delete(ctx, storage.Pgx, id), repositories.ErrProofRequestTemplateDoesNotExist)
+	})
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/iden3comm/protocol"
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestAuthRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	reqsRepo := repositories.NewAuthRequests()
+	verifier := "did:polygonid:polygon:mumbai:2qJT3RnL8ZwU7mgQeVjgw6qNpyYTV3Z7CgtxueBdsA"
+	threadID := uuid.NewString()
+	challenge, err := domain.NewRequestChallenge()
+	require.NoError(t, err)
+
+	authRequest, err := domain.FromAuthRequester(protocol.AuthorizationRequestMessage{
+		ID:       threadID,
+		ThreadID: threadID,
+		From:     verifier,
+		To:       "did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS",
+		Type:     protocol.AuthorizationRequestMessageType,
+	}, challenge, nil)
+	require.NoError(t, err)
+
+	id, err := reqsRepo.Save(ctx, storage.Pgx, authRequest)
+	require.NoError(t, err)
+
+	t.Run("should get the request by id and thread id", func(t *testing.T) {
+		byID, err := reqsRepo.GetByID(ctx, storage.Pgx, id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AuthRequestStatusCreated, byID.Status)
+		stored, err := byID.GetChallenge()
+		require.NoError(t, err)
+		assert.Equal(t, challenge, stored)
+		message, err := byID.GetAuthorizationRequestMessage()
+		require.NoError(t, err)
+		assert.Equal(t, threadID, message.ThreadID)
+
+		byThread, err := reqsRepo.GetByThreadID(ctx, storage.Pgx, threadID)
+		require.NoError(t, err)
+		assert.Equal(t, id, byThread.ID)
+	})
+
+	t.Run("should list the requests of the verifier", func(t *testing.T) {
+		requests, err := reqsRepo.ListByVerifier(ctx, storage.Pgx, verifier)
+		require.NoError(t, err)
+		assert.True(t, len(requests) >= 1)
+	})
+
+	t.Run("should follow the status lifecycle", func(t *testing.T) {
+		assert.ErrorIs(t, reqsRepo.UpdateStatus(ctx, storage.Pgx, id, domain.AuthRequestStatusVerified), repositories.ErrAuthRequestInvalidStatus)
+		assert.NoError(t, reqsRepo.UpdateStatus(ctx, storage.Pgx, id, domain.AuthRequestStatusPending))
+		assert.NoError(t, reqsRepo.UpdateStatus(ctx, storage.Pgx, id, domain.AuthRequestStatusVerified))
+		assert.ErrorIs(t, reqsRepo.UpdateStatus(ctx, storage.Pgx, id, domain.AuthRequestStatusExpired), repositories.ErrAuthRequestInvalidStatus)
+	})
+
+	t.Run("should record the verification", func(t *testing.T) {
+		holder := "did:polygonid:polygon:mumbai:2qFjyCGFs4yNEnUC4wec7YoTcoQGCHAbn3Ur8r49FS"
+		disclosed, err := domain.DisclosedFromResponse(protocol.AuthorizationResponseMessage{
+			Body: protocol.AuthorizationMessageResponseBody{
+				Scope: []protocol.ZeroKnowledgeProofResponse{{ID: 1, VerifiablePresentation: []byte(`{"type":"VerifiablePresentation"}`)}},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, reqsRepo.UpdateVerification(ctx, storage.Pgx, id, holder, disclosed))
+
+		authRequest, err := reqsRepo.GetByID(ctx, storage.Pgx, id)
+		require.NoError(t, err)
+		assert.Equal(t, holder, *authRequest.VerifiedDID)
+		values, err := authRequest.GetDisclosed()
+		require.NoError(t, err)
+		assert.Contains(t, values, "1")
+	})
+
+	t.Run("should not find unknown requests", func(t *testing.T) {
+		_, err := reqsRepo.GetByID(ctx, storage.Pgx, uuid.New())
+		assert.ErrorIs(t, err, repositories.ErrAuthRequestDoesNotExist)
+		assert.ErrorIs(t, reqsRepo.UpdateStatus(ctx, storage.Pgx, uuid.New(), domain.AuthRequestStatusPending), repositories.ErrAuthRequestDoesNotExist)
+	})
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lastingasset/wallet-service/internal/core/domain"
+	"github.com/lastingasset/wallet-service/internal/repositories"
+)
+
+func TestSybilNullifiers(t *testing.T) {
+	ctx := context.Background()
+	nullifiersRepo := repositories.NewSybilNullifiers()
+	verifier := "did:iden3:polygon:mumbai:x2Uw18ATvY7mEsgfrrDipBmQQdPWAao4NmF56wGvp"
+	sybilID := uuid.NewString()
+
+	nullifier := &domain.SybilNullifier{
+		ID:            uuid.New(),
+		Verifier:      verifier,
+		CRS:           "1234567890",
+		SybilID:       sybilID,
+		UserID:        "did:iden3:polygon:mumbai:wyFiV4w71QgWPn6bYLsZoysFay66gKtVa9kfu6yMZ",
+		AuthRequestID: uuid.New(),
+		CreatedAt:     time.Now(),
+	}
+	require.NoError(t, nullifiersRepo.Save(ctx, storage.Pgx, nullifier))
+
+	t.Run("should get the registered nullifier", func(t *testing.T) {
+		registered, err := nullifiersRepo.Get(ctx, storage.Pgx, verifier, "1234567890", sybilID)
+		require.NoError(t, err)
+		assert.Equal(t, nullifier.ID, registered.ID)
+		assert.Equal(t, nullifier.UserID, registered.UserID)
+	})
+
+	t.Run("should reject a second registration with another identity", func(t *testing.T) {
+		err := nullifiersRepo.Save(ctx, storage.Pgx, &domain.SybilNullifier{
+			ID:            uuid.New(),
+			Verifier:      verifier,
+			CRS:           "1234567890",
+			SybilID:       sybilID,
+			UserID:        "did:iden3:polygon:mumbai:wzS76vqXD6XhkU1LtUXbM1MMZEbjgt2GS8tU1koD3",
+			AuthRequestID: uuid.New(),
+			CreatedAt:     time.Now(),
+		})
+		assert.ErrorIs(t, err, repositories.ErrSybilNullifierDuplicated)
+	})
+
+	t.Run("should register the same person for another crs", func(t *testing.T) {
+		require.NoError(t, nullifiersRepo.Save(ctx, storage.Pgx, &domain.SybilNullifier{
+			ID:            uuid.New(),
+			Verifier:      verifier,
+			CRS:           "987654321",
+			SybilID:       sybilID,
+			UserID:        nullifier.UserID,
+			AuthRequestID: uuid.New(),
+			CreatedAt:     time.Now(),
+		}))
+		_, err := nullifiersRepo.Get(ctx, storage.Pgx, verifier, "555", sybilID)
+		assert.ErrorIs(t, err, repositories.ErrSybilNullifierDoesNotExist)
+	})
+}
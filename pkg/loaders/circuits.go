@@ -81,3 +81,14 @@ func (l *Circuits) getPathToFile(circuitID circuits.CircuitID, fileName string)
 	}
 	return data, nil
 }
+
+// VerificationKeyLoader loads only the verification keys of the circuits.
+// It can be used as a go-iden3-auth verification key loader.
+type VerificationKeyLoader struct {
+	Circuits *Circuits
+}
+
+// Load verification key by circuit ID.
+func (l VerificationKeyLoader) Load(circuitID circuits.CircuitID) ([]byte, error) {
+	return l.Circuits.LoadVerificationKey(circuitID)
+}
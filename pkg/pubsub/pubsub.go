@@ -0,0 +1,9 @@
+package pubsub
+
+import "context"
+
+// Publisher interface propose an interface that any event publisher should adhere
+type Publisher interface {
+	// Publish sends the payload, encoded as json, to the subscribers of the topic
+	Publish(ctx context.Context, topic string, payload any) error
+}
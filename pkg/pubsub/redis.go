@@ -0,0 +1,26 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisPublisher struct {
+	client *redis.Client
+}
+
+// NewRedisPublisher returns a new publisher based on Redis pub/sub channels, one per topic
+func NewRedisPublisher(client *redis.Client) Publisher {
+	return &redisPublisher{client: client}
+}
+
+// Publish publishes the json encoded payload in the redis channel of the topic
+func (p *redisPublisher) Publish(ctx context.Context, topic string, payload any) error {
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return p.client.Publish(ctx, topic, msg).Err()
+}